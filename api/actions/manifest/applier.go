@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/actions/shared"
@@ -14,6 +15,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
 )
 
 type Applier struct {
@@ -23,6 +26,8 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	sidecarRepo         shared.CFSidecarRepository
+	dropletRepo         shared.CFDropletRepository
 }
 
 func NewApplier(
@@ -32,6 +37,8 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	sidecarRepo shared.CFSidecarRepository,
+	dropletRepo shared.CFDropletRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -40,6 +47,8 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		sidecarRepo:         sidecarRepo,
+		dropletRepo:         dropletRepo,
 	}
 }
 
@@ -53,6 +62,10 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 		return err
 	}
 
+	if err := a.applySidecars(ctx, authInfo, appInfo, appState); err != nil {
+		return err
+	}
+
 	if err := a.applyRoutes(ctx, authInfo, appInfo, appState); err != nil {
 		return err
 	}
@@ -99,6 +112,28 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+func (a *Applier) applySidecars(
+	ctx context.Context,
+	authInfo authorization.Info,
+	appInfo payloads.ManifestApplication,
+	appState AppState,
+) error {
+	for _, sidecarInfo := range appInfo.Sidecars {
+		if sidecar, ok := appState.Sidecars[sidecarInfo.Name]; ok {
+			if _, err := a.sidecarRepo.PatchSidecar(ctx, authInfo, sidecarInfo.ToSidecarPatchMessage(sidecar.GUID)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := a.sidecarRepo.CreateSidecar(ctx, authInfo, sidecarInfo.ToSidecarCreateMessage(appState.App.GUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
@@ -109,7 +144,7 @@ func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info,
 
 func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	for _, route := range appInfo.Routes {
-		err := a.createOrUpdateRoute(ctx, authInfo, *route.Route, appState)
+		err := a.createOrUpdateRoute(ctx, authInfo, route, appState)
 		if err != nil {
 			return fmt.Errorf("createOrUpdateRoutes: %w", err)
 		}
@@ -118,9 +153,17 @@ func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorizati
 	return nil
 }
 
-func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, routeString string, appState AppState) error {
-	if _, routeExists := appState.Routes[routeString]; routeExists {
-		return nil
+func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, route payloads.ManifestRoute, appState AppState) error {
+	routeString := *route.Route
+	processType := tools.IfZero(tools.ZeroIfNil(route.Process), korifiv1alpha1.ProcessTypeWeb)
+	if existingRoute, routeExists := appState.Routes[routeString]; routeExists {
+		if dest, ok := findDestination(existingRoute, appState.App.GUID, processType, route.Port); ok {
+			return a.updateDestinationProtocol(ctx, authInfo, existingRoute, dest, route.Protocol)
+		}
+	}
+
+	if err := a.validateRoutePort(ctx, authInfo, route, appState); err != nil {
+		return err
 	}
 
 	hostName, domainName, path := splitRoute(routeString)
@@ -158,7 +201,10 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 		ExistingDestinations: routeRecord.Destinations,
 		NewDestinations: []repositories.DesiredDestination{{
 			AppGUID:     appState.App.GUID,
-			ProcessType: korifiv1alpha1.ProcessTypeWeb,
+			SpaceGUID:   appState.App.SpaceGUID,
+			ProcessType: processType,
+			Port:        route.Port,
+			Protocol:    route.Protocol,
 		}},
 	})
 	if err != nil {
@@ -168,6 +214,73 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 	return nil
 }
 
+// validateRoutePort ensures that the route port is exposed by the app
+// droplet. Apps that have not been staged yet cannot be validated, the port
+// is then checked once the droplet is there
+func (a *Applier) validateRoutePort(ctx context.Context, authInfo authorization.Info, route payloads.ManifestRoute, appState AppState) error {
+	if route.Port == nil || appState.App.DropletGUID == "" {
+		return nil
+	}
+
+	droplet, err := a.dropletRepo.GetDroplet(ctx, authInfo, appState.App.DropletGUID)
+	if err != nil {
+		return fmt.Errorf("failed to get droplet: %w", err)
+	}
+
+	if len(droplet.Ports) == 0 || slices.Contains(droplet.Ports, *route.Port) {
+		return nil
+	}
+
+	return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+		"Route %q port %d is not exposed by the app droplet. Exposed ports: %s.",
+		*route.Route, *route.Port, strings.Join(slices.Collect(it.Map(slices.Values(droplet.Ports), func(p int32) string {
+			return strconv.Itoa(int(p))
+		})), ", "),
+	))
+}
+
+func findDestination(route repositories.RouteRecord, appGUID, processType string, port *int32) (repositories.DestinationRecord, bool) {
+	destIdx := slices.IndexFunc(route.Destinations, func(dest repositories.DestinationRecord) bool {
+		if dest.AppGUID != appGUID || dest.ProcessType != processType {
+			return false
+		}
+
+		return port == nil || (dest.Port != nil && *dest.Port == *port)
+	})
+	if destIdx < 0 {
+		return repositories.DestinationRecord{}, false
+	}
+
+	return route.Destinations[destIdx], true
+}
+
+// updateDestinationProtocol applies the manifest route protocol to an
+// existing destination. Routes that do not specify a protocol keep the one
+// the destination already has
+func (a *Applier) updateDestinationProtocol(
+	ctx context.Context,
+	authInfo authorization.Info,
+	route repositories.RouteRecord,
+	dest repositories.DestinationRecord,
+	protocol *string,
+) error {
+	if protocol == nil || tools.ZeroIfNil(dest.Protocol) == *protocol {
+		return nil
+	}
+
+	_, err := a.routeRepo.UpdateDestination(ctx, authInfo, repositories.UpdateDestinationMessage{
+		RouteGUID: route.GUID,
+		SpaceGUID: route.SpaceGUID,
+		GUID:      dest.GUID,
+		Protocol:  protocol,
+	})
+	if err != nil {
+		return fmt.Errorf("updateDestination: %w", err)
+	}
+
+	return nil
+}
+
 func (a *Applier) deleteAppDestinations(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +27,8 @@ var _ = Describe("Applier", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		sidecarRepo         *fake.CFSidecarRepository
+		dropletRepo         *fake.CFDropletRepository
 		applier             *manifest.Applier
 		applierErr          error
 		ctx                 context.Context
@@ -41,7 +44,9 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		dropletRepo = new(fake.CFDropletRepository)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo, dropletRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -264,6 +269,88 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("applying sidecars", func() {
+		BeforeEach(func() {
+			appState.App.GUID = "app-guid"
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{
+					Name:         "apm-agent",
+					Command:      tools.PtrTo("run-agent"),
+					ProcessTypes: []string{"web"},
+					Memory:       tools.PtrTo("64M"),
+				},
+				{
+					Name:         "log-shipper",
+					Command:      tools.PtrTo("ship-logs"),
+					ProcessTypes: []string{"web", "worker"},
+				},
+			}
+		})
+
+		It("creates each sidecar", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(0))
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(2))
+
+			_, _, createMsg := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				Name:         "apm-agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+
+			_, _, createMsg = sidecarRepo.CreateSidecarArgsForCall(1)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				Name:         "log-shipper",
+				Command:      "ship-logs",
+				ProcessTypes: []string{"web", "worker"},
+			}))
+		})
+
+		When("creating a sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-sidecar-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError("create-sidecar-failed"))
+			})
+		})
+
+		When("a sidecar exists", func() {
+			BeforeEach(func() {
+				appState.Sidecars = map[string]repositories.SidecarRecord{
+					"log-shipper": {GUID: "sidecar-guid"},
+				}
+			})
+
+			It("patches that sidecar", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+
+				_, _, patchMsg := sidecarRepo.PatchSidecarArgsForCall(0)
+				Expect(patchMsg.GUID).To(Equal("sidecar-guid"))
+				Expect(patchMsg.Command).To(Equal(tools.PtrTo("ship-logs")))
+				Expect(patchMsg.ProcessTypes).To(Equal([]string{"web", "worker"}))
+				Expect(patchMsg.MemoryMB).To(BeNil())
+			})
+
+			When("patching the sidecar fails", func() {
+				BeforeEach(func() {
+					sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("sidecar-patch-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError("sidecar-patch-error"))
+				})
+			})
+		})
+	})
+
 	Describe("applying routes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
@@ -313,11 +400,103 @@ var _ = Describe("Applier", func() {
 				ExistingDestinations: []repositories.DestinationRecord{{GUID: "dest-guid"}},
 				NewDestinations: []repositories.DesiredDestination{{
 					AppGUID:     "app-guid",
+					SpaceGUID:   "space-guid",
 					ProcessType: "web",
 				}},
 			}))
 		})
 
+		When("the route specifies a process and a port", func() {
+			BeforeEach(func() {
+				appInfo.Routes[0].Process = tools.PtrTo("admin")
+				appInfo.Routes[0].Port = tools.PtrTo[int32](9090)
+			})
+
+			It("adds a destination for that process and port", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				_, _, addDestinationMessage := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(addDestinationMessage.NewDestinations).To(ConsistOf(repositories.DesiredDestination{
+					AppGUID:     "app-guid",
+					SpaceGUID:   "space-guid",
+					ProcessType: "admin",
+					Port:        tools.PtrTo[int32](9090),
+				}))
+			})
+
+			It("checks the port against the app droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualDropletGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualDropletGUID).To(Equal("droplet-guid"))
+			})
+
+			When("the droplet exposes the port", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{Ports: []int32{8080, 9090}}, nil)
+				})
+
+				It("adds the destination", func() {
+					Expect(applierErr).NotTo(HaveOccurred())
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the droplet does not expose the port", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{Ports: []int32{8080, 8081}}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(applierErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					var unprocessableErr apierrors.UnprocessableEntityError
+					Expect(errors.As(applierErr, &unprocessableErr)).To(BeTrue())
+					Expect(unprocessableErr.Detail()).To(ContainSubstring("port 9090 is not exposed by the app droplet. Exposed ports: 8080, 8081."))
+				})
+
+				It("does not create the route", func() {
+					Expect(routeRepo.GetOrCreateRouteCallCount()).To(BeZero())
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the droplet fails", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, errors.New("get-droplet-err"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError(ContainSubstring("get-droplet-err")))
+				})
+			})
+
+			When("the app has not been staged yet", func() {
+				BeforeEach(func() {
+					appState.App.DropletGUID = ""
+				})
+
+				It("adds the destination without checking the port", func() {
+					Expect(dropletRepo.GetDropletCallCount()).To(BeZero())
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		When("the route specifies a protocol", func() {
+			BeforeEach(func() {
+				appInfo.Routes[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("adds a destination with that protocol", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				_, _, addDestinationMessage := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(addDestinationMessage.NewDestinations).To(ConsistOf(repositories.DesiredDestination{
+					AppGUID:     "app-guid",
+					SpaceGUID:   "space-guid",
+					ProcessType: "web",
+					Protocol:    tools.PtrTo("http2"),
+				}))
+			})
+		})
+
 		When("adding the destination to the route fails", func() {
 			BeforeEach(func() {
 				routeRepo.AddDestinationsToRouteReturns(repositories.RouteRecord{}, errors.New("add-route-to-dest-error"))
@@ -359,12 +538,85 @@ var _ = Describe("Applier", func() {
 
 		When("the route already exists", func() {
 			BeforeEach(func() {
-				appState.Routes = map[string]repositories.RouteRecord{"r1.my.domain/my-path": {}}
+				appState.Routes = map[string]repositories.RouteRecord{"r1.my.domain/my-path": {
+					GUID:      "route-guid",
+					SpaceGUID: "space-guid",
+					Destinations: []repositories.DestinationRecord{{
+						GUID:        "dest-guid",
+						AppGUID:     "app-guid",
+						ProcessType: "web",
+						Port:        tools.PtrTo[int32](8080),
+						Protocol:    tools.PtrTo("http1"),
+					}},
+				}}
 			})
 
 			It("doesn't do any route creation", func() {
 				Expect(routeRepo.GetOrCreateRouteCallCount()).To(BeZero())
 			})
+
+			It("does not update the destination", func() {
+				Expect(routeRepo.UpdateDestinationCallCount()).To(BeZero())
+			})
+
+			When("the manifest changes the route protocol", func() {
+				BeforeEach(func() {
+					appInfo.Routes[0].Protocol = tools.PtrTo("http2")
+				})
+
+				It("updates the existing destination protocol", func() {
+					Expect(applierErr).NotTo(HaveOccurred())
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(BeZero())
+					Expect(routeRepo.UpdateDestinationCallCount()).To(Equal(1))
+					_, _, updateMsg := routeRepo.UpdateDestinationArgsForCall(0)
+					Expect(updateMsg).To(Equal(repositories.UpdateDestinationMessage{
+						RouteGUID: "route-guid",
+						SpaceGUID: "space-guid",
+						GUID:      "dest-guid",
+						Protocol:  tools.PtrTo("http2"),
+					}))
+				})
+
+				When("updating the destination fails", func() {
+					BeforeEach(func() {
+						routeRepo.UpdateDestinationReturns(repositories.RouteRecord{}, errors.New("update-dest-err"))
+					})
+
+					It("returns the error", func() {
+						Expect(applierErr).To(MatchError(ContainSubstring("update-dest-err")))
+					})
+				})
+			})
+
+			When("the manifest sets the protocol the destination already has", func() {
+				BeforeEach(func() {
+					appInfo.Routes[0].Protocol = tools.PtrTo("http1")
+				})
+
+				It("does not update the destination", func() {
+					Expect(routeRepo.UpdateDestinationCallCount()).To(BeZero())
+				})
+			})
+
+			When("the existing destination targets a different process", func() {
+				BeforeEach(func() {
+					appInfo.Routes[0].Process = tools.PtrTo("admin")
+				})
+
+				It("adds a destination for the process", func() {
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the existing destination targets a different port", func() {
+				BeforeEach(func() {
+					appInfo.Routes[0].Port = tools.PtrTo[int32](9090)
+				})
+
+				It("adds a destination for the port", func() {
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		When("the no-route is set in the manifest", func() {
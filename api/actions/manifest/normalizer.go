@@ -33,6 +33,7 @@ func (n Normalizer) Normalize(appInfo payloads.ManifestApplication, appState App
 		Metadata:   appInfo.Metadata,
 		Services:   appInfo.Services,
 		Docker:     appInfo.Docker,
+		Sidecars:   appInfo.Sidecars,
 	}
 }
 
@@ -83,7 +84,8 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 	}
 
 	if appInfo.Memory != nil || appInfo.DiskQuota != nil || appInfo.Instances != nil || appInfo.Command != nil ||
-		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil {
+		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil ||
+		appInfo.ReadinessHealthCheckHTTPEndpoint != nil || appInfo.ReadinessHealthCheckType != nil || appInfo.ReadinessHealthCheckInvocationTimeout != nil || appInfo.ReadinessHealthCheckInterval != nil {
 
 		webProc.Memory = procValIfSet(appInfo.Memory, webProc.Memory)
 		webProc.DiskQuota = procValIfSet(appInfo.DiskQuota, webProc.DiskQuota)
@@ -93,6 +95,10 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 		webProc.HealthCheckType = procValIfSet(appInfo.HealthCheckType, webProc.HealthCheckType)
 		webProc.HealthCheckInvocationTimeout = procValIfSet(appInfo.HealthCheckInvocationTimeout, webProc.HealthCheckInvocationTimeout)
 		webProc.Timeout = procValIfSet(appInfo.Timeout, webProc.Timeout)
+		webProc.ReadinessHealthCheckHTTPEndpoint = procValIfSet(appInfo.ReadinessHealthCheckHTTPEndpoint, webProc.ReadinessHealthCheckHTTPEndpoint)
+		webProc.ReadinessHealthCheckType = procValIfSet(appInfo.ReadinessHealthCheckType, webProc.ReadinessHealthCheckType)
+		webProc.ReadinessHealthCheckInvocationTimeout = procValIfSet(appInfo.ReadinessHealthCheckInvocationTimeout, webProc.ReadinessHealthCheckInvocationTimeout)
+		webProc.ReadinessHealthCheckInterval = procValIfSet(appInfo.ReadinessHealthCheckInterval, webProc.ReadinessHealthCheckInterval)
 	}
 
 	return processes
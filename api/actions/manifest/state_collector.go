@@ -22,6 +22,7 @@ type StateCollector struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	sidecarRepo         shared.CFSidecarRepository
 }
 
 type AppState struct {
@@ -29,6 +30,7 @@ type AppState struct {
 	Processes       map[string]repositories.ProcessRecord
 	Routes          map[string]repositories.RouteRecord
 	ServiceBindings map[string]repositories.ServiceBindingRecord
+	Sidecars        map[string]repositories.SidecarRecord
 }
 
 func NewStateCollector(
@@ -38,6 +40,7 @@ func NewStateCollector(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) StateCollector {
 	return StateCollector{
 		appRepo:             appRepo,
@@ -46,6 +49,7 @@ func NewStateCollector(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		sidecarRepo:         sidecarRepo,
 	}
 }
 
@@ -81,11 +85,17 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
+	existingSidecars, err := s.collectSidecars(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	return AppState{
 		App:             appRecord,
 		Processes:       existingProcesses,
 		Routes:          existingAppRoutes,
 		ServiceBindings: existingServiceBindings,
+		Sidecars:        existingSidecars,
 	}, nil
 }
 
@@ -154,6 +164,22 @@ func (s StateCollector) collectServiceBindings(ctx context.Context, authInfo aut
 	return existingServiceBindings, nil
 }
 
+func (s StateCollector) collectSidecars(ctx context.Context, authInfo authorization.Info, appGUID string) (map[string]repositories.SidecarRecord, error) {
+	sidecars, err := s.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUID: appGUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existingSidecars := map[string]repositories.SidecarRecord{}
+	for _, sidecar := range sidecars {
+		existingSidecars[sidecar.Name] = sidecar
+	}
+
+	return existingSidecars, nil
+}
+
 func unsplitRoute(route repositories.RouteRecord) string {
 	return path.Join(fmt.Sprintf("%s.%s", route.Host, route.Domain.Name), route.Path)
 }
@@ -21,6 +21,7 @@ var _ = Describe("StateCollector", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		stateCollector      manifest.StateCollector
 		appState            manifest.AppState
 		collectStateErr     error
@@ -33,6 +34,7 @@ var _ = Describe("StateCollector", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		stateCollector = manifest.NewStateCollector(
 			appRepo,
 			domainRepo,
@@ -40,6 +42,7 @@ var _ = Describe("StateCollector", func() {
 			routeRepo,
 			serviceInstanceRepo,
 			serviceBindingRepo,
+			sidecarRepo,
 		)
 	})
 
@@ -245,4 +248,38 @@ var _ = Describe("StateCollector", func() {
 			}))
 		})
 	})
+
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-guid"}}, nil)
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "apm-guid", Name: "apm-agent"},
+				{GUID: "logs-guid", Name: "log-shipper"},
+			}, nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMsg := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMsg.AppGUID).To(Equal("app-guid"))
+		})
+
+		It("constructs the sidecar map using sidecar name", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.Sidecars).To(Equal(map[string]repositories.SidecarRecord{
+				"apm-agent":   {GUID: "apm-guid", Name: "apm-agent"},
+				"log-shipper": {GUID: "logs-guid", Name: "log-shipper"},
+			}))
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-sidecars-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-sidecars-error"))
+			})
+		})
+	})
 })
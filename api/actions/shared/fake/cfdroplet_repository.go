@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFDropletRepository struct {
+	GetDropletStub        func(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
+	getDropletMutex       sync.RWMutex
+	getDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getDropletReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	getDropletReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFDropletRepository) GetDroplet(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DropletRecord, error) {
+	fake.getDropletMutex.Lock()
+	ret, specificReturn := fake.getDropletReturnsOnCall[len(fake.getDropletArgsForCall)]
+	fake.getDropletArgsForCall = append(fake.getDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetDropletStub
+	fakeReturns := fake.getDropletReturns
+	fake.recordInvocation("GetDroplet", []interface{}{arg1, arg2, arg3})
+	fake.getDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) GetDropletCallCount() int {
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	return len(fake.getDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) GetDropletCalls(stub func(context.Context, authorization.Info, string) (repositories.DropletRecord, error)) {
+	fake.getDropletMutex.Lock()
+	defer fake.getDropletMutex.Unlock()
+	fake.GetDropletStub = stub
+}
+
+func (fake *CFDropletRepository) GetDropletArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	argsForCall := fake.getDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) GetDropletReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.getDropletMutex.Lock()
+	defer fake.getDropletMutex.Unlock()
+	fake.GetDropletStub = nil
+	fake.getDropletReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) GetDropletReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.getDropletMutex.Lock()
+	defer fake.getDropletMutex.Unlock()
+	fake.GetDropletStub = nil
+	if fake.getDropletReturnsOnCall == nil {
+		fake.getDropletReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.getDropletReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFDropletRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ shared.CFDropletRepository = new(CFDropletRepository)
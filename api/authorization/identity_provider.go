@@ -8,9 +8,10 @@ import (
 )
 
 const (
-	BearerScheme  string = "bearer"
-	CertScheme    string = "clientcert"
-	UnknownScheme string = "unknown"
+	BearerScheme        string = "bearer"
+	CertScheme          string = "clientcert"
+	ImpersonationScheme string = "impersonation"
+	UnknownScheme       string = "unknown"
 )
 
 //counterfeiter:generate -o fake -fake-name TokenIdentityInspector . TokenIdentityInspector
@@ -19,6 +20,9 @@ const (
 type Identity struct {
 	Name string
 	Kind string
+	// Groups are the groups the identity was authenticated with. They are
+	// only needed when the API impersonates the identity
+	Groups []string
 }
 
 func (i *Identity) Hash() string {
@@ -48,6 +52,10 @@ func NewCertTokenIdentityProvider(tokenInspector TokenIdentityInspector, certIns
 }
 
 func (p *CertTokenIdentityProvider) GetIdentity(ctx context.Context, info Info) (Identity, error) {
+	if info.Impersonate != nil {
+		return *info.Impersonate, nil
+	}
+
 	if info.Token != "" {
 		return p.tokenInspector.WhoAmI(ctx, info.Token)
 	}
@@ -64,6 +64,19 @@ var _ = Describe("IdentityProvider", func() {
 		})
 	})
 
+	When("the authorization.Info impersonates an identity", func() {
+		BeforeEach(func() {
+			authInfo.Impersonate = &aliceId
+		})
+
+		It("returns the impersonated identity without inspecting credentials", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(aliceId))
+			Expect(tokenInspector.WhoAmICallCount()).To(BeZero())
+			Expect(certInspector.WhoAmICallCount()).To(BeZero())
+		})
+	})
+
 	When("the authorization.Info contains a client cert", func() {
 		BeforeEach(func() {
 			authInfo.CertData = []byte("a-cert")
@@ -4,12 +4,16 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"strings"
 )
 
 type Info struct {
 	Token         string
 	CertData      []byte
 	RawAuthHeader string
+	// Impersonate is set when the API acts on behalf of an identity whose
+	// credentials it does not hold, e.g. when redeeming an SSH code
+	Impersonate *Identity
 }
 
 type key int
@@ -30,6 +34,10 @@ func InfoFromContext(ctx context.Context) (Info, bool) {
 }
 
 func (i Info) Scheme() string {
+	if i.Impersonate != nil {
+		return ImpersonationScheme
+	}
+
 	if i.Token != "" {
 		return BearerScheme
 	}
@@ -43,6 +51,10 @@ func (i Info) Scheme() string {
 
 func (i Info) Hash() string {
 	key := append([]byte(i.Token), i.CertData...)
+	if i.Impersonate != nil {
+		key = append(key, []byte(i.Impersonate.Name+i.Impersonate.Kind)...)
+		key = append(key, []byte(strings.Join(i.Impersonate.Groups, "\n"))...)
+	}
 	hasher := sha256.New()
 	return hex.EncodeToString(hasher.Sum(key))
 }
@@ -0,0 +1,45 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodExecPermissions checks whether a user is allowed to run commands in the
+// pods of a namespace. Korifi grants this permission to space developers.
+type PodExecPermissions struct {
+	clientsetFactory UserClientsetFactory
+}
+
+func NewPodExecPermissions(clientsetFactory UserClientsetFactory) *PodExecPermissions {
+	return &PodExecPermissions{
+		clientsetFactory: clientsetFactory,
+	}
+}
+
+func (p *PodExecPermissions) CanExec(ctx context.Context, authInfo Info, namespace string) (bool, error) {
+	clientset, err := p.clientsetFactory.BuildClientset(authInfo)
+	if err != nil {
+		return false, err
+	}
+
+	review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "exec",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review pod exec permissions: %w", apierrors.FromK8sError(err, ""))
+	}
+
+	return review.Status.Allowed, nil
+}
@@ -1,11 +1,6 @@
 package authorization
 
 import (
-	"encoding/pem"
-	"errors"
-	"fmt"
-	"strings"
-
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -28,7 +23,7 @@ type UnprivilegedClientFactory struct {
 
 func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper) UnprivilegedClientFactory {
 	return UnprivilegedClientFactory{
-		config:   rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		config:   rest.CopyConfig(config),
 		mapper:   mapper,
 		wrappers: []ClientWrappingFunc{},
 	}
@@ -40,28 +35,9 @@ func (f UnprivilegedClientFactory) WithWrappingFunc(wrapper ClientWrappingFunc)
 }
 
 func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch, error) {
-	config := rest.CopyConfig(f.config)
-
-	switch strings.ToLower(authInfo.Scheme()) {
-	case BearerScheme:
-		config.BearerToken = authInfo.Token
-
-	case CertScheme:
-		certBlock, rst := pem.Decode(authInfo.CertData)
-		if certBlock == nil {
-			return nil, fmt.Errorf("failed to decode cert PEM")
-		}
-
-		keyBlock, _ := pem.Decode(rst)
-		if keyBlock == nil {
-			return nil, fmt.Errorf("failed to decode key PEM")
-		}
-
-		config.CertData = pem.EncodeToMemory(certBlock)
-		config.KeyData = pem.EncodeToMemory(keyBlock)
-
-	default:
-		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
+	config, err := buildUserRestConfig(f.config, authInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	userClient, err := client.NewWithWatch(config, client.Options{
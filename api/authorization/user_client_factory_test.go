@@ -47,7 +47,7 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 		userClient, buildClientErr = clientFactory.BuildClient(authInfo)
 	})
 
-	allowListingPods := func(user string) {
+	allowListingPods := func(kind, name string) {
 		listPodClusterRole := rbacv1.ClusterRole{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: userName + "-list-pods",
@@ -68,8 +68,8 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 			},
 			Subjects: []rbacv1.Subject{
 				{
-					Kind: rbacv1.UserKind,
-					Name: user,
+					Kind: kind,
+					Name: name,
 				},
 			},
 			RoleRef: rbacv1.RoleRef{
@@ -103,7 +103,7 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 
 			When("a role binding exists", func() {
 				BeforeEach(func() {
-					allowListingPods(userName)
+					allowListingPods(rbacv1.UserKind, userName)
 				})
 
 				It("allows listing pods", func() {
@@ -126,7 +126,43 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 
 			When("a role binding exists", func() {
 				BeforeEach(func() {
-					allowListingPods(oidcPrefix + userName)
+					allowListingPods(rbacv1.UserKind, oidcPrefix+userName)
+				})
+
+				It("allows listing pods", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(podListErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+		Context("impersonation", func() {
+			BeforeEach(func() {
+				authInfo.Impersonate = &authorization.Identity{
+					Name:   userName,
+					Kind:   rbacv1.UserKind,
+					Groups: []string{userName + "-group"},
+				}
+			})
+
+			It("succeeds and forbids access to the user", func() {
+				Expect(buildClientErr).NotTo(HaveOccurred())
+				Expect(k8serrors.IsForbidden(podListErr)).To(BeTrue())
+			})
+
+			When("a role binding exists", func() {
+				BeforeEach(func() {
+					allowListingPods(rbacv1.UserKind, userName)
+				})
+
+				It("allows listing pods", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(podListErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a role binding exists for a group of the user", func() {
+				BeforeEach(func() {
+					allowListingPods(rbacv1.GroupKind, userName+"-group")
 				})
 
 				It("allows listing pods", func() {
@@ -155,7 +191,7 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 				cert2, key2 := testhelpers.ObtainClientCert(testEnv, name2)
 				authInfo1.CertData = testhelpers.JoinCertAndKey(cert1, key1)
 				authInfo2.CertData = testhelpers.JoinCertAndKey(cert2, key2)
-				allowListingPods(name1)
+				allowListingPods(rbacv1.UserKind, name1)
 			})
 
 			It("doesn't muddle up their config", func() {
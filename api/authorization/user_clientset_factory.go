@@ -1,11 +1,6 @@
 package authorization
 
 import (
-	"encoding/pem"
-	"errors"
-	"fmt"
-	"strings"
-
 	k8sclient "k8s.io/client-go/kubernetes"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -22,33 +17,14 @@ type UnprivilegedClientsetFactory struct {
 
 func NewUnprivilegedClientsetFactory(config *rest.Config) UnprivilegedClientsetFactory {
 	return UnprivilegedClientsetFactory{
-		config: rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		config: rest.CopyConfig(config),
 	}
 }
 
 func (f UnprivilegedClientsetFactory) BuildClientset(authInfo Info) (k8sclient.Interface, error) {
-	config := rest.CopyConfig(f.config)
-
-	switch strings.ToLower(authInfo.Scheme()) {
-	case BearerScheme:
-		config.BearerToken = authInfo.Token
-
-	case CertScheme:
-		certBlock, rst := pem.Decode(authInfo.CertData)
-		if certBlock == nil {
-			return nil, fmt.Errorf("failed to decode cert PEM")
-		}
-
-		keyBlock, _ := pem.Decode(rst)
-		if keyBlock == nil {
-			return nil, fmt.Errorf("failed to decode key PEM")
-		}
-
-		config.CertData = pem.EncodeToMemory(certBlock)
-		config.KeyData = pem.EncodeToMemory(keyBlock)
-
-	default:
-		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
+	config, err := buildUserRestConfig(f.config, authInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	userK8sClient, err := k8sclient.NewForConfig(config)
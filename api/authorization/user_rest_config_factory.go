@@ -0,0 +1,70 @@
+package authorization
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+type UserRestConfigFactory interface {
+	BuildRestConfig(Info) (*rest.Config, error)
+}
+
+type UnprivilegedRestConfigFactory struct {
+	config *rest.Config
+}
+
+func NewUnprivilegedRestConfigFactory(config *rest.Config) UnprivilegedRestConfigFactory {
+	return UnprivilegedRestConfigFactory{
+		config: rest.CopyConfig(config),
+	}
+}
+
+func (f UnprivilegedRestConfigFactory) BuildRestConfig(authInfo Info) (*rest.Config, error) {
+	return buildUserRestConfig(f.config, authInfo)
+}
+
+// buildUserRestConfig derives a config that authenticates as the user from
+// the API's own config. Only impersonation keeps the API credentials, all
+// other schemes start from an anonymous config. Impersonation requires the
+// RBAC granted by the helm chart when the SSH proxy is enabled.
+func buildUserRestConfig(apiConfig *rest.Config, authInfo Info) (*rest.Config, error) {
+	if authInfo.Scheme() == ImpersonationScheme {
+		config := rest.CopyConfig(apiConfig)
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: authInfo.Impersonate.Name,
+			Groups:   authInfo.Impersonate.Groups,
+		}
+		return config, nil
+	}
+
+	config := rest.AnonymousClientConfig(apiConfig)
+
+	switch strings.ToLower(authInfo.Scheme()) {
+	case BearerScheme:
+		config.BearerToken = authInfo.Token
+
+	case CertScheme:
+		certBlock, rst := pem.Decode(authInfo.CertData)
+		if certBlock == nil {
+			return nil, fmt.Errorf("failed to decode cert PEM")
+		}
+
+		keyBlock, _ := pem.Decode(rst)
+		if keyBlock == nil {
+			return nil, fmt.Errorf("failed to decode key PEM")
+		}
+
+		config.CertData = pem.EncodeToMemory(certBlock)
+		config.KeyData = pem.EncodeToMemory(keyBlock)
+
+	default:
+		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
+	}
+
+	return config, nil
+}
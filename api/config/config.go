@@ -57,6 +57,7 @@ type (
 		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
 		K8SClient        K8SClientConfig `yaml:"k8sClient"`
 		SecurityGroups   SecurityGroups  `yaml:"securityGroups"`
+		SSH              SSH             `yaml:"ssh"`
 	}
 
 	ManagedServices struct {
@@ -83,6 +84,16 @@ type (
 		Enabled bool `yaml:"enabled"`
 	}
 
+	SSH struct {
+		Enabled bool `yaml:"enabled"`
+		// Port is the port the SSH proxy listens on
+		Port int `yaml:"port"`
+		// ExternalEndpoint is the host:port users connect to with `cf ssh`
+		ExternalEndpoint string `yaml:"externalEndpoint"`
+		// HostKeyPath is the path to the PEM encoded private host key of the SSH proxy
+		HostKeyPath string `yaml:"hostKeyPath"`
+	}
+
 	RoleLevel string
 
 	Role struct {
@@ -160,6 +171,12 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.Experimental.SSH.Enabled {
+		if c.Experimental.SSH.Port == 0 || c.Experimental.SSH.ExternalEndpoint == "" || c.Experimental.SSH.HostKeyPath == "" {
+			return errors.New("the ssh port, externalEndpoint and hostKeyPath must be set when ssh is enabled")
+		}
+	}
+
 	return nil
 }
 
@@ -213,6 +213,40 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("ssh is enabled", func() {
+		var sshConfig map[string]any
+
+		BeforeEach(func() {
+			sshConfig = map[string]any{
+				"enabled":          true,
+				"port":             2222,
+				"externalEndpoint": "ssh.foo:2222",
+				"hostKeyPath":      "/etc/korifi-ssh/host-key",
+			}
+			configMap["experimental"].(map[string]any)["ssh"] = sshConfig
+		})
+
+		It("loads the ssh config", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.SSH).To(Equal(config.SSH{
+				Enabled:          true,
+				Port:             2222,
+				ExternalEndpoint: "ssh.foo:2222",
+				HostKeyPath:      "/etc/korifi-ssh/host-key",
+			}))
+		})
+
+		When("the host key path is not set", func() {
+			BeforeEach(func() {
+				delete(sshConfig, "hostKeyPath")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("must be set when ssh is enabled")))
+			})
+		})
+	})
+
 	When("external port is specified", func() {
 		BeforeEach(func() {
 			configMap["externalPort"] = 1234
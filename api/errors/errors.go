@@ -370,6 +370,27 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type FeatureDisabledError struct {
+	apiError
+}
+
+func NewFeatureDisabledError(cause error, featureFlagName, customErrorMessage string) FeatureDisabledError {
+	message := featureFlagName
+	if customErrorMessage != "" {
+		message = customErrorMessage
+	}
+
+	return FeatureDisabledError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-FeatureDisabled",
+			detail:     "Feature Disabled: " + message,
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
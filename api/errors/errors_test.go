@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "github.com/onsi/ginkgo/v2"
@@ -355,6 +356,19 @@ var _ = Describe("invalid lists", func() {
 	})
 })
 
+var _ = Describe("feature disabled", func() {
+	It("mentions the feature flag", func() {
+		err := apierrors.NewFeatureDisabledError(nil, "task_creation", "")
+		Expect(err.Detail()).To(Equal("Feature Disabled: task_creation"))
+		Expect(err.HttpStatus()).To(Equal(http.StatusForbidden))
+	})
+
+	It("prefers the custom error message", func() {
+		err := apierrors.NewFeatureDisabledError(nil, "task_creation", "no tasks please")
+		Expect(err.Detail()).To(Equal("Feature Disabled: no tasks please"))
+	})
+})
+
 type testApiError struct {
 	apierrors.ApiError
 }
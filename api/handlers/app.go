@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -78,6 +79,9 @@ type App struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	auditEventRepo          CFAuditEventRepository
+	featureFlagEnforcer     FeatureFlagEnforcer
+	sshEnabled              bool
 }
 
 func NewApp(
@@ -93,6 +97,9 @@ func NewApp(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	auditEventRepo CFAuditEventRepository,
+	featureFlagEnforcer FeatureFlagEnforcer,
+	sshEnabled bool,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -107,6 +114,9 @@ func NewApp(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		auditEventRepo:          auditEventRepo,
+		featureFlagEnforcer:     featureFlagEnforcer,
+		sshEnabled:              sshEnabled,
 	}
 }
 
@@ -272,6 +282,8 @@ func (h *App) start(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
 
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppStart, app)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -307,6 +319,8 @@ func (h *App) stop(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to stop app", "AppGUID", appGUID)
 	}
 
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppStop, app)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -398,6 +412,10 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	scaledProcessRecord, err := h.processRepo.ScaleProcess(r.Context(), authInfo, repositories.ScaleProcessMessage{
 		GUID:               process.GUID,
 		SpaceGUID:          app.SpaceGUID,
@@ -407,6 +425,8 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed due to error from Kubernetes", "appGUID", appGUID)
 	}
 
+	recordAuditEvent(r.Context(), h.auditEventRepo, processScaleAuditEvent(app.Name, process, payload))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(scaledProcessRecord, h.serverURL)), nil
 }
 
@@ -440,9 +460,23 @@ func (h *App) restart(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
 
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppRestart, app)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
+func (h *App) recordAppEvent(ctx context.Context, eventType string, app repositories.AppRecord) {
+	recordAuditEvent(ctx, h.auditEventRepo, repositories.CreateAuditEventMessage{
+		Type:      eventType,
+		SpaceGUID: app.SpaceGUID,
+		Target: repositories.AuditEventParticipant{
+			GUID: app.GUID,
+			Type: repositories.AuditEventTargetTypeApp,
+			Name: app.Name,
+		},
+	})
+}
+
 func (h *App) delete(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.delete")
@@ -503,6 +537,10 @@ func (h *App) getEnvVars(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app environment variables", "AppGUID", appGUID)
 	}
 
+	if err = h.ensureEnvVarsVisible(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app environment variables are not visible", "AppGUID", appGUID)
+	}
+
 	appEnvVarsRecord := repositories.AppEnvVarsRecord{
 		AppGUID:              appEnvRecord.AppGUID,
 		EnvironmentVariables: appEnvRecord.EnvironmentVariables,
@@ -544,9 +582,23 @@ func (h *App) getEnvironment(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app environment variables", "AppGUID", appGUID)
 	}
 
+	if err = h.ensureEnvVarsVisible(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app environment variables are not visible", "AppGUID", appGUID)
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppEnv(appEnvRecord)), nil
 }
 
+func (h *App) ensureEnvVarsVisible(ctx context.Context, authInfo authorization.Info) error {
+	for _, featureFlag := range []string{korifiv1alpha1.FeatureFlagEnvVarVisibility, korifiv1alpha1.FeatureFlagSpaceDeveloperEnvVarVisibility} {
+		if err := h.featureFlagEnforcer.EnsureFeatureFlagEnabled(ctx, authInfo, featureFlag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (h *App) getProcess(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-process")
@@ -660,30 +712,129 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
-	}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	sshEnabled, err := h.resolveSSHEnabled(r.Context(), authInfo, app)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(sshEnabled), nil
+}
+
+// resolveSSHEnabled combines the global, space and app ssh settings, the
+// first one that is disabled being reported as the reason
+func (h *App) resolveSSHEnabled(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord) (presenter.AppSSHEnabled, error) {
+	if !h.sshEnabled {
+		return presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled globally",
+		}, nil
+	}
+
+	space, err := h.spaceRepo.GetSpace(ctx, authInfo, app.SpaceGUID)
+	if err != nil {
+		return presenter.AppSSHEnabled{}, err
+	}
+
+	if !space.SSHEnabled {
+		return presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  fmt.Sprintf("Disabled for space %s", space.Name),
+		}, nil
+	}
+
+	if !app.SSHEnabled {
+		return presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled for app",
+		}, nil
+	}
+
+	return presenter.AppSSHEnabled{
+		Enabled: true,
+	}, nil
+}
+
+func (h *App) presentAppFeature(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord, featureName string) (presenter.AppFeatureResponse, error) {
+	if featureName != presenter.SSHAppFeature {
+		return presenter.ForAppFeature(app, featureName), nil
+	}
+
+	sshEnabled, err := h.resolveSSHEnabled(ctx, authInfo, app)
+	if err != nil {
+		return presenter.AppFeatureResponse{}, err
+	}
+
+	return presenter.ForAppSSHFeature(sshEnabled), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-feature")
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName != presenter.SSHAppFeature && featureName != presenter.RevisionsAppFeature {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	feature, err := h.presentAppFeature(r.Context(), authInfo, app, featureName)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(feature), nil
+}
+
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
-	switch featureName {
-	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
-	case "revisions":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "revisions",
-			"description": "Enable versioning of an application",
-			"enabled":     false,
-		}), nil
-	default:
+
+	if featureName != presenter.SSHAppFeature && featureName != presenter.RevisionsAppFeature {
 		return nil, apierrors.NewNotFoundError(nil, "Feature")
 	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.AppFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	patchMessage := payload.ToSSHPatchMessage(appGUID, app.SpaceGUID)
+	if featureName == presenter.RevisionsAppFeature {
+		patchMessage = payload.ToRevisionsPatchMessage(appGUID, app.SpaceGUID)
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, patchMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
+	}
+
+	feature, err := h.presentAppFeature(r.Context(), authInfo, app, featureName)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(feature), nil
 }
 
 func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
@@ -758,6 +909,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
@@ -42,6 +42,9 @@ var _ = Describe("App", func() {
 		requestValidator        *fake.RequestValidator
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		auditEventRepo          *fake.CFAuditEventRepository
+		featureFlagEnforcer     *fake.FeatureFlagEnforcer
+		sshEnabled              bool
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -59,21 +62,9 @@ var _ = Describe("App", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
-
-		apiHandler := NewApp(
-			*serverURL,
-			appRepo,
-			dropletRepo,
-			processRepo,
-			routeRepo,
-			domainRepo,
-			spaceRepo,
-			packageRepo,
-			requestValidator,
-			podRepo,
-			gaugesCollector,
-			instancesStateCollector,
-		)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
+		sshEnabled = false
 
 		appRecord = repositories.AppRecord{
 			GUID:        appGUID,
@@ -97,10 +88,27 @@ var _ = Describe("App", func() {
 			},
 		}
 		appRepo.GetAppReturns(appRecord, nil)
-		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
+		apiHandler := NewApp(
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			processRepo,
+			routeRepo,
+			domainRepo,
+			spaceRepo,
+			packageRepo,
+			requestValidator,
+			podRepo,
+			gaugesCollector,
+			instancesStateCollector,
+			auditEventRepo,
+			featureFlagEnforcer,
+			sshEnabled,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -705,6 +713,31 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type:      repositories.AuditEventTypeAppStart,
+				SpaceGUID: spaceGUID,
+				Target: repositories.AuditEventParticipant{
+					GUID: appGUID,
+					Type: repositories.AuditEventTargetTypeApp,
+					Name: "test-app",
+				},
+			}))
+		})
+
+		When("recording the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.CreateAuditEventReturns(repositories.AuditEventRecord{}, errors.New("boom"))
+			})
+
+			It("still starts the app", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+
 		When("getting the app is forbidden", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -767,6 +800,13 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal(repositories.AuditEventTypeAppStop))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+		})
+
 		When("fetching the app is forbidden", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, "App"))
@@ -1095,6 +1135,30 @@ var _ = Describe("App", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
+
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type:      repositories.AuditEventTypeAppProcessScale,
+				SpaceGUID: spaceGUID,
+				Target: repositories.AuditEventParticipant{
+					GUID: appGUID,
+					Type: repositories.AuditEventTargetTypeApp,
+					Name: "test-app",
+				},
+				Data: map[string]any{
+					"process_guid": "process-1-guid",
+					"process_type": "web",
+					"request": map[string]any{
+						"instances":    int32(5),
+						"memory_in_mb": int64(256),
+						"disk_in_mb":   int64(1024),
+					},
+				},
+			}))
+		})
+
 		When("the request body is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
@@ -1105,6 +1169,22 @@ var _ = Describe("App", func() {
 			})
 		})
 
+		When("app scaling is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_scaling", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFeatureFlag).To(Equal("app_scaling"))
+
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+				expectFeatureDisabledError("app_scaling")
+			})
+		})
+
 		When("the user does not have permissions to get the app", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, "App"))
@@ -1434,6 +1514,13 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal(repositories.AuditEventTypeAppRestart))
+			Expect(message.Target.GUID).To(Equal(appGUID))
+		})
+
 		When("no permissions to get the app", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -1581,6 +1668,25 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.environment_variables.VAR", "VAL")))
 		})
 
+		It("checks the env var visibility feature flags", func() {
+			Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFeatureFlag).To(Equal("env_var_visibility"))
+			_, _, actualFeatureFlag = featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(1)
+			Expect(actualFeatureFlag).To(Equal("space_developer_env_var_visibility"))
+		})
+
+		When("env var visibility is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "space_developer_env_var_visibility", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				expectFeatureDisabledError("space_developer_env_var_visibility")
+			})
+		})
+
 		When("there is an error fetching the app env", func() {
 			BeforeEach(func() {
 				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("unknown!"))
@@ -1612,6 +1718,25 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.var.VAR", "VAL")))
 		})
 
+		It("checks the env var visibility feature flags", func() {
+			Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFeatureFlag).To(Equal("env_var_visibility"))
+			_, _, actualFeatureFlag = featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(1)
+			Expect(actualFeatureFlag).To(Equal("space_developer_env_var_visibility"))
+		})
+
+		When("env var visibility is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "space_developer_env_var_visibility", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				expectFeatureDisabledError("space_developer_env_var_visibility")
+			})
+		})
+
 		When("there is an error fetching the app env", func() {
 			BeforeEach(func() {
 				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("unknown!"))
@@ -1788,10 +1913,17 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
+			appRecord.SSHEnabled = true
+			appRepo.GetAppReturns(appRecord, nil)
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:       spaceGUID,
+				Name:       "my-space",
+				SSHEnabled: true,
+			}, nil)
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
 		})
 
-		It("returns false", func() {
+		It("returns disabled globally", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
@@ -1799,36 +1931,198 @@ var _ = Describe("App", func() {
 				MatchJSONPath("$.reason", Equal("Disabled globally")),
 			)))
 		})
+
+		When("ssh is enabled globally", func() {
+			BeforeEach(func() {
+				sshEnabled = true
+			})
+
+			It("returns enabled", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeTrue()),
+					MatchJSONPath("$.reason", Equal("")),
+				)))
+			})
+
+			When("ssh is disabled for the space", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+						GUID:       spaceGUID,
+						Name:       "my-space",
+						SSHEnabled: false,
+					}, nil)
+				})
+
+				It("returns disabled for space", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.enabled", BeFalse()),
+						MatchJSONPath("$.reason", Equal("Disabled for space my-space")),
+					)))
+				})
+			})
+
+			When("ssh is disabled for the app", func() {
+				BeforeEach(func() {
+					appRecord.SSHEnabled = false
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns disabled for app", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.enabled", BeFalse()),
+						MatchJSONPath("$.reason", Equal("Disabled for app")),
+					)))
+				})
+			})
+
+			When("getting the space fails", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features", func() {
 		When("feature ssh is called", func() {
 			BeforeEach(func() {
+				sshEnabled = true
+				appRecord.SSHEnabled = true
+				appRepo.GetAppReturns(appRecord, nil)
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					GUID:       spaceGUID,
+					Name:       "my-space",
+					SSHEnabled: true,
+				}, nil)
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/ssh", nil)
 			})
 
-			It("returns ssh enabled false", func() {
+			It("returns whether ssh is enabled for the app", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualAppGUID).To(Equal(appGUID))
+
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("ssh")),
 					MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
+					MatchJSONPath("$.reason", Equal("")),
 				)))
 			})
+
+			When("ssh is disabled globally", func() {
+				BeforeEach(func() {
+					sshEnabled = false
+				})
+
+				It("returns disabled globally", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.enabled", BeFalse()),
+						MatchJSONPath("$.reason", Equal("Disabled globally")),
+					)))
+				})
+			})
+
+			When("ssh is disabled for the space", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+						GUID:       spaceGUID,
+						Name:       "my-space",
+						SSHEnabled: false,
+					}, nil)
+				})
+
+				It("returns disabled for space", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.enabled", BeFalse()),
+						MatchJSONPath("$.reason", Equal("Disabled for space my-space")),
+					)))
+				})
+			})
+
+			When("ssh is disabled for the app", func() {
+				BeforeEach(func() {
+					appRecord.SSHEnabled = false
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns disabled for app", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.enabled", BeFalse()),
+						MatchJSONPath("$.reason", Equal("Disabled for app")),
+					)))
+				})
+			})
+
+			When("getting the space fails", func() {
+				BeforeEach(func() {
+					spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("the app is not accessible", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError("App")
+				})
+			})
 		})
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
+				revisionsApp := appRecord
+				revisionsApp.RevisionsEnabled = true
+				appRepo.GetAppReturns(revisionsApp, nil)
+
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
 			})
 
-			It("returns revisions enabled false", func() {
+			It("returns the revisions feature", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("revisions")),
 					MatchJSONPath("$.description", Equal("Enable versioning of an application")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
 		})
@@ -1850,6 +2144,107 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features/ssh", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			patchedApp := appRecord
+			patchedApp.SSHEnabled = false
+			appRepo.PatchAppReturns(patchedApp, nil)
+
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("updates the app ssh feature", func() {
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, msg := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(msg.AppGUID).To(Equal(appGUID))
+			Expect(msg.SpaceGUID).To(Equal(spaceGUID))
+			Expect(msg.SSHEnabled).To(PointTo(BeFalse()))
+		})
+
+		It("returns the updated feature", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+
+			It("does not patch the app", func() {
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("updating the revisions feature", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("updates the app revisions feature", func() {
+				Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+				_, _, msg := appRepo.PatchAppArgsForCall(0)
+				Expect(msg.AppGUID).To(Equal(appGUID))
+				Expect(msg.RevisionsEnabled).To(PointTo(BeFalse()))
+				Expect(msg.SSHEnabled).To(BeNil())
+			})
+
+			It("returns the updated feature", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", Equal("revisions"))))
+			})
+		})
+
+		When("updating an unknown feature", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/anything-else", strings.NewReader("the-json-body"))
+			})
+
+			It("returns feature not found", func() {
+				expectNotFoundError("Feature")
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = "/v3/audit_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+
+type CFAuditEventRepository interface {
+	CreateAuditEvent(context.Context, authorization.Info, repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error)
+	GetAuditEvent(context.Context, authorization.Info, string) (repositories.AuditEventRecord, error)
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	auditEventRepo   CFAuditEventRepository
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	auditEventRepo CFAuditEventRepository,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		auditEventRepo:   auditEventRepo,
+	}
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+
+	auditEventGUID := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, auditEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch audit event from Kubernetes", "AuditEventGUID", auditEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	payload := new(payloads.AuditEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch audit events from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
+
+// recordAuditEvent records an audit event for an action the user has
+// performed. Failing to record the event does not fail the action, as it has
+// already taken effect.
+func recordAuditEvent(ctx context.Context, auditEventRepo CFAuditEventRepository, message repositories.CreateAuditEventMessage) {
+	authInfo, _ := authorization.InfoFromContext(ctx)
+	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.record-audit-event")
+
+	if _, err := auditEventRepo.CreateAuditEvent(ctx, authInfo, message); err != nil {
+		logger.Info("failed to record audit event", "type", message.Type, "targetGUID", message.Target.GUID, "reason", err)
+	}
+}
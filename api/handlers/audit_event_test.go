@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		auditEventRepo   *fake.CFAuditEventRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+
+		apiHandler := handlers.NewAuditEvent(*serverURL, requestValidator, auditEventRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/audit_events/{guid}", func() {
+		BeforeEach(func() {
+			auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+				GUID:      "audit-event-guid",
+				Type:      "app.crash",
+				Target:    repositories.AuditEventParticipant{GUID: appGUID, Type: "app"},
+				Data:      map[string]any{"exit_status": 1},
+				SpaceGUID: spaceGUID,
+			}, nil)
+			req = createHttpRequest("GET", "/v3/audit_events/audit-event-guid", nil)
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("audit-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "audit-event-guid"),
+				MatchJSONPath("$.type", "app.crash"),
+				MatchJSONPath("$.target.guid", appGUID),
+				MatchJSONPath("$.data.exit_status", BeEquivalentTo(1)),
+				MatchJSONPath("$.space.guid", spaceGUID),
+			)))
+		})
+
+		When("the audit event is not accessible", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/audit_events", func() {
+		BeforeEach(func() {
+			auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+				{GUID: "audit-event-1", Type: "app.crash"},
+				{GUID: "audit-event-2", Type: "audit.app.start"},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AuditEventList{
+				TargetGUIDs: appGUID,
+				OrderBy:     "-created_at",
+			})
+			req = createHttpRequest("GET", "/v3/audit_events?target_guids="+appGUID, nil)
+		})
+
+		It("lists the audit events", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListAuditEventsMessage{
+				TargetGUIDs: []string{appGUID},
+				OrderBy:     "-created_at",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "audit-event-1"),
+				MatchJSONPath("$.resources[1].guid", "audit-event-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid query"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("invalid query")
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
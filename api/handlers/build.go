@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"net/url"
 
@@ -27,6 +26,7 @@ type CFBuildRepository interface {
 	ListBuilds(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)
 	GetLatestBuildByAppGUID(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)
 	CreateBuild(context.Context, authorization.Info, repositories.CreateBuildMessage) (repositories.BuildRecord, error)
+	UpdateBuild(context.Context, authorization.Info, repositories.UpdateBuildMessage) (repositories.BuildRecord, error)
 }
 
 type Build struct {
@@ -35,6 +35,7 @@ type Build struct {
 	packageRepo      CFPackageRepository
 	appRepo          CFAppRepository
 	requestValidator RequestValidator
+	auditEventRepo   CFAuditEventRepository
 }
 
 func NewBuild(
@@ -43,6 +44,7 @@ func NewBuild(
 	packageRepo CFPackageRepository,
 	appRepo CFAppRepository,
 	requestValidator RequestValidator,
+	auditEventRepo CFAuditEventRepository,
 ) *Build {
 	return &Build{
 		serverURL:        serverURL,
@@ -50,6 +52,7 @@ func NewBuild(
 		packageRepo:      packageRepo,
 		appRepo:          appRepo,
 		requestValidator: requestValidator,
+		auditEventRepo:   auditEventRepo,
 	}
 }
 
@@ -108,11 +111,45 @@ func (h *Build) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating build with repository")
 	}
 
+	recordAuditEvent(r.Context(), h.auditEventRepo, repositories.CreateAuditEventMessage{
+		Type:      repositories.AuditEventTypeAppBuildCreate,
+		SpaceGUID: appRecord.SpaceGUID,
+		Target: repositories.AuditEventParticipant{
+			GUID: appRecord.GUID,
+			Type: repositories.AuditEventTargetTypeApp,
+			Name: appRecord.Name,
+		},
+		Data: map[string]any{
+			"build_guid":   record.GUID,
+			"package_guid": packageRecord.GUID,
+		},
+	})
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForBuild(record, h.serverURL)), nil
 }
 
-func (h *Build) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
-	return nil, apierrors.NewUnprocessableEntityError(errors.New("update build failed"), "Labels and annotations are not supported for builds.")
+func (h *Build) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.build.update")
+
+	buildGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.BuildUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.buildRepo.GetBuild(r.Context(), authInfo, buildGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to fetch "+repositories.BuildResourceType, "guid", buildGUID)
+	}
+
+	build, err := h.buildRepo.UpdateBuild(r.Context(), authInfo, payload.ToMessage(buildGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error updating build in repository", "guid", buildGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuild(build, h.serverURL)), nil
 }
 
 func (h *Build) list(r *http.Request) (*routing.Response, error) {
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppBuildpackCachePath        = "/v3/apps/{guid}/buildpack_cache"
+	AdminClearBuildpackCachePath = "/v3/admin/actions/clear_buildpack_cache"
+)
+
+//counterfeiter:generate -o fake -fake-name BuildCacheRepository . BuildCacheRepository
+
+type BuildCacheRepository interface {
+	ClearAppBuildCache(context.Context, authorization.Info, string) (string, error)
+	ClearAllBuildCaches(context.Context, authorization.Info) (string, error)
+}
+
+type BuildCache struct {
+	serverURL      url.URL
+	buildCacheRepo BuildCacheRepository
+}
+
+func NewBuildCache(
+	serverURL url.URL,
+	buildCacheRepo BuildCacheRepository,
+) *BuildCache {
+	return &BuildCache{
+		serverURL:      serverURL,
+		buildCacheRepo: buildCacheRepo,
+	}
+}
+
+func (h *BuildCache) clearAppCache(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.build-cache.clear-app-cache")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	requestGUID, err := h.buildCacheRepo.ClearAppBuildCache(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to clear app build cache", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(requestGUID, presenter.AppClearBuildpackCacheOperation, h.serverURL),
+	), nil
+}
+
+func (h *BuildCache) clearAllCaches(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.build-cache.clear-all-caches")
+
+	requestGUID, err := h.buildCacheRepo.ClearAllBuildCaches(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to clear build caches")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(requestGUID, presenter.AdminClearBuildpackCacheOperation, h.serverURL),
+	), nil
+}
+
+func (h *BuildCache) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *BuildCache) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "DELETE", Pattern: AppBuildpackCachePath, Handler: h.clearAppCache},
+		{Method: "POST", Pattern: AdminClearBuildpackCachePath, Handler: h.clearAllCaches},
+	}
+}
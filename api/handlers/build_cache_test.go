@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildCache", func() {
+	var (
+		buildCacheRepo *fake.BuildCacheRepository
+		req            *http.Request
+	)
+
+	BeforeEach(func() {
+		buildCacheRepo = new(fake.BuildCacheRepository)
+		apiHandler := NewBuildCache(*serverURL, buildCacheRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the DELETE /v3/apps/:guid/buildpack_cache endpoint", func() {
+		BeforeEach(func() {
+			buildCacheRepo.ClearAppBuildCacheReturns("clear-request-guid", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/apps/app-guid/buildpack_cache", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requests clearing the build cache of the app", func() {
+			Expect(buildCacheRepo.ClearAppBuildCacheCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := buildCacheRepo.ClearAppBuildCacheArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/app.clear_buildpack_cache~clear-request-guid"))
+		})
+
+		When("the user is not authorized to get the app", func() {
+			BeforeEach(func() {
+				buildCacheRepo.ClearAppBuildCacheReturns("", apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("clearing the build cache fails", func() {
+			BeforeEach(func() {
+				buildCacheRepo.ClearAppBuildCacheReturns("", errors.New("clear-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/admin/actions/clear_buildpack_cache endpoint", func() {
+		BeforeEach(func() {
+			buildCacheRepo.ClearAllBuildCachesReturns("clear-request-guid", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/admin/actions/clear_buildpack_cache", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("requests clearing all build caches", func() {
+			Expect(buildCacheRepo.ClearAllBuildCachesCallCount()).To(Equal(1))
+			_, actualAuthInfo := buildCacheRepo.ClearAllBuildCachesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/admin.clear_buildpack_cache~clear-request-guid"))
+		})
+
+		When("the user is not an admin", func() {
+			BeforeEach(func() {
+				buildCacheRepo.ClearAllBuildCachesReturns("", apierrors.NewForbiddenError(nil, repositories.BuildCacheResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
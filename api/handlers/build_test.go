@@ -1,7 +1,6 @@
 package handlers_test
 
 import (
-	"context"
 	"errors"
 	"net/http"
 	"strings"
@@ -31,6 +30,7 @@ var _ = Describe("Build", func() {
 		appRepo          *fake.CFAppRepository
 		buildRepo        *fake.CFBuildRepository
 		packageRepo      *fake.CFPackageRepository
+		auditEventRepo   *fake.CFAuditEventRepository
 	)
 
 	BeforeEach(func() {
@@ -38,6 +38,7 @@ var _ = Describe("Build", func() {
 		appRepo = new(fake.CFAppRepository)
 		buildRepo = new(fake.CFBuildRepository)
 		packageRepo = new(fake.CFPackageRepository)
+		auditEventRepo = new(fake.CFAuditEventRepository)
 
 		apiHandler = handlers.NewBuild(
 			*serverURL,
@@ -45,6 +46,7 @@ var _ = Describe("Build", func() {
 			packageRepo,
 			appRepo,
 			requestValidator,
+			auditEventRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -286,6 +288,23 @@ var _ = Describe("Build", func() {
 			)))
 		})
 
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type:      repositories.AuditEventTypeAppBuildCreate,
+				SpaceGUID: spaceGUID,
+				Target: repositories.AuditEventParticipant{
+					GUID: appGUID,
+					Type: repositories.AuditEventTargetTypeApp,
+				},
+				Data: map[string]any{
+					"build_guid":   buildGUID,
+					"package_guid": packageGUID,
+				},
+			}))
+		})
+
 		When("the package doesn't exist", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewNotFoundError(nil, repositories.PackageResourceType))
@@ -375,13 +394,83 @@ var _ = Describe("Build", func() {
 
 	Describe("the PATCH /v3/builds endpoint", func() {
 		BeforeEach(func() {
+			buildRepo.GetBuildReturns(repositories.BuildRecord{GUID: "build-guid", State: "STAGING"}, nil)
+			buildRepo.UpdateBuildReturns(repositories.BuildRecord{GUID: "build-guid", State: "STAGING"}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildUpdate{
+				State: "FAILED",
+				Metadata: payloads.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+
 			var err error
-			req, err = http.NewRequestWithContext(context.Background(), "PATCH", "/v3/builds/build-guid", strings.NewReader(`{}`))
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/builds/build-guid", strings.NewReader("the-json-body"))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("returns an unprocessable entity error", func() {
-			expectUnprocessableEntityError("Labels and annotations are not supported for builds.")
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("updates the build", func() {
+			Expect(buildRepo.UpdateBuildCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := buildRepo.UpdateBuildArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.UpdateBuildMessage{
+				GUID:  "build-guid",
+				State: "FAILED",
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "build-guid")))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the user is not authorized to get the build", func() {
+			BeforeEach(func() {
+				buildRepo.GetBuildReturns(repositories.BuildRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildResourceType)
+				Expect(buildRepo.UpdateBuildCallCount()).To(BeZero())
+			})
+		})
+
+		When("the build cannot be cancelled", func() {
+			BeforeEach(func() {
+				buildRepo.UpdateBuildReturns(repositories.BuildRecord{}, apierrors.NewUnprocessableEntityError(nil, "Only builds in the STAGING state can be cancelled"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only builds in the STAGING state can be cancelled")
+			})
+		})
+
+		When("updating the build fails", func() {
+			BeforeEach(func() {
+				buildRepo.UpdateBuildReturns(repositories.BuildRecord{}, errors.New("update-build-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 })
@@ -1,32 +1,41 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Buildpack", func() {
 	var (
 		buildpackRepo    *fake.BuildpackRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 		requestValidator *fake.RequestValidator
 	)
 
 	BeforeEach(func() {
 		buildpackRepo = new(fake.BuildpackRepository)
+		imageRepo = new(fake.ImageRepository)
 
 		requestValidator = new(fake.RequestValidator)
-		apiHandler := NewBuildpack(*serverURL, buildpackRepo, requestValidator)
+		apiHandler := NewBuildpack(*serverURL, buildpackRepo, imageRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -39,6 +48,7 @@ var _ = Describe("Buildpack", func() {
 			buildpackRepo.ListBuildpacksReturns([]repositories.BuildpackRecord{
 				{
 					Name:      "paketo-foopacks/bar",
+					Filename:  "paketo-foopacks/bar@1.0.0",
 					Position:  1,
 					Stack:     "waffle-house",
 					Version:   "1.0.0",
@@ -83,4 +93,328 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+				GUID: "bp-guid",
+				Name: "my-buildpack",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/buildpacks/bp-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the buildpack", func() {
+			Expect(buildpackRepo.GetBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := buildpackRepo.GetBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("bp-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.name", "my-buildpack"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/buildpacks/bp-guid"),
+			)))
+		})
+
+		When("the user cannot get the buildpack", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/buildpacks endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackCreate{
+				Name:     "my-buildpack",
+				Position: tools.PtrTo(2),
+			})
+
+			buildpackRepo.CreateBuildpackReturns(repositories.BuildpackRecord{
+				GUID:     "bp-guid",
+				Name:     "my-buildpack",
+				Position: 2,
+				State:    repositories.BuildpackStateAwaitingUpload,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/buildpacks", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the buildpack", func() {
+			Expect(buildpackRepo.CreateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildpackRepo.CreateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-buildpack"))
+			Expect(message.Position).To(Equal(2))
+			Expect(message.Enabled).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/buildpacks/bp-guid/upload"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(buildpackRepo.CreateBuildpackCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the buildpack fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.CreateBuildpackReturns(repositories.BuildpackRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackUpdate{
+				Position: tools.PtrTo(4),
+			})
+
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{GUID: "bp-guid"}, nil)
+			buildpackRepo.UpdateBuildpackReturns(repositories.BuildpackRecord{
+				GUID:     "bp-guid",
+				Position: 4,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/buildpacks/bp-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the buildpack", func() {
+			Expect(buildpackRepo.UpdateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildpackRepo.UpdateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("bp-guid"))
+			Expect(message.Position).To(PointTo(Equal(4)))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.position", BeEquivalentTo(4))))
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+				Expect(buildpackRepo.UpdateBuildpackCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/buildpacks/bp-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the buildpack", func() {
+			Expect(buildpackRepo.DeleteBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := buildpackRepo.DeleteBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("bp-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/buildpack.delete~bp-guid"))
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				buildpackRepo.DeleteBuildpackReturns(apierrors.NewNotFoundError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/buildpacks/:guid/upload endpoint", func() {
+		createUploadRequest := func(bits string, image string) *http.Request {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			if bits != "" {
+				part, err := writer.CreateFormFile("bits", "my-buildpack.cnb")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader(bits))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			if image != "" {
+				Expect(writer.WriteField("image", image)).To(Succeed())
+			}
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", "/v3/buildpacks/bp-guid/upload", &b)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+				GUID:          "bp-guid",
+				RepositoryRef: "registry.repo/bp-guid-buildpack",
+			}, nil)
+			buildpackRepo.UpdateBuildpackSourceReturns(repositories.BuildpackRecord{
+				GUID:  "bp-guid",
+				State: repositories.BuildpackStateReady,
+			}, nil)
+			imageRepo.UploadBuildpackImageReturns("registry.repo/bp-guid-buildpack@sha256:some-sha", nil)
+
+			req = createUploadRequest("the-buildpack-contents", "")
+		})
+
+		It("pushes the archive and updates the buildpack source", func() {
+			Expect(imageRepo.UploadBuildpackImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, srcFile, actualTags := imageRepo.UploadBuildpackImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/bp-guid-buildpack"))
+			actualContents, err := io.ReadAll(srcFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContents)).To(Equal("the-buildpack-contents"))
+			Expect(actualTags).To(ConsistOf("bp-guid"))
+
+			Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(Equal(1))
+			_, _, message := buildpackRepo.UpdateBuildpackSourceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateBuildpackSourceMessage{
+				GUID:     "bp-guid",
+				Image:    "registry.repo/bp-guid-buildpack@sha256:some-sha",
+				Filename: "my-buildpack.cnb",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/buildpack.upload~bp-guid"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.state", "READY")))
+		})
+
+		When("an image reference is uploaded", func() {
+			BeforeEach(func() {
+				req = createUploadRequest("", "docker.io/paketobuildpacks/java:1.0.0")
+			})
+
+			It("sets the image on the buildpack without pushing", func() {
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+
+				Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(Equal(1))
+				_, _, message := buildpackRepo.UpdateBuildpackSourceArgsForCall(0)
+				Expect(message).To(Equal(repositories.UpdateBuildpackSourceMessage{
+					GUID:     "bp-guid",
+					Image:    "docker.io/paketobuildpacks/java:1.0.0",
+					Filename: "docker.io/paketobuildpacks/java:1.0.0",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			})
+		})
+
+		When("the image reference is invalid", func() {
+			BeforeEach(func() {
+				req = createUploadRequest("", "NOT A REF")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`invalid image ref: "NOT A REF"`)
+				Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(BeZero())
+			})
+		})
+
+		When("neither bits nor an image are uploaded", func() {
+			BeforeEach(func() {
+				req = createUploadRequest("", "")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("both bits and an image are uploaded", func() {
+			BeforeEach(func() {
+				req = createUploadRequest("the-buildpack-contents", "docker.io/paketobuildpacks/java:1.0.0")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("the buildpack is locked", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+					GUID:   "bp-guid",
+					Locked: true,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The buildpack is locked")
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot get the buildpack", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+
+		When("pushing the archive fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadBuildpackImageReturns("", errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -130,6 +130,32 @@ var _ = Describe("Deployment", func() {
 			}))
 		})
 
+		When("rolling back to a revision", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DeploymentCreate{
+					Revision: &payloads.RevisionGUID{
+						Guid: "revision-guid",
+					},
+					Relationships: &payloads.DeploymentRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+				})
+			})
+
+			It("creates the deployment for the revision", func() {
+				Expect(deploymentsRepo.CreateDeploymentCallCount()).To(Equal(1))
+				_, _, createMessage := deploymentsRepo.CreateDeploymentArgsForCall(0)
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      appGUID,
+					RevisionGUID: "revision-guid",
+				}))
+			})
+		})
+
 		When("the request payload is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 
@@ -17,8 +18,10 @@ import (
 )
 
 const (
-	DomainsPath = "/v3/domains"
-	DomainPath  = "/v3/domains/{guid}"
+	DomainsPath                   = "/v3/domains"
+	DomainPath                    = "/v3/domains/{guid}"
+	DomainSharedOrganizationsPath = "/v3/domains/{guid}/relationships/shared_organizations"
+	DomainSharedOrganizationPath  = "/v3/domains/{guid}/relationships/shared_organizations/{org_guid}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -29,23 +32,28 @@ type CFDomainRepository interface {
 	UpdateDomain(context.Context, authorization.Info, repositories.UpdateDomainMessage) (repositories.DomainRecord, error)
 	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) ([]repositories.DomainRecord, error)
 	DeleteDomain(context.Context, authorization.Info, string) error
+	ShareDomain(context.Context, authorization.Info, repositories.ShareDomainMessage) (repositories.DomainRecord, error)
+	UnshareDomain(context.Context, authorization.Info, repositories.UnshareDomainMessage) error
 }
 
 type Domain struct {
-	serverURL        url.URL
-	requestValidator RequestValidator
-	domainRepo       CFDomainRepository
+	serverURL           url.URL
+	requestValidator    RequestValidator
+	domainRepo          CFDomainRepository
+	featureFlagEnforcer FeatureFlagEnforcer
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	featureFlagEnforcer FeatureFlagEnforcer,
 ) *Domain {
 	return &Domain{
-		serverURL:        serverURL,
-		requestValidator: requestValidator,
-		domainRepo:       domainRepo,
+		serverURL:           serverURL,
+		requestValidator:    requestValidator,
+		domainRepo:          domainRepo,
+		featureFlagEnforcer: featureFlagEnforcer,
 	}
 }
 
@@ -64,6 +72,12 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.OrganizationGUID != "" {
+		if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagPrivateDomainCreation); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "private domain creation is disabled")
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
@@ -144,6 +158,63 @@ func (h *Domain) delete(r *http.Request) (*routing.Response, error) {
 	), nil
 }
 
+func (h *Domain) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.share")
+
+	domainGUID := routing.URLParam(r, "guid")
+	logger = logger.WithValues("guid", domainGUID)
+
+	var payload payloads.DomainShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	domain, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	if !domain.IsPrivate() {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Shared domains cannot be shared with organizations."),
+			"cannot share a shared domain",
+		)
+	}
+
+	domain, err = h.domainRepo.ShareDomain(r.Context(), authInfo, payload.ToMessage(domainGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error sharing domain")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDomainSharedOrganizations(domain)), nil
+}
+
+func (h *Domain) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.unshare")
+
+	domainGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+	logger = logger.WithValues("guid", domainGUID, "orgGUID", orgGUID)
+
+	_, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	err = h.domainRepo.UnshareDomain(r.Context(), authInfo, repositories.UnshareDomainMessage{
+		DomainGUID:       domainGUID,
+		OrganizationGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error unsharing domain")
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *Domain) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -155,5 +226,7 @@ func (h *Domain) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: DomainPath, Handler: h.update},
 		{Method: "GET", Pattern: DomainsPath, Handler: h.list},
 		{Method: "DELETE", Pattern: DomainPath, Handler: h.delete},
+		{Method: "POST", Pattern: DomainSharedOrganizationsPath, Handler: h.share},
+		{Method: "DELETE", Pattern: DomainSharedOrganizationPath, Handler: h.unshare},
 	}
 }
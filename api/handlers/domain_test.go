@@ -19,19 +19,22 @@ import (
 
 var _ = Describe("Domain", func() {
 	var (
-		apiHandler       *handlers.Domain
-		domainRepo       *fake.CFDomainRepository
-		requestValidator *fake.RequestValidator
-		req              *http.Request
+		apiHandler          *handlers.Domain
+		domainRepo          *fake.CFDomainRepository
+		requestValidator    *fake.RequestValidator
+		featureFlagEnforcer *fake.FeatureFlagEnforcer
+		req                 *http.Request
 	)
 
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 		apiHandler = handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			featureFlagEnforcer,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -96,6 +99,36 @@ var _ = Describe("Domain", func() {
 			)))
 		})
 
+		It("does not check the private domain creation feature flag for shared domains", func() {
+			Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(BeZero())
+		})
+
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				payload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				}
+			})
+
+			It("checks the private domain creation feature flag", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFeatureFlag).To(Equal("private_domain_creation"))
+			})
+
+			When("private domain creation is disabled", func() {
+				BeforeEach(func() {
+					featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "private_domain_creation", ""))
+				})
+
+				It("returns a Feature Disabled error", func() {
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+					expectFeatureDisabledError("private_domain_creation")
+				})
+			})
+		})
+
 		When("decoding the payload fails", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
@@ -365,4 +398,133 @@ var _ = Describe("Domain", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/domains/:guid/relationships/shared_organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DomainShare{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+			})
+
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				GUID:             "my-domain",
+				OrganizationGUID: "owner-org",
+			}, nil)
+
+			domainRepo.ShareDomainReturns(repositories.DomainRecord{
+				GUID:                    "my-domain",
+				OrganizationGUID:        "owner-org",
+				SharedOrganizationGUIDs: []string{"org-1", "org-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/domains/my-domain/relationships/shared_organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("shares the domain", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
+			_, _, actualDomainGUID := domainRepo.GetDomainArgsForCall(0)
+			Expect(actualDomainGUID).To(Equal("my-domain"))
+
+			Expect(domainRepo.ShareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, shareMessage := domainRepo.ShareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(shareMessage).To(Equal(repositories.ShareDomainMessage{
+				DomainGUID:        "my-domain",
+				OrganizationGUIDs: []string{"org-1", "org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"data": [{"guid": "org-1"}, {"guid": "org-2"}]}`)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the user is not authorized to get the domain", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, "CFDomain"))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError("CFDomain")
+			})
+		})
+
+		When("the domain is a shared domain", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "my-domain"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Shared domains cannot be shared with organizations.")
+			})
+
+			It("does not share the domain", func() {
+				Expect(domainRepo.ShareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("sharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.ShareDomainReturns(repositories.DomainRecord{}, errors.New("share-domain-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/domains/:guid/relationships/shared_organizations/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/domains/my-domain/relationships/shared_organizations/org-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unshares the domain", func() {
+			Expect(domainRepo.UnshareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, unshareMessage := domainRepo.UnshareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(unshareMessage).To(Equal(repositories.UnshareDomainMessage{
+				DomainGUID:       "my-domain",
+				OrganizationGUID: "org-1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the user is not authorized to get the domain", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, "CFDomain"))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError("CFDomain")
+			})
+		})
+
+		When("unsharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.UnshareDomainReturns(errors.New("unshare-domain-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -14,10 +15,14 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
 const (
-	DropletPath = "/v3/droplets/{guid}"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -25,22 +30,31 @@ type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	CopyDroplet(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletImage(context.Context, authorization.Info, repositories.UpdateDropletImageMessage) (repositories.DropletRecord, error)
 }
 
 type Droplet struct {
 	serverURL        url.URL
 	dropletRepo      CFDropletRepository
+	appRepo          CFAppRepository
+	imageRepo        ImageRepository
 	requestValidator RequestValidator
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
 ) *Droplet {
 	return &Droplet{
 		serverURL:        serverURL,
 		dropletRepo:      dropletRepo,
+		appRepo:          appRepo,
+		imageRepo:        imageRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -93,6 +107,210 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	var source payloads.DropletCreateSource
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &source); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if source.SourceGUID != "" {
+		return h.copy(r, source.SourceGUID)
+	}
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.getApp(r, payload.App.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.App.GUID)
+	}
+
+	if appRecord.Lifecycle.Type != "buildpack" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Droplet creation is not supported for %s apps.", appRecord.Lifecycle.Type)),
+			"cannot create a droplet for a non-buildpack app",
+			"App GUID", appRecord.GUID,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.copy")
+
+	var payload payloads.DropletCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceDroplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source droplet is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source droplet",
+			"Source GUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.getApp(r, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error finding App", "App GUID", payload.Relationships.App.Data.GUID)
+	}
+
+	if appRecord.Lifecycle.Type != sourceDroplet.Lifecycle.Type {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot copy a %s droplet to a %s app.", sourceDroplet.Lifecycle.Type, appRecord.Lifecycle.Type)),
+			"droplet and app lifecycle types do not match",
+			"Source GUID", sourceGUID,
+			"App GUID", appRecord.GUID,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CopyDroplet(r.Context(), authInfo, payload.ToMessage(sourceGUID, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type != string(korifiv1alpha1.DockerLifecycle) {
+		copiedImageRef, err := h.imageRepo.CopyDropletImage(r.Context(), authInfo, sourceDroplet.ImageRef, droplet.RepositoryRef, droplet.SpaceGUID, droplet.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling CopyDropletImage")
+		}
+
+		droplet, err = h.dropletRepo.UpdateDropletImage(r.Context(), authInfo, repositories.UpdateDropletImageMessage{
+			GUID:     droplet.GUID,
+			ImageRef: copiedImageRef,
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletImage")
+		}
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) getApp(r *http.Request, appGUID string) (repositories.AppRecord, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.AsUnprocessableEntity(
+			err,
+			"App is invalid. Ensure it exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return appRecord, nil
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	err := r.ParseForm()
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	bitsFile, _, err := r.FormFile("bits")
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	}
+	defer bitsFile.Close()
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet must be in AWAITING_UPLOAD state."),
+			"cannot upload to a droplet that is not awaiting upload",
+			"dropletGUID", dropletGUID,
+			"state", droplet.State,
+		)
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.RepositoryRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadDropletImage")
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletImage(r.Context(), authInfo, repositories.UpdateDropletImageMessage{
+		GUID:     dropletGUID,
+		ImageRef: uploadedImageRef,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(dropletGUID, presenter.DropletUploadOperation, h.serverURL)).
+		WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type != "buildpack" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot download droplets with '%s' lifecycle.", droplet.Lifecycle.Type)),
+			"only buildpack droplets can be downloaded",
+			"dropletGUID", dropletGUID,
+		)
+	}
+
+	if droplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Only staged droplets can be downloaded."),
+			"cannot download a droplet that is not staged",
+			"dropletGUID", dropletGUID,
+			"state", droplet.State,
+		)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tar")).
+		WithStream("application/x-tar", func(w io.Writer) error {
+			return h.imageRepo.DownloadDropletImage(r.Context(), authInfo, droplet.ImageRef, w)
+		}), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -101,5 +319,8 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
@@ -1,11 +1,16 @@
 package handlers_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -31,11 +36,15 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.ImageRepository)
 		var err error
 		req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID, nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,6 +54,8 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -240,4 +251,465 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		var payload *payloads.DropletCreate
+
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: "the-space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:    dropletGUID,
+				State:   "AWAITING_UPLOAD",
+				AppGUID: appGUID,
+			}, nil)
+
+			payload = &payloads.DropletCreate{
+				App:          &payloads.DropletApp{GUID: appGUID},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the droplet", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      appGUID,
+				SpaceGUID:    "the-space-guid",
+				Lifecycle:    repositories.Lifecycle{Type: "buildpack"},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the app is a docker app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet creation is not supported for docker apps.")
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is a dockerfile app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "dockerfile"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet creation is not supported for dockerfile apps.")
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("create-droplet-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the source_guid query parameter is set", func() {
+			var copyPayload *payloads.DropletCopy
+
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletCreateSource{
+					SourceGUID: "the-source-guid",
+				})
+
+				copyPayload = &payloads.DropletCopy{
+					Relationships: &payloads.DropletCopyRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: appGUID},
+						},
+					},
+				}
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(copyPayload)
+
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      "the-source-guid",
+					State:     "STAGED",
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					ImageRef:  "source-app-droplets@sha256:abc",
+				}, nil)
+
+				dropletRepo.CopyDropletReturns(repositories.DropletRecord{
+					GUID:          dropletGUID,
+					State:         "AWAITING_UPLOAD",
+					AppGUID:       appGUID,
+					SpaceGUID:     "the-space-guid",
+					Lifecycle:     repositories.Lifecycle{Type: "buildpack"},
+					RepositoryRef: "target-app-droplets",
+				}, nil)
+
+				imageRepo.CopyDropletImageReturns("target-app-droplets@sha256:abc", nil)
+
+				dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{
+					GUID:    dropletGUID,
+					State:   "PROCESSING_UPLOAD",
+					AppGUID: appGUID,
+				}, nil)
+			})
+
+			It("copies the droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualSourceGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualSourceGUID).To(Equal("the-source-guid"))
+
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+				Expect(dropletRepo.CopyDropletCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := dropletRepo.CopyDropletArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.CopyDropletMessage{
+					SourceGUID: "the-source-guid",
+					AppGUID:    appGUID,
+					SpaceGUID:  "the-space-guid",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", dropletGUID),
+					MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+				)))
+			})
+
+			It("copies the droplet image into the repository of the target app", func() {
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualImageRef, actualRepoRef, actualSpaceGUID, actualTags := imageRepo.CopyDropletImageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualImageRef).To(Equal("source-app-droplets@sha256:abc"))
+				Expect(actualRepoRef).To(Equal("target-app-droplets"))
+				Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+				Expect(actualTags).To(ConsistOf(dropletGUID))
+
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.UpdateDropletImageMessage{
+					GUID:     dropletGUID,
+					ImageRef: "target-app-droplets@sha256:abc",
+				}))
+			})
+
+			When("the droplet is a docker droplet", func() {
+				BeforeEach(func() {
+					dropletRepo.CopyDropletReturns(repositories.DropletRecord{
+						GUID:      dropletGUID,
+						State:     "PROCESSING_UPLOAD",
+						AppGUID:   appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("does not copy the docker image", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(imageRepo.CopyDropletImageCallCount()).To(BeZero())
+					Expect(dropletRepo.UpdateDropletImageCallCount()).To(BeZero())
+				})
+			})
+
+			When("copying the droplet image fails", func() {
+				BeforeEach(func() {
+					imageRepo.CopyDropletImageReturns("", errors.New("copy-image-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+					Expect(dropletRepo.UpdateDropletImageCallCount()).To(BeZero())
+				})
+			})
+
+			When("the source droplet does not exist", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewNotFoundError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet is invalid. Ensure it exists and you have access to it.")
+				})
+			})
+
+			When("the app lifecycle does not match the droplet lifecycle", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						GUID:      appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot copy a buildpack droplet to a docker app.")
+					Expect(dropletRepo.CopyDropletCallCount()).To(BeZero())
+				})
+			})
+
+			When("copying the droplet fails", func() {
+				BeforeEach(func() {
+					dropletRepo.CopyDropletReturns(repositories.DropletRecord{}, errors.New("copy-droplet-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		createUploadRequest := func(withBits bool) *http.Request {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			if withBits {
+				part, err := writer.CreateFormFile("bits", "droplet.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-droplet-contents"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", "/v3/droplets/"+dropletGUID+"/upload", &b)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:          dropletGUID,
+				State:         "AWAITING_UPLOAD",
+				SpaceGUID:     "the-space-guid",
+				RepositoryRef: "registry.repo/droplets",
+			}, nil)
+
+			dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{
+				GUID:  dropletGUID,
+				State: "PROCESSING_UPLOAD",
+			}, nil)
+
+			imageRepo.UploadDropletImageReturns("registry.repo/droplets@sha256:some-sha", nil)
+
+			req = createUploadRequest(true)
+		})
+
+		It("uploads the droplet", func() {
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, srcFile, actualSpaceGUID, actualTags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/droplets"))
+			actualContents, err := io.ReadAll(srcFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContents)).To(Equal("the-droplet-contents"))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(dropletRepo.UpdateDropletImageCallCount()).To(Equal(1))
+			_, _, message := dropletRepo.UpdateDropletImageArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateDropletImageMessage{
+				GUID:     dropletGUID,
+				ImageRef: "registry.repo/droplets@sha256:some-sha",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/droplet.upload~"+dropletGUID))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.state", "PROCESSING_UPLOAD")))
+		})
+
+		When("the droplet is not awaiting upload", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: "STAGED",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet must be in AWAITING_UPLOAD state.")
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot get the droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("the bits are missing", func() {
+			BeforeEach(func() {
+				req = createUploadRequest(false)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include bits")
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", errors.New("upload-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(dropletRepo.UpdateDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the droplet image fails", func() {
+			BeforeEach(func() {
+				dropletRepo.UpdateDropletImageReturns(repositories.DropletRecord{}, errors.New("update-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     "STAGED",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				ImageRef:  "registry.repo/droplets@sha256:some-sha",
+			}, nil)
+
+			imageRepo.DownloadDropletImageStub = func(_ context.Context, _ authorization.Info, _ string, w io.Writer) error {
+				_, err := w.Write([]byte("the-droplet-tarball"))
+				return err
+			}
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams the droplet image", func() {
+			Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, _ := imageRepo.DownloadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/droplets@sha256:some-sha"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", `attachment; filename="`+dropletGUID+`.tar"`))
+			Expect(rr).To(HaveHTTPBody("the-droplet-tarball"))
+		})
+
+		When("the droplet is a docker droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     "STAGED",
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+				Expect(imageRepo.DownloadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet is a dockerfile droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     "STAGED",
+					Lifecycle: repositories.Lifecycle{Type: "dockerfile"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'dockerfile' lifecycle.")
+				Expect(imageRepo.DownloadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     "AWAITING_UPLOAD",
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+			})
+		})
+
+		When("the user cannot get the droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+	})
 })
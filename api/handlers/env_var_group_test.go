@@ -0,0 +1,166 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroup", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		envVarGroupRepo  *fake.CFEnvVarGroupRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		envVarGroupRepo = new(fake.CFEnvVarGroupRepository)
+
+		apiHandler := handlers.NewEnvVarGroup(*serverURL, requestValidator, envVarGroupRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/environment_variable_groups/{name}", func() {
+		BeforeEach(func() {
+			envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+				Name: "running",
+				Var:  map[string]string{"HTTP_PROXY": "http://proxy.example.com"},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/environment_variable_groups/running", nil)
+		})
+
+		It("returns the env var group", func() {
+			Expect(envVarGroupRepo.GetEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := envVarGroupRepo.GetEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("running"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "running"),
+				MatchJSONPath("$.var.HTTP_PROXY", "http://proxy.example.com"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/environment_variable_groups/running"),
+			)))
+		})
+
+		When("the group name is unknown", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("GET", "/v3/environment_variable_groups/other", nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(envVarGroupRepo.GetEnvVarGroupCallCount()).To(BeZero())
+				expectNotFoundError(repositories.EnvVarGroupResourceType)
+			})
+		})
+
+		When("the env var group is not accessible", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.EnvVarGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.EnvVarGroupResourceType)
+			})
+		})
+
+		When("getting the env var group fails", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/environment_variable_groups/{name}", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.EnvVarGroupPatch{
+				Var: map[string]any{
+					"HTTP_PROXY": "http://proxy.example.com",
+					"REMOVE":     nil,
+				},
+			})
+			envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+				Name: "staging",
+				Var:  map[string]string{"HTTP_PROXY": "http://proxy.example.com"},
+			}, nil)
+			req = createHttpRequest("PATCH", "/v3/environment_variable_groups/staging", strings.NewReader("the-json-body"))
+		})
+
+		It("updates the env var group", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(envVarGroupRepo.PatchEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := envVarGroupRepo.PatchEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchEnvVarGroupMessage{
+				Name: "staging",
+				Var: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://proxy.example.com"),
+					"REMOVE":     nil,
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "staging"),
+				MatchJSONPath("$.var.HTTP_PROXY", "http://proxy.example.com"),
+			)))
+		})
+
+		When("the group name is unknown", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/environment_variable_groups/other", strings.NewReader("the-json-body"))
+			})
+
+			It("returns a not found error", func() {
+				Expect(envVarGroupRepo.PatchEnvVarGroupCallCount()).To(BeZero())
+				expectNotFoundError(repositories.EnvVarGroupResourceType)
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(envVarGroupRepo.PatchEnvVarGroupCallCount()).To(BeZero())
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("patching the env var group fails", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
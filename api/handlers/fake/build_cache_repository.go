@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type BuildCacheRepository struct {
+	ClearAllBuildCachesStub        func(context.Context, authorization.Info) (string, error)
+	clearAllBuildCachesMutex       sync.RWMutex
+	clearAllBuildCachesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	clearAllBuildCachesReturns struct {
+		result1 string
+		result2 error
+	}
+	clearAllBuildCachesReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ClearAppBuildCacheStub        func(context.Context, authorization.Info, string) (string, error)
+	clearAppBuildCacheMutex       sync.RWMutex
+	clearAppBuildCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	clearAppBuildCacheReturns struct {
+		result1 string
+		result2 error
+	}
+	clearAppBuildCacheReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCaches(arg1 context.Context, arg2 authorization.Info) (string, error) {
+	fake.clearAllBuildCachesMutex.Lock()
+	ret, specificReturn := fake.clearAllBuildCachesReturnsOnCall[len(fake.clearAllBuildCachesArgsForCall)]
+	fake.clearAllBuildCachesArgsForCall = append(fake.clearAllBuildCachesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.ClearAllBuildCachesStub
+	fakeReturns := fake.clearAllBuildCachesReturns
+	fake.recordInvocation("ClearAllBuildCaches", []interface{}{arg1, arg2})
+	fake.clearAllBuildCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCachesCallCount() int {
+	fake.clearAllBuildCachesMutex.RLock()
+	defer fake.clearAllBuildCachesMutex.RUnlock()
+	return len(fake.clearAllBuildCachesArgsForCall)
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCachesCalls(stub func(context.Context, authorization.Info) (string, error)) {
+	fake.clearAllBuildCachesMutex.Lock()
+	defer fake.clearAllBuildCachesMutex.Unlock()
+	fake.ClearAllBuildCachesStub = stub
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCachesArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.clearAllBuildCachesMutex.RLock()
+	defer fake.clearAllBuildCachesMutex.RUnlock()
+	argsForCall := fake.clearAllBuildCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCachesReturns(result1 string, result2 error) {
+	fake.clearAllBuildCachesMutex.Lock()
+	defer fake.clearAllBuildCachesMutex.Unlock()
+	fake.ClearAllBuildCachesStub = nil
+	fake.clearAllBuildCachesReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildCacheRepository) ClearAllBuildCachesReturnsOnCall(i int, result1 string, result2 error) {
+	fake.clearAllBuildCachesMutex.Lock()
+	defer fake.clearAllBuildCachesMutex.Unlock()
+	fake.ClearAllBuildCachesStub = nil
+	if fake.clearAllBuildCachesReturnsOnCall == nil {
+		fake.clearAllBuildCachesReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.clearAllBuildCachesReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCache(arg1 context.Context, arg2 authorization.Info, arg3 string) (string, error) {
+	fake.clearAppBuildCacheMutex.Lock()
+	ret, specificReturn := fake.clearAppBuildCacheReturnsOnCall[len(fake.clearAppBuildCacheArgsForCall)]
+	fake.clearAppBuildCacheArgsForCall = append(fake.clearAppBuildCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ClearAppBuildCacheStub
+	fakeReturns := fake.clearAppBuildCacheReturns
+	fake.recordInvocation("ClearAppBuildCache", []interface{}{arg1, arg2, arg3})
+	fake.clearAppBuildCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCacheCallCount() int {
+	fake.clearAppBuildCacheMutex.RLock()
+	defer fake.clearAppBuildCacheMutex.RUnlock()
+	return len(fake.clearAppBuildCacheArgsForCall)
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCacheCalls(stub func(context.Context, authorization.Info, string) (string, error)) {
+	fake.clearAppBuildCacheMutex.Lock()
+	defer fake.clearAppBuildCacheMutex.Unlock()
+	fake.ClearAppBuildCacheStub = stub
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCacheArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.clearAppBuildCacheMutex.RLock()
+	defer fake.clearAppBuildCacheMutex.RUnlock()
+	argsForCall := fake.clearAppBuildCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCacheReturns(result1 string, result2 error) {
+	fake.clearAppBuildCacheMutex.Lock()
+	defer fake.clearAppBuildCacheMutex.Unlock()
+	fake.ClearAppBuildCacheStub = nil
+	fake.clearAppBuildCacheReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildCacheRepository) ClearAppBuildCacheReturnsOnCall(i int, result1 string, result2 error) {
+	fake.clearAppBuildCacheMutex.Lock()
+	defer fake.clearAppBuildCacheMutex.Unlock()
+	fake.ClearAppBuildCacheStub = nil
+	if fake.clearAppBuildCacheReturnsOnCall == nil {
+		fake.clearAppBuildCacheReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.clearAppBuildCacheReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildCacheRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.clearAllBuildCachesMutex.RLock()
+	defer fake.clearAllBuildCachesMutex.RUnlock()
+	fake.clearAppBuildCacheMutex.RLock()
+	defer fake.clearAppBuildCacheMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BuildCacheRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.BuildCacheRepository = new(BuildCacheRepository)
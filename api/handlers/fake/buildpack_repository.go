@@ -11,6 +11,49 @@ import (
 )
 
 type BuildpackRepository struct {
+	CreateBuildpackStub        func(context.Context, authorization.Info, repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)
+	createBuildpackMutex       sync.RWMutex
+	createBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateBuildpackMessage
+	}
+	createBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	createBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	DeleteBuildpackStub        func(context.Context, authorization.Info, string) error
+	deleteBuildpackMutex       sync.RWMutex
+	deleteBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteBuildpackReturns struct {
+		result1 error
+	}
+	deleteBuildpackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetBuildpackStub        func(context.Context, authorization.Info, string) (repositories.BuildpackRecord, error)
+	getBuildpackMutex       sync.RWMutex
+	getBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	getBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
 	ListBuildpacksStub        func(context.Context, authorization.Info, repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error)
 	listBuildpacksMutex       sync.RWMutex
 	listBuildpacksArgsForCall []struct {
@@ -26,10 +69,235 @@ type BuildpackRepository struct {
 		result1 []repositories.BuildpackRecord
 		result2 error
 	}
+	UpdateBuildpackStub        func(context.Context, authorization.Info, repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)
+	updateBuildpackMutex       sync.RWMutex
+	updateBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackMessage
+	}
+	updateBuildpackReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	updateBuildpackReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	UpdateBuildpackSourceStub        func(context.Context, authorization.Info, repositories.UpdateBuildpackSourceMessage) (repositories.BuildpackRecord, error)
+	updateBuildpackSourceMutex       sync.RWMutex
+	updateBuildpackSourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackSourceMessage
+	}
+	updateBuildpackSourceReturns struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
+	updateBuildpackSourceReturnsOnCall map[int]struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *BuildpackRepository) CreateBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error) {
+	fake.createBuildpackMutex.Lock()
+	ret, specificReturn := fake.createBuildpackReturnsOnCall[len(fake.createBuildpackArgsForCall)]
+	fake.createBuildpackArgsForCall = append(fake.createBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateBuildpackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateBuildpackStub
+	fakeReturns := fake.createBuildpackReturns
+	fake.recordInvocation("CreateBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.createBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) CreateBuildpackCallCount() int {
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	return len(fake.createBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) CreateBuildpackCalls(stub func(context.Context, authorization.Info, repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) CreateBuildpackArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateBuildpackMessage) {
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	argsForCall := fake.createBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) CreateBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = nil
+	fake.createBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) CreateBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.createBuildpackMutex.Lock()
+	defer fake.createBuildpackMutex.Unlock()
+	fake.CreateBuildpackStub = nil
+	if fake.createBuildpackReturnsOnCall == nil {
+		fake.createBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.createBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) DeleteBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteBuildpackMutex.Lock()
+	ret, specificReturn := fake.deleteBuildpackReturnsOnCall[len(fake.deleteBuildpackArgsForCall)]
+	fake.deleteBuildpackArgsForCall = append(fake.deleteBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteBuildpackStub
+	fakeReturns := fake.deleteBuildpackReturns
+	fake.recordInvocation("DeleteBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.deleteBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackCallCount() int {
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	return len(fake.deleteBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	argsForCall := fake.deleteBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackReturns(result1 error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = nil
+	fake.deleteBuildpackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *BuildpackRepository) DeleteBuildpackReturnsOnCall(i int, result1 error) {
+	fake.deleteBuildpackMutex.Lock()
+	defer fake.deleteBuildpackMutex.Unlock()
+	fake.DeleteBuildpackStub = nil
+	if fake.deleteBuildpackReturnsOnCall == nil {
+		fake.deleteBuildpackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBuildpackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *BuildpackRepository) GetBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.BuildpackRecord, error) {
+	fake.getBuildpackMutex.Lock()
+	ret, specificReturn := fake.getBuildpackReturnsOnCall[len(fake.getBuildpackArgsForCall)]
+	fake.getBuildpackArgsForCall = append(fake.getBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetBuildpackStub
+	fakeReturns := fake.getBuildpackReturns
+	fake.recordInvocation("GetBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.getBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) GetBuildpackCallCount() int {
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
+	return len(fake.getBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) GetBuildpackCalls(stub func(context.Context, authorization.Info, string) (repositories.BuildpackRecord, error)) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) GetBuildpackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
+	argsForCall := fake.getBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) GetBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = nil
+	fake.getBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) GetBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.getBuildpackMutex.Lock()
+	defer fake.getBuildpackMutex.Unlock()
+	fake.GetBuildpackStub = nil
+	if fake.getBuildpackReturnsOnCall == nil {
+		fake.getBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.getBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) ListBuildpacks(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListBuildpacksMessage) ([]repositories.BuildpackRecord, error) {
 	fake.listBuildpacksMutex.Lock()
 	ret, specificReturn := fake.listBuildpacksReturnsOnCall[len(fake.listBuildpacksArgsForCall)]
@@ -96,11 +364,153 @@ func (fake *BuildpackRepository) ListBuildpacksReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *BuildpackRepository) UpdateBuildpack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error) {
+	fake.updateBuildpackMutex.Lock()
+	ret, specificReturn := fake.updateBuildpackReturnsOnCall[len(fake.updateBuildpackArgsForCall)]
+	fake.updateBuildpackArgsForCall = append(fake.updateBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateBuildpackStub
+	fakeReturns := fake.updateBuildpackReturns
+	fake.recordInvocation("UpdateBuildpack", []interface{}{arg1, arg2, arg3})
+	fake.updateBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackCallCount() int {
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	return len(fake.updateBuildpackArgsForCall)
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackCalls(stub func(context.Context, authorization.Info, repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = stub
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateBuildpackMessage) {
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	argsForCall := fake.updateBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = nil
+	fake.updateBuildpackReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackMutex.Lock()
+	defer fake.updateBuildpackMutex.Unlock()
+	fake.UpdateBuildpackStub = nil
+	if fake.updateBuildpackReturnsOnCall == nil {
+		fake.updateBuildpackReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.updateBuildpackReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSource(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateBuildpackSourceMessage) (repositories.BuildpackRecord, error) {
+	fake.updateBuildpackSourceMutex.Lock()
+	ret, specificReturn := fake.updateBuildpackSourceReturnsOnCall[len(fake.updateBuildpackSourceArgsForCall)]
+	fake.updateBuildpackSourceArgsForCall = append(fake.updateBuildpackSourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateBuildpackSourceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateBuildpackSourceStub
+	fakeReturns := fake.updateBuildpackSourceReturns
+	fake.recordInvocation("UpdateBuildpackSource", []interface{}{arg1, arg2, arg3})
+	fake.updateBuildpackSourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSourceCallCount() int {
+	fake.updateBuildpackSourceMutex.RLock()
+	defer fake.updateBuildpackSourceMutex.RUnlock()
+	return len(fake.updateBuildpackSourceArgsForCall)
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSourceCalls(stub func(context.Context, authorization.Info, repositories.UpdateBuildpackSourceMessage) (repositories.BuildpackRecord, error)) {
+	fake.updateBuildpackSourceMutex.Lock()
+	defer fake.updateBuildpackSourceMutex.Unlock()
+	fake.UpdateBuildpackSourceStub = stub
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSourceArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateBuildpackSourceMessage) {
+	fake.updateBuildpackSourceMutex.RLock()
+	defer fake.updateBuildpackSourceMutex.RUnlock()
+	argsForCall := fake.updateBuildpackSourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSourceReturns(result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackSourceMutex.Lock()
+	defer fake.updateBuildpackSourceMutex.Unlock()
+	fake.UpdateBuildpackSourceStub = nil
+	fake.updateBuildpackSourceReturns = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackRepository) UpdateBuildpackSourceReturnsOnCall(i int, result1 repositories.BuildpackRecord, result2 error) {
+	fake.updateBuildpackSourceMutex.Lock()
+	defer fake.updateBuildpackSourceMutex.Unlock()
+	fake.UpdateBuildpackSourceStub = nil
+	if fake.updateBuildpackSourceReturnsOnCall == nil {
+		fake.updateBuildpackSourceReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildpackRecord
+			result2 error
+		})
+	}
+	fake.updateBuildpackSourceReturnsOnCall[i] = struct {
+		result1 repositories.BuildpackRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BuildpackRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createBuildpackMutex.RLock()
+	defer fake.createBuildpackMutex.RUnlock()
+	fake.deleteBuildpackMutex.RLock()
+	defer fake.deleteBuildpackMutex.RUnlock()
+	fake.getBuildpackMutex.RLock()
+	defer fake.getBuildpackMutex.RUnlock()
 	fake.listBuildpacksMutex.RLock()
 	defer fake.listBuildpacksMutex.RUnlock()
+	fake.updateBuildpackMutex.RLock()
+	defer fake.updateBuildpackMutex.RUnlock()
+	fake.updateBuildpackSourceMutex.RLock()
+	defer fake.updateBuildpackSourceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,287 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFFeatureFlagRepository struct {
+	GetFeatureFlagStub        func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)
+	getFeatureFlagMutex       sync.RWMutex
+	getFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	getFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	ListFeatureFlagsStub        func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	listFeatureFlagsMutex       sync.RWMutex
+	listFeatureFlagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	listFeatureFlagsReturns struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	listFeatureFlagsReturnsOnCall map[int]struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	PatchFeatureFlagStub        func(context.Context, authorization.Info, repositories.PatchFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	patchFeatureFlagMutex       sync.RWMutex
+	patchFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchFeatureFlagMessage
+	}
+	patchFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	patchFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.FeatureFlagRecord, error) {
+	fake.getFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.getFeatureFlagReturnsOnCall[len(fake.getFeatureFlagArgsForCall)]
+	fake.getFeatureFlagArgsForCall = append(fake.getFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetFeatureFlagStub
+	fakeReturns := fake.getFeatureFlagReturns
+	fake.recordInvocation("GetFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.getFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCallCount() int {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	return len(fake.getFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCalls(stub func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	argsForCall := fake.getFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	fake.getFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	if fake.getFeatureFlagReturnsOnCall == nil {
+		fake.getFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.getFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlags(arg1 context.Context, arg2 authorization.Info) ([]repositories.FeatureFlagRecord, error) {
+	fake.listFeatureFlagsMutex.Lock()
+	ret, specificReturn := fake.listFeatureFlagsReturnsOnCall[len(fake.listFeatureFlagsArgsForCall)]
+	fake.listFeatureFlagsArgsForCall = append(fake.listFeatureFlagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.ListFeatureFlagsStub
+	fakeReturns := fake.listFeatureFlagsReturns
+	fake.recordInvocation("ListFeatureFlags", []interface{}{arg1, arg2})
+	fake.listFeatureFlagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCallCount() int {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	return len(fake.listFeatureFlagsArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCalls(stub func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	argsForCall := fake.listFeatureFlagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturns(result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	fake.listFeatureFlagsReturns = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturnsOnCall(i int, result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	if fake.listFeatureFlagsReturnsOnCall == nil {
+		fake.listFeatureFlagsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.listFeatureFlagsReturnsOnCall[i] = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchFeatureFlagMessage) (repositories.FeatureFlagRecord, error) {
+	fake.patchFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.patchFeatureFlagReturnsOnCall[len(fake.patchFeatureFlagArgsForCall)]
+	fake.patchFeatureFlagArgsForCall = append(fake.patchFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchFeatureFlagMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchFeatureFlagStub
+	fakeReturns := fake.patchFeatureFlagReturns
+	fake.recordInvocation("PatchFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.patchFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlagCallCount() int {
+	fake.patchFeatureFlagMutex.RLock()
+	defer fake.patchFeatureFlagMutex.RUnlock()
+	return len(fake.patchFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlagCalls(stub func(context.Context, authorization.Info, repositories.PatchFeatureFlagMessage) (repositories.FeatureFlagRecord, error)) {
+	fake.patchFeatureFlagMutex.Lock()
+	defer fake.patchFeatureFlagMutex.Unlock()
+	fake.PatchFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchFeatureFlagMessage) {
+	fake.patchFeatureFlagMutex.RLock()
+	defer fake.patchFeatureFlagMutex.RUnlock()
+	argsForCall := fake.patchFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.patchFeatureFlagMutex.Lock()
+	defer fake.patchFeatureFlagMutex.Unlock()
+	fake.PatchFeatureFlagStub = nil
+	fake.patchFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) PatchFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.patchFeatureFlagMutex.Lock()
+	defer fake.patchFeatureFlagMutex.Unlock()
+	fake.PatchFeatureFlagStub = nil
+	if fake.patchFeatureFlagReturnsOnCall == nil {
+		fake.patchFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.patchFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	fake.patchFeatureFlagMutex.RLock()
+	defer fake.patchFeatureFlagMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFFeatureFlagRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFFeatureFlagRepository = new(CFFeatureFlagRepository)
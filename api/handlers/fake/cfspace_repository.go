@@ -85,6 +85,21 @@ type CFSpaceRepository struct {
 		result1 []repositories.SpaceRecord
 		result2 error
 	}
+	PatchSpaceFeaturesStub        func(context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error)
+	patchSpaceFeaturesMutex       sync.RWMutex
+	patchSpaceFeaturesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSpaceFeaturesMessage
+	}
+	patchSpaceFeaturesReturns struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
+	patchSpaceFeaturesReturnsOnCall map[int]struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
 	PatchSpaceMetadataStub        func(context.Context, authorization.Info, repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error)
 	patchSpaceMetadataMutex       sync.RWMutex
 	patchSpaceMetadataArgsForCall []struct {
@@ -431,6 +446,72 @@ func (fake *CFSpaceRepository) ListSpacesReturnsOnCall(i int, result1 []reposito
 	}{result1, result2}
 }
 
+func (fake *CFSpaceRepository) PatchSpaceFeatures(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error) {
+	fake.patchSpaceFeaturesMutex.Lock()
+	ret, specificReturn := fake.patchSpaceFeaturesReturnsOnCall[len(fake.patchSpaceFeaturesArgsForCall)]
+	fake.patchSpaceFeaturesArgsForCall = append(fake.patchSpaceFeaturesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSpaceFeaturesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchSpaceFeaturesStub
+	fakeReturns := fake.patchSpaceFeaturesReturns
+	fake.recordInvocation("PatchSpaceFeatures", []interface{}{arg1, arg2, arg3})
+	fake.patchSpaceFeaturesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceRepository) PatchSpaceFeaturesCallCount() int {
+	fake.patchSpaceFeaturesMutex.RLock()
+	defer fake.patchSpaceFeaturesMutex.RUnlock()
+	return len(fake.patchSpaceFeaturesArgsForCall)
+}
+
+func (fake *CFSpaceRepository) PatchSpaceFeaturesCalls(stub func(context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error)) {
+	fake.patchSpaceFeaturesMutex.Lock()
+	defer fake.patchSpaceFeaturesMutex.Unlock()
+	fake.PatchSpaceFeaturesStub = stub
+}
+
+func (fake *CFSpaceRepository) PatchSpaceFeaturesArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) {
+	fake.patchSpaceFeaturesMutex.RLock()
+	defer fake.patchSpaceFeaturesMutex.RUnlock()
+	argsForCall := fake.patchSpaceFeaturesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceRepository) PatchSpaceFeaturesReturns(result1 repositories.SpaceRecord, result2 error) {
+	fake.patchSpaceFeaturesMutex.Lock()
+	defer fake.patchSpaceFeaturesMutex.Unlock()
+	fake.PatchSpaceFeaturesStub = nil
+	fake.patchSpaceFeaturesReturns = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceRepository) PatchSpaceFeaturesReturnsOnCall(i int, result1 repositories.SpaceRecord, result2 error) {
+	fake.patchSpaceFeaturesMutex.Lock()
+	defer fake.patchSpaceFeaturesMutex.Unlock()
+	fake.PatchSpaceFeaturesStub = nil
+	if fake.patchSpaceFeaturesReturnsOnCall == nil {
+		fake.patchSpaceFeaturesReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceRecord
+			result2 error
+		})
+	}
+	fake.patchSpaceFeaturesReturnsOnCall[i] = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFSpaceRepository) PatchSpaceMetadata(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error) {
 	fake.patchSpaceMetadataMutex.Lock()
 	ret, specificReturn := fake.patchSpaceMetadataReturnsOnCall[len(fake.patchSpaceMetadataArgsForCall)]
@@ -510,6 +591,8 @@ func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	defer fake.getSpaceMutex.RUnlock()
 	fake.listSpacesMutex.RLock()
 	defer fake.listSpacesMutex.RUnlock()
+	fake.patchSpaceFeaturesMutex.RLock()
+	defer fake.patchSpaceFeaturesMutex.RUnlock()
 	fake.patchSpaceMetadataMutex.RLock()
 	defer fake.patchSpaceMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
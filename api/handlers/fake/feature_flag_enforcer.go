@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type FeatureFlagEnforcer struct {
+	EnsureFeatureFlagEnabledStub        func(context.Context, authorization.Info, string) error
+	ensureFeatureFlagEnabledMutex       sync.RWMutex
+	ensureFeatureFlagEnabledArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	ensureFeatureFlagEnabledReturns struct {
+		result1 error
+	}
+	ensureFeatureFlagEnabledReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabled(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.ensureFeatureFlagEnabledMutex.Lock()
+	ret, specificReturn := fake.ensureFeatureFlagEnabledReturnsOnCall[len(fake.ensureFeatureFlagEnabledArgsForCall)]
+	fake.ensureFeatureFlagEnabledArgsForCall = append(fake.ensureFeatureFlagEnabledArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EnsureFeatureFlagEnabledStub
+	fakeReturns := fake.ensureFeatureFlagEnabledReturns
+	fake.recordInvocation("EnsureFeatureFlagEnabled", []interface{}{arg1, arg2, arg3})
+	fake.ensureFeatureFlagEnabledMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabledCallCount() int {
+	fake.ensureFeatureFlagEnabledMutex.RLock()
+	defer fake.ensureFeatureFlagEnabledMutex.RUnlock()
+	return len(fake.ensureFeatureFlagEnabledArgsForCall)
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabledCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.ensureFeatureFlagEnabledMutex.Lock()
+	defer fake.ensureFeatureFlagEnabledMutex.Unlock()
+	fake.EnsureFeatureFlagEnabledStub = stub
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabledArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.ensureFeatureFlagEnabledMutex.RLock()
+	defer fake.ensureFeatureFlagEnabledMutex.RUnlock()
+	argsForCall := fake.ensureFeatureFlagEnabledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabledReturns(result1 error) {
+	fake.ensureFeatureFlagEnabledMutex.Lock()
+	defer fake.ensureFeatureFlagEnabledMutex.Unlock()
+	fake.EnsureFeatureFlagEnabledStub = nil
+	fake.ensureFeatureFlagEnabledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagEnforcer) EnsureFeatureFlagEnabledReturnsOnCall(i int, result1 error) {
+	fake.ensureFeatureFlagEnabledMutex.Lock()
+	defer fake.ensureFeatureFlagEnabledMutex.Unlock()
+	fake.EnsureFeatureFlagEnabledStub = nil
+	if fake.ensureFeatureFlagEnabledReturnsOnCall == nil {
+		fake.ensureFeatureFlagEnabledReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureFeatureFlagEnabledReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagEnforcer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ensureFeatureFlagEnabledMutex.RLock()
+	defer fake.ensureFeatureFlagEnabledMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FeatureFlagEnforcer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.FeatureFlagEnforcer = new(FeatureFlagEnforcer)
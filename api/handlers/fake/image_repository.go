@@ -11,6 +11,105 @@ import (
 )
 
 type ImageRepository struct {
+	CopyDropletImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copyDropletImageMutex       sync.RWMutex
+	copyDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copyDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copyDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CopySourceImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copySourceImageMutex       sync.RWMutex
+	copySourceImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copySourceImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copySourceImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadDropletImageStub        func(context.Context, authorization.Info, string, io.Writer) error
+	downloadDropletImageMutex       sync.RWMutex
+	downloadDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Writer
+	}
+	downloadDropletImageReturns struct {
+		result1 error
+	}
+	downloadDropletImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DownloadSourceImageStub        func(context.Context, authorization.Info, string, io.Writer) error
+	downloadSourceImageMutex       sync.RWMutex
+	downloadSourceImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Writer
+	}
+	downloadSourceImageReturns struct {
+		result1 error
+	}
+	downloadSourceImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UploadBuildpackImageStub        func(context.Context, authorization.Info, string, io.Reader, ...string) (string, error)
+	uploadBuildpackImageMutex       sync.RWMutex
+	uploadBuildpackImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	uploadBuildpackImageReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadBuildpackImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	UploadDropletImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
+	uploadDropletImageMutex       sync.RWMutex
+	uploadDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}
+	uploadDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
 	uploadSourceImageMutex       sync.RWMutex
 	uploadSourceImageArgsForCall []struct {
@@ -33,6 +132,409 @@ type ImageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImageRepository) CopyDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copyDropletImageMutex.Lock()
+	ret, specificReturn := fake.copyDropletImageReturnsOnCall[len(fake.copyDropletImageArgsForCall)]
+	fake.copyDropletImageArgsForCall = append(fake.copyDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopyDropletImageStub
+	fakeReturns := fake.copyDropletImageReturns
+	fake.recordInvocation("CopyDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copyDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) CopyDropletImageCallCount() int {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	return len(fake.copyDropletImageArgsForCall)
+}
+
+func (fake *ImageRepository) CopyDropletImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = stub
+}
+
+func (fake *ImageRepository) CopyDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	argsForCall := fake.copyDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) CopyDropletImageReturns(result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	fake.copyDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopyDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	if fake.copyDropletImageReturnsOnCall == nil {
+		fake.copyDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopySourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copySourceImageMutex.Lock()
+	ret, specificReturn := fake.copySourceImageReturnsOnCall[len(fake.copySourceImageArgsForCall)]
+	fake.copySourceImageArgsForCall = append(fake.copySourceImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopySourceImageStub
+	fakeReturns := fake.copySourceImageReturns
+	fake.recordInvocation("CopySourceImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copySourceImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) CopySourceImageCallCount() int {
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	return len(fake.copySourceImageArgsForCall)
+}
+
+func (fake *ImageRepository) CopySourceImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = stub
+}
+
+func (fake *ImageRepository) CopySourceImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	argsForCall := fake.copySourceImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) CopySourceImageReturns(result1 string, result2 error) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = nil
+	fake.copySourceImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopySourceImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = nil
+	if fake.copySourceImageReturnsOnCall == nil {
+		fake.copySourceImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copySourceImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Writer) error {
+	fake.downloadDropletImageMutex.Lock()
+	ret, specificReturn := fake.downloadDropletImageReturnsOnCall[len(fake.downloadDropletImageArgsForCall)]
+	fake.downloadDropletImageArgsForCall = append(fake.downloadDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Writer
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadDropletImageStub
+	fakeReturns := fake.downloadDropletImageReturns
+	fake.recordInvocation("DownloadDropletImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageRepository) DownloadDropletImageCallCount() int {
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	return len(fake.downloadDropletImageArgsForCall)
+}
+
+func (fake *ImageRepository) DownloadDropletImageCalls(stub func(context.Context, authorization.Info, string, io.Writer) error) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = stub
+}
+
+func (fake *ImageRepository) DownloadDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Writer) {
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	argsForCall := fake.downloadDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ImageRepository) DownloadDropletImageReturns(result1 error) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = nil
+	fake.downloadDropletImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageRepository) DownloadDropletImageReturnsOnCall(i int, result1 error) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = nil
+	if fake.downloadDropletImageReturnsOnCall == nil {
+		fake.downloadDropletImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadDropletImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageRepository) DownloadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Writer) error {
+	fake.downloadSourceImageMutex.Lock()
+	ret, specificReturn := fake.downloadSourceImageReturnsOnCall[len(fake.downloadSourceImageArgsForCall)]
+	fake.downloadSourceImageArgsForCall = append(fake.downloadSourceImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Writer
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadSourceImageStub
+	fakeReturns := fake.downloadSourceImageReturns
+	fake.recordInvocation("DownloadSourceImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadSourceImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageRepository) DownloadSourceImageCallCount() int {
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
+	return len(fake.downloadSourceImageArgsForCall)
+}
+
+func (fake *ImageRepository) DownloadSourceImageCalls(stub func(context.Context, authorization.Info, string, io.Writer) error) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = stub
+}
+
+func (fake *ImageRepository) DownloadSourceImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Writer) {
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
+	argsForCall := fake.downloadSourceImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ImageRepository) DownloadSourceImageReturns(result1 error) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = nil
+	fake.downloadSourceImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageRepository) DownloadSourceImageReturnsOnCall(i int, result1 error) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = nil
+	if fake.downloadSourceImageReturnsOnCall == nil {
+		fake.downloadSourceImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadSourceImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageRepository) UploadBuildpackImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	ret, specificReturn := fake.uploadBuildpackImageReturnsOnCall[len(fake.uploadBuildpackImageArgsForCall)]
+	fake.uploadBuildpackImageArgsForCall = append(fake.uploadBuildpackImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UploadBuildpackImageStub
+	fakeReturns := fake.uploadBuildpackImageReturns
+	fake.recordInvocation("UploadBuildpackImage", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.uploadBuildpackImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) UploadBuildpackImageCallCount() int {
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	return len(fake.uploadBuildpackImageArgsForCall)
+}
+
+func (fake *ImageRepository) UploadBuildpackImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, ...string) (string, error)) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = stub
+}
+
+func (fake *ImageRepository) UploadBuildpackImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, []string) {
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	argsForCall := fake.uploadBuildpackImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImageRepository) UploadBuildpackImageReturns(result1 string, result2 error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = nil
+	fake.uploadBuildpackImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadBuildpackImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = nil
+	if fake.uploadBuildpackImageReturnsOnCall == nil {
+		fake.uploadBuildpackImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.uploadBuildpackImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
+	fake.uploadDropletImageMutex.Lock()
+	ret, specificReturn := fake.uploadDropletImageReturnsOnCall[len(fake.uploadDropletImageArgsForCall)]
+	fake.uploadDropletImageArgsForCall = append(fake.uploadDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.UploadDropletImageStub
+	fakeReturns := fake.uploadDropletImageReturns
+	fake.recordInvocation("UploadDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.uploadDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) UploadDropletImageCallCount() int {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	return len(fake.uploadDropletImageArgsForCall)
+}
+
+func (fake *ImageRepository) UploadDropletImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = stub
+}
+
+func (fake *ImageRepository) UploadDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, string, []string) {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	argsForCall := fake.uploadDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) UploadDropletImageReturns(result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	fake.uploadDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	if fake.uploadDropletImageReturnsOnCall == nil {
+		fake.uploadDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.uploadDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
 	fake.uploadSourceImageMutex.Lock()
 	ret, specificReturn := fake.uploadSourceImageReturnsOnCall[len(fake.uploadSourceImageArgsForCall)]
@@ -105,6 +607,18 @@ func (fake *ImageRepository) UploadSourceImageReturnsOnCall(i int, result1 strin
 func (fake *ImageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
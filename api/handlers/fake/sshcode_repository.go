@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type SSHCodeRepository struct {
+	CreateCodeStub        func(context.Context, authorization.Info) (string, error)
+	createCodeMutex       sync.RWMutex
+	createCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	createCodeReturns struct {
+		result1 string
+		result2 error
+	}
+	createCodeReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SSHCodeRepository) CreateCode(arg1 context.Context, arg2 authorization.Info) (string, error) {
+	fake.createCodeMutex.Lock()
+	ret, specificReturn := fake.createCodeReturnsOnCall[len(fake.createCodeArgsForCall)]
+	fake.createCodeArgsForCall = append(fake.createCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.CreateCodeStub
+	fakeReturns := fake.createCodeReturns
+	fake.recordInvocation("CreateCode", []interface{}{arg1, arg2})
+	fake.createCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSHCodeRepository) CreateCodeCallCount() int {
+	fake.createCodeMutex.RLock()
+	defer fake.createCodeMutex.RUnlock()
+	return len(fake.createCodeArgsForCall)
+}
+
+func (fake *SSHCodeRepository) CreateCodeCalls(stub func(context.Context, authorization.Info) (string, error)) {
+	fake.createCodeMutex.Lock()
+	defer fake.createCodeMutex.Unlock()
+	fake.CreateCodeStub = stub
+}
+
+func (fake *SSHCodeRepository) CreateCodeArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.createCodeMutex.RLock()
+	defer fake.createCodeMutex.RUnlock()
+	argsForCall := fake.createCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SSHCodeRepository) CreateCodeReturns(result1 string, result2 error) {
+	fake.createCodeMutex.Lock()
+	defer fake.createCodeMutex.Unlock()
+	fake.CreateCodeStub = nil
+	fake.createCodeReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeRepository) CreateCodeReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createCodeMutex.Lock()
+	defer fake.createCodeMutex.Unlock()
+	fake.CreateCodeStub = nil
+	if fake.createCodeReturnsOnCall == nil {
+		fake.createCodeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createCodeReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createCodeMutex.RLock()
+	defer fake.createCodeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SSHCodeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SSHCodeRepository = new(SSHCodeRepository)
@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		featureFlagRepo  *fake.CFFeatureFlagRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+
+		apiHandler := handlers.NewFeatureFlag(*serverURL, requestValidator, featureFlagRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/feature_flags/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "task_creation",
+				Enabled:            false,
+				CustomErrorMessage: "no tasks",
+			}, nil)
+			req = createHttpRequest("GET", "/v3/feature_flags/task_creation", nil)
+		})
+
+		It("returns the feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("task_creation"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "task_creation"),
+				MatchJSONPath("$.enabled", false),
+				MatchJSONPath("$.custom_error_message", "no tasks"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/feature_flags/task_creation"),
+			)))
+		})
+
+		When("the feature flag does not exist", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.FeatureFlagResourceType)
+			})
+		})
+
+		When("the feature flag is not accessible", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.FeatureFlagResourceType)
+			})
+		})
+
+		When("getting the feature flag fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/feature_flags", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListFeatureFlagsReturns([]repositories.FeatureFlagRecord{
+				{Name: "app_scaling", Enabled: true},
+				{Name: "task_creation", Enabled: false},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/feature_flags", nil)
+		})
+
+		It("returns the feature flags", func() {
+			Expect(featureFlagRepo.ListFeatureFlagsCallCount()).To(Equal(1))
+			_, actualAuthInfo := featureFlagRepo.ListFeatureFlagsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/feature_flags"),
+				MatchJSONPath("$.resources[0].name", "app_scaling"),
+				MatchJSONPath("$.resources[1].name", "task_creation"),
+			)))
+		})
+
+		When("listing the feature flags fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.ListFeatureFlagsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/feature_flags/{name}", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureFlagPatch{
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks"),
+			})
+			featureFlagRepo.PatchFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "task_creation",
+				Enabled:            false,
+				CustomErrorMessage: "no tasks",
+			}, nil)
+			req = createHttpRequest("PATCH", "/v3/feature_flags/task_creation", strings.NewReader("the-json-body"))
+		})
+
+		It("updates the feature flag", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagRepo.PatchFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := featureFlagRepo.PatchFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchFeatureFlagMessage{
+				Name:               "task_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "task_creation"),
+				MatchJSONPath("$.enabled", false),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(featureFlagRepo.PatchFeatureFlagCallCount()).To(BeZero())
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the user is not an admin", func() {
+			BeforeEach(func() {
+				featureFlagRepo.PatchFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("patching the feature flag fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.PatchFeatureFlagReturns(repositories.FeatureFlagRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
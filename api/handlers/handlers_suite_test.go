@@ -90,6 +90,12 @@ func expectUnprocessableEntityError(detail string) {
 	expectErrorResponse(http.StatusUnprocessableEntity, "CF-UnprocessableEntity", detail, 10008)
 }
 
+func expectFeatureDisabledError(featureFlagName string) {
+	GinkgoHelper()
+
+	expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: "+featureFlagName, 330002)
+}
+
 func expectBlobstoreUnavailableError() {
 	GinkgoHelper()
 
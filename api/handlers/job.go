@@ -34,6 +34,11 @@ const (
 	ManagedServiceInstanceCreateJobType = "managed_service_instance.create"
 	ManagedServiceBindingCreateJobType  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteJobType  = "managed_service_binding.delete"
+	DropletUploadJobType                = "droplet.upload"
+	BuildpackUploadJobType              = "buildpack.upload"
+	BuildpackDeleteJobType              = "buildpack.delete"
+	AppClearBuildpackCacheJobType       = "app.clear_buildpack_cache"
+	AdminClearBuildpackCacheJobType     = "admin.clear_buildpack_cache"
 	JobTimeoutDuration                  = 120.0
 )
 
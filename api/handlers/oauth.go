@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	OAuthAuthorizePath  = "/oauth/authorize"
+	SSHProxyOAuthClient = "ssh-proxy"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeRepository . SSHCodeRepository
+
+type SSHCodeRepository interface {
+	CreateCode(context.Context, authorization.Info) (string, error)
+}
+
+// OAuth implements the part of the UAA authorization code flow the cf cli
+// uses to obtain one-time passcodes for cf ssh
+type OAuth struct {
+	serverURL        url.URL
+	sshCodeRepo      SSHCodeRepository
+	requestValidator RequestValidator
+}
+
+func NewOAuth(serverURL url.URL, sshCodeRepo SSHCodeRepository, requestValidator RequestValidator) *OAuth {
+	return &OAuth{
+		serverURL:        serverURL,
+		sshCodeRepo:      sshCodeRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *OAuth) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.authorize")
+
+	payload := new(payloads.OAuthAuthorize)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if payload.ClientID != SSHProxyOAuthClient {
+		return nil, apierrors.LogAndReturn(logger,
+			apierrors.NewUnprocessableEntityError(nil, "Unknown client_id "+payload.ClientID),
+			"unsupported oauth client", "clientID", payload.ClientID,
+		)
+	}
+
+	code, err := h.sshCodeRepo.CreateCode(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create ssh code")
+	}
+
+	location := buildLoginURL(h.serverURL, code)
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", location), nil
+}
+
+func buildLoginURL(serverURL url.URL, code string) string {
+	loginURL := serverURL.JoinPath("login")
+	loginURL.RawQuery = url.Values{"code": []string{code}}.Encode()
+	return loginURL.String()
+}
+
+func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *OAuth) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: OAuthAuthorizePath, Handler: h.authorize},
+	}
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuth", func() {
+	var (
+		sshCodeRepo      *fake.SSHCodeRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		sshCodeRepo = new(fake.SSHCodeRepository)
+		sshCodeRepo.CreateCodeReturns("the-code", nil)
+
+		requestValidator = new(fake.RequestValidator)
+		requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OAuthAuthorize{
+			ResponseType: "code",
+			ClientID:     "ssh-proxy",
+		})
+
+		apiHandler := handlers.NewOAuth(*serverURL, sshCodeRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		var err error
+		req, err = http.NewRequestWithContext(ctx, "GET", "/oauth/authorize?response_type=code&client_id=ssh-proxy", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /oauth/authorize", func() {
+		It("validates the query parameters", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(Equal("/oauth/authorize?response_type=code&client_id=ssh-proxy"))
+		})
+
+		It("creates an ssh code for the user", func() {
+			Expect(sshCodeRepo.CreateCodeCallCount()).To(Equal(1))
+			_, actualAuthInfo := sshCodeRepo.CreateCodeArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+		})
+
+		It("redirects to the login url with the code", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/login?code=the-code"))
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid")
+				Expect(sshCodeRepo.CreateCodeCallCount()).To(BeZero())
+			})
+		})
+
+		When("the client is not the ssh proxy", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OAuthAuthorize{
+					ResponseType: "code",
+					ClientID:     "cf",
+				})
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Unknown client_id cf")
+				Expect(sshCodeRepo.CreateCodeCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the code fails", func() {
+			BeforeEach(func() {
+				sshCodeRepo.CreateCodeReturns("", apierrors.NewNotAuthenticatedError(errors.New("unsupported")))
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -156,7 +156,10 @@ func (h *Org) listDomains(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
 	}
 
-	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListFilter.ToMessage())
+	domainListMessage := domainListFilter.ToMessage()
+	domainListMessage.AvailableInOrgGUID = orgGUID
+
+	domainList, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
@@ -385,6 +385,10 @@ var _ = Describe("Org", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
 			Expect(actualReq.URL.String()).To(HaveSuffix(requestURL))
 
+			Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
+			_, _, listMessage := domainRepo.ListDomainsArgsForCall(0)
+			Expect(listMessage.AvailableInOrgGUID).To(Equal("org-guid"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
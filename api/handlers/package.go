@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -22,6 +23,7 @@ const (
 	PackagesPath        = "/v3/packages"
 	PackageUploadPath   = "/v3/packages/{guid}/upload"
 	PackageDropletsPath = "/v3/packages/{guid}/droplets"
+	PackageDownloadPath = "/v3/packages/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
@@ -38,6 +40,12 @@ type CFPackageRepository interface {
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	CopySourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, repoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, w io.Writer) error
+	UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, tags ...string) (imageRefWithDigest string, err error)
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	CopyDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, repoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, w io.Writer) error
 }
 
 type Package struct {
@@ -47,6 +55,7 @@ type Package struct {
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
 	requestValidator    RequestValidator
+	featureFlagEnforcer FeatureFlagEnforcer
 	registrySecretNames []string
 }
 
@@ -57,6 +66,7 @@ func NewPackage(
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
 	requestValidator RequestValidator,
+	featureFlagEnforcer FeatureFlagEnforcer,
 	registrySecretNames []string,
 ) *Package {
 	return &Package{
@@ -67,6 +77,7 @@ func NewPackage(
 		imageRepo:           imageRepo,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
+		featureFlagEnforcer: featureFlagEnforcer,
 	}
 }
 
@@ -106,6 +117,15 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
+	var source payloads.PackageCreateSource
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &source); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if source.SourceGUID != "" {
+		return h.copy(r, source.SourceGUID)
+	}
+
 	var payload payloads.PackageCreate
 	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
@@ -134,6 +154,113 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+func (h Package) copy(r *http.Request, sourceGUID string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.copy")
+
+	var payload payloads.PackageCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourcePackage, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source package is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source package",
+			"Source GUID", sourceGUID,
+		)
+	}
+
+	if sourcePackage.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package has no bits to copy."),
+			"cannot copy a package that is not ready",
+			"Source GUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(sourcePackage, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
+	}
+
+	if record.Type == "bits" {
+		copiedImageRef, err := h.imageRepo.CopySourceImage(r.Context(), authInfo, sourcePackage.SourceImageRef, record.ImageRef, record.SpaceGUID, record.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling CopySourceImage")
+		}
+
+		record, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
+			GUID:                record.GUID,
+			SpaceGUID:           record.SpaceGUID,
+			ImageRef:            copiedImageRef,
+			RegistrySecretNames: h.registrySecretNames,
+		})
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+		}
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+}
+
+func (h Package) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.download")
+
+	packageGUID := routing.URLParam(r, "guid")
+	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
+	}
+
+	if packageRecord.Type != "bits" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package type must be bits."),
+			fmt.Sprintf("downloading bits of %s packages is not supported", packageRecord.Type),
+		)
+	}
+
+	if packageRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package has no bits to download."),
+			"cannot download a package that is not ready",
+			"packageGUID", packageGUID,
+		)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")).
+		WithStream("application/zip", func(w io.Writer) error {
+			return h.imageRepo.DownloadSourceImage(r.Context(), authInfo, packageRecord.SourceImageRef, w)
+		}), nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -173,6 +300,10 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
 	}
 
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppBitsUpload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app bits upload is disabled")
+	}
+
 	if packageRecord.Type != "bits" {
 		return nil, apierrors.LogAndReturn(
 			logger,
@@ -239,5 +370,6 @@ func (h *Package) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: PackagesPath, Handler: h.create},
 		{Method: "POST", Pattern: PackageUploadPath, Handler: h.upload},
 		{Method: "GET", Pattern: PackageDropletsPath, Handler: h.listDroplets},
+		{Method: "GET", Pattern: PackageDownloadPath, Handler: h.download},
 	}
 }
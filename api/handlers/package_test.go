@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -10,11 +11,13 @@ import (
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -30,6 +33,7 @@ var _ = Describe("Package", func() {
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
 		requestValidator            *fake.RequestValidator
+		featureFlagEnforcer         *fake.FeatureFlagEnforcer
 		packageImagePullSecretNames []string
 
 		packageGUID string
@@ -45,6 +49,7 @@ var _ = Describe("Package", func() {
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
 		packageGUID = generateGUID("package")
@@ -60,6 +65,7 @@ var _ = Describe("Package", func() {
 			dropletRepo,
 			imageRepo,
 			requestValidator,
+			featureFlagEnforcer,
 			packageImagePullSecretNames,
 		)
 
@@ -419,6 +425,210 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the POST /v3/packages?source_guid= endpoint", func() {
+		var sourcePackageGUID string
+
+		BeforeEach(func() {
+			sourcePackageGUID = generateGUID("source-package")
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageCreateSource{
+				SourceGUID: sourcePackageGUID,
+			})
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopy{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: appGUID,
+						},
+					},
+				},
+			})
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:           "bits",
+				GUID:           sourcePackageGUID,
+				State:          "READY",
+				SourceImageRef: "registry.repo/source-app@sha256:source",
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: spaceGUID,
+				GUID:      appGUID,
+			}, nil)
+
+			packageRepo.CreatePackageReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "AWAITING_UPLOAD",
+				ImageRef:  "registry.repo/dest-app-packages",
+			}, nil)
+
+			imageRepo.CopySourceImageReturns("registry.repo/dest-app-packages@sha256:source", nil)
+
+			packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "READY",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourcePackageGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("copies the package bits into the destination app", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSourceGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSourceGUID).To(Equal(sourcePackageGUID))
+
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+			Expect(actualCreate).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+			}))
+
+			Expect(imageRepo.CopySourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, actualRepoRef, actualSpaceGUID, actualTags := imageRepo.CopySourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/source-app@sha256:source"))
+			Expect(actualRepoRef).To(Equal("registry.repo/dest-app-packages"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualTags).To(ConsistOf(packageGUID))
+
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			_, _, message := packageRepo.UpdatePackageSourceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdatePackageSourceMessage{
+				GUID:                packageGUID,
+				SpaceGUID:           spaceGUID,
+				ImageRef:            "registry.repo/dest-app-packages@sha256:source",
+				RegistrySecretNames: packageImagePullSecretNames,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "READY"),
+			)))
+		})
+
+		When("the source package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:           "docker",
+					GUID:           sourcePackageGUID,
+					State:          "READY",
+					SourceImageRef: "some/image",
+				}, nil)
+
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:      "docker",
+					AppGUID:   appGUID,
+					SpaceGUID: spaceGUID,
+					GUID:      packageGUID,
+					State:     "READY",
+				}, nil)
+			})
+
+			It("creates a docker package referencing the same image", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+				Expect(actualCreate.Type).To(Equal("docker"))
+				Expect(actualCreate.Data).To(Equal(&repositories.PackageData{Image: "some/image"}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("doesn't copy any images", func() {
+				Expect(imageRepo.CopySourceImageCallCount()).To(Equal(0))
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the source package does not exist", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewNotFoundError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the source package has no bits", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					GUID:  sourcePackageGUID,
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package has no bits to copy.")
+			})
+
+			It("doesn't create a package", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the destination app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopySourceImageReturns("", errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+
+			It("doesn't update the package source", func() {
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("updating the package source fails", func() {
+			BeforeEach(func() {
+				packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the PATCH /v3/packages/:guid endpoint", func() {
 		BeforeEach(func() {
 			packageGUID = generateGUID("package")
@@ -608,6 +818,25 @@ var _ = Describe("Package", func() {
 			})
 		}
 
+		It("checks the app_bits_upload feature flag", func() {
+			Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFeatureFlag).To(Equal(korifiv1alpha1.FeatureFlagAppBitsUpload))
+		})
+
+		When("the app_bits_upload feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_bits_upload", ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectFeatureDisabledError("app_bits_upload")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
 		When("getting the package is forbidden", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.PackageResourceType))
@@ -853,4 +1082,85 @@ var _ = Describe("Package", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/packages/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:           "bits",
+				GUID:           packageGUID,
+				State:          "READY",
+				SourceImageRef: "registry.repo/foo@sha256:bar",
+			}, nil)
+
+			imageRepo.DownloadSourceImageStub = func(_ context.Context, _ authorization.Info, _ string, w io.Writer) error {
+				_, err := w.Write([]byte("the-zip-contents"))
+				return err
+			}
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/packages/"+packageGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("streams the package bits", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(packageGUID))
+
+			Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, _ := imageRepo.DownloadSourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/foo@sha256:bar"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")))
+			Expect(rr).To(HaveHTTPBody("the-zip-contents"))
+		})
+
+		When("the package is not accessible", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Package")
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  packageGUID,
+					State: "READY",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package type must be bits.")
+			})
+		})
+
+		When("the package has no bits", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					GUID:  packageGUID,
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package has no bits to download.")
+			})
+
+			It("doesn't download anything", func() {
+				Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -15,13 +15,13 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
 
 const (
 	ProcessPath                = "/v3/processes/{guid}"
-	ProcessSidecarsPath        = "/v3/processes/{guid}/sidecars"
 	ProcessScalePath           = "/v3/processes/{guid}/actions/scale"
 	ProcessStatsPath           = "/v3/processes/{guid}/stats"
 	ProcessesPath              = "/v3/processes"
@@ -51,27 +51,36 @@ type InstancesStateCollector interface {
 type Process struct {
 	serverURL               url.URL
 	processRepo             CFProcessRepository
+	appRepo                 CFAppRepository
 	requestValidator        RequestValidator
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	auditEventRepo          CFAuditEventRepository
+	featureFlagEnforcer     FeatureFlagEnforcer
 }
 
 func NewProcess(
 	serverURL url.URL,
 	processRepo CFProcessRepository,
+	appRepo CFAppRepository,
 	requestValidator RequestValidator,
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	auditEventRepo CFAuditEventRepository,
+	featureFlagEnforcer FeatureFlagEnforcer,
 ) *Process {
 	return &Process{
 		serverURL:               serverURL,
 		processRepo:             processRepo,
+		appRepo:                 appRepo,
 		requestValidator:        requestValidator,
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		auditEventRepo:          auditEventRepo,
+		featureFlagEnforcer:     featureFlagEnforcer,
 	}
 }
 
@@ -134,34 +143,6 @@ func (h *Process) restartProcessInstance(r *http.Request) (*routing.Response, er
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
-func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
-	authInfo, _ := authorization.InfoFromContext(r.Context())
-	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.get-sidecars")
-
-	processGUID := routing.URLParam(r, "guid")
-
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
-	}
-
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
-}
-
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.scale")
@@ -178,6 +159,15 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.ForbiddenAsNotFound(err)
 	}
 
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, process.AppGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "appGUID", process.AppGUID)
+	}
+
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	processRecord, err := h.processRepo.ScaleProcess(r.Context(), authInfo, repositories.ScaleProcessMessage{
 		GUID:               process.GUID,
 		SpaceGUID:          process.SpaceGUID,
@@ -187,9 +177,39 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to scale process", "processGUID", processGUID)
 	}
 
+	recordAuditEvent(r.Context(), h.auditEventRepo, processScaleAuditEvent(app.Name, process, payload))
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(processRecord, h.serverURL)), nil
 }
 
+func processScaleAuditEvent(appName string, process repositories.ProcessRecord, payload payloads.ProcessScale) repositories.CreateAuditEventMessage {
+	request := map[string]any{}
+	if payload.Instances != nil {
+		request["instances"] = *payload.Instances
+	}
+	if payload.MemoryMB != nil {
+		request["memory_in_mb"] = *payload.MemoryMB
+	}
+	if payload.DiskMB != nil {
+		request["disk_in_mb"] = *payload.DiskMB
+	}
+
+	return repositories.CreateAuditEventMessage{
+		Type:      repositories.AuditEventTypeAppProcessScale,
+		SpaceGUID: process.SpaceGUID,
+		Target: repositories.AuditEventParticipant{
+			GUID: process.AppGUID,
+			Type: repositories.AuditEventTargetTypeApp,
+			Name: appName,
+		},
+		Data: map[string]any{
+			"process_guid": process.GUID,
+			"process_type": process.Type,
+			"request":      request,
+		},
+	}
+}
+
 func (h *Process) getStats(r *http.Request) (*routing.Response, error) {
 	processGUID := routing.URLParam(r, "guid")
 	authInfo, _ := authorization.InfoFromContext(r.Context())
@@ -262,7 +282,6 @@ func (h *Process) UnauthenticatedRoutes() []routing.Route {
 func (h *Process) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: ProcessPath, Handler: h.get},
-		{Method: "GET", Pattern: ProcessSidecarsPath, Handler: h.getSidecars},
 		{Method: "POST", Pattern: ProcessScalePath, Handler: h.scale},
 		{Method: "GET", Pattern: ProcessStatsPath, Handler: h.getStats},
 		{Method: "GET", Pattern: ProcessesPath, Handler: h.list},
@@ -22,26 +22,35 @@ import (
 var _ = Describe("Process", func() {
 	var (
 		processRepo             *fake.CFProcessRepository
+		appRepo                 *fake.CFAppRepository
 		requestValidator        *fake.RequestValidator
 		podRepo                 *fake.PodRepository
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		auditEventRepo          *fake.CFAuditEventRepository
+		featureFlagEnforcer     *fake.FeatureFlagEnforcer
 	)
 
 	BeforeEach(func() {
 		processRepo = new(fake.CFProcessRepository)
+		appRepo = new(fake.CFAppRepository)
 		requestValidator = new(fake.RequestValidator)
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 
 		apiHandler := NewProcess(
 			*serverURL,
 			processRepo,
+			appRepo,
 			requestValidator,
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			auditEventRepo,
+			featureFlagEnforcer,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -95,62 +104,24 @@ var _ = Describe("Process", func() {
 		})
 	})
 
-	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
-		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
-		})
-
-		JustBeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/sidecars", nil)
-			Expect(err).NotTo(HaveOccurred())
-			routerBuilder.Build().ServeHTTP(rr, req)
-		})
-
-		It("returns the empty list of sidecars", func() {
-			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
-			_, actualAuthInfo, _ := processRepo.GetProcessArgsForCall(0)
-			Expect(actualAuthInfo).To(Equal(authInfo))
-
-			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
-			)))
-		})
-
-		When("the process isn't accessible to the user", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
-			})
-
-			It("returns an error", func() {
-				expectNotFoundError("Process")
-			})
-		})
-
-		When("there is some other error fetching the process", func() {
-			BeforeEach(func() {
-				processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
-			})
-
-			It("returns an error", func() {
-				expectUnknownError()
-			})
-		})
-	})
-
 	Describe("the POST /v3/processes/:guid/actions/scale endpoint", func() {
 		BeforeEach(func() {
 			processRepo.GetProcessReturns(repositories.ProcessRecord{
 				GUID:      "process-guid",
 				SpaceGUID: spaceGUID,
+				AppGUID:   appGUID,
+				Type:      "web",
 			}, nil)
 
 			processRepo.ScaleProcessReturns(repositories.ProcessRecord{
 				GUID: "process-guid",
 			}, nil)
 
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID: appGUID,
+				Name: "my-app",
+			}, nil)
+
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessScale{
 				Instances: tools.PtrTo[int32](3),
 				MemoryMB:  tools.PtrTo[int64](512),
@@ -197,6 +168,30 @@ var _ = Describe("Process", func() {
 			)))
 		})
 
+		It("records an audit event", func() {
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateAuditEventMessage{
+				Type:      repositories.AuditEventTypeAppProcessScale,
+				SpaceGUID: spaceGUID,
+				Target: repositories.AuditEventParticipant{
+					GUID: appGUID,
+					Type: repositories.AuditEventTargetTypeApp,
+					Name: "my-app",
+				},
+				Data: map[string]any{
+					"process_guid": "process-guid",
+					"process_type": "web",
+					"request": map[string]any{
+						"instances":    int32(3),
+						"memory_in_mb": int64(512),
+						"disk_in_mb":   int64(256),
+					},
+				},
+			}))
+		})
+
 		When("the request JSON is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -207,6 +202,22 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("app scaling is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "app_scaling", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFeatureFlag).To(Equal("app_scaling"))
+
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+				expectFeatureDisabledError("app_scaling")
+			})
+		})
+
 		When("the user does not have permissions to get the process", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
@@ -227,6 +238,17 @@ var _ = Describe("Process", func() {
 			})
 		})
 
+		When("getting the app errors", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+			})
+
+			It("returns an error and does not scale the process", func() {
+				expectUnknownError()
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("scaling errors", func() {
 			BeforeEach(func() {
 				processRepo.ScaleProcessReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
@@ -436,6 +458,14 @@ var _ = Describe("Process", func() {
 						InvocationTimeout: tools.PtrTo[int32](2),
 					},
 				},
+				ReadinessHealthCheck: &payloads.ReadinessHealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.ReadinessData{
+						Endpoint:          tools.PtrTo("/ready"),
+						InvocationTimeout: tools.PtrTo[int32](3),
+						Interval:          tools.PtrTo[int32](10),
+					},
+				},
 			})
 		})
 
@@ -458,6 +488,10 @@ var _ = Describe("Process", func() {
 			Expect(actualMsg.HealthCheckTimeoutSeconds).To(Equal(tools.PtrTo(int32(5))))
 			Expect(actualMsg.HealthCheckHTTPEndpoint).To(Equal(tools.PtrTo("http://myapp.com/health")))
 			Expect(actualMsg.HealthCheckType).To(Equal(tools.PtrTo("port")))
+			Expect(actualMsg.ReadinessHealthCheckType).To(Equal(tools.PtrTo("http")))
+			Expect(actualMsg.ReadinessHealthCheckHTTPEndpoint).To(Equal(tools.PtrTo("/ready")))
+			Expect(actualMsg.ReadinessHealthCheckInvocationTimeoutSeconds).To(Equal(tools.PtrTo(int32(3))))
+			Expect(actualMsg.ReadinessHealthCheckIntervalSeconds).To(Equal(tools.PtrTo(int32(10))))
 			Expect(actualMsg.MetadataPatch.Labels).To(Equal(map[string]*string{"foo": tools.PtrTo("value1")}))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
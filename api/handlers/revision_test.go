@@ -0,0 +1,222 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		revisionRepo     *fake.CFRevisionRepository
+		appRepo          *fake.CFAppRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		revisionRepo = new(fake.CFRevisionRepository)
+		appRepo = new(fake.CFAppRepository)
+
+		apiHandler := handlers.NewRevision(*serverURL, requestValidator, revisionRepo, appRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+				GUID:        "revision-guid",
+				Version:     3,
+				AppGUID:     appGUID,
+				DropletGUID: dropletGUID,
+				Deployable:  true,
+			}, nil)
+			req = createHttpRequest("GET", "/v3/revisions/revision-guid", nil)
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-guid"),
+				MatchJSONPath("$.version", BeEquivalentTo(3)),
+				MatchJSONPath("$.droplet.guid", dropletGUID),
+				MatchJSONPath("$.deployable", BeTrue()),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+
+		When("getting the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/revisions/{guid}/environment_variables", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionEnvVarsReturns(repositories.RevisionEnvVarsRecord{
+				RevisionGUID:         "revision-guid",
+				AppGUID:              appGUID,
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/revisions/revision-guid/environment_variables", nil)
+		})
+
+		It("returns the revision environment variables", func() {
+			Expect(revisionRepo.GetRevisionEnvVarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionEnvVarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.var.FOO", "bar"),
+				MatchJSONPath("$.links.revision.href", defaultServerURL+"/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionEnvVarsReturns(repositories.RevisionEnvVarsRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-1", Version: 1, AppGUID: appGUID},
+				{GUID: "revision-2", Version: 2, AppGUID: appGUID},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RevisionList{
+				Versions: "1,2",
+				OrderBy:  "created_at",
+			})
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/revisions?versions=1,2", nil)
+		})
+
+		It("lists the app revisions", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  appGUID,
+				Versions: []string{"1", "2"},
+				OrderBy:  "created_at",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[1].guid", "revision-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid query"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("invalid query")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(revisionRepo.ListRevisionsCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions/deployed", func() {
+		BeforeEach(func() {
+			revisionRepo.ListDeployedRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-2", Version: 2, AppGUID: appGUID},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/revisions/deployed", nil)
+		})
+
+		It("lists the deployed app revisions", func() {
+			Expect(revisionRepo.ListDeployedRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := revisionRepo.ListDeployedRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "revision-2"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.ListDeployedRevisionsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+	})
+})
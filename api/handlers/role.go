@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 )
@@ -30,16 +31,18 @@ type CFRoleRepository interface {
 }
 
 type Role struct {
-	apiBaseURL       url.URL
-	roleRepo         CFRoleRepository
-	requestValidator RequestValidator
+	apiBaseURL          url.URL
+	roleRepo            CFRoleRepository
+	requestValidator    RequestValidator
+	featureFlagEnforcer FeatureFlagEnforcer
 }
 
-func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator) *Role {
+func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator, featureFlagEnforcer FeatureFlagEnforcer) *Role {
 	return &Role{
-		apiBaseURL:       apiBaseURL,
-		roleRepo:         roleRepo,
-		requestValidator: requestValidator,
+		apiBaseURL:          apiBaseURL,
+		roleRepo:            roleRepo,
+		requestValidator:    requestValidator,
+		featureFlagEnforcer: featureFlagEnforcer,
 	}
 }
 
@@ -52,6 +55,12 @@ func (h *Role) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Relationships.User.Data.GUID == "" {
+		if err := h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagSetRolesByUsername); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "setting roles by username is disabled")
+		}
+	}
+
 	role := payload.ToMessage()
 	role.GUID = uuid.NewString()
 
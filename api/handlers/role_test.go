@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -26,16 +27,18 @@ const (
 
 var _ = Describe("Role", func() {
 	var (
-		apiHandler       *handlers.Role
-		roleRepo         *fake.CFRoleRepository
-		requestValidator *fake.RequestValidator
+		apiHandler          *handlers.Role
+		roleRepo            *fake.CFRoleRepository
+		requestValidator    *fake.RequestValidator
+		featureFlagEnforcer *fake.FeatureFlagEnforcer
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator, featureFlagEnforcer)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -90,6 +93,27 @@ var _ = Describe("Role", func() {
 			)))
 		})
 
+		It("checks the set_roles_by_username feature flag", func() {
+			Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFeatureFlag).To(Equal(korifiv1alpha1.FeatureFlagSetRolesByUsername))
+		})
+
+		When("the set_roles_by_username feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "set_roles_by_username", ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectFeatureDisabledError("set_roles_by_username")
+			})
+
+			It("does not create the role", func() {
+				Expect(roleRepo.CreateRoleCallCount()).To(BeZero())
+			})
+		})
+
 		When("username is passed in the guid field", func() {
 			BeforeEach(func() {
 				roleCreate.Relationships.User.Data.Username = ""
@@ -101,6 +125,10 @@ var _ = Describe("Role", func() {
 				_, _, roleMessage := roleRepo.CreateRoleArgsForCall(0)
 				Expect(roleMessage.User).To(Equal("my-user"))
 			})
+
+			It("does not check the set_roles_by_username feature flag", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(BeZero())
+			})
 		})
 
 		When("the role is an organisation role", func() {
@@ -17,18 +17,20 @@ type Root struct {
 	baseURL     url.URL
 	uaaConfig   config.UAA
 	logCacheURL url.URL
+	appSSHInfo  presenter.AppSSHInfo
 }
 
-func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) *Root {
+func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, appSSHInfo presenter.AppSSHInfo) *Root {
 	return &Root{
 		baseURL:     baseURL,
 		uaaConfig:   uaaConfig,
 		logCacheURL: logCacheURL,
+		appSSHInfo:  appSSHInfo,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL, h.appSSHInfo)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -20,6 +21,9 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+	RouteSharedSpacesPath = "/v3/routes/{guid}/relationships/shared_spaces"
+	RouteSharedSpacePath  = "/v3/routes/{guid}/relationships/shared_spaces/{space_guid}"
+	RouteSpacePath        = "/v3/routes/{guid}/relationships/space"
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -33,16 +37,20 @@ type CFRouteRepository interface {
 	DeleteUnmappedRoutes(context.Context, authorization.Info, string) error
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
-	PatchRouteMetadata(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
+	PatchRoute(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)
+	ShareRoute(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	UnshareRoute(context.Context, authorization.Info, repositories.UnshareRouteMessage) (repositories.RouteRecord, error)
+	TransferRouteOwnership(context.Context, authorization.Info, repositories.TransferRouteOwnershipMessage) (repositories.RouteRecord, error)
 }
 
 type Route struct {
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	routeRepo           CFRouteRepository
+	domainRepo          CFDomainRepository
+	appRepo             CFAppRepository
+	spaceRepo           CFSpaceRepository
+	requestValidator    RequestValidator
+	featureFlagEnforcer FeatureFlagEnforcer
 }
 
 func NewRoute(
@@ -52,14 +60,16 @@ func NewRoute(
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
+	featureFlagEnforcer FeatureFlagEnforcer,
 ) *Route {
 	return &Route{
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		routeRepo:           routeRepo,
+		domainRepo:          domainRepo,
+		appRepo:             appRepo,
+		spaceRepo:           spaceRepo,
+		requestValidator:    requestValidator,
+		featureFlagEnforcer: featureFlagEnforcer,
 	}
 }
 
@@ -147,6 +157,10 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagRouteCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route creation is disabled")
+	}
+
 	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
 	responseRouteRecord, err := h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
 	if err != nil {
@@ -175,6 +189,23 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	}
 
 	destinationListCreateMessage := destinationCreatePayload.ToMessage(routeRecord)
+	for i, destination := range destinationListCreateMessage.NewDestinations {
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, destination.AppGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(
+					err,
+					"Unable to map route to app. Ensure that the app exists and you have access to it.",
+					apierrors.NotFoundError{},
+					apierrors.ForbiddenError{},
+				),
+				"Failed to fetch destination app from Kubernetes",
+				"AppGUID", destination.AppGUID,
+			)
+		}
+		destinationListCreateMessage.NewDestinations[i].SpaceGUID = app.SpaceGUID
+	}
 
 	responseRouteRecord, err := h.routeRepo.AddDestinationsToRoute(r.Context(), authInfo, destinationListCreateMessage)
 	if err != nil {
@@ -288,13 +319,131 @@ func (h *Route) update(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	route, err = h.routeRepo.PatchRouteMetadata(r.Context(), authInfo, payload.ToMessage(routeGUID, route.SpaceGUID))
+	route, err = h.routeRepo.PatchRoute(r.Context(), authInfo, payload.ToMessage(routeGUID, route.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch route metadata", "RouteGUID", routeGUID)
 	}
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL)), nil
 }
 
+func (h *Route) listSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.list-shared-spaces")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.share")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagRouteSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route sharing is disabled")
+	}
+
+	for _, space := range payload.Data {
+		if err = h.ensureSpaceIsAccessible(r.Context(), authInfo, space.GUID); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space from Kubernetes", "SpaceGUID", space.GUID)
+		}
+	}
+
+	route, err = h.routeRepo.ShareRoute(r.Context(), authInfo, payload.ToMessage(routeGUID, route.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share route", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.unshare")
+
+	routeGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagRouteSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route sharing is disabled")
+	}
+
+	_, err = h.routeRepo.UnshareRoute(r.Context(), authInfo, repositories.UnshareRouteMessage{
+		RouteGUID:       routeGUID,
+		SpaceGUID:       route.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare route", "RouteGUID", routeGUID, "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Route) transferOwnership(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.transfer-ownership")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteTransferOwnership
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagRouteSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route sharing is disabled")
+	}
+
+	if err = h.ensureSpaceIsAccessible(r.Context(), authInfo, payload.Data.GUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space from Kubernetes", "SpaceGUID", payload.Data.GUID)
+	}
+
+	route, err = h.routeRepo.TransferRouteOwnership(r.Context(), authInfo, payload.ToMessage(routeGUID, route.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to transfer route ownership", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSpace(route)), nil
+}
+
+func (h *Route) ensureSpaceIsAccessible(ctx context.Context, authInfo authorization.Info, spaceGUID string) error {
+	_, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+	return apierrors.AsUnprocessableEntity(
+		err,
+		"Invalid space. Ensure that the space exists and you have access to it.",
+		apierrors.NotFoundError{},
+		apierrors.ForbiddenError{},
+	)
+}
+
 func (h *Route) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -309,5 +458,9 @@ func (h *Route) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: RouteDestinationsPath, Handler: h.insertDestinations},
 		{Method: "DELETE", Pattern: RouteDestinationPath, Handler: h.deleteDestination},
 		{Method: "PATCH", Pattern: RoutePath, Handler: h.update},
+		{Method: "GET", Pattern: RouteSharedSpacesPath, Handler: h.listSharedSpaces},
+		{Method: "POST", Pattern: RouteSharedSpacesPath, Handler: h.share},
+		{Method: "DELETE", Pattern: RouteSharedSpacePath, Handler: h.unshare},
+		{Method: "PATCH", Pattern: RouteSpacePath, Handler: h.transferOwnership},
 	}
 }
@@ -1,11 +1,13 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -21,11 +23,12 @@ import (
 
 var _ = Describe("Route", func() {
 	var (
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		appRepo          *fake.CFAppRepository
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
+		routeRepo           *fake.CFRouteRepository
+		domainRepo          *fake.CFDomainRepository
+		appRepo             *fake.CFAppRepository
+		spaceRepo           *fake.CFSpaceRepository
+		requestValidator    *fake.RequestValidator
+		featureFlagEnforcer *fake.FeatureFlagEnforcer
 
 		requestMethod string
 		requestPath   string
@@ -65,6 +68,7 @@ var _ = Describe("Route", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 
 		apiHandler := NewRoute(
 			*serverURL,
@@ -73,6 +77,7 @@ var _ = Describe("Route", func() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			featureFlagEnforcer,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -319,6 +324,22 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		When("route creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "route_creation", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFeatureFlag).To(Equal("route_creation"))
+
+				Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				expectFeatureDisabledError("route_creation")
+			})
+		})
+
 		When("the request body is invalid JSON", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -401,7 +422,7 @@ var _ = Describe("Route", func() {
 		})
 
 		BeforeEach(func() {
-			routeRepo.PatchRouteMetadataReturns(repositories.RouteRecord{
+			routeRepo.PatchRouteReturns(repositories.RouteRecord{
 				GUID:      "test-route-guid",
 				SpaceGUID: spaceGUID,
 				Labels: map[string]string{
@@ -430,8 +451,8 @@ var _ = Describe("Route", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 
-			Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(1))
-			_, _, msg := routeRepo.PatchRouteMetadataArgsForCall(0)
+			Expect(routeRepo.PatchRouteCallCount()).To(Equal(1))
+			_, _, msg := routeRepo.PatchRouteArgsForCall(0)
 			Expect(msg.RouteGUID).To(Equal("test-route-guid"))
 			Expect(msg.SpaceGUID).To(Equal(spaceGUID))
 			Expect(msg.Annotations).To(HaveKeyWithValue("a", PointTo(Equal("av"))))
@@ -451,7 +472,7 @@ var _ = Describe("Route", func() {
 			})
 
 			It("returns a not found error and doesn't try patching", func() {
-				Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(0))
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(0))
 				expectNotFoundError("Route")
 			})
 		})
@@ -462,14 +483,14 @@ var _ = Describe("Route", func() {
 			})
 
 			It("returns an error and doesn't try patching", func() {
-				Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(0))
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(0))
 				expectUnknownError()
 			})
 		})
 
 		When("patching the Route errors", func() {
 			BeforeEach(func() {
-				routeRepo.PatchRouteMetadataReturns(repositories.RouteRecord{}, errors.New("boom"))
+				routeRepo.PatchRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
 			})
 
 			It("returns an error", func() {
@@ -580,6 +601,13 @@ var _ = Describe("Route", func() {
 				},
 			}
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+
+			appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+				if appGUID == "app-2-guid" {
+					return repositories.AppRecord{GUID: appGUID, SpaceGUID: "shared-space-guid"}, nil
+				}
+				return repositories.AppRecord{GUID: appGUID, SpaceGUID: "test-space-guid"}, nil
+			}
 		})
 
 		It("adds the destinations to the route", func() {
@@ -605,12 +633,14 @@ var _ = Describe("Route", func() {
 			Expect(message.NewDestinations).To(ConsistOf(
 				MatchFields(IgnoreExtras, Fields{
 					"AppGUID":     Equal("app-1-guid"),
+					"SpaceGUID":   Equal("test-space-guid"),
 					"ProcessType": Equal("web"),
 					"Port":        BeNil(),
 					"Protocol":    BeNil(),
 				}),
 				MatchFields(IgnoreExtras, Fields{
 					"AppGUID":     Equal("app-2-guid"),
+					"SpaceGUID":   Equal("shared-space-guid"),
 					"ProcessType": Equal("queue"),
 					"Port":        PointTo(BeEquivalentTo(1234)),
 					"Protocol":    PointTo(Equal("http1")),
@@ -659,6 +689,18 @@ var _ = Describe("Route", func() {
 			})
 		})
 
+		When("a destination app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error and doesn't add the destinations", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Unable to map route to app. Ensure that the app exists and you have access to it.")
+			})
+		})
+
 		When("adding the destinations to the Route errors", func() {
 			BeforeEach(func() {
 				routeRepo.AddDestinationsToRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
@@ -748,6 +790,232 @@ var _ = Describe("Route", func() {
 		})
 	})
 
+	Describe("the GET /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRecord.SharedSpaceGUIDs = []string{"space-1", "space-2"}
+			routeRepo.GetRouteReturns(routeRecord, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+		})
+
+		It("returns the shared spaces", func() {
+			Expect(routeRepo.GetRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRouteGUID := routeRepo.GetRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRouteGUID).To(Equal("test-route-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the user lacks permission to fetch the route", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route")
+			})
+		})
+	})
+
+	Describe("the POST /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			sharedRoute := routeRecord
+			sharedRoute.SharedSpaceGUIDs = []string{"space-1"}
+			routeRepo.ShareRouteReturns(sharedRoute, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.RouteShare{
+				Data: []payloads.RelationshipData{{GUID: "space-1"}},
+			})
+		})
+
+		It("shares the route", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-1"))
+
+			Expect(routeRepo.ShareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.ShareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        "test-route-guid",
+				SpaceGUID:        "test-space-guid",
+				SharedSpaceGUIDs: []string{"space-1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("space-1"))))
+		})
+
+		When("the route_sharing feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "route_sharing", ""))
+			})
+
+			It("returns a feature disabled error and doesn't share the route", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(Equal(0))
+				expectFeatureDisabledError("route_sharing")
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error and doesn't share the route", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("sharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.ShareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/routes/:guid/relationships/shared_spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces/space-1"
+		})
+
+		It("unshares the route", func() {
+			Expect(routeRepo.UnshareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.UnshareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareRouteMessage{
+				RouteGUID:       "test-route-guid",
+				SpaceGUID:       "test-space-guid",
+				SharedSpaceGUID: "space-1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the route_sharing feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "route_sharing", ""))
+			})
+
+			It("returns a feature disabled error and doesn't unshare the route", func() {
+				Expect(routeRepo.UnshareRouteCallCount()).To(Equal(0))
+				expectFeatureDisabledError("route_sharing")
+			})
+		})
+
+		When("unsharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.UnshareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/routes/:guid/relationships/space endpoint", func() {
+		BeforeEach(func() {
+			transferredRoute := routeRecord
+			transferredRoute.SpaceGUID = "new-space-guid"
+			routeRepo.TransferRouteOwnershipReturns(transferredRoute, nil)
+
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/test-route-guid/relationships/space"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.RouteTransferOwnership{
+				Data: &payloads.RelationshipData{GUID: "new-space-guid"},
+			})
+		})
+
+		It("transfers the route to the new space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("new-space-guid"))
+
+			Expect(routeRepo.TransferRouteOwnershipCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.TransferRouteOwnershipArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.TransferRouteOwnershipMessage{
+				RouteGUID:    "test-route-guid",
+				SpaceGUID:    "test-space-guid",
+				NewSpaceGUID: "new-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "new-space-guid")))
+		})
+
+		When("the route_sharing feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "route_sharing", ""))
+			})
+
+			It("returns a feature disabled error and doesn't transfer the route", func() {
+				Expect(routeRepo.TransferRouteOwnershipCallCount()).To(Equal(0))
+				expectFeatureDisabledError("route_sharing")
+			})
+		})
+
+		When("the new space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error and doesn't transfer the route", func() {
+				Expect(routeRepo.TransferRouteOwnershipCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("the route cannot be found", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("transferring the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.TransferRouteOwnershipReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the DELETE /v3/routes/:guid endpoint", func() {
 		BeforeEach(func() {
 			requestMethod = http.MethodDelete
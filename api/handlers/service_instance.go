@@ -42,6 +42,7 @@ type ServiceInstance struct {
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           CFSpaceRepository
 	requestValidator    RequestValidator
+	featureFlagEnforcer FeatureFlagEnforcer
 	includeResolver     *include.IncludeResolver[
 		[]repositories.ServiceInstanceRecord,
 		repositories.ServiceInstanceRecord,
@@ -54,12 +55,14 @@ func NewServiceInstance(
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
 	relationshipRepo include.ResourceRelationshipRepository,
+	featureFlagEnforcer FeatureFlagEnforcer,
 ) *ServiceInstance {
 	return &ServiceInstance{
 		serverURL:           serverURL,
 		serviceInstanceRepo: serviceInstanceRepo,
 		spaceRepo:           spaceRepo,
 		requestValidator:    requestValidator,
+		featureFlagEnforcer: featureFlagEnforcer,
 		includeResolver:     include.NewIncludeResolver[[]repositories.ServiceInstanceRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
@@ -133,6 +136,10 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagServiceInstanceCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "service instance creation is disabled")
+	}
+
 	if payload.Type == korifiv1alpha1.ManagedType {
 		return h.createManagedServiceInstance(r.Context(), logger, authInfo, payload)
 	}
@@ -29,6 +29,7 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo     *fake.CFServicePlanRepository
 		serviceBrokerRepo   *fake.CFServiceBrokerRepository
 		requestValidator    *fake.RequestValidator
+		featureFlagEnforcer *fake.FeatureFlagEnforcer
 
 		reqMethod string
 		reqPath   string
@@ -49,6 +50,7 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo = new(fake.CFServicePlanRepository)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 
 		apiHandler := NewServiceInstance(
 			*serverURL,
@@ -62,6 +64,7 @@ var _ = Describe("ServiceInstance", func() {
 				spaceRepo,
 				orgRepo,
 			),
+			featureFlagEnforcer,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 
@@ -432,6 +435,22 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 
+		When("service instance creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "service_instance_creation", "no services"))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, _, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualFeatureFlag).To(Equal("service_instance_creation"))
+
+				Expect(serviceInstanceRepo.CreateUserProvidedServiceInstanceCallCount()).To(BeZero())
+				Expect(serviceInstanceRepo.CreateManagedServiceInstanceCallCount()).To(BeZero())
+				expectFeatureDisabledError("no services")
+			})
+		})
+
 		When("the get space returns an unknown error", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(
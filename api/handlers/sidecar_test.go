@@ -0,0 +1,332 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		sidecarRepo      *fake.CFSidecarRepository
+		appRepo          *fake.CFAppRepository
+		processRepo      *fake.CFProcessRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		appRepo = new(fake.CFAppRepository)
+		processRepo = new(fake.CFProcessRepository)
+
+		apiHandler := handlers.NewSidecar(*serverURL, requestValidator, sidecarRepo, appRepo, processRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
+		sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+			GUID:         "sidecar-guid",
+			Name:         "apm-agent",
+			Command:      "run-agent",
+			ProcessTypes: []string{"web"},
+			AppGUID:      appGUID,
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarCreate{
+				Name:         "apm-agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			})
+			sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{
+				GUID:         "sidecar-guid",
+				Name:         "apm-agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+				AppGUID:      appGUID,
+			}, nil)
+			req = createHttpRequest("POST", "/v3/apps/"+appGUID+"/sidecars", strings.NewReader("the-json-body"))
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the sidecar", func() {
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      appGUID,
+				Name:         "apm-agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "apm-agent"),
+				MatchJSONPath("$.memory_in_mb", BeEquivalentTo(64)),
+				MatchJSONPath("$.relationships.app.data.guid", appGUID),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, apierrors.NewUnprocessableEntityError(nil, "Sidecar with name 'apm-agent' already exists for given app"))
+			})
+
+			It("returns the error", func() {
+				expectUnprocessableEntityError("Sidecar with name 'apm-agent' already exists for given app")
+			})
+		})
+	})
+
+	Describe("GET /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			req = createHttpRequest("GET", "/v3/sidecars/sidecar-guid", nil)
+		})
+
+		It("returns the sidecar", func() {
+			Expect(sidecarRepo.GetSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.command", "run-agent"),
+			)))
+		})
+
+		When("getting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the sidecar does not exist", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewNotFoundError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarUpdate{
+				Command: tools.PtrTo("run-agent --verbose"),
+			})
+			sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Command: "run-agent --verbose",
+			}, nil)
+			req = createHttpRequest("PATCH", "/v3/sidecars/sidecar-guid", strings.NewReader("the-json-body"))
+		})
+
+		It("patches the sidecar", func() {
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.PatchSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSidecarMessage{
+				GUID:    "sidecar-guid",
+				Command: tools.PtrTo("run-agent --verbose"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.command", "run-agent --verbose")))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+	})
+
+	Describe("DELETE /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			req = createHttpRequest("DELETE", "/v3/sidecars/sidecar-guid", nil)
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-1", AppGUID: appGUID},
+				{GUID: "sidecar-2", AppGUID: appGUID},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/sidecars", nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListSidecarsMessage{AppGUID: appGUID}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/"+appGUID+"/sidecars"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-1"),
+				MatchJSONPath("$.resources[1].guid", "sidecar-2"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/processes/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:    "process-guid",
+				AppGUID: appGUID,
+				Type:    "web",
+			}, nil)
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-1", AppGUID: appGUID},
+			}, nil)
+			req = createHttpRequest("GET", "/v3/processes/process-guid/sidecars", nil)
+		})
+
+		It("lists the sidecars of the process", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, actualMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:     appGUID,
+				ProcessType: "web",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-1"),
+			)))
+		})
+
+		When("the process isn't accessible to the user", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ProcessResourceType)
+			})
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
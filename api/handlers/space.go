@@ -21,6 +21,8 @@ const (
 	SpacesPath         = "/v3/spaces"
 	SpacePath          = "/v3/spaces/{guid}"
 	RoutesForSpacePath = "/v3/spaces/{guid}/routes"
+	SpaceFeaturesPath  = "/v3/spaces/{guid}/features"
+	SpaceFeaturePath   = "/v3/spaces/{guid}/features/{name}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
@@ -31,6 +33,7 @@ type CFSpaceRepository interface {
 	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, repositories.DeleteSpaceMessage) error
 	PatchSpaceMetadata(context.Context, authorization.Info, repositories.PatchSpaceMetadataMessage) (repositories.SpaceRecord, error)
+	PatchSpaceFeatures(context.Context, authorization.Info, repositories.PatchSpaceFeaturesMessage) (repositories.SpaceRecord, error)
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
@@ -197,6 +200,65 @@ func (h *Space) deleteUnmappedRoutes(r *http.Request) (*routing.Response, error)
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceDeleteUnmappedRoutesOperation, h.apiBaseURL)), nil
 }
 
+func (h *Space) getFeatures(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-features")
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeatures(space)), nil
+}
+
+func (h *Space) getFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-feature")
+	spaceGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName != presenter.SSHSpaceFeature {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeature(space)), nil
+}
+
+func (h *Space) updateFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.update-feature")
+	spaceGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName != presenter.SSHSpaceFeature {
+		return nil, apierrors.NewNotFoundError(nil, "Feature")
+	}
+
+	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "SpaceGUID", spaceGUID)
+	}
+
+	var payload payloads.SpaceFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err := h.spaceRepo.PatchSpaceFeatures(r.Context(), authInfo, payload.ToSSHPatchMessage(spaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch space features", "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeature(space)), nil
+}
+
 func (h *Space) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -209,5 +271,8 @@ func (h *Space) AuthenticatedRoutes() []routing.Route {
 		{Method: "DELETE", Pattern: SpacePath, Handler: h.delete},
 		{Method: "GET", Pattern: SpacePath, Handler: h.get},
 		{Method: "DELETE", Pattern: RoutesForSpacePath, Handler: h.deleteUnmappedRoutes},
+		{Method: "GET", Pattern: SpaceFeaturesPath, Handler: h.getFeatures},
+		{Method: "GET", Pattern: SpaceFeaturePath, Handler: h.getFeature},
+		{Method: "PATCH", Pattern: SpaceFeaturePath, Handler: h.updateFeature},
 	}
 }
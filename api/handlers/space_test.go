@@ -486,4 +486,165 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+
+	Describe("Get space features", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/features"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:       "the-space-guid",
+				SSHEnabled: true,
+			}, nil)
+		})
+
+		It("returns the space features", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.resources", HaveLen(1)),
+				MatchJSONPath("$.resources[0].name", "ssh"),
+				MatchJSONPath("$.resources[0].enabled", BeTrue()),
+			)))
+		})
+
+		When("fetching the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("Get a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:       "the-space-guid",
+				SSHEnabled: false,
+			}, nil)
+		})
+
+		It("returns the space feature", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/unknown"
+			})
+
+			It("returns a not found error", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(BeZero())
+				expectNotFoundError("Feature")
+			})
+		})
+
+		When("fetching the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("Update a space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/features/ssh"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+			spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{
+				GUID:       "the-space-guid",
+				SSHEnabled: false,
+			}, nil)
+		})
+
+		It("updates the space feature", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := spaceRepo.PatchSpaceFeaturesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:       "the-space-guid",
+				SSHEnabled: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/unknown"
+			})
+
+			It("returns a not found error", func() {
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+				expectNotFoundError("Feature")
+			})
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(BeZero())
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the user is not a space manager", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
 })
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -15,25 +16,38 @@ import (
 )
 
 const (
-	StacksPath = "/v3/stacks"
+	StacksPath    = "/v3/stacks"
+	StackPath     = "/v3/stacks/{guid}"
+	StackAppsPath = "/v3/stacks/{guid}/apps"
 )
 
+//counterfeiter:generate -o fake -fake-name StackRepository . StackRepository
 type StackRepository interface {
 	ListStacks(ctx context.Context, authInfo authorization.Info) ([]repositories.StackRecord, error)
+	GetStack(ctx context.Context, authInfo authorization.Info, guid string) (repositories.StackRecord, error)
+	CreateStack(ctx context.Context, authInfo authorization.Info, message repositories.CreateStackMessage) (repositories.StackRecord, error)
+	UpdateStack(ctx context.Context, authInfo authorization.Info, message repositories.UpdateStackMessage) (repositories.StackRecord, error)
+	DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error
 }
 
 type Stack struct {
-	serverURL url.URL
-	stackRepo StackRepository
+	serverURL        url.URL
+	stackRepo        StackRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
 }
 
 func NewStack(
 	serverURL url.URL,
 	stackRepo StackRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
 ) *Stack {
 	return &Stack{
-		serverURL: serverURL,
-		stackRepo: stackRepo,
+		serverURL:        serverURL,
+		stackRepo:        stackRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
 	}
 }
 
@@ -49,6 +63,115 @@ func (h *Stack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForStack, stacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Stack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.get")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.create")
+
+	var payload payloads.StackCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	stack, err := h.stackRepo.CreateStack(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.update")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.StackUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	stack, err := h.stackRepo.UpdateStack(r.Context(), authInfo, payload.ToMessage(stackGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error updating stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+// delete refuses to delete stacks that apps still use, so that they do not
+// silently fall back to the default stack on their next build
+func (h *Stack) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.delete")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	apps, err := h.appRepo.ListApps(r.Context(), authInfo, repositories.ListAppsMessage{Stacks: []string{stack.Name}})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch apps from Kubernetes", "stackGUID", stackGUID)
+	}
+
+	if len(apps) > 0 {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Please delete the app associations for your stack."),
+			"cannot delete a stack with apps",
+			"stackGUID", stackGUID,
+		)
+	}
+
+	err = h.stackRepo.DeleteStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete stack from Kubernetes", "stackGUID", stackGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Stack) listApps(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.list-apps")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	apps, err := h.appRepo.ListApps(r.Context(), authInfo, repositories.ListAppsMessage{Stacks: []string{stack.Name}})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch apps from Kubernetes", "stackGUID", stackGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForApp, apps, h.serverURL, *r.URL)), nil
+}
+
 func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -56,5 +179,10 @@ func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 func (h *Stack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: StacksPath, Handler: h.list},
+		{Method: "POST", Pattern: StacksPath, Handler: h.create},
+		{Method: "GET", Pattern: StackPath, Handler: h.get},
+		{Method: "PATCH", Pattern: StackPath, Handler: h.update},
+		{Method: "DELETE", Pattern: StackPath, Handler: h.delete},
+		{Method: "GET", Pattern: StackAppsPath, Handler: h.listApps},
 	}
 }
@@ -3,28 +3,36 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Stack", func() {
 	var (
-		stackRepo *fake.StackRepository
-		req       *http.Request
+		stackRepo        *fake.StackRepository
+		appRepo          *fake.CFAppRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		stackRepo = new(fake.StackRepository)
+		appRepo = new(fake.CFAppRepository)
+		requestValidator = new(fake.RequestValidator)
 
-		apiHandler := NewStack(*serverURL, stackRepo)
+		apiHandler := NewStack(*serverURL, stackRepo, appRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -62,6 +70,7 @@ var _ = Describe("Stack", func() {
 				MatchJSONPath("$.resources[0].name", "io.buildpacks.stacks.jammy"),
 			)))
 		})
+
 		When("there is some other error fetching the stacks", func() {
 			BeforeEach(func() {
 				stackRepo.ListStacksReturns([]repositories.StackRecord{}, errors.New("unknown!"))
@@ -72,4 +81,244 @@ var _ = Describe("Stack", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{
+				GUID: "stack-guid",
+				Name: "my-stack",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks/stack-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the stack", func() {
+			Expect(stackRepo.GetStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.GetStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-guid"),
+				MatchJSONPath("$.name", "my-stack"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/stacks/stack-guid"),
+			)))
+		})
+
+		When("the user cannot get the stack", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewForbiddenError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+			})
+		})
+	})
+
+	Describe("the POST /v3/stacks endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackCreate{
+				Name:        "my-stack",
+				Description: "my description",
+			})
+
+			stackRepo.CreateStackReturns(repositories.StackRecord{
+				GUID:        "stack-guid",
+				Name:        "my-stack",
+				Description: "my description",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/stacks", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the stack", func() {
+			Expect(stackRepo.CreateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := stackRepo.CreateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-stack"))
+			Expect(message.Description).To(Equal("my description"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-guid"),
+				MatchJSONPath("$.description", "my description"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(stackRepo.CreateStackCallCount()).To(BeZero())
+			})
+		})
+
+		When("a stack with the same name exists", func() {
+			BeforeEach(func() {
+				stackRepo.CreateStackReturns(repositories.StackRecord{}, apierrors.NewUniquenessError(nil, "Stack with name \"my-stack\" already exists"))
+			})
+
+			It("returns a uniqueness error", func() {
+				expectErrorResponse(http.StatusUnprocessableEntity, "CF-UniquenessError", "Stack with name \"my-stack\" already exists", 10016)
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackUpdate{
+				Metadata: payloads.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid"}, nil)
+			stackRepo.UpdateStackReturns(repositories.StackRecord{
+				GUID:   "stack-guid",
+				Labels: map[string]string{"foo": "bar"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/stacks/stack-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the stack", func() {
+			Expect(stackRepo.UpdateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := stackRepo.UpdateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("stack-guid"))
+			Expect(message.MetadataPatch.Labels).To(HaveKeyWithValue("foo", PointTo(Equal("bar"))))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.metadata.labels.foo", "bar")))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.UpdateStackCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid", Name: "my-stack"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/stacks/stack-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the stack", func() {
+			Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+			_, _, message := appRepo.ListAppsArgsForCall(0)
+			Expect(message.Stacks).To(ConsistOf("my-stack"))
+
+			Expect(stackRepo.DeleteStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.DeleteStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.DeleteStackCallCount()).To(BeZero())
+			})
+		})
+
+		When("apps are using the stack", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-guid"}}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Please delete the app associations for your stack.")
+				Expect(stackRepo.DeleteStackCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the GET /v3/stacks/:guid/apps endpoint", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid", Name: "my-stack"}, nil)
+			appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: "app-guid"}}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks/stack-guid/apps", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the apps on the stack", func() {
+			Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := appRepo.ListAppsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Stacks).To(ConsistOf("my-stack"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/stacks/stack-guid/apps"),
+				MatchJSONPath("$.resources[0].guid", "app-guid"),
+			)))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(appRepo.ListAppsCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the apps fails", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/go-logr/logr"
@@ -33,10 +34,11 @@ type CFTaskRepository interface {
 }
 
 type Task struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	taskRepo         CFTaskRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	appRepo             CFAppRepository
+	taskRepo            CFTaskRepository
+	requestValidator    RequestValidator
+	featureFlagEnforcer FeatureFlagEnforcer
 }
 
 func NewTask(
@@ -44,12 +46,14 @@ func NewTask(
 	appRepo CFAppRepository,
 	taskRepo CFTaskRepository,
 	requestValidator RequestValidator,
+	featureFlagEnforcer FeatureFlagEnforcer,
 ) *Task {
 	return &Task{
-		serverURL:        serverURL,
-		taskRepo:         taskRepo,
-		appRepo:          appRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		taskRepo:            taskRepo,
+		appRepo:             appRepo,
+		requestValidator:    requestValidator,
+		featureFlagEnforcer: featureFlagEnforcer,
 	}
 }
 
@@ -95,6 +99,10 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
 	}
 
+	if err = h.featureFlagEnforcer.EnsureFeatureFlagEnabled(r.Context(), authInfo, korifiv1alpha1.FeatureFlagTaskCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "task creation is disabled")
+	}
+
 	if !appRecord.IsStaged {
 		return nil, apierrors.LogAndReturn(
 			logger,
@@ -22,11 +22,12 @@ import (
 
 var _ = Describe("Task", func() {
 	var (
-		requestMethod    string
-		requestPath      string
-		appRepo          *fake.CFAppRepository
-		taskRepo         *fake.CFTaskRepository
-		requestValidator *fake.RequestValidator
+		requestMethod       string
+		requestPath         string
+		appRepo             *fake.CFAppRepository
+		taskRepo            *fake.CFTaskRepository
+		requestValidator    *fake.RequestValidator
+		featureFlagEnforcer *fake.FeatureFlagEnforcer
 	)
 
 	BeforeEach(func() {
@@ -48,8 +49,9 @@ var _ = Describe("Task", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagEnforcer = new(fake.FeatureFlagEnforcer)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator, featureFlagEnforcer)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -129,6 +131,22 @@ var _ = Describe("Task", func() {
 			})
 		})
 
+		When("task creation is disabled", func() {
+			BeforeEach(func() {
+				featureFlagEnforcer.EnsureFeatureFlagEnabledReturns(apierrors.NewFeatureDisabledError(nil, "task_creation", ""))
+			})
+
+			It("returns a Feature Disabled error", func() {
+				Expect(featureFlagEnforcer.EnsureFeatureFlagEnabledCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFeatureFlag := featureFlagEnforcer.EnsureFeatureFlagEnabledArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFeatureFlag).To(Equal("task_creation"))
+
+				Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+				expectFeatureDisabledError("task_creation")
+			})
+		})
+
 		When("the app is not staged", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{
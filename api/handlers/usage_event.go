@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppUsageEventsPath     = "/v3/app_usage_events"
+	AppUsageEventPath      = "/v3/app_usage_events/{guid}"
+	ServiceUsageEventsPath = "/v3/service_usage_events"
+	ServiceUsageEventPath  = "/v3/service_usage_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFUsageEventRepository . CFUsageEventRepository
+
+type CFUsageEventRepository interface {
+	GetAppUsageEvent(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	ListAppUsageEvents(context.Context, authorization.Info, repositories.ListUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)
+	GetServiceUsageEvent(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	ListServiceUsageEvents(context.Context, authorization.Info, repositories.ListUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+}
+
+type UsageEvent struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	usageEventRepo   CFUsageEventRepository
+}
+
+func NewUsageEvent(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	usageEventRepo CFUsageEventRepository,
+) *UsageEvent {
+	return &UsageEvent{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		usageEventRepo:   usageEventRepo,
+	}
+}
+
+func (h *UsageEvent) getApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.get")
+
+	usageEventGUID := routing.URLParam(r, "guid")
+
+	usageEvent, err := h.usageEventRepo.GetAppUsageEvent(r.Context(), authInfo, usageEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app usage event from Kubernetes", "UsageEventGUID", usageEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppUsageEvent(usageEvent, h.serverURL)), nil
+}
+
+func (h *UsageEvent) listApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.list")
+
+	payload := new(payloads.UsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	usageEvents, err := h.usageEventRepo.ListAppUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app usage events from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAppUsageEvent, usageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) getService(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.get")
+
+	usageEventGUID := routing.URLParam(r, "guid")
+
+	usageEvent, err := h.usageEventRepo.GetServiceUsageEvent(r.Context(), authInfo, usageEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch service usage event from Kubernetes", "UsageEventGUID", usageEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceUsageEvent(usageEvent, h.serverURL)), nil
+}
+
+func (h *UsageEvent) listService(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.list")
+
+	payload := new(payloads.UsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	usageEvents, err := h.usageEventRepo.ListServiceUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch service usage events from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceUsageEvent, usageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *UsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.listApp},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.getApp},
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.listService},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.getService},
+	}
+}
@@ -0,0 +1,205 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageEvent", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		usageEventRepo   *fake.CFUsageEventRepository
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		usageEventRepo = new(fake.CFUsageEventRepository)
+
+		apiHandler := handlers.NewUsageEvent(*serverURL, requestValidator, usageEventRepo)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:          "usage-event-guid",
+				State:         "STARTED",
+				AppGUID:       appGUID,
+				InstanceCount: 2,
+			}, nil)
+			req = createHttpRequest("GET", "/v3/app_usage_events/usage-event-guid", nil)
+		})
+
+		It("returns the app usage event", func() {
+			Expect(usageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("usage-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "usage-event-guid"),
+				MatchJSONPath("$.state.current", "STARTED"),
+				MatchJSONPath("$.app.guid", appGUID),
+				MatchJSONPath("$.instance_count.current", BeEquivalentTo(2)),
+			)))
+		})
+
+		When("the app usage event is not accessible", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+
+		When("getting the app usage event fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListAppUsageEventsReturns([]repositories.AppUsageEventRecord{
+				{GUID: "usage-event-2"},
+				{GUID: "usage-event-3"},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UsageEventList{
+				AfterGUID: "usage-event-1",
+			})
+			req = createHttpRequest("GET", "/v3/app_usage_events?after_guid=usage-event-1", nil)
+		})
+
+		It("lists the app usage events", func() {
+			Expect(usageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListUsageEventsMessage{
+				AfterGUID: "usage-event-1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "usage-event-2"),
+				MatchJSONPath("$.resources[1].guid", "usage-event-3"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid query"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("invalid query")
+			})
+		})
+
+		When("the user is not allowed to list usage events", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListAppUsageEventsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:                "usage-event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "service-instance-guid",
+			}, nil)
+			req = createHttpRequest("GET", "/v3/service_usage_events/usage-event-guid", nil)
+		})
+
+		It("returns the service usage event", func() {
+			Expect(usageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("usage-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "usage-event-guid"),
+				MatchJSONPath("$.state", "CREATED"),
+				MatchJSONPath("$.service_instance.guid", "service-instance-guid"),
+			)))
+		})
+
+		When("the service usage event is not accessible", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListServiceUsageEventsReturns([]repositories.ServiceUsageEventRecord{
+				{GUID: "usage-event-2"},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UsageEventList{
+				AfterGUID: "usage-event-1",
+			})
+			req = createHttpRequest("GET", "/v3/service_usage_events?after_guid=usage-event-1", nil)
+		})
+
+		It("lists the service usage events", func() {
+			Expect(usageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListUsageEventsMessage{
+				AfterGUID: "usage-event-1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "usage-event-2"),
+			)))
+		})
+
+		When("listing the service usage events fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListServiceUsageEventsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -20,11 +21,13 @@ import (
 	"code.cloudfoundry.org/korifi/api/middleware"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/conditions"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient"
 	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	"code.cloudfoundry.org/korifi/api/routing"
+	apissh "code.cloudfoundry.org/korifi/api/ssh"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
@@ -35,6 +38,7 @@ import (
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
@@ -50,7 +54,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-var conditionTimeout = time.Second * 120
+var (
+	conditionTimeout = time.Second * 120
+	sshCodeTTL       = time.Minute * 2
+)
 
 func init() {
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme.Scheme))
@@ -152,6 +159,7 @@ func main() {
 		orgRepo,
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](conditionTimeout),
+		privilegedClient,
 	)
 	processRepo := repositories.NewProcessRepo(klient)
 	podRepo := repositories.NewPodRepo(klientUnfiltered)
@@ -160,20 +168,38 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		repositories.NewAppSorter(),
 	)
-	dropletRepo := repositories.NewDropletRepo(klient)
+	dropletRepo := repositories.NewDropletRepo(
+		klient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
+		cfg.PackageRegistrySecretNames,
+	)
 	routeRepo := repositories.NewRouteRepo(klient)
 	domainRepo := repositories.NewDomainRepo(
 		klientUnfiltered,
+		privilegedClient,
 		cfg.RootNamespace,
+		nsPermissions,
 	)
-	deploymentRepo := repositories.NewDeploymentRepo(
+	revisionRepo := repositories.NewRevisionRepo(
 		klient,
-		repositories.NewDeploymentSorter(),
+		repositories.NewRevisionSorter(),
 	)
+	sidecarRepo := repositories.NewSidecarRepo(klient)
+	auditEventRepo := repositories.NewAuditEventRepo(
+		klient,
+		privilegedClient,
+		cachingIdentityProvider,
+		repositories.NewAuditEventSorter(),
+	)
+	usageEventRepo := repositories.NewUsageEventRepo(klient, cfg.RootNamespace)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(klient, cfg.RootNamespace)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(klient, cfg.RootNamespace)
 	buildRepo := repositories.NewBuildRepo(
 		klient,
 		repositories.NewBuildSorter(),
 	)
+	buildCacheRepo := repositories.NewBuildCacheRepo(klient, cfg.RootNamespace)
 	logRepo := repositories.NewLogRepo(
 		klientUnfiltered,
 		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig),
@@ -213,6 +239,8 @@ func main() {
 		cfg.BuilderName,
 		cfg.RootNamespace,
 		repositories.NewBuildpackSorter(),
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
 	)
 	roleRepo := repositories.NewRoleRepo(
 		klient,
@@ -227,9 +255,15 @@ func main() {
 	imageRepo := repositories.NewImageRepository(
 		klientUnfiltered,
 		imageClient,
+		imageClient,
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
+	deploymentRepo := repositories.NewDeploymentRepo(
+		klient,
+		repositories.NewDeploymentSorter(),
+		imageRepo,
+	)
 	taskRepo := repositories.NewTaskRepo(
 		klient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](conditionTimeout),
@@ -244,9 +278,9 @@ func main() {
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo, dropletRepo),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -315,9 +349,51 @@ func main() {
 		)
 	}
 
+	var sshCodeRepo *repositories.SSHCodeRepo
+	appSSHInfo := presenter.AppSSHInfo{}
+	if cfg.Experimental.SSH.Enabled {
+		sshCodeRepo = repositories.NewSSHCodeRepo(privilegedClient, cachingIdentityProvider, cfg.RootNamespace, sshCodeTTL)
+
+		sshServer, hostKey, err := wireSSHServer(
+			cfg.Experimental.SSH,
+			apissh.NewInstanceAuthenticator(
+				sshCodeRepo,
+				processRepo,
+				appRepo,
+				spaceRepo,
+				podRepo,
+				authorization.NewPodExecPermissions(authorization.NewUnprivilegedClientsetFactory(k8sClientConfig)),
+			),
+			apissh.NewWebsocketPodStreamer(authorization.NewUnprivilegedRestConfigFactory(k8sClientConfig)),
+		)
+		if err != nil {
+			panic(fmt.Sprintf("could not create the ssh server: %v", err))
+		}
+
+		appSSHInfo = presenter.AppSSHInfo{
+			Endpoint:           cfg.Experimental.SSH.ExternalEndpoint,
+			HostKeyFingerprint: apissh.HostKeyFingerprint(hostKey.PublicKey()),
+			OAuthClient:        handlers.SSHProxyOAuthClient,
+		}
+
+		sshPortString := fmt.Sprintf(":%v", cfg.Experimental.SSH.Port)
+		sshListener, err := net.Listen("tcp", sshPortString)
+		if err != nil {
+			panic(fmt.Sprintf("could not listen for ssh connections: %v", err))
+		}
+
+		go func() {
+			ctrl.Log.Info("listening for ssh connections on " + sshPortString)
+			if err2 := sshServer.Serve(sshListener); err2 != nil {
+				ctrl.Log.Error(err2, "error serving ssh")
+				os.Exit(1)
+			}
+		}()
+	}
+
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL, appSSHInfo),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
@@ -336,6 +412,9 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			auditEventRepo,
+			featureFlagRepo,
+			cfg.Experimental.SSH.Enabled,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -344,6 +423,7 @@ func main() {
 			appRepo,
 			spaceRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
@@ -355,6 +435,7 @@ func main() {
 			dropletRepo,
 			imageRepo,
 			requestValidator,
+			featureFlagRepo,
 			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewBuild(
@@ -363,24 +444,35 @@ func main() {
 			packageRepo,
 			appRepo,
 			requestValidator,
+			auditEventRepo,
+		),
+		handlers.NewBuildCache(
+			*serverURL,
+			buildCacheRepo,
 		),
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
 		),
 		handlers.NewProcess(
 			*serverURL,
 			processRepo,
+			appRepo,
 			requestValidator,
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			auditEventRepo,
+			featureFlagRepo,
 		),
 		handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			featureFlagRepo,
 		),
 		handlers.NewDeployment(
 			*serverURL,
@@ -389,9 +481,44 @@ func main() {
 			runnerInfoRepo,
 			cfg.RunnerName,
 		),
+		handlers.NewRevision(
+			*serverURL,
+			requestValidator,
+			revisionRepo,
+			appRepo,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			requestValidator,
+			auditEventRepo,
+		),
+		handlers.NewUsageEvent(
+			*serverURL,
+			requestValidator,
+			usageEventRepo,
+		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			requestValidator,
+			envVarGroupRepo,
+		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			requestValidator,
+			featureFlagRepo,
+		),
+		handlers.NewSidecar(
+			*serverURL,
+			requestValidator,
+			sidecarRepo,
+			appRepo,
+			processRepo,
+		),
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
+			appRepo,
+			requestValidator,
 		),
 		handlers.NewJob(
 			*serverURL,
@@ -405,12 +532,17 @@ func main() {
 				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
+				handlers.BuildpackDeleteJobType:              buildpackRepo,
 			},
 			map[string]handlers.StateRepository{
 				handlers.ServiceBrokerCreateJobType:          serviceBrokerRepo,
 				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.DropletUploadJobType:                dropletRepo,
+				handlers.BuildpackUploadJobType:              buildpackRepo,
+				handlers.AppClearBuildpackCacheJobType:       buildCacheRepo,
+				handlers.AdminClearBuildpackCacheJobType:     buildCacheRepo,
 			},
 			routeRepo,
 			500*time.Millisecond,
@@ -441,12 +573,14 @@ func main() {
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
 		handlers.NewUser(*serverURL),
 		handlers.NewBuildpack(
 			*serverURL,
 			buildpackRepo,
+			imageRepo,
 			requestValidator,
 		),
 		handlers.NewServiceInstance(
@@ -455,6 +589,7 @@ func main() {
 			spaceRepo,
 			requestValidator,
 			relationshipsRepo,
+			featureFlagRepo,
 		),
 		handlers.NewServiceBinding(
 			*serverURL,
@@ -468,6 +603,7 @@ func main() {
 			appRepo,
 			taskRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewServiceBroker(
 			*serverURL,
@@ -505,6 +641,10 @@ func main() {
 		))
 	}
 
+	if cfg.Experimental.SSH.Enabled {
+		apiHandlers = append(apiHandlers, handlers.NewOAuth(*serverURL, sshCodeRepo, requestValidator))
+	}
+
 	for _, handler := range apiHandlers {
 		routerBuilder.LoadRoutes(handler)
 	}
@@ -569,3 +709,17 @@ func wireIdentityProvider(client client.Client, restConfig *rest.Config) authori
 	certInspector := authorization.NewCertInspector(restConfig)
 	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
 }
+
+func wireSSHServer(sshConfig config.SSH, authenticator apissh.Authenticator, podStreamer apissh.PodStreamer) (*apissh.Server, ssh.Signer, error) {
+	hostKeyBytes, err := os.ReadFile(sshConfig.HostKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the ssh host key: %w", err)
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the ssh host key: %w", err)
+	}
+
+	return apissh.NewServer(hostKey, authenticator, podStreamer, ctrl.Log.WithName("ssh")), hostKey, nil
+}
@@ -204,3 +204,29 @@ func (a *AppPatch) ToMessage(appGUID, spaceGUID string) repositories.PatchAppMes
 
 	return msg
 }
+
+type AppFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p AppFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p AppFeatureUpdate) ToSSHPatchMessage(appGUID, spaceGUID string) repositories.PatchAppMessage {
+	return repositories.PatchAppMessage{
+		AppGUID:    appGUID,
+		SpaceGUID:  spaceGUID,
+		SSHEnabled: p.Enabled,
+	}
+}
+
+func (p AppFeatureUpdate) ToRevisionsPatchMessage(appGUID, spaceGUID string) repositories.PatchAppMessage {
+	return repositories.PatchAppMessage{
+		AppGUID:          appGUID,
+		SpaceGUID:        spaceGUID,
+		RevisionsEnabled: p.Enabled,
+	}
+}
@@ -504,4 +504,57 @@ var _ = Describe("App payload validation", func() {
 			})
 		})
 	})
+
+	Describe("AppFeatureUpdate", func() {
+		var (
+			payload        payloads.AppFeatureUpdate
+			decodedPayload *payloads.AppFeatureUpdate
+		)
+
+		BeforeEach(func() {
+			payload = payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(true),
+			}
+			decodedPayload = new(payloads.AppFeatureUpdate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("enabled is not set", func() {
+			BeforeEach(func() {
+				payload.Enabled = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "enabled is required")
+			})
+		})
+
+		Describe("ToSSHPatchMessage", func() {
+			It("converts to a patch app message", func() {
+				Expect(payload.ToSSHPatchMessage("app-guid", "space-guid")).To(Equal(repositories.PatchAppMessage{
+					AppGUID:    "app-guid",
+					SpaceGUID:  "space-guid",
+					SSHEnabled: tools.PtrTo(true),
+				}))
+			})
+		})
+
+		Describe("ToRevisionsPatchMessage", func() {
+			It("converts to a patch app message", func() {
+				Expect(payload.ToRevisionsPatchMessage("app-guid", "space-guid")).To(Equal(repositories.PatchAppMessage{
+					AppGUID:          "app-guid",
+					SpaceGUID:        "space-guid",
+					RevisionsEnabled: tools.PtrTo(true),
+				}))
+			})
+		})
+	})
 })
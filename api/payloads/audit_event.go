@@ -0,0 +1,87 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AuditEventList struct {
+	Types       string
+	TargetGUIDs string
+	SpaceGUIDs  string
+	CreatedAts  repositories.TimestampFilter
+	OrderBy     string
+}
+
+func (a AuditEventList) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+	)
+}
+
+func (a *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		Types:       parse.ArrayParam(a.Types),
+		TargetGUIDs: parse.ArrayParam(a.TargetGUIDs),
+		SpaceGUIDs:  parse.ArrayParam(a.SpaceGUIDs),
+		CreatedAts:  a.CreatedAts,
+		OrderBy:     a.OrderBy,
+	}
+}
+
+func (a *AuditEventList) SupportedKeys() []string {
+	return []string{
+		"types", "target_guids", "space_guids",
+		"created_ats", "created_ats[lt]", "created_ats[lte]", "created_ats[gt]", "created_ats[gte]",
+		"order_by", "per_page", "page",
+	}
+}
+
+func (a *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	a.Types = values.Get("types")
+	a.TargetGUIDs = values.Get("target_guids")
+	a.SpaceGUIDs = values.Get("space_guids")
+	a.OrderBy = values.Get("order_by")
+
+	var err error
+	for _, createdAt := range parse.ArrayParam(values.Get("created_ats")) {
+		var timestamp *time.Time
+		if timestamp, err = parseTimestamp("created_ats", createdAt); err != nil {
+			return err
+		}
+		a.CreatedAts.Equals = append(a.CreatedAts.Equals, *timestamp)
+	}
+	if a.CreatedAts.LessThan, err = parseTimestamp("created_ats[lt]", values.Get("created_ats[lt]")); err != nil {
+		return err
+	}
+	if a.CreatedAts.LessThanOrEqual, err = parseTimestamp("created_ats[lte]", values.Get("created_ats[lte]")); err != nil {
+		return err
+	}
+	if a.CreatedAts.GreaterThan, err = parseTimestamp("created_ats[gt]", values.Get("created_ats[gt]")); err != nil {
+		return err
+	}
+	if a.CreatedAts.GreaterThanOrEqual, err = parseTimestamp("created_ats[gte]", values.Get("created_ats[gte]")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func parseTimestamp(key, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s has an invalid timestamp format: %q", key, value)
+	}
+
+	return &timestamp, nil
+}
@@ -0,0 +1,80 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventList", func() {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedAuditEventList payloads.AuditEventList) {
+				actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualAuditEventList).To(Equal(expectedAuditEventList))
+			},
+
+			Entry("types", "types=app.crash,audit.app.start", payloads.AuditEventList{Types: "app.crash,audit.app.start"}),
+			Entry("target_guids", "target_guids=g1,g2", payloads.AuditEventList{TargetGUIDs: "g1,g2"}),
+			Entry("space_guids", "space_guids=s1,s2", payloads.AuditEventList{SpaceGUIDs: "s1,s2"}),
+			Entry("created_ats", "created_ats=2024-01-02T03:04:05Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{Equals: []time.Time{timestamp}},
+			}),
+			Entry("created_ats[lt]", "created_ats[lt]=2024-01-02T03:04:05Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{LessThan: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[lte]", "created_ats[lte]=2024-01-02T03:04:05Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{LessThanOrEqual: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[gt]", "created_ats[gt]=2024-01-02T03:04:05Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{GreaterThan: tools.PtrTo(timestamp)},
+			}),
+			Entry("created_ats[gte]", "created_ats[gte]=2024-01-02T03:04:05Z", payloads.AuditEventList{
+				CreatedAts: repositories.TimestampFilter{GreaterThanOrEqual: tools.PtrTo(timestamp)},
+			}),
+			Entry("order_by created_at", "order_by=created_at", payloads.AuditEventList{OrderBy: "created_at"}),
+			Entry("order_by -created_at", "order_by=-created_at", payloads.AuditEventList{OrderBy: "-created_at"}),
+			Entry("order_by updated_at", "order_by=updated_at", payloads.AuditEventList{OrderBy: "updated_at"}),
+			Entry("per_page", "per_page=10", payloads.AuditEventList{}),
+			Entry("page", "page=2", payloads.AuditEventList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid created_ats", "created_ats=yesterday", "created_ats has an invalid timestamp format"),
+			Entry("invalid created_ats[gt]", "created_ats[gt]=yesterday", "created_ats[gt] has an invalid timestamp format"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			auditEventList := payloads.AuditEventList{
+				Types:       "app.crash",
+				TargetGUIDs: "g1,g2",
+				SpaceGUIDs:  "s1",
+				CreatedAts:  repositories.TimestampFilter{GreaterThan: tools.PtrTo(timestamp)},
+				OrderBy:     "-created_at",
+			}
+			Expect(auditEventList.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				Types:       []string{"app.crash"},
+				TargetGUIDs: []string{"g1", "g2"},
+				SpaceGUIDs:  []string{"s1"},
+				CreatedAts:  repositories.TimestampFilter{GreaterThan: tools.PtrTo(timestamp)},
+				OrderBy:     "-created_at",
+			}))
+		})
+	})
+})
@@ -50,6 +50,29 @@ func (c *BuildCreate) ToMessage(appRecord repositories.AppRecord) repositories.C
 	return toReturn
 }
 
+type BuildUpdate struct {
+	Metadata MetadataPatch `json:"metadata"`
+	State    string        `json:"state"`
+}
+
+func (b BuildUpdate) Validate() error {
+	return jellidation.ValidateStruct(&b,
+		jellidation.Field(&b.Metadata),
+		jellidation.Field(&b.State, payload_validation.OneOf(repositories.BuildStateFailed)),
+	)
+}
+
+func (b *BuildUpdate) ToMessage(buildGUID string) repositories.UpdateBuildMessage {
+	return repositories.UpdateBuildMessage{
+		GUID:  buildGUID,
+		State: b.State,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      b.Metadata.Labels,
+			Annotations: b.Metadata.Annotations,
+		},
+	}
+}
+
 type BuildList struct {
 	PackageGUIDs string
 	AppGUIDs     string
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 var _ = Describe("BuildCreate", func() {
@@ -98,7 +99,7 @@ var _ = Describe("BuildCreate", func() {
 			})
 
 			It("says lifecycle is invalid", func() {
-				expectUnprocessableEntityError(validatorErr, "lifecycle.type value must be one of: buildpack, docker")
+				expectUnprocessableEntityError(validatorErr, "lifecycle.type value must be one of: buildpack, docker, dockerfile")
 			})
 		})
 	})
@@ -145,3 +146,74 @@ var _ = Describe("BuildCreate", func() {
 		})
 	})
 })
+
+var _ = Describe("BuildUpdate", func() {
+	var (
+		updatePayload        payloads.BuildUpdate
+		decodedUpdatePayload *payloads.BuildUpdate
+		validatorErr         error
+	)
+
+	BeforeEach(func() {
+		updatePayload = payloads.BuildUpdate{
+			State: "FAILED",
+			Metadata: payloads.MetadataPatch{
+				Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+				Annotations: map[string]*string{"bar": nil},
+			},
+		}
+		decodedUpdatePayload = new(payloads.BuildUpdate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedUpdatePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedUpdatePayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	It("converts to a repo message", func() {
+		Expect(decodedUpdatePayload.ToMessage("build-guid")).To(Equal(repositories.UpdateBuildMessage{
+			GUID:  "build-guid",
+			State: "FAILED",
+			MetadataPatch: repositories.MetadataPatch{
+				Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+				Annotations: map[string]*string{"bar": nil},
+			},
+		}))
+	})
+
+	When("the state is not FAILED", func() {
+		BeforeEach(func() {
+			updatePayload.State = "STAGED"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "state value must be one of: FAILED")
+		})
+	})
+
+	When("the state is not set", func() {
+		BeforeEach(func() {
+			updatePayload.State = ""
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the metadata uses the cloudfoundry.org domain", func() {
+		BeforeEach(func() {
+			updatePayload.Metadata.Labels = map[string]*string{
+				"foo.cloudfoundry.org/bar": tools.PtrTo("baz"),
+			}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
+		})
+	})
+})
@@ -5,9 +5,73 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
+type BuildpackCreate struct {
+	Name     string   `json:"name"`
+	Stack    string   `json:"stack"`
+	Position *int     `json:"position"`
+	Enabled  *bool    `json:"enabled"`
+	Locked   *bool    `json:"locked"`
+	Metadata Metadata `json:"metadata"`
+}
+
+func (c BuildpackCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Position, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c BuildpackCreate) ToMessage() repositories.CreateBuildpackMessage {
+	return repositories.CreateBuildpackMessage{
+		Name:     c.Name,
+		Stack:    c.Stack,
+		Position: *tools.IfNil(c.Position, tools.PtrTo(1)),
+		Enabled:  *tools.IfNil(c.Enabled, tools.PtrTo(true)),
+		Locked:   tools.ZeroIfNil(c.Locked),
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type BuildpackUpdate struct {
+	Name     *string       `json:"name"`
+	Stack    *string       `json:"stack"`
+	Position *int          `json:"position"`
+	Enabled  *bool         `json:"enabled"`
+	Locked   *bool         `json:"locked"`
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (u BuildpackUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Position, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u BuildpackUpdate) ToMessage(guid string) repositories.UpdateBuildpackMessage {
+	return repositories.UpdateBuildpackMessage{
+		GUID:     guid,
+		Name:     u.Name,
+		Stack:    u.Stack,
+		Position: u.Position,
+		Enabled:  u.Enabled,
+		Locked:   u.Locked,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
+
 type BuildpackList struct {
 	OrderBy string
 }
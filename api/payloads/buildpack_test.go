@@ -6,6 +6,8 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("BuildpackList", func() {
@@ -45,3 +47,141 @@ var _ = Describe("BuildpackList", func() {
 		Entry("created_at", payloads.BuildpackList{OrderBy: "created_at"}, repositories.ListBuildpacksMessage{OrderBy: "created_at"}),
 	)
 })
+
+var _ = Describe("BuildpackCreate", func() {
+	var (
+		createPayload  payloads.BuildpackCreate
+		decodedPayload *payloads.BuildpackCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.BuildpackCreate)
+		createPayload = payloads.BuildpackCreate{
+			Name:     "my-buildpack",
+			Stack:    "my-stack",
+			Position: tools.PtrTo(2),
+			Enabled:  tools.PtrTo(false),
+			Locked:   tools.PtrTo(true),
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the position is less than 1", func() {
+		BeforeEach(func() {
+			createPayload.Position = tools.PtrTo(0)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "position must be no less than 1")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create buildpack message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "my-stack",
+				Position: 2,
+				Enabled:  false,
+				Locked:   true,
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+
+		It("defaults to an enabled and unlocked buildpack at the first position", func() {
+			Expect(payloads.BuildpackCreate{Name: "my-buildpack"}.ToMessage()).To(Equal(repositories.CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Position: 1,
+				Enabled:  true,
+				Locked:   false,
+			}))
+		})
+	})
+})
+
+var _ = Describe("BuildpackUpdate", func() {
+	var (
+		updatePayload  payloads.BuildpackUpdate
+		decodedPayload *payloads.BuildpackUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.BuildpackUpdate)
+		updatePayload = payloads.BuildpackUpdate{
+			Name:     tools.PtrTo("new-name"),
+			Position: tools.PtrTo(3),
+			Enabled:  tools.PtrTo(true),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the position is less than 1", func() {
+		BeforeEach(func() {
+			updatePayload.Position = tools.PtrTo(0)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "position must be no less than 1")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to an update buildpack message", func() {
+			Expect(updatePayload.ToMessage("the-guid")).To(Equal(repositories.UpdateBuildpackMessage{
+				GUID:     "the-guid",
+				Name:     tools.PtrTo("new-name"),
+				Position: tools.PtrTo(3),
+				Enabled:  tools.PtrTo(true),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
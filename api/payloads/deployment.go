@@ -17,21 +17,38 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
+func (r RevisionGUID) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Guid, jellidation.Required))
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision"`
 	Relationships *DeploymentRelationships `json:"relationships"`
 }
 
 func (c DeploymentCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Revision, jellidation.When(c.Droplet.Guid != "", jellidation.Nil.Error("cannot be set together with droplet"))),
 		jellidation.Field(&c.Relationships, jellidation.NotNil))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
 	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	return message
 }
 
 type DeploymentRelationships struct {
@@ -57,6 +57,38 @@ var _ = Describe("DeploymentCreate", func() {
 			})
 		})
 
+		When("a revision is specified instead of a droplet", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("the revision guid is not specified", func() {
+				BeforeEach(func() {
+					createDeployment.Revision.Guid = ""
+				})
+
+				It("says revision guid is required", func() {
+					expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+				})
+			})
+		})
+
+		When("both a droplet and a revision are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision cannot be set together with droplet")
+			})
+		})
+
 		When("the relationship is not specified", func() {
 			BeforeEach(func() {
 				createDeployment.Relationships = nil
@@ -101,6 +133,20 @@ var _ = Describe("DeploymentCreate", func() {
 				DropletGUID: "the-droplet",
 			}))
 		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("sets the revision guid", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      "the-app",
+					RevisionGUID: "the-revision",
+				}))
+			})
+		})
 	})
 })
 
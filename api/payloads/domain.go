@@ -4,17 +4,32 @@ import (
 	"errors"
 	"net/url"
 
+	"slices"
+
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/jellydator/validation"
 )
 
+type DomainRelationships struct {
+	Organization        *Relationship       `json:"organization"`
+	SharedOrganizations *ToManyRelationship `json:"shared_organizations"`
+}
+
+func (r DomainRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Organization),
+		validation.Field(&r.SharedOrganizations, validation.When(r.Organization == nil, validation.Nil.Error("cannot be set for shared domains"))),
+	)
+}
+
 type DomainCreate struct {
-	Name          string                  `json:"name"`
-	Internal      bool                    `json:"internal"`
-	Metadata      Metadata                `json:"metadata"`
-	Relationships map[string]Relationship `json:"relationships"`
+	Name          string               `json:"name"`
+	Internal      bool                 `json:"internal"`
+	Metadata      Metadata             `json:"metadata"`
+	Relationships *DomainRelationships `json:"relationships"`
 }
 
 func (c DomainCreate) Validate() error {
@@ -30,17 +45,44 @@ func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
 		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
 	}
 
-	if len(c.Relationships) > 0 {
-		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
-	}
-
-	return repositories.CreateDomainMessage{
+	message := repositories.CreateDomainMessage{
 		Name: c.Name,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
 		},
-	}, nil
+	}
+
+	if c.Relationships != nil && c.Relationships.Organization != nil {
+		message.OrganizationGUID = c.Relationships.Organization.Data.GUID
+	}
+
+	if c.Relationships != nil && c.Relationships.SharedOrganizations != nil {
+		message.SharedOrganizationGUIDs = relationshipGUIDs(c.Relationships.SharedOrganizations.Data)
+	}
+
+	return message, nil
+}
+
+type DomainShare struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (d DomainShare) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Data, validation.Required),
+	)
+}
+
+func (d DomainShare) ToMessage(domainGUID string) repositories.ShareDomainMessage {
+	return repositories.ShareDomainMessage{
+		DomainGUID:        domainGUID,
+		OrganizationGUIDs: relationshipGUIDs(d.Data),
+	}
+}
+
+func relationshipGUIDs(data []RelationshipData) []string {
+	return slices.Collect(it.Map(slices.Values(data), func(d RelationshipData) string { return d.GUID }))
 }
 
 type DomainUpdate struct {
@@ -64,20 +106,23 @@ func (c DomainUpdate) Validate() error {
 }
 
 type DomainList struct {
-	Names string
+	Names             string
+	OrganizationGUIDs string
 }
 
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 	return repositories.ListDomainsMessage{
-		Names: parse.ArrayParam(d.Names),
+		Names:             parse.ArrayParam(d.Names),
+		OrganizationGUIDs: parse.ArrayParam(d.OrganizationGUIDs),
 	}
 }
 
 func (d *DomainList) SupportedKeys() []string {
-	return []string{"names", "per_page", "page"}
+	return []string{"names", "organization_guids", "per_page", "page"}
 }
 
 func (d *DomainList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
+	d.OrganizationGUIDs = values.Get("organization_guids")
 	return nil
 }
@@ -68,10 +68,10 @@ var _ = Describe("DomainCreate", func() {
 			})
 		})
 
-		When("relationship is invalid", func() {
+		When("the organization relationship is invalid", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {Data: nil},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: nil},
 				}
 			})
 
@@ -79,6 +79,20 @@ var _ = Describe("DomainCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "data is required")
 			})
 		})
+
+		When("shared organizations are set without an organization", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.DomainRelationships{
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+					},
+				}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "shared_organizations cannot be set for shared domains")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -125,18 +139,78 @@ var _ = Describe("DomainCreate", func() {
 
 		When("the payload has relationships", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "org-guid"},
+					},
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+					},
 				}
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("private domains are not supported")))
+			It("returns a private domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.OrganizationGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrganizationGUIDs).To(Equal([]string{"org-1", "org-2"}))
 			})
 		})
 	})
 })
 
+var _ = Describe("DomainShare", func() {
+	var (
+		sharePayload        payloads.DomainShare
+		decodedSharePayload *payloads.DomainShare
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		decodedSharePayload = new(payloads.DomainShare)
+		sharePayload = payloads.DomainShare{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), decodedSharePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedSharePayload).To(gstruct.PointTo(Equal(sharePayload)))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	When("an org guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = []payloads.RelationshipData{{GUID: ""}}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("returns a share domain message", func() {
+			Expect(sharePayload.ToMessage("domain-guid")).To(Equal(repositories.ShareDomainMessage{
+				DomainGUID:        "domain-guid",
+				OrganizationGUIDs: []string{"org-1", "org-2"},
+			}))
+		})
+	})
+})
+
 var _ = Describe("DomainUpdate", func() {
 	var (
 		updatePayload        payloads.DomainUpdate
@@ -187,12 +261,13 @@ var _ = Describe("DomainList", func() {
 	Describe("decodes from url values", func() {
 		It("succeeds", func() {
 			domainList := payloads.DomainList{}
-			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar", nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?names=foo,bar&organization_guids=o1,o2", nil)
 			Expect(err).NotTo(HaveOccurred())
 			err = validator.DecodeAndValidateURLValues(req, &domainList)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(domainList.Names).To(Equal("foo,bar"))
+			Expect(domainList.OrganizationGUIDs).To(Equal("o1,o2"))
 		})
 	})
 
@@ -203,5 +278,12 @@ var _ = Describe("DomainList", func() {
 			}
 			Expect(domainList.ToMessage().Names).To(ConsistOf("foo", "bar"))
 		})
+
+		It("splits organization guids to strings", func() {
+			domainList := payloads.DomainList{
+				OrganizationGUIDs: "o1,o2",
+			}
+			Expect(domainList.ToMessage().OrganizationGUIDs).To(ConsistOf("o1", "o2"))
+		})
 	})
 })
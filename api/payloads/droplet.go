@@ -1,10 +1,83 @@
 package payloads
 
 import (
+	"net/url"
+
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
 
+type DropletCreate struct {
+	App          *DropletApp       `json:"app"`
+	ProcessTypes map[string]string `json:"process_types"`
+}
+
+type DropletApp struct {
+	GUID string `json:"guid"`
+}
+
+func (d DropletCreate) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.App, validation.NotNil),
+	)
+}
+
+func (a DropletApp) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.GUID, validation.Required),
+	)
+}
+
+func (d DropletCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Lifecycle:    appRecord.Lifecycle,
+		ProcessTypes: d.ProcessTypes,
+	}
+}
+
+type DropletCopy struct {
+	Relationships *DropletCopyRelationships `json:"relationships"`
+}
+
+type DropletCopyRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (d DropletCopy) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Relationships, validation.NotNil),
+	)
+}
+
+func (r DropletCopyRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.App, validation.NotNil),
+	)
+}
+
+func (d DropletCopy) ToMessage(sourceGUID string, appRecord repositories.AppRecord) repositories.CopyDropletMessage {
+	return repositories.CopyDropletMessage{
+		SourceGUID: sourceGUID,
+		AppGUID:    appRecord.GUID,
+		SpaceGUID:  appRecord.SpaceGUID,
+	}
+}
+
+type DropletCreateSource struct {
+	SourceGUID string
+}
+
+func (d *DropletCreateSource) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (d *DropletCreateSource) DecodeFromURLValues(values url.Values) error {
+	d.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
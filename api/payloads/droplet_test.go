@@ -1,7 +1,10 @@
 package payloads_test
 
 import (
+	"net/url"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
@@ -69,3 +72,121 @@ var _ = Describe("DropletUpdate", func() {
 		})
 	})
 })
+
+var _ = Describe("DropletCreate", func() {
+	var (
+		createPayload  payloads.DropletCreate
+		decodedPayload *payloads.DropletCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.DropletCreate)
+		createPayload = payloads.DropletCreate{
+			App: &payloads.DropletApp{GUID: "app-guid"},
+			ProcessTypes: map[string]string{
+				"web": "bundle exec rackup",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the app is missing", func() {
+		BeforeEach(func() {
+			createPayload.App = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	When("the app guid is empty", func() {
+		BeforeEach(func() {
+			createPayload.App.GUID = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "app.guid cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create droplet message", func() {
+			Expect(createPayload.ToMessage(repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			})).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Lifecycle:    repositories.Lifecycle{Type: "buildpack"},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("DropletCopy", func() {
+	var (
+		copyPayload    payloads.DropletCopy
+		decodedPayload *payloads.DropletCopy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.DropletCopy)
+		copyPayload = payloads.DropletCopy{
+			Relationships: &payloads.DropletCopyRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships.App = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.app is required")
+		})
+	})
+})
+
+var _ = Describe("DropletCreateSource", func() {
+	It("decodes the source guid", func() {
+		decoded := new(payloads.DropletCreateSource)
+		Expect(decoded.DecodeFromURLValues(url.Values{"source_guid": []string{"the-source"}})).To(Succeed())
+		Expect(decoded.SourceGUID).To(Equal("the-source"))
+	})
+})
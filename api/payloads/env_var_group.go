@@ -0,0 +1,61 @@
+package payloads
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type EnvVarGroupPatch struct {
+	Var map[string]any `json:"var"`
+}
+
+func (p EnvVarGroupPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Var,
+			validation.StrictlyRequired,
+			jellidation.By(func(value any) error {
+				vars, ok := value.(map[string]any)
+				if !ok {
+					return fmt.Errorf("%T is not supported, map is expected", value)
+				}
+
+				for name, v := range vars {
+					if name == "" {
+						return errors.New("env var names must not be empty")
+					}
+
+					if _, isString := v.(string); v != nil && !isString {
+						return fmt.Errorf("value of %q must be a string or null", name)
+					}
+				}
+
+				return nil
+			}),
+			jellidation.Map().Keys(
+				validation.NotStartWith("VCAP_"),
+				validation.NotStartWith("VMC_"),
+				validation.NotEqual("PORT"),
+			).AllowExtraKeys(),
+		))
+}
+
+func (p EnvVarGroupPatch) ToMessage(name string) repositories.PatchEnvVarGroupMessage {
+	message := repositories.PatchEnvVarGroupMessage{
+		Name: name,
+		Var:  map[string]*string{},
+	}
+
+	for k, v := range p.Var {
+		if value, ok := v.(string); ok {
+			message.Var[k] = &value
+			continue
+		}
+		message.Var[k] = nil
+	}
+
+	return message
+}
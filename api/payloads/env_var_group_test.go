@@ -0,0 +1,101 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("EnvVarGroupPatch", func() {
+	var (
+		payload        payloads.EnvVarGroupPatch
+		decodedPayload *payloads.EnvVarGroupPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.EnvVarGroupPatch{
+			Var: map[string]any{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   nil,
+			},
+		}
+
+		decodedPayload = new(payloads.EnvVarGroupPatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("var is not set", func() {
+		BeforeEach(func() {
+			payload.Var = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	When("a value is not a string", func() {
+		BeforeEach(func() {
+			payload.Var["PROXY_PORT"] = 8080
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, `value of "PROXY_PORT" must be a string or null`)
+		})
+	})
+
+	When("it contains a 'PORT' key", func() {
+		BeforeEach(func() {
+			payload.Var["PORT"] = "2222"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VCAP_'", func() {
+		BeforeEach(func() {
+			payload.Var["VCAP_SERVICES"] = "{}"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VMC_'", func() {
+		BeforeEach(func() {
+			payload.Var["VMC_foo"] = "bar"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VMC_ is not allowed")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repository message", func() {
+			Expect(payload.ToMessage("running")).To(Equal(repositories.PatchEnvVarGroupMessage{
+				Name: "running",
+				Var: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://proxy.example.com"),
+					"NO_PROXY":   nil,
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureFlagPatch", func() {
+	var (
+		payload        payloads.FeatureFlagPatch
+		decodedPayload *payloads.FeatureFlagPatch
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.FeatureFlagPatch{
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("not today"),
+		}
+
+		decodedPayload = new(payloads.FeatureFlagPatch)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("nothing is set", func() {
+		BeforeEach(func() {
+			payload = payloads.FeatureFlagPatch{}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repository message", func() {
+			Expect(payload.ToMessage("task_creation")).To(Equal(repositories.PatchFeatureFlagMessage{
+				Name:               "task_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("not today"),
+			}))
+		})
+	})
+})
@@ -19,7 +19,7 @@ func (l Lifecycle) Validate() error {
 			return fmt.Errorf("%T is not supported, LifecycleData is expected", value)
 		}
 
-		if l.Type == "docker" {
+		if l.Type == "docker" || l.Type == "dockerfile" {
 			return data.ValidateDockerLifecycleData()
 		}
 
@@ -33,7 +33,7 @@ func (l Lifecycle) Validate() error {
 	return jellidation.ValidateStruct(&l,
 		jellidation.Field(&l.Type,
 			jellidation.Required,
-			validation.OneOf("buildpack", "docker")),
+			validation.OneOf("buildpack", "docker", "dockerfile")),
 		jellidation.Field(&l.Data, jellidation.Required, lifecycleDataRule),
 	)
 }
@@ -60,7 +60,7 @@ type LifecyclePatch struct {
 
 func (p LifecyclePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Type, validation.OneOf("buildpack", "docker")),
+		jellidation.Field(&p.Type, validation.OneOf("buildpack", "docker", "dockerfile")),
 		jellidation.Field(&p.Data, jellidation.NotNil),
 	)
 }
@@ -119,6 +119,30 @@ var _ = Describe("Lifecycle", func() {
 		})
 	})
 
+	Describe("dockerfile lifecycle", func() {
+		BeforeEach(func() {
+			payload = payloads.Lifecycle{
+				Type: "dockerfile",
+				Data: &payloads.LifecycleData{},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("buildpacks are specified in the data", func() {
+			BeforeEach(func() {
+				payload.Data.Buildpacks = []string{"foo"}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "data must be an empty object")
+			})
+		})
+	})
+
 	Describe("unsupported lifecycle type", func() {
 		BeforeEach(func() {
 			payload = payloads.Lifecycle{
@@ -127,7 +151,7 @@ var _ = Describe("Lifecycle", func() {
 		})
 
 		It("returns an error", func() {
-			expectUnprocessableEntityError(validatorErr, "value must be one of: buildpack, docker")
+			expectUnprocessableEntityError(validatorErr, "value must be one of: buildpack, docker, dockerfile")
 		})
 	})
 })
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 
+	payload_validation "code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
@@ -33,19 +34,24 @@ type ManifestApplication struct {
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string                      `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string                      `json:"health-check-type" yaml:"health-check-type"`
-	Timeout                      *int32                       `json:"timeout" yaml:"timeout"`
-	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes"`
-	Routes                       []ManifestRoute              `json:"routes" yaml:"routes"`
-	Buildpacks                   []string                     `yaml:"buildpacks"`
+	AltDiskQuota                          *string                      `json:"disk-quota" yaml:"disk-quota"`
+	HealthCheckHTTPEndpoint               *string                      `yaml:"health-check-http-endpoint"`
+	HealthCheckInvocationTimeout          *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
+	HealthCheckType                       *string                      `json:"health-check-type" yaml:"health-check-type"`
+	ReadinessHealthCheckHTTPEndpoint      *string                      `yaml:"readiness-health-check-http-endpoint"`
+	ReadinessHealthCheckInvocationTimeout *int32                       `json:"readiness-health-check-invocation-timeout" yaml:"readiness-health-check-invocation-timeout"`
+	ReadinessHealthCheckInterval          *int32                       `json:"readiness-health-check-interval" yaml:"readiness-health-check-interval"`
+	ReadinessHealthCheckType              *string                      `json:"readiness-health-check-type" yaml:"readiness-health-check-type"`
+	Timeout                               *int32                       `json:"timeout" yaml:"timeout"`
+	Processes                             []ManifestApplicationProcess `json:"processes" yaml:"processes"`
+	Routes                                []ManifestRoute              `json:"routes" yaml:"routes"`
+	Buildpacks                            []string                     `yaml:"buildpacks"`
 	// Deprecated: Use Buildpacks instead
 	Buildpack *string                      `json:"buildpack" yaml:"buildpack"`
 	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
 	Services  []ManifestApplicationService `json:"services" yaml:"services"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
+	Sidecars  []ManifestApplicationSidecar `json:"sidecars" yaml:"sidecars"`
 }
 
 // TODO: Why is kebab-case used everywhere anyway and we have a deprecated field that claims to use
@@ -58,13 +64,24 @@ type ManifestApplicationProcess struct {
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type"`
-	Instances                    *int32  `json:"instances" yaml:"instances"`
-	Memory                       *string `json:"memory" yaml:"memory"`
-	Timeout                      *int32  `json:"timeout" yaml:"timeout"`
+	AltDiskQuota                          *string `json:"disk-quota" yaml:"disk-quota"`
+	HealthCheckHTTPEndpoint               *string `yaml:"health-check-http-endpoint"`
+	HealthCheckInvocationTimeout          *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
+	HealthCheckType                       *string `json:"health-check-type" yaml:"health-check-type"`
+	ReadinessHealthCheckHTTPEndpoint      *string `yaml:"readiness-health-check-http-endpoint"`
+	ReadinessHealthCheckInvocationTimeout *int32  `json:"readiness-health-check-invocation-timeout" yaml:"readiness-health-check-invocation-timeout"`
+	ReadinessHealthCheckInterval          *int32  `json:"readiness-health-check-interval" yaml:"readiness-health-check-interval"`
+	ReadinessHealthCheckType              *string `json:"readiness-health-check-type" yaml:"readiness-health-check-type"`
+	Instances                             *int32  `json:"instances" yaml:"instances"`
+	Memory                                *string `json:"memory" yaml:"memory"`
+	Timeout                               *int32  `json:"timeout" yaml:"timeout"`
+}
+
+type ManifestApplicationSidecar struct {
+	Name         string   `json:"name" yaml:"name"`
+	Command      *string  `json:"command" yaml:"command"`
+	ProcessTypes []string `json:"process_types" yaml:"process_types"`
+	Memory       *string  `json:"memory" yaml:"memory"`
 }
 
 type ManifestApplicationService struct {
@@ -89,7 +106,12 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 }
 
 type ManifestRoute struct {
-	Route *string `json:"route" yaml:"route"`
+	Route    *string `json:"route" yaml:"route"`
+	Protocol *string `json:"protocol" yaml:"protocol"`
+	// Process is the type of the app process receiving the route traffic, defaults to web
+	Process *string `json:"process" yaml:"process"`
+	// Port is the app port receiving the route traffic, defaults to the first port exposed by the droplet
+	Port *int32 `json:"port" yaml:"port"`
 }
 
 func (a ManifestApplication) ToAppCreateMessage(spaceGUID string) repositories.CreateAppMessage {
@@ -170,6 +192,18 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 			msg.HealthCheck.Type = "process"
 		}
 	}
+	if p.ReadinessHealthCheckHTTPEndpoint != nil {
+		msg.ReadinessHealthCheck.Data.HTTPEndpoint = *p.ReadinessHealthCheckHTTPEndpoint
+	}
+	if p.ReadinessHealthCheckInvocationTimeout != nil {
+		msg.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *p.ReadinessHealthCheckInvocationTimeout
+	}
+	if p.ReadinessHealthCheckInterval != nil {
+		msg.ReadinessHealthCheck.Data.IntervalSeconds = *p.ReadinessHealthCheckInterval
+	}
+	if p.ReadinessHealthCheckType != nil {
+		msg.ReadinessHealthCheck.Type = *p.ReadinessHealthCheckType
+	}
 	msg.DesiredInstances = p.Instances
 
 	if p.Memory != nil {
@@ -192,6 +226,11 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 		HealthCheckInvocationTimeoutSeconds: p.HealthCheckInvocationTimeout,
 		HealthCheckTimeoutSeconds:           p.Timeout,
 		DesiredInstances:                    p.Instances,
+
+		ReadinessHealthCheckHTTPEndpoint:             p.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInvocationTimeoutSeconds: p.ReadinessHealthCheckInvocationTimeout,
+		ReadinessHealthCheckIntervalSeconds:          p.ReadinessHealthCheckInterval,
+		ReadinessHealthCheckType:                     p.ReadinessHealthCheckType,
 	}
 	if p.HealthCheckType != nil {
 		message.HealthCheckType = p.HealthCheckType
@@ -208,6 +247,31 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	return message
 }
 
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID string) repositories.CreateSidecarMessage {
+	message := repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		Name:         s.Name,
+		Command:      tools.ZeroIfNil(s.Command),
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		message.MemoryMB = tools.PtrTo(parseMegabytes(*s.Memory))
+	}
+	return message
+}
+
+func (s ManifestApplicationSidecar) ToSidecarPatchMessage(sidecarGUID string) repositories.PatchSidecarMessage {
+	message := repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		message.MemoryMB = tools.PtrTo(parseMegabytes(*s.Memory))
+	}
+	return message
+}
+
 func (m Manifest) Validate() error {
 	return validation.ValidateStruct(&m,
 		validation.Field(&m.Applications))
@@ -222,10 +286,14 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.Instances, validation.Min(0)),
 		validation.Field(&a.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.HealthCheckType, validation.In("none", "process", "port", "http")),
+		validation.Field(&a.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckType, validation.In("process", "port", "http")),
 		validation.Field(&a.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
+		validation.Field(&a.Sidecars),
 		validation.Field(&a.Docker, validation.When(len(a.Buildpacks) > 0 || a.Buildpack != nil,
 			validation.Nil.Error("must be blank when buildpacks are specified"),
 		)),
@@ -239,18 +307,34 @@ func (p ManifestApplicationProcess) Validate() error {
 		validation.Field(&p.AltDiskQuota, validation.By(validateAmountWithUnit)),
 		validation.Field(&p.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&p.HealthCheckType, validation.In("none", "process", "port", "http")),
+		validation.Field(&p.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckType, validation.In("process", "port", "http")),
 		validation.Field(&p.Instances, validation.Min(0)),
 		validation.Field(&p.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&p.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 	)
 }
 
+func (s ManifestApplicationSidecar) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.Command, validation.Required),
+		validation.Field(&s.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&s.Memory, validation.By(validateAmountWithUnit)),
+	)
+}
+
 func (m ManifestRoute) Validate() error {
 	routeRegex := regexp.MustCompile(
 		`^(?:https?://|tcp://)?(?:(?:[\w-]+\.)|(?:[*]\.))+\w+(?:\:\d+)?(?:/.*)*(?:\.\w+)?$`,
 	)
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")))
+		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")),
+		validation.Field(&m.Protocol, payload_validation.OneOf("http1", "http2")),
+		validation.Field(&m.Process, validation.NilOrNotEmpty),
+		validation.Field(&m.Port, validation.Min(int32(1)), validation.Max(int32(65535))),
+	)
 }
 
 func (s ManifestApplicationService) Validate() error {
@@ -517,6 +517,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("ReadinessHealthCheckInterval is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-interval must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckType is invalid", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckType = tools.PtrTo("none")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-type must be a valid value")
+				})
+			})
+
 			When("Instances is negative", func() {
 				BeforeEach(func() {
 					testManifestProcess.Instances = tools.PtrTo[int32](-1)
@@ -584,6 +604,11 @@ var _ = Describe("Manifest payload", func() {
 						Instances:                    tools.PtrTo[int32](3),
 						Memory:                       tools.PtrTo("1G"),
 						Timeout:                      tools.PtrTo(int32(60)),
+
+						ReadinessHealthCheckHTTPEndpoint:      tools.PtrTo("/ready"),
+						ReadinessHealthCheckInvocationTimeout: tools.PtrTo(int32(3)),
+						ReadinessHealthCheckInterval:          tools.PtrTo(int32(10)),
+						ReadinessHealthCheckType:              tools.PtrTo("http"),
 					}
 				})
 
@@ -604,6 +629,14 @@ var _ = Describe("Manifest payload", func() {
 								InvocationTimeoutSeconds: 90,
 							},
 						},
+						ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+							Type: "http",
+							Data: repositories.ReadinessHealthCheckData{
+								HTTPEndpoint:             "/ready",
+								InvocationTimeoutSeconds: 3,
+								IntervalSeconds:          10,
+							},
+						},
 						DesiredInstances: tools.PtrTo[int32](3),
 						MemoryMB:         1024,
 					}))
@@ -778,6 +811,134 @@ var _ = Describe("Manifest payload", func() {
 				expectUnprocessableEntityError(validateErr, "route is not a valid route")
 			})
 		})
+
+		When("the protocol is http2", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("http2")
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the process is empty", func() {
+			BeforeEach(func() {
+				testManifestRoute.Process = tools.PtrTo("")
+			})
+
+			It("returns a validation error", func() {
+				expectUnprocessableEntityError(validateErr, "process cannot be blank")
+			})
+		})
+
+		When("the port is out of range", func() {
+			BeforeEach(func() {
+				testManifestRoute.Port = tools.PtrTo[int32](70000)
+			})
+
+			It("returns a validation error", func() {
+				expectUnprocessableEntityError(validateErr, "port must be no greater than 65535")
+			})
+		})
+
+		When("the protocol is not supported", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("tcp")
+			})
+
+			It("returns a validation error", func() {
+				expectUnprocessableEntityError(validateErr, "protocol value must be one of: http1, http2")
+			})
+		})
+	})
+
+	Describe("ManifestApplicationSidecar", func() {
+		var testManifestSidecar ManifestApplicationSidecar
+
+		BeforeEach(func() {
+			testManifestSidecar = ManifestApplicationSidecar{
+				Name:         "apm-agent",
+				Command:      tools.PtrTo("run-agent"),
+				ProcessTypes: []string{"web", "worker"},
+				Memory:       tools.PtrTo("64M"),
+			}
+		})
+
+		Describe("Validate", func() {
+			var validateErr error
+
+			JustBeforeEach(func() {
+				validateErr = validator.DecodeAndValidateYAMLPayload(createYAMLRequest(testManifestSidecar), &ManifestApplicationSidecar{})
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+
+			When("name is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Name = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "name cannot be blank")
+				})
+			})
+
+			When("command is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Command = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "command cannot be blank")
+				})
+			})
+
+			When("process types are not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.ProcessTypes = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "process_types cannot be blank")
+				})
+			})
+
+			When("the memory doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = tools.PtrTo("64")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "memory must use a supported unit")
+				})
+			})
+		})
+
+		Describe("ToSidecarCreateMessage", func() {
+			It("converts to a create sidecar message", func() {
+				Expect(testManifestSidecar.ToSidecarCreateMessage("app-guid")).To(Equal(repositories.CreateSidecarMessage{
+					AppGUID:      "app-guid",
+					Name:         "apm-agent",
+					Command:      "run-agent",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+		})
+
+		Describe("ToSidecarPatchMessage", func() {
+			It("converts to a patch sidecar message", func() {
+				Expect(testManifestSidecar.ToSidecarPatchMessage("sidecar-guid")).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					Command:      tools.PtrTo("run-agent"),
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+		})
 	})
 
 	Describe("ManifestApplicationService", func() {
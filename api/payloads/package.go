@@ -48,6 +48,45 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 	return message
 }
 
+type PackageCopy struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopy) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopy) ToMessage(source repositories.PackageRecord, appRecord repositories.AppRecord) repositories.CreatePackageMessage {
+	message := repositories.CreatePackageMessage{
+		Type:      source.Type,
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+	}
+
+	if source.Type == "docker" {
+		message.Data = &repositories.PackageData{
+			Image: source.SourceImageRef,
+		}
+	}
+
+	return message
+}
+
+type PackageCreateSource struct {
+	SourceGUID string
+}
+
+func (s *PackageCreateSource) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (s *PackageCreateSource) DecodeFromURLValues(values url.Values) error {
+	s.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
 type PackageData struct {
 	Image    string  `json:"image"`
 	Username *string `json:"username"`
@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"net/url"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
@@ -333,3 +335,84 @@ var _ = Describe("PackageList", func() {
 		Entry("invalid order_by", "order_by=foo", "value must be one of"),
 	)
 })
+
+var _ = Describe("PackageCopy", func() {
+	var (
+		copyPayload    payloads.PackageCopy
+		decodedPayload *payloads.PackageCopy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.PackageCopy)
+		copyPayload = payloads.PackageCopy{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is missing", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships.App = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		var appRecord repositories.AppRecord
+
+		BeforeEach(func() {
+			appRecord = repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"}
+		})
+
+		It("converts a bits package to a create message", func() {
+			Expect(copyPayload.ToMessage(repositories.PackageRecord{Type: "bits", SourceImageRef: "foo@sha256:bar"}, appRecord)).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		It("keeps the image of a docker package", func() {
+			Expect(copyPayload.ToMessage(repositories.PackageRecord{Type: "docker", SourceImageRef: "some/image"}, appRecord)).To(Equal(repositories.CreatePackageMessage{
+				Type:      "docker",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				Data:      &repositories.PackageData{Image: "some/image"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("PackageCreateSource", func() {
+	It("decodes the source guid", func() {
+		decoded := new(payloads.PackageCreateSource)
+		Expect(decoded.DecodeFromURLValues(url.Values{"source_guid": []string{"the-source"}})).To(Succeed())
+		Expect(decoded.SourceGUID).To(Equal("the-source"))
+	})
+})
@@ -23,9 +23,10 @@ func (p ProcessScale) Validate() error {
 }
 
 type ProcessPatch struct {
-	Metadata    *MetadataPatch `json:"metadata"`
-	Command     *string        `json:"command"`
-	HealthCheck *HealthCheck   `json:"health_check"`
+	Metadata             *MetadataPatch        `json:"metadata"`
+	Command              *string               `json:"command"`
+	HealthCheck          *HealthCheck          `json:"health_check"`
+	ReadinessHealthCheck *ReadinessHealthCheck `json:"readiness_health_check"`
 }
 
 type HealthCheck struct {
@@ -39,6 +40,17 @@ type Data struct {
 	InvocationTimeout *int32  `json:"invocation_timeout"`
 }
 
+type ReadinessHealthCheck struct {
+	Type *string        `json:"type"`
+	Data *ReadinessData `json:"data"`
+}
+
+type ReadinessData struct {
+	Endpoint          *string `json:"endpoint"`
+	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
+}
+
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 	return repositories.ProcessScaleValues{
 		Instances: p.Instances,
@@ -83,6 +95,16 @@ func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repos
 		}
 	}
 
+	if p.ReadinessHealthCheck != nil {
+		message.ReadinessHealthCheckType = p.ReadinessHealthCheck.Type
+
+		if p.ReadinessHealthCheck.Data != nil {
+			message.ReadinessHealthCheckHTTPEndpoint = p.ReadinessHealthCheck.Data.Endpoint
+			message.ReadinessHealthCheckInvocationTimeoutSeconds = p.ReadinessHealthCheck.Data.InvocationTimeout
+			message.ReadinessHealthCheckIntervalSeconds = p.ReadinessHealthCheck.Data.Interval
+		}
+	}
+
 	if p.Metadata != nil {
 		message.MetadataPatch = &repositories.MetadataPatch{
 			Annotations: p.Metadata.Annotations,
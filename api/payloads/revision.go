@@ -0,0 +1,42 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type RevisionList struct {
+	Versions      string
+	OrderBy       string
+	LabelSelector string
+}
+
+func (r RevisionList) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+	)
+}
+
+func (r *RevisionList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUID:       appGUID,
+		Versions:      parse.ArrayParam(r.Versions),
+		LabelSelector: r.LabelSelector,
+		OrderBy:       r.OrderBy,
+	}
+}
+
+func (r *RevisionList) SupportedKeys() []string {
+	return []string{"versions", "order_by", "per_page", "page", "label_selector"}
+}
+
+func (r *RevisionList) DecodeFromURLValues(values url.Values) error {
+	r.Versions = values.Get("versions")
+	r.OrderBy = values.Get("order_by")
+	r.LabelSelector = values.Get("label_selector")
+	return nil
+}
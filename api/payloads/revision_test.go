@@ -0,0 +1,55 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedRevisionList payloads.RevisionList) {
+				actualRevisionList, decodeErr := decodeQuery[payloads.RevisionList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualRevisionList).To(Equal(expectedRevisionList))
+			},
+
+			Entry("versions", "versions=1,2", payloads.RevisionList{Versions: "1,2"}),
+			Entry("label_selector", "label_selector=foo", payloads.RevisionList{LabelSelector: "foo"}),
+			Entry("order_by created_at", "order_by=created_at", payloads.RevisionList{OrderBy: "created_at"}),
+			Entry("order_by -created_at", "order_by=-created_at", payloads.RevisionList{OrderBy: "-created_at"}),
+			Entry("order_by updated_at", "order_by=updated_at", payloads.RevisionList{OrderBy: "updated_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.RevisionList{OrderBy: "-updated_at"}),
+			Entry("per_page", "per_page=10", payloads.RevisionList{}),
+			Entry("page", "page=2", payloads.RevisionList{}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.RevisionList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			revisionList := payloads.RevisionList{
+				Versions:      "1,2",
+				OrderBy:       "created_at",
+				LabelSelector: "foo=bar",
+			}
+			Expect(revisionList.ToMessage("app-guid")).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:       "app-guid",
+				Versions:      []string{"1", "2"},
+				OrderBy:       "created_at",
+				LabelSelector: "foo=bar",
+			}))
+		})
+	})
+})
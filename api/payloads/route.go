@@ -1,19 +1,30 @@
 package payloads
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/url"
+	"regexp"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
+var (
+	routeTimeoutRegex = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+	// the HTTPHeaderName pattern of the Gateway API
+	routeHeaderNameRegex = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+\\-.^_\x60|~]{1,256}$")
+)
+
 type RouteCreate struct {
 	Host          string              `json:"host"`
 	Path          string              `json:"path"`
 	Relationships *RouteRelationships `json:"relationships"`
+	Options       *RouteOptions       `json:"options"`
 	Metadata      Metadata            `json:"metadata"`
 }
 
@@ -21,12 +32,13 @@ func (p RouteCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
 		jellidation.Field(&p.Host, jellidation.Required),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Options),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
 func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.CreateRouteMessage {
-	return repositories.CreateRouteMessage{
+	message := repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
@@ -36,6 +48,106 @@ func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.
 		Labels:          p.Metadata.Labels,
 		Annotations:     p.Metadata.Annotations,
 	}
+
+	if p.Options != nil {
+		message.Options = p.Options.toPatch().Apply(repositories.RouteOptions{})
+	}
+
+	return message
+}
+
+// RouteOptions are the options of a route. Setting an option to null in a
+// patch request clears it
+type RouteOptions struct {
+	LoadBalancing  *string              `json:"loadbalancing,omitempty"`
+	Timeout        *string              `json:"timeout,omitempty"`
+	RequestHeaders *RouteHeaderModifier `json:"request_headers,omitempty"`
+}
+
+func (o RouteOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.LoadBalancing, validation.OneOf("round-robin", "least-connection")),
+		jellidation.Field(&o.Timeout, jellidation.Match(routeTimeoutRegex).Error("must be a duration such as 30s, 5m or 1h30m")),
+		jellidation.Field(&o.RequestHeaders),
+	)
+}
+
+func (o *RouteOptions) UnmarshalJSON(data []byte) error {
+	type alias RouteOptions
+
+	var options alias
+	err := json.Unmarshal(data, &options)
+	if err != nil {
+		return err
+	}
+
+	var optionsMap map[string]any
+	err = json.Unmarshal(data, &optionsMap)
+	if err != nil {
+		return err
+	}
+
+	if v, ok := optionsMap["loadbalancing"]; ok && v == nil {
+		options.LoadBalancing = tools.PtrTo("")
+	}
+
+	if v, ok := optionsMap["timeout"]; ok && v == nil {
+		options.Timeout = tools.PtrTo("")
+	}
+
+	if v, ok := optionsMap["request_headers"]; ok && v == nil {
+		options.RequestHeaders = &RouteHeaderModifier{}
+	}
+
+	*o = RouteOptions(options)
+
+	return nil
+}
+
+func (o RouteOptions) toPatch() repositories.RouteOptionsPatch {
+	patch := repositories.RouteOptionsPatch{
+		LoadBalancing: o.LoadBalancing,
+		Timeout:       o.Timeout,
+	}
+
+	if o.RequestHeaders != nil {
+		patch.RequestHeaders = &repositories.RouteHeaderModifier{
+			Set:    o.RequestHeaders.Set,
+			Add:    o.RequestHeaders.Add,
+			Remove: o.RequestHeaders.Remove,
+		}
+	}
+
+	return patch
+}
+
+type RouteHeaderModifier struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Add    map[string]string `json:"add,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+func (m RouteHeaderModifier) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Set, jellidation.Each(jellidation.Required), jellidation.By(validateHeaderNames)),
+		jellidation.Field(&m.Add, jellidation.Each(jellidation.Required), jellidation.By(validateHeaderNames)),
+		jellidation.Field(&m.Remove, jellidation.Each(jellidation.Required, jellidation.Match(routeHeaderNameRegex).Error("must be a valid header name"))),
+	)
+}
+
+func validateHeaderNames(value any) error {
+	headers, ok := value.(map[string]string)
+	if !ok {
+		return fmt.Errorf("%T is not supported, map is expected", value)
+	}
+
+	for name := range headers {
+		if !routeHeaderNameRegex.MatchString(name) {
+			return fmt.Errorf("%q is not a valid header name", name)
+		}
+	}
+
+	return nil
 }
 
 type RouteRelationships struct {
@@ -82,17 +194,19 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 }
 
 type RoutePatch struct {
+	Options  *RouteOptions `json:"options"`
 	Metadata MetadataPatch `json:"metadata"`
 }
 
 func (p RoutePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Options),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
-func (p RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRouteMetadataMessage {
-	return repositories.PatchRouteMetadataMessage{
+func (p RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRouteMessage {
+	message := repositories.PatchRouteMessage{
 		RouteGUID: routeGUID,
 		SpaceGUID: spaceGUID,
 		MetadataPatch: repositories.MetadataPatch{
@@ -100,6 +214,48 @@ func (p RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRou
 			Labels:      p.Metadata.Labels,
 		},
 	}
+
+	if p.Options != nil {
+		message.Options = tools.PtrTo(p.Options.toPatch())
+	}
+
+	return message
+}
+
+type RouteShare struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (p RouteShare) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Data, jellidation.Required),
+	)
+}
+
+func (p RouteShare) ToMessage(routeGUID, spaceGUID string) repositories.ShareRouteMessage {
+	return repositories.ShareRouteMessage{
+		RouteGUID:        routeGUID,
+		SpaceGUID:        spaceGUID,
+		SharedSpaceGUIDs: relationshipGUIDs(p.Data),
+	}
+}
+
+type RouteTransferOwnership struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (p RouteTransferOwnership) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Data, jellidation.NotNil),
+	)
+}
+
+func (p RouteTransferOwnership) ToMessage(routeGUID, spaceGUID string) repositories.TransferRouteOwnershipMessage {
+	return repositories.TransferRouteOwnershipMessage{
+		RouteGUID:    routeGUID,
+		SpaceGUID:    spaceGUID,
+		NewSpaceGUID: p.Data.GUID,
+	}
 }
 
 type RouteDestinationCreate struct {
@@ -121,7 +277,7 @@ type RouteDestination struct {
 func (r RouteDestination) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.App),
-		jellidation.Field(&r.Protocol, validation.OneOf("http1")),
+		jellidation.Field(&r.Protocol, validation.OneOf("http1", "http2")),
 	)
 }
 
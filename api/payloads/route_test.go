@@ -2,9 +2,11 @@ package payloads_test
 
 import (
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -78,6 +80,13 @@ var _ = Describe("RouteCreate", func() {
 					},
 				},
 			},
+			Options: &payloads.RouteOptions{
+				LoadBalancing: tools.PtrTo("round-robin"),
+				Timeout:       tools.PtrTo("1m30s"),
+				RequestHeaders: &payloads.RouteHeaderModifier{
+					Set: map[string]string{"X-Foo": "bar"},
+				},
+			},
 			Metadata: payloads.Metadata{
 				Annotations: map[string]string{"a": "av"},
 				Labels:      map[string]string{"l": "lv"},
@@ -149,6 +158,86 @@ var _ = Describe("RouteCreate", func() {
 			Expect(apiError.Detail()).To(ContainSubstring("cannot use the cloudfoundry.org domain"))
 		})
 	})
+
+	When("the loadbalancing option is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Options.LoadBalancing = tools.PtrTo("random")
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("options.loadbalancing value must be one of: round-robin, least-connection"))
+		})
+	})
+
+	When("the timeout option is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Options.Timeout = tools.PtrTo("forever")
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("options.timeout must be a duration"))
+		})
+	})
+
+	When("a request header name is blank", func() {
+		BeforeEach(func() {
+			createPayload.Options.RequestHeaders.Remove = []string{""}
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("options.request_headers.remove"))
+		})
+	})
+
+	When("a removed request header name is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Options.RequestHeaders.Remove = []string{"x bad"}
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("options.request_headers.remove"))
+			Expect(apiError.Detail()).To(ContainSubstring("must be a valid header name"))
+		})
+	})
+
+	When("a set request header name is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Options.RequestHeaders.Set = map[string]string{"x:bad": "value"}
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring(`"x:bad" is not a valid header name`))
+		})
+	})
+
+	When("an added request header name is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Options.RequestHeaders.Add = map[string]string{"x/bad": "value"}
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring(`"x/bad" is not a valid header name`))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the options", func() {
+			message := createPayload.ToMessage("domain-ns", "domain-name")
+			Expect(message.Options).To(Equal(repositories.RouteOptions{
+				LoadBalancing: "round-robin",
+				Timeout:       "1m30s",
+				RequestHeaders: repositories.RouteHeaderModifier{
+					Set: map[string]string{"X-Foo": "bar"},
+				},
+			}))
+		})
+	})
 })
 
 var _ = Describe("RoutePatch", func() {
@@ -189,6 +278,141 @@ var _ = Describe("RoutePatch", func() {
 			Expect(apiError.Detail()).To(ContainSubstring("cannot use the cloudfoundry.org domain"))
 		})
 	})
+
+	When("options are set", func() {
+		BeforeEach(func() {
+			patchPayload.Options = &payloads.RouteOptions{
+				Timeout: tools.PtrTo("10s"),
+			}
+		})
+
+		It("only patches the specified options", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			message := routePatch.ToMessage("route-guid", "space-guid")
+			Expect(message.Options).To(gstruct.PointTo(Equal(repositories.RouteOptionsPatch{
+				Timeout: tools.PtrTo("10s"),
+			})))
+		})
+	})
+
+	When("options are explicitly set to null", func() {
+		JustBeforeEach(func() {
+			routePatch = new(payloads.RoutePatch)
+			req, err := http.NewRequest("", "", strings.NewReader(`{
+				"options": {"loadbalancing": null, "timeout": null, "request_headers": null}
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+			validatorErr = validator.DecodeAndValidateJSONPayload(req, routePatch)
+		})
+
+		It("clears them", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			message := routePatch.ToMessage("route-guid", "space-guid")
+			Expect(message.Options).To(gstruct.PointTo(Equal(repositories.RouteOptionsPatch{
+				LoadBalancing:  tools.PtrTo(""),
+				Timeout:        tools.PtrTo(""),
+				RequestHeaders: &repositories.RouteHeaderModifier{},
+			})))
+		})
+	})
+})
+
+var _ = Describe("RouteShare", func() {
+	var (
+		sharePayload payloads.RouteShare
+		routeShare   *payloads.RouteShare
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		routeShare = new(payloads.RouteShare)
+		sharePayload = payloads.RouteShare{
+			Data: []payloads.RelationshipData{{GUID: "space-1"}, {GUID: "space-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), routeShare)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeShare).To(gstruct.PointTo(Equal(sharePayload)))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	When("a space guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = []payloads.RelationshipData{{GUID: ""}}
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("returns a share route message", func() {
+			Expect(sharePayload.ToMessage("route-guid", "space-guid")).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        "route-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"space-1", "space-2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("RouteTransferOwnership", func() {
+	var (
+		transferPayload payloads.RouteTransferOwnership
+		routeTransfer   *payloads.RouteTransferOwnership
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		routeTransfer = new(payloads.RouteTransferOwnership)
+		transferPayload = payloads.RouteTransferOwnership{
+			Data: &payloads.RelationshipData{GUID: "new-space"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(transferPayload), routeTransfer)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeTransfer).To(gstruct.PointTo(Equal(transferPayload)))
+	})
+
+	When("data is missing", func() {
+		BeforeEach(func() {
+			transferPayload.Data = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("returns a transfer route ownership message", func() {
+			Expect(transferPayload.ToMessage("route-guid", "space-guid")).To(Equal(repositories.TransferRouteOwnershipMessage{
+				RouteGUID:    "route-guid",
+				SpaceGUID:    "space-guid",
+				NewSpaceGUID: "new-space",
+			}))
+		})
+	})
 })
 
 var _ = Describe("Add destination", func() {
@@ -254,14 +478,25 @@ var _ = Describe("Add destination", func() {
 		})
 	})
 
-	When("protocol is not http1", func() {
+	When("protocol is http2", func() {
+		BeforeEach(func() {
+			addPayload.Destinations[1].Protocol = tools.PtrTo("http2")
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(destinationAdd).To(gstruct.PointTo(Equal(addPayload)))
+		})
+	})
+
+	When("protocol is not supported", func() {
 		BeforeEach(func() {
 			addPayload.Destinations[1].Protocol = tools.PtrTo("http")
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1"))
+			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1, http2"))
 		})
 	})
 })
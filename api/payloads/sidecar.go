@@ -0,0 +1,58 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryMB     *int64   `json:"memory_in_mb"`
+}
+
+func (c SidecarCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Command, jellidation.Required),
+		jellidation.Field(&c.ProcessTypes, jellidation.Required, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&c.MemoryMB, jellidation.Min(1).Error("must be greater than 0")),
+	)
+}
+
+func (c SidecarCreate) ToMessage(appGUID string) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		Name:         c.Name,
+		Command:      c.Command,
+		ProcessTypes: c.ProcessTypes,
+		MemoryMB:     c.MemoryMB,
+	}
+}
+
+type SidecarUpdate struct {
+	Name         *string  `json:"name"`
+	Command      *string  `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryMB     *int64   `json:"memory_in_mb"`
+}
+
+func (u SidecarUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Command, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.ProcessTypes, jellidation.NilOrNotEmpty, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&u.MemoryMB, jellidation.Min(1).Error("must be greater than 0")),
+	)
+}
+
+func (u SidecarUpdate) ToMessage(sidecarGUID string) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		Name:         u.Name,
+		Command:      u.Command,
+		ProcessTypes: u.ProcessTypes,
+		MemoryMB:     u.MemoryMB,
+	}
+}
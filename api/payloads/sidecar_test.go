@@ -0,0 +1,154 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SidecarCreate", func() {
+	var (
+		createPayload  payloads.SidecarCreate
+		decodedPayload *payloads.SidecarCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SidecarCreate)
+		createPayload = payloads.SidecarCreate{
+			Name:         "apm-agent",
+			Command:      "run-agent",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryMB:     tools.PtrTo[int64](64),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the memory is not specified", func() {
+		BeforeEach(func() {
+			createPayload.MemoryMB = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+		})
+	})
+
+	When("the name is not specified", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the command is not specified", func() {
+		BeforeEach(func() {
+			createPayload.Command = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+		})
+	})
+
+	When("the process types are not specified", func() {
+		BeforeEach(func() {
+			createPayload.ProcessTypes = []string{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	When("the memory is not positive", func() {
+		BeforeEach(func() {
+			createPayload.MemoryMB = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb must be greater than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage("app-guid")).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				Name:         "apm-agent",
+				Command:      "run-agent",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+		})
+	})
+})
+
+var _ = Describe("SidecarUpdate", func() {
+	var (
+		updatePayload  payloads.SidecarUpdate
+		decodedPayload *payloads.SidecarUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SidecarUpdate)
+		updatePayload = payloads.SidecarUpdate{
+			Command: tools.PtrTo("run-agent --verbose"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the process types are empty", func() {
+		BeforeEach(func() {
+			updatePayload.ProcessTypes = []string{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("sidecar-guid")).To(Equal(repositories.PatchSidecarMessage{
+				GUID:    "sidecar-guid",
+				Command: tools.PtrTo("run-agent --verbose"),
+			}))
+		})
+	})
+})
@@ -62,6 +62,23 @@ func (p SpacePatch) ToMessage(spaceGUID, orgGUID string) repositories.PatchSpace
 	}
 }
 
+type SpaceFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p SpaceFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p SpaceFeatureUpdate) ToSSHPatchMessage(spaceGUID string) repositories.PatchSpaceFeaturesMessage {
+	return repositories.PatchSpaceFeaturesMessage{
+		GUID:       spaceGUID,
+		SSHEnabled: p.Enabled,
+	}
+}
+
 type SpaceGet struct {
 	IncludeResourceRules []params.IncludeResourceRule
 }
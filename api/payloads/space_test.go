@@ -94,6 +94,49 @@ var _ = Describe("Space", func() {
 		})
 	})
 
+	Describe("SpaceFeatureUpdate", func() {
+		var (
+			payload        payloads.SpaceFeatureUpdate
+			decodedPayload *payloads.SpaceFeatureUpdate
+			validatorErr   error
+		)
+
+		BeforeEach(func() {
+			payload = payloads.SpaceFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			}
+			decodedPayload = new(payloads.SpaceFeatureUpdate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("enabled is not set", func() {
+			BeforeEach(func() {
+				payload.Enabled = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "enabled is required")
+			})
+		})
+
+		Describe("ToSSHPatchMessage", func() {
+			It("converts to a patch space features message", func() {
+				Expect(payload.ToSSHPatchMessage("space-guid")).To(Equal(repositories.PatchSpaceFeaturesMessage{
+					GUID:       "space-guid",
+					SSHEnabled: tools.PtrTo(false),
+				}))
+			})
+		})
+	})
+
 	Describe("SpacePatch", func() {
 		var (
 			payload        payloads.SpacePatch
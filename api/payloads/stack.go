@@ -0,0 +1,52 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type StackCreate struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Metadata    Metadata `json:"metadata"`
+}
+
+func (c StackCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired, jellidation.Length(0, 250)),
+		jellidation.Field(&c.Description, jellidation.Length(0, 250)),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c StackCreate) ToMessage() repositories.CreateStackMessage {
+	return repositories.CreateStackMessage{
+		Name:        c.Name,
+		Description: c.Description,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type StackUpdate struct {
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (u StackUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u StackUpdate) ToMessage(guid string) repositories.UpdateStackMessage {
+	return repositories.UpdateStackMessage{
+		GUID: guid,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
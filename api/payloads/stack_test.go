@@ -0,0 +1,120 @@
+package payloads_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("StackCreate", func() {
+	var (
+		createPayload  payloads.StackCreate
+		decodedPayload *payloads.StackCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.StackCreate)
+		createPayload = payloads.StackCreate{
+			Name:        "my-stack",
+			Description: "my description",
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the name is missing", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the description is too long", func() {
+		BeforeEach(func() {
+			createPayload.Description = strings.Repeat("a", 251)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "description the length must be no more than 250")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create stack message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateStackMessage{
+				Name:        "my-stack",
+				Description: "my description",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("StackUpdate", func() {
+	var (
+		updatePayload  payloads.StackUpdate
+		decodedPayload *payloads.StackUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.StackUpdate)
+		updatePayload = payloads.StackUpdate{
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the metadata uses the korifi prefix", func() {
+		BeforeEach(func() {
+			updatePayload.Metadata.Labels = map[string]*string{"korifi.cloudfoundry.org/foo": tools.PtrTo("bar")}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to an update stack message", func() {
+			Expect(updatePayload.ToMessage("the-guid")).To(Equal(repositories.UpdateStackMessage{
+				GUID: "the-guid",
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package payloads
+
+import (
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	defaultUsageEventsPerPage = 50
+	maxUsageEventsPerPage     = 5000
+)
+
+type UsageEventList struct {
+	GUIDs     string
+	AfterGUID string
+	PerPage   int
+}
+
+func (l UsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.PerPage, jellidation.Min(1), jellidation.Max(maxUsageEventsPerPage), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (l *UsageEventList) ToMessage() repositories.ListUsageEventsMessage {
+	return repositories.ListUsageEventsMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		AfterGUID: l.AfterGUID,
+		PerPage:   l.PerPage,
+	}
+}
+
+func (l *UsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "per_page", "page"}
+}
+
+func (l *UsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+
+	l.PerPage = defaultUsageEventsPerPage
+	if perPage := values.Get("per_page"); perPage != "" {
+		var err error
+		l.PerPage, err = strconv.Atoi(perPage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
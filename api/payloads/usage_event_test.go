@@ -0,0 +1,58 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("UsageEventList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedUsageEventList payloads.UsageEventList) {
+				actualUsageEventList, decodeErr := decodeQuery[payloads.UsageEventList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualUsageEventList).To(Equal(expectedUsageEventList))
+			},
+
+			Entry("guids", "guids=g1,g2", payloads.UsageEventList{GUIDs: "g1,g2", PerPage: 50}),
+			Entry("after_guid", "after_guid=g1", payloads.UsageEventList{AfterGUID: "g1", PerPage: 50}),
+			Entry("per_page", "per_page=10", payloads.UsageEventList{PerPage: 10}),
+			Entry("page", "page=2", payloads.UsageEventList{PerPage: 50}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, errMatcher types.GomegaMatcher) {
+				_, decodeErr := decodeQuery[payloads.UsageEventList](query)
+				Expect(decodeErr).To(errMatcher)
+			},
+			Entry("per_page is not a number", "per_page=many", MatchError(ContainSubstring("invalid syntax"))),
+			Entry("per_page is zero", "per_page=0", MatchError(ContainSubstring("must be no less than 1"))),
+			Entry("per_page is too large", "per_page=5001", MatchError(ContainSubstring("must be no greater than 5000"))),
+		)
+
+		It("rejects unsupported keys", func() {
+			_, decodeErr := decodeQuery[payloads.UsageEventList]("foo=bar")
+			Expect(decodeErr).To(MatchError(ContainSubstring("unsupported query parameter")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repository message", func() {
+			usageEventList := payloads.UsageEventList{
+				GUIDs:     "g1,g2",
+				AfterGUID: "g0",
+				PerPage:   10,
+			}
+			Expect(usageEventList.ToMessage()).To(Equal(repositories.ListUsageEventsMessage{
+				GUIDs:     []string{"g1", "g2"},
+				AfterGUID: "g0",
+				PerPage:   10,
+			}))
+		})
+	})
+})
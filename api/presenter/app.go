@@ -186,3 +186,42 @@ type AppSSHEnabled struct {
 	Enabled bool   `json:"enabled"`
 	Reason  string `json:"reason"`
 }
+
+const (
+	SSHAppFeature       = "ssh"
+	RevisionsAppFeature = "revisions"
+)
+
+type AppFeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Reason      string `json:"reason"`
+}
+
+func ForAppFeature(app repositories.AppRecord, featureName string) AppFeatureResponse {
+	if featureName == SSHAppFeature {
+		return AppFeatureResponse{
+			Name:        SSHAppFeature,
+			Description: "Enable SSHing into the app.",
+			Enabled:     app.SSHEnabled,
+		}
+	}
+
+	return AppFeatureResponse{
+		Name:        RevisionsAppFeature,
+		Description: "Enable versioning of an application",
+		Enabled:     app.RevisionsEnabled,
+	}
+}
+
+// ForAppSSHFeature presents the ssh feature of an app taking the global and
+// space settings into account
+func ForAppSSHFeature(sshEnabled AppSSHEnabled) AppFeatureResponse {
+	return AppFeatureResponse{
+		Name:        SSHAppFeature,
+		Description: "Enable SSHing into the app.",
+		Enabled:     sshEnabled.Enabled,
+		Reason:      sshEnabled.Reason,
+	}
+}
@@ -0,0 +1,75 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	auditEventsBase = "/v3/audit_events"
+)
+
+type AuditEventResponse struct {
+	GUID         string                  `json:"guid"`
+	Type         string                  `json:"type"`
+	Actor        AuditEventParticipant   `json:"actor"`
+	Target       AuditEventParticipant   `json:"target"`
+	Data         map[string]any          `json:"data"`
+	Space        *AuditEventSpace        `json:"space"`
+	Organization *AuditEventOrganization `json:"organization"`
+	CreatedAt    string                  `json:"created_at"`
+	UpdatedAt    string                  `json:"updated_at"`
+	Links        AuditEventLinks         `json:"links"`
+}
+
+type AuditEventParticipant struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventSpace struct {
+	GUID string `json:"guid"`
+}
+
+type AuditEventOrganization struct {
+	GUID string `json:"guid"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(auditEvent repositories.AuditEventRecord, baseURL url.URL, includes ...include.Resource) AuditEventResponse {
+	var space *AuditEventSpace
+	if auditEvent.SpaceGUID != "" {
+		space = &AuditEventSpace{GUID: auditEvent.SpaceGUID}
+	}
+
+	return AuditEventResponse{
+		GUID: auditEvent.GUID,
+		Type: auditEvent.Type,
+		Actor: AuditEventParticipant{
+			GUID: auditEvent.Actor.GUID,
+			Type: auditEvent.Actor.Type,
+			Name: auditEvent.Actor.Name,
+		},
+		Target: AuditEventParticipant{
+			GUID: auditEvent.Target.GUID,
+			Type: auditEvent.Target.Type,
+			Name: auditEvent.Target.Name,
+		},
+		Data:      emptyMapIfNil(auditEvent.Data),
+		Space:     space,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&auditEvent.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(auditEvent.UpdatedAt)),
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, auditEvent.GUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvents", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.AuditEventRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.AuditEventRecord{
+			GUID: "audit-event-guid",
+			Type: "app.crash",
+			Actor: repositories.AuditEventParticipant{
+				GUID: "app-guid",
+				Type: "app",
+			},
+			Target: repositories.AuditEventParticipant{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			},
+			Data: map[string]any{
+				"exit_status": 137,
+				"reason":      "CRASHED",
+			},
+			SpaceGUID: "space-guid",
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAuditEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected audit event json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "audit-event-guid",
+			"type": "app.crash",
+			"actor": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": ""
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {
+				"exit_status": 137,
+				"reason": "CRASHED"
+			},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": null,
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/audit-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event has no data", func() {
+		BeforeEach(func() {
+			record.Data = nil
+		})
+
+		It("presents empty data", func() {
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+})
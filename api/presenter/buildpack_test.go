@@ -14,23 +14,30 @@ import (
 
 var _ = Describe("Buildpacks", func() {
 	var (
-		output []byte
-		record repositories.BuildpackRecord
+		baseURL *url.URL
+		output  []byte
+		record  repositories.BuildpackRecord
 	)
 
 	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
 		record = repositories.BuildpackRecord{
 			Name:      "paketo-foopacks/bar",
 			Position:  1,
 			Stack:     "waffle-house",
 			Version:   "1.0.0",
+			Filename:  "paketo-foopacks/bar@1.0.0",
+			State:     "READY",
+			Enabled:   true,
 			CreatedAt: time.UnixMilli(1000),
 			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
 		}
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForBuildpack(record, url.URL{})
+		response := presenter.ForBuildpack(record, *baseURL)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,6 +52,7 @@ var _ = Describe("Buildpacks", func() {
 			"filename": "paketo-foopacks/bar@1.0.0",
 			"stack": "waffle-house",
 			"position": 1,
+			"state": "READY",
 			"enabled": true,
 			"locked": false,
 			"metadata": {
@@ -54,4 +62,43 @@ var _ = Describe("Buildpacks", func() {
 			"links": {}
 		}`))
 	})
+
+	When("the buildpack is managed via the API", func() {
+		BeforeEach(func() {
+			record.GUID = "the-guid"
+			record.Filename = "my-buildpack.cnb"
+			record.State = "AWAITING_UPLOAD"
+			record.Enabled = false
+			record.Locked = true
+			record.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("produces expected buildpack json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "the-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "paketo-foopacks/bar",
+				"filename": "my-buildpack.cnb",
+				"stack": "waffle-house",
+				"position": 1,
+				"state": "AWAITING_UPLOAD",
+				"enabled": false,
+				"locked": true,
+				"metadata": {
+					"labels": {"foo": "bar"},
+					"annotations": {}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/buildpacks/the-guid"
+					},
+					"upload": {
+						"href": "https://api.example.org/v3/buildpacks/the-guid/upload",
+						"method": "POST"
+					}
+				}
+			}`))
+		})
+	})
 })
@@ -27,26 +27,24 @@ type DomainResponse struct {
 }
 
 type DomainLinks struct {
-	Self              Link  `json:"self"`
-	RouteReservations Link  `json:"route_reservations"`
-	RouterGroup       *Link `json:"router_group"`
+	Self                Link  `json:"self"`
+	RouteReservations   Link  `json:"route_reservations"`
+	RouterGroup         *Link `json:"router_group"`
+	Organization        *Link `json:"organization,omitempty"`
+	SharedOrganizations *Link `json:"shared_organizations,omitempty"`
 }
 
 type DomainRelationships struct {
-	Organization        `json:"organization"`
-	SharedOrganizations `json:"shared_organizations"`
+	Organization        Organization       `json:"organization"`
+	SharedOrganizations ToManyRelationship `json:"shared_organizations"`
 }
 
 type Organization struct {
-	Data *string `json:"data"`
-}
-
-type SharedOrganizations struct {
-	Data []string `json:"data"`
+	Data *RelationshipData `json:"data"`
 }
 
 func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includes ...include.Resource) DomainResponse {
-	return DomainResponse{
+	response := DomainResponse{
 		Name:               responseDomain.Name,
 		GUID:               responseDomain.GUID,
 		Internal:           false,
@@ -63,9 +61,7 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 			Organization: Organization{
 				Data: nil,
 			},
-			SharedOrganizations: SharedOrganizations{
-				Data: []string{},
-			},
+			SharedOrganizations: ForDomainSharedOrganizations(responseDomain),
 		},
 		Links: DomainLinks{
 			Self: Link{
@@ -77,4 +73,20 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 			RouterGroup: nil,
 		},
 	}
+
+	if responseDomain.IsPrivate() {
+		response.Relationships.Organization.Data = &RelationshipData{GUID: responseDomain.OrganizationGUID}
+		response.Links.Organization = &Link{
+			HRef: buildURL(baseURL).appendPath(orgsBase, responseDomain.OrganizationGUID).build(),
+		}
+		response.Links.SharedOrganizations = &Link{
+			HRef: buildURL(baseURL).appendPath(domainsBase, responseDomain.GUID, "relationships", "shared_organizations").build(),
+		}
+	}
+
+	return response
+}
+
+func ForDomainSharedOrganizations(domain repositories.DomainRecord) ToManyRelationship {
+	return ForToManyRelationship(domain.SharedOrganizationGUIDs)
 }
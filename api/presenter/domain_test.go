@@ -15,6 +15,16 @@ import (
 )
 
 var _ = Describe("Domains", func() {
+	Describe("ForDomainSharedOrganizations", func() {
+		It("presents the shared organizations", func() {
+			output, err := json.Marshal(presenter.ForDomainSharedOrganizations(repositories.DomainRecord{
+				SharedOrganizationGUIDs: []string{"org-1"},
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{"data": [{"guid": "org-1"}]}`))
+		})
+	})
+
 	var (
 		baseURL *url.URL
 		output  []byte
@@ -82,6 +92,20 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain is private", func() {
+		BeforeEach(func() {
+			record.OrganizationGUID = "org-guid"
+			record.SharedOrganizationGUIDs = []string{"org-1", "org-2"}
+		})
+
+		It("presents the owning and shared organizations", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.organization.data.guid", "org-guid"))
+			Expect(output).To(MatchJSONPath("$.relationships.shared_organizations.data[*].guid", ConsistOf("org-1", "org-2")))
+			Expect(output).To(MatchJSONPath("$.links.organization.href", "https://api.example.org/v3/organizations/org-guid"))
+			Expect(output).To(MatchJSONPath("$.links.shared_organizations.href", "https://api.example.org/v3/domains/domain-guid/relationships/shared_organizations"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -80,8 +80,22 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 	if dropletRecord.DropletErrorMsg != "" {
 		toReturn.Error = &dropletRecord.DropletErrorMsg
 	}
-	if dropletRecord.Lifecycle.Type == "docker" {
+	if dropletRecord.Lifecycle.Type == "docker" || dropletRecord.Lifecycle.Type == "dockerfile" {
 		toReturn.Image = &dropletRecord.Image
 	}
+	if dropletRecord.PackageGUID == "" {
+		toReturn.Links["package"] = nil
+	}
+	if dropletRecord.State == repositories.DropletStateAwaitingUpload {
+		toReturn.Links["upload"] = &Link{
+			HRef:   buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	}
+	if dropletRecord.Lifecycle.Type == "buildpack" && dropletRecord.State == repositories.DropletStateStaged {
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	}
 	return toReturn
 }
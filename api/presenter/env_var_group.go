@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const envVarGroupsBase = "/v3/environment_variable_groups"
+
+type EnvVarGroupResponse struct {
+	UpdatedAt *string           `json:"updated_at"`
+	Name      string            `json:"name"`
+	Var       map[string]string `json:"var"`
+	Links     EnvVarGroupLinks  `json:"links"`
+}
+
+type EnvVarGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForEnvVarGroup(record repositories.EnvVarGroupRecord, baseURL url.URL) EnvVarGroupResponse {
+	return EnvVarGroupResponse{
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Var:       record.Var,
+		Links: EnvVarGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(envVarGroupsBase, record.Name).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroup", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.EnvVarGroupRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.EnvVarGroupRecord{
+			Name:      "running",
+			Var:       map[string]string{"HTTP_PROXY": "http://proxy.example.com"},
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForEnvVarGroup(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected env var group json", func() {
+		Expect(output).To(MatchJSON(`{
+			"updated_at": "1970-01-01T00:00:02Z",
+			"name": "running",
+			"var": {
+				"HTTP_PROXY": "http://proxy.example.com"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/environment_variable_groups/running"
+				}
+			}
+		}`))
+	})
+
+	When("the group has never been updated", func() {
+		BeforeEach(func() {
+			record.UpdatedAt = nil
+			record.Var = map[string]string{}
+		})
+
+		It("presents updated_at as null", func() {
+			Expect(output).To(MatchJSON(`{
+				"updated_at": null,
+				"name": "running",
+				"var": {},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/environment_variable_groups/running"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+)
+
+const featureFlagsBase = "/v3/feature_flags"
+
+type FeatureFlagResponse struct {
+	Name               string           `json:"name"`
+	Enabled            bool             `json:"enabled"`
+	UpdatedAt          *string          `json:"updated_at"`
+	CustomErrorMessage *string          `json:"custom_error_message"`
+	Links              FeatureFlagLinks `json:"links"`
+}
+
+type FeatureFlagLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForFeatureFlag(record repositories.FeatureFlagRecord, baseURL url.URL, includes ...include.Resource) FeatureFlagResponse {
+	var customErrorMessage *string
+	if record.CustomErrorMessage != "" {
+		customErrorMessage = &record.CustomErrorMessage
+	}
+
+	return FeatureFlagResponse{
+		Name:               record.Name,
+		Enabled:            record.Enabled,
+		UpdatedAt:          formatTimestamp(record.UpdatedAt),
+		CustomErrorMessage: customErrorMessage,
+		Links: FeatureFlagLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(featureFlagsBase, record.Name).build(),
+			},
+		},
+	}
+}
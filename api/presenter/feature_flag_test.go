@@ -0,0 +1,78 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.FeatureFlagRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.FeatureFlagRecord{
+			Name:               "task_creation",
+			Enabled:            false,
+			CustomErrorMessage: "no tasks",
+			UpdatedAt:          tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForFeatureFlag(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected feature flag json", func() {
+		Expect(output).To(MatchJSON(`{
+			"name": "task_creation",
+			"enabled": false,
+			"updated_at": "1970-01-01T00:00:02Z",
+			"custom_error_message": "no tasks",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/feature_flags/task_creation"
+				}
+			}
+		}`))
+	})
+
+	When("the feature flag has its default value", func() {
+		BeforeEach(func() {
+			record = repositories.FeatureFlagRecord{
+				Name:    "task_creation",
+				Enabled: true,
+			}
+		})
+
+		It("presents updated_at and custom_error_message as null", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "task_creation",
+				"enabled": true,
+				"updated_at": null,
+				"custom_error_message": null,
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/feature_flags/task_creation"
+					}
+				}
+			}`))
+		})
+	})
+})
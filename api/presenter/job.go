@@ -27,6 +27,11 @@ const (
 	ServiceBrokerCreateOperation       = "service_broker.create"
 	ServiceBrokerDeleteOperation       = "service_broker.delete"
 	ServiceBrokerUpdateOperation       = "service_broker.update"
+	DropletUploadOperation             = "droplet.upload"
+	BuildpackUploadOperation           = "buildpack.upload"
+	BuildpackDeleteOperation           = "buildpack.delete"
+	AppClearBuildpackCacheOperation    = "app.clear_buildpack_cache"
+	AdminClearBuildpackCacheOperation  = "admin.clear_buildpack_cache"
 
 	ManagedServiceInstanceResourceType    = "managed_service_instance"
 	ManagedServiceBindingResourceType     = "managed_service_binding"
@@ -15,18 +15,19 @@ const (
 )
 
 type ProcessResponse struct {
-	GUID          string                       `json:"guid"`
-	Type          string                       `json:"type"`
-	Command       string                       `json:"command"`
-	Instances     int32                        `json:"instances"`
-	MemoryMB      int64                        `json:"memory_in_mb"`
-	DiskQuotaMB   int64                        `json:"disk_in_mb"`
-	HealthCheck   ProcessResponseHealthCheck   `json:"health_check"`
-	Relationships map[string]ToOneRelationship `json:"relationships"`
-	Metadata      Metadata                     `json:"metadata"`
-	CreatedAt     string                       `json:"created_at"`
-	UpdatedAt     string                       `json:"updated_at"`
-	Links         ProcessLinks                 `json:"links"`
+	GUID                 string                              `json:"guid"`
+	Type                 string                              `json:"type"`
+	Command              string                              `json:"command"`
+	Instances            int32                               `json:"instances"`
+	MemoryMB             int64                               `json:"memory_in_mb"`
+	DiskQuotaMB          int64                               `json:"disk_in_mb"`
+	HealthCheck          ProcessResponseHealthCheck          `json:"health_check"`
+	ReadinessHealthCheck ProcessResponseReadinessHealthCheck `json:"readiness_health_check"`
+	Relationships        map[string]ToOneRelationship        `json:"relationships"`
+	Metadata             Metadata                            `json:"metadata"`
+	CreatedAt            string                              `json:"created_at"`
+	UpdatedAt            string                              `json:"updated_at"`
+	Links                ProcessLinks                        `json:"links"`
 }
 
 type ProcessLinks struct {
@@ -98,6 +99,38 @@ type ProcessResponseProcessHealthCheckData struct {
 	Timeout *int32 `json:"timeout"`
 }
 
+type ProcessResponseReadinessHealthCheck struct {
+	Type string                                  `json:"type"`
+	Data ProcessResponseReadinessHealthCheckData `json:"data"`
+}
+
+type ProcessResponseReadinessHealthCheckData struct {
+	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
+	HTTPEndpoint      *string `json:"endpoint,omitempty"`
+}
+
+func forReadinessHealthCheck(readinessHealthCheck repositories.ReadinessHealthCheck) ProcessResponseReadinessHealthCheck {
+	response := ProcessResponseReadinessHealthCheck{
+		Type: readinessHealthCheck.Type,
+	}
+	if response.Type == "" {
+		response.Type = "process"
+	}
+
+	if readinessHealthCheck.Data.InvocationTimeoutSeconds != 0 {
+		response.Data.InvocationTimeout = tools.PtrTo(readinessHealthCheck.Data.InvocationTimeoutSeconds)
+	}
+	if readinessHealthCheck.Data.IntervalSeconds != 0 {
+		response.Data.Interval = tools.PtrTo(readinessHealthCheck.Data.IntervalSeconds)
+	}
+	if response.Type == "http" {
+		response.Data.HTTPEndpoint = tools.PtrTo(readinessHealthCheck.Data.HTTPEndpoint)
+	}
+
+	return response
+}
+
 func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) ProcessResponse {
 	return ProcessResponse{
 		GUID:        responseProcess.GUID,
@@ -115,7 +148,8 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) Pro
 				HTTPEndpoint:      responseProcess.HealthCheck.Data.HTTPEndpoint,
 			},
 		},
-		Relationships: ForRelationships(responseProcess.Relationships()),
+		ReadinessHealthCheck: forReadinessHealthCheck(responseProcess.ReadinessHealthCheck),
+		Relationships:        ForRelationships(responseProcess.Relationships()),
 		Metadata: Metadata{
 			Labels:      responseProcess.Labels,
 			Annotations: responseProcess.Annotations,
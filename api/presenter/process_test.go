@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 )
 
 var _ = Describe("Process", func() {
@@ -73,6 +75,13 @@ var _ = Describe("Process", func() {
 						"invocation_timeout": null
 					}
 				},
+				"readiness_health_check": {
+					"type": "process",
+					"data": {
+						"invocation_timeout": null,
+						"interval": null
+					}
+				},
 				"relationships": {
 					"app": {
 						"data": {
@@ -110,5 +119,25 @@ var _ = Describe("Process", func() {
 				}
 			}`))
 		})
+
+		When("the process has an http readiness health check", func() {
+			BeforeEach(func() {
+				record.ReadinessHealthCheck = repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 3,
+						IntervalSeconds:          7,
+					},
+				}
+			})
+
+			It("presents the readiness health check", func() {
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.type", "http"))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.endpoint", "/ready"))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.invocation_timeout", BeEquivalentTo(3)))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.interval", BeEquivalentTo(7)))
+			})
+		})
 	})
 })
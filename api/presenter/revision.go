@@ -0,0 +1,107 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                       `json:"guid"`
+	Version       int                          `json:"version"`
+	Droplet       DropletGUID                  `json:"droplet"`
+	Processes     map[string]RevisionProcess   `json:"processes"`
+	Sidecars      []any                        `json:"sidecars"`
+	Description   string                       `json:"description"`
+	Deployable    bool                         `json:"deployable"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	Metadata      Metadata                     `json:"metadata"`
+	CreatedAt     string                       `json:"created_at"`
+	UpdatedAt     string                       `json:"updated_at"`
+	Links         RevisionLinks                `json:"links"`
+}
+
+type RevisionProcess struct {
+	Command *string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self                 Link `json:"self"`
+	App                  Link `json:"app"`
+	EnvironmentVariables Link `json:"environment_variables"`
+}
+
+func ForRevision(revision repositories.RevisionRecord, baseURL url.URL, includes ...include.Resource) RevisionResponse {
+	processes := map[string]RevisionProcess{}
+	for processType, command := range revision.Processes {
+		process := RevisionProcess{}
+		if command != "" {
+			process.Command = tools.PtrTo(command)
+		}
+		processes[processType] = process
+	}
+
+	return RevisionResponse{
+		GUID:    revision.GUID,
+		Version: revision.Version,
+		Droplet: DropletGUID{
+			Guid: revision.DropletGUID,
+		},
+		Processes:     processes,
+		Sidecars:      []any{},
+		Description:   revision.Description,
+		Deployable:    revision.Deployable,
+		Relationships: ForRelationships(revision.Relationships()),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(revision.Labels),
+			Annotations: emptyMapIfNil(revision.Annotations),
+		},
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&revision.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(revision.UpdatedAt)),
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revision.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, revision.AppGUID).build(),
+			},
+			EnvironmentVariables: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revision.GUID, "environment_variables").build(),
+			},
+		},
+	}
+}
+
+type RevisionEnvVarsResponse struct {
+	Var   map[string]string    `json:"var"`
+	Links RevisionEnvVarsLinks `json:"links"`
+}
+
+type RevisionEnvVarsLinks struct {
+	Self     Link `json:"self"`
+	Revision Link `json:"revision"`
+	App      Link `json:"app"`
+}
+
+func ForRevisionEnvVars(record repositories.RevisionEnvVarsRecord, baseURL url.URL) RevisionEnvVarsResponse {
+	return RevisionEnvVarsResponse{
+		Var: emptyMapIfNil(record.EnvironmentVariables),
+		Links: RevisionEnvVarsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID, "environment_variables").build(),
+			},
+			Revision: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+			},
+		},
+	}
+}
@@ -12,7 +12,16 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
+}
+
+// AppSSHInfo describes how the cf cli reaches the SSH proxy
+type AppSSHInfo struct {
+	Endpoint           string
+	HostKeyFingerprint string
+	OAuthClient        string
 }
 
 type RootResponse struct {
@@ -22,7 +31,7 @@ type RootResponse struct {
 
 const V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootResponse {
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, appSSHInfo AppSSHInfo) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -76,6 +85,18 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 		}
 	}
 
+	if appSSHInfo.Endpoint != "" {
+		rootResponse.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: appSSHInfo.Endpoint,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: appSSHInfo.HostKeyFingerprint,
+				OAuthClient:        appSSHInfo.OAuthClient,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
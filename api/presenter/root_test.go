@@ -9,6 +9,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Root endpoints", func() {
@@ -27,14 +28,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig  config.UAA
+			appSSHInfo presenter.AppSSHInfo
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			appSSHInfo = presenter.AppSSHInfo{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL)
+			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL, appSSHInfo)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -137,6 +142,29 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 			})
 		})
+
+		When("SSH is enabled", func() {
+			BeforeEach(func() {
+				appSSHInfo = presenter.AppSSHInfo{
+					Endpoint:           "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+					OAuthClient:        "ssh-proxy",
+				}
+			})
+
+			It("includes the app ssh link", func() {
+				var response map[string]any
+				Expect(json.Unmarshal(output, &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", MatchAllKeys(Keys{
+					"href": Equal("ssh.example.org:2222"),
+					"meta": MatchAllKeys(Keys{
+						"version":              Equal(""),
+						"host_key_fingerprint": Equal("the-fingerprint"),
+						"oauth_client":         Equal("ssh-proxy"),
+					}),
+				}))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
@@ -21,6 +22,7 @@ type RouteResponse struct {
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
 	Destinations []routeDestination `json:"destinations"`
+	Options      routeOptions       `json:"options"`
 
 	CreatedAt     string                       `json:"created_at"`
 	UpdatedAt     string                       `json:"updated_at"`
@@ -34,6 +36,15 @@ type RouteDestinationsResponse struct {
 	Links        routeDestinationsLinks `json:"links"`
 }
 
+type RouteSharedSpacesResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links routeSharedSpacesLinks      `json:"links"`
+}
+
+type routeSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
 type routeDestination struct {
 	GUID     string              `json:"guid"`
 	App      routeDestinationApp `json:"app"`
@@ -51,6 +62,18 @@ type routeDestinationAppProcess struct {
 	Type string `json:"type"`
 }
 
+type routeOptions struct {
+	LoadBalancing  string               `json:"loadbalancing,omitempty"`
+	Timeout        string               `json:"timeout,omitempty"`
+	RequestHeaders *routeHeaderModifier `json:"request_headers,omitempty"`
+}
+
+type routeHeaderModifier struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Add    map[string]string `json:"add,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
 type routeLinks struct {
 	Self         Link `json:"self"`
 	Space        Link `json:"space"`
@@ -78,6 +101,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...inclu
 		UpdatedAt:     tools.ZeroIfNil(formatTimestamp(route.UpdatedAt)),
 		Relationships: ForRelationships(route.Relationships()),
 		Destinations:  destinations,
+		Options:       forRouteOptions(route.Options),
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(route.Labels),
 			Annotations: emptyMapIfNil(route.Annotations),
@@ -139,3 +163,40 @@ func routeURL(route repositories.RouteRecord) string {
 		return fmt.Sprintf("%s%s", route.Domain.Name, route.Path)
 	}
 }
+
+func forRouteOptions(options repositories.RouteOptions) routeOptions {
+	result := routeOptions{
+		LoadBalancing: options.LoadBalancing,
+		Timeout:       options.Timeout,
+	}
+
+	headers := options.RequestHeaders
+	if len(headers.Set) > 0 || len(headers.Add) > 0 || len(headers.Remove) > 0 {
+		result.RequestHeaders = &routeHeaderModifier{
+			Set:    headers.Set,
+			Add:    headers.Add,
+			Remove: headers.Remove,
+		}
+	}
+
+	return result
+}
+
+func ForRouteSharedSpaces(route repositories.RouteRecord, baseURL url.URL) RouteSharedSpacesResponse {
+	return RouteSharedSpacesResponse{
+		Data: toManyRelationshipData(route.SharedSpaceGUIDs),
+		Links: routeSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
+func ForRouteSpace(route repositories.RouteRecord) ToOneRelationship {
+	return ToOneRelationship{
+		Data: Relationship{
+			GUID: route.SpaceGUID,
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("Route", func() {
@@ -101,6 +102,7 @@ var _ = Describe("Route", func() {
 						"protocol": "http2"
 					}
 				],
+				"options": {},
 				"relationships": {
 					"space": {
 						"data": {
@@ -134,6 +136,30 @@ var _ = Describe("Route", func() {
 			}`))
 		})
 
+		When("the route has options", func() {
+			BeforeEach(func() {
+				record.Options = repositories.RouteOptions{
+					LoadBalancing: "least-connection",
+					Timeout:       "30s",
+					RequestHeaders: repositories.RouteHeaderModifier{
+						Set:    map[string]string{"X-Foo": "bar"},
+						Remove: []string{"X-Bar"},
+					},
+				}
+			})
+
+			It("presents them", func() {
+				Expect(output).To(MatchJSONPath("$.options", MatchAllKeys(Keys{
+					"loadbalancing": Equal("least-connection"),
+					"timeout":       Equal("30s"),
+					"request_headers": MatchAllKeys(Keys{
+						"set":    MatchAllKeys(Keys{"X-Foo": Equal("bar")}),
+						"remove": ConsistOf("X-Bar"),
+					}),
+				})))
+			})
+		})
+
 		When("host is empty", func() {
 			BeforeEach(func() {
 				record.Host = ""
@@ -145,6 +171,32 @@ var _ = Describe("Route", func() {
 		})
 	})
 
+	Describe("shared spaces", func() {
+		BeforeEach(func() {
+			record.SharedSpaceGUIDs = []string{"space-1", "space-2"}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForRouteSharedSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{"guid": "space-1"},
+					{"guid": "space-2"}
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"
+					}
+				}
+			}`))
+		})
+	})
+
 	Describe("destinations", func() {
 		JustBeforeEach(func() {
 			response := presenter.ForRouteDestinations(record, *baseURL)
@@ -71,6 +71,19 @@ type ToOneRelationship struct {
 	Data Relationship `json:"data"`
 }
 
+type ToManyRelationship struct {
+	Data []Relationship `json:"data"`
+}
+
+func ForToManyRelationship(guids []string) ToManyRelationship {
+	data := []Relationship{}
+	for _, guid := range guids {
+		data = append(data, Relationship{GUID: guid})
+	}
+
+	return ToManyRelationship{Data: data}
+}
+
 type itemPresenter[T, S any] func(T, url.URL, ...include.Resource) S
 
 func ForList[T, S any](itemPresenter itemPresenter[T, S], resources []T, baseURL, requestURL url.URL, includes ...include.Resource) ListResponse[S] {
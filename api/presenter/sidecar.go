@@ -0,0 +1,35 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+type SidecarResponse struct {
+	GUID          string                       `json:"guid"`
+	Name          string                       `json:"name"`
+	Command       string                       `json:"command"`
+	ProcessTypes  []string                     `json:"process_types"`
+	MemoryInMB    *int64                       `json:"memory_in_mb"`
+	Origin        string                       `json:"origin"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	CreatedAt     string                       `json:"created_at"`
+	UpdatedAt     string                       `json:"updated_at"`
+}
+
+func ForSidecar(sidecar repositories.SidecarRecord, _ url.URL, includes ...include.Resource) SidecarResponse {
+	return SidecarResponse{
+		GUID:          sidecar.GUID,
+		Name:          sidecar.Name,
+		Command:       sidecar.Command,
+		ProcessTypes:  sidecar.ProcessTypes,
+		MemoryInMB:    sidecar.MemoryMB,
+		Origin:        sidecar.Origin,
+		Relationships: ForRelationships(sidecar.Relationships()),
+		CreatedAt:     tools.ZeroIfNil(formatTimestamp(&sidecar.CreatedAt)),
+		UpdatedAt:     tools.ZeroIfNil(formatTimestamp(sidecar.UpdatedAt)),
+	}
+}
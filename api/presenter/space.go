@@ -49,3 +49,31 @@ func ForSpace(space repositories.SpaceRecord, apiBaseURL url.URL, includes ...in
 		Included: includedResources(includes...),
 	}
 }
+
+const SSHSpaceFeature = "ssh"
+
+type SpaceFeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type SpaceFeaturesResponse struct {
+	Resources []SpaceFeatureResponse `json:"resources"`
+}
+
+func ForSpaceFeature(space repositories.SpaceRecord) SpaceFeatureResponse {
+	return SpaceFeatureResponse{
+		Name:        SSHSpaceFeature,
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     space.SSHEnabled,
+	}
+}
+
+func ForSpaceFeatures(space repositories.SpaceRecord) SpaceFeaturesResponse {
+	return SpaceFeaturesResponse{
+		Resources: []SpaceFeatureResponse{
+			ForSpaceFeature(space),
+		},
+	}
+}
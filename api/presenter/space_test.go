@@ -99,3 +99,35 @@ var _ = Describe("Space", func() {
 		})
 	})
 })
+
+var _ = Describe("SpaceFeatures", func() {
+	var (
+		output []byte
+		record repositories.SpaceRecord
+	)
+
+	BeforeEach(func() {
+		record = repositories.SpaceRecord{
+			GUID:       "the-space-guid",
+			SSHEnabled: true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForSpaceFeatures(record))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected space features json", func() {
+		Expect(output).To(MatchJSON(`{
+			"resources": [
+				{
+					"name": "ssh",
+					"description": "Enable SSHing into apps in the space.",
+					"enabled": true
+				}
+			]
+		}`))
+	})
+})
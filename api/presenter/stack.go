@@ -13,27 +13,28 @@ const (
 )
 
 type StackResponse struct {
-	GUID        string     `json:"guid"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Metadata    Metadata   `json:"metadata"`
-	Links       StackLinks `json:"links"`
-}
-
-type StackLinks struct {
-	Self Link `json:"self"`
+	GUID        string          `json:"guid"`
+	CreatedAt   string          `json:"created_at"`
+	UpdatedAt   string          `json:"updated_at"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Metadata    Metadata        `json:"metadata"`
+	Links       map[string]Link `json:"links"`
 }
 
 func ForStack(stackRecord repositories.StackRecord, baseURL url.URL, includes ...include.Resource) StackResponse {
 	return StackResponse{
-		GUID:      stackRecord.GUID,
-		CreatedAt: tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
-		UpdatedAt: tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
-		Name:      stackRecord.Name,
-		Links: StackLinks{
-			Self: Link{
+		GUID:        stackRecord.GUID,
+		CreatedAt:   tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
+		UpdatedAt:   tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
+		Name:        stackRecord.Name,
+		Description: stackRecord.Description,
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(stackRecord.Labels),
+			Annotations: emptyMapIfNil(stackRecord.Annotations),
+		},
+		Links: map[string]Link{
+			"self": {
 				HRef: buildURL(baseURL).appendPath(stacksBase, stackRecord.GUID).build(),
 			},
 		},
@@ -0,0 +1,87 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stacks", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.StackRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.StackRecord{
+			GUID:      "the-guid",
+			Name:      "io.buildpacks.stacks.jammy",
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForStack(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected stack json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "the-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"name": "io.buildpacks.stacks.jammy",
+			"description": "",
+			"metadata": {
+				"labels": {},
+				"annotations": {}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/stacks/the-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the stack is managed via the API", func() {
+		BeforeEach(func() {
+			record.Description = "the jammy stack"
+			record.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("produces expected stack json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "the-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "io.buildpacks.stacks.jammy",
+				"description": "the jammy stack",
+				"metadata": {
+					"labels": {
+						"foo": "bar"
+					},
+					"annotations": {}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/stacks/the-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
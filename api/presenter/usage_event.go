@@ -0,0 +1,155 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                            `json:"guid"`
+	CreatedAt             string                            `json:"created_at"`
+	UpdatedAt             string                            `json:"updated_at"`
+	State                 UsageEventCurrentPrevious[string] `json:"state"`
+	App                   UsageEventResource                `json:"app"`
+	Process               UsageEventProcess                 `json:"process"`
+	Space                 UsageEventResource                `json:"space"`
+	Organization          UsageEventOrganization            `json:"organization"`
+	Buildpack             UsageEventResource                `json:"buildpack"`
+	Task                  UsageEventResource                `json:"task"`
+	MemoryInMBPerInstance UsageEventCurrentPrevious[int64]  `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventCurrentPrevious[int32]  `json:"instance_count"`
+	Links                 UsageEventLinks                   `json:"links"`
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                 `json:"guid"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
+	State           string                 `json:"state"`
+	Space           UsageEventResource     `json:"space"`
+	Organization    UsageEventOrganization `json:"organization"`
+	ServiceInstance UsageEventInstance     `json:"service_instance"`
+	ServicePlan     UsageEventResource     `json:"service_plan"`
+	ServiceOffering UsageEventResource     `json:"service_offering"`
+	ServiceBroker   UsageEventResource     `json:"service_broker"`
+	Links           UsageEventLinks        `json:"links"`
+}
+
+type UsageEventCurrentPrevious[T any] struct {
+	Current  *T `json:"current"`
+	Previous *T `json:"previous"`
+}
+
+type UsageEventResource struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+}
+
+type UsageEventProcess struct {
+	GUID *string `json:"guid"`
+	Type *string `json:"type"`
+}
+
+type UsageEventInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UsageEventOrganization struct {
+	GUID *string `json:"guid"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(usageEvent repositories.AppUsageEventRecord, baseURL url.URL, includes ...include.Resource) AppUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&usageEvent.CreatedAt))
+
+	return AppUsageEventResponse{
+		GUID:      usageEvent.GUID,
+		CreatedAt: createdAt,
+		// Usage events are never updated
+		UpdatedAt: createdAt,
+		State: UsageEventCurrentPrevious[string]{
+			Current:  tools.PtrTo(usageEvent.State),
+			Previous: nilIfEmpty(usageEvent.PreviousState),
+		},
+		App: UsageEventResource{
+			GUID: tools.PtrTo(usageEvent.AppGUID),
+			Name: tools.PtrTo(usageEvent.AppName),
+		},
+		Process: UsageEventProcess{
+			GUID: tools.PtrTo(usageEvent.ProcessGUID),
+			Type: tools.PtrTo(usageEvent.ProcessType),
+		},
+		Space: UsageEventResource{
+			GUID: tools.PtrTo(usageEvent.SpaceGUID),
+			Name: nilIfEmpty(usageEvent.SpaceName),
+		},
+		Organization: UsageEventOrganization{
+			GUID: nilIfEmpty(usageEvent.OrgGUID),
+		},
+		MemoryInMBPerInstance: UsageEventCurrentPrevious[int64]{
+			Current:  tools.PtrTo(usageEvent.MemoryInMBPerInstance),
+			Previous: usageEvent.PreviousMemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventCurrentPrevious[int32]{
+			Current:  tools.PtrTo(usageEvent.InstanceCount),
+			Previous: usageEvent.PreviousInstanceCount,
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, usageEvent.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceUsageEvent(usageEvent repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...include.Resource) ServiceUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&usageEvent.CreatedAt))
+
+	return ServiceUsageEventResponse{
+		GUID:      usageEvent.GUID,
+		CreatedAt: createdAt,
+		// Usage events are never updated
+		UpdatedAt: createdAt,
+		State:     usageEvent.State,
+		Space: UsageEventResource{
+			GUID: tools.PtrTo(usageEvent.SpaceGUID),
+			Name: nilIfEmpty(usageEvent.SpaceName),
+		},
+		Organization: UsageEventOrganization{
+			GUID: nilIfEmpty(usageEvent.OrgGUID),
+		},
+		ServiceInstance: UsageEventInstance{
+			GUID: usageEvent.ServiceInstanceGUID,
+			Name: usageEvent.ServiceInstanceName,
+			Type: usageEvent.ServiceInstanceType,
+		},
+		ServicePlan: UsageEventResource{
+			GUID: nilIfEmpty(usageEvent.ServicePlanGUID),
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, usageEvent.GUID).build(),
+			},
+		},
+	}
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,184 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageEvents", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppUsageEvent", func() {
+		var record repositories.AppUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.AppUsageEventRecord{
+				GUID:                          "usage-event-guid",
+				State:                         "STARTED",
+				PreviousState:                 "STOPPED",
+				AppGUID:                       "app-guid",
+				AppName:                       "my-app",
+				ProcessGUID:                   "process-guid",
+				ProcessType:                   "web",
+				SpaceGUID:                     "space-guid",
+				SpaceName:                     "my-space",
+				OrgGUID:                       "org-guid",
+				InstanceCount:                 2,
+				PreviousInstanceCount:         tools.PtrTo[int32](1),
+				MemoryInMBPerInstance:         256,
+				PreviousMemoryInMBPerInstance: tools.PtrTo[int64](128),
+				CreatedAt:                     time.UnixMilli(1000),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces expected app usage event json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "usage-event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": {
+					"current": "STARTED",
+					"previous": "STOPPED"
+				},
+				"app": {
+					"guid": "app-guid",
+					"name": "my-app"
+				},
+				"process": {
+					"guid": "process-guid",
+					"type": "web"
+				},
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"buildpack": {
+					"guid": null,
+					"name": null
+				},
+				"task": {
+					"guid": null,
+					"name": null
+				},
+				"memory_in_mb_per_instance": {
+					"current": 256,
+					"previous": 128
+				},
+				"instance_count": {
+					"current": 2,
+					"previous": 1
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/app_usage_events/usage-event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("there is no previous event", func() {
+			BeforeEach(func() {
+				record.PreviousState = ""
+				record.PreviousInstanceCount = nil
+				record.PreviousMemoryInMBPerInstance = nil
+			})
+
+			It("presents the previous values as null", func() {
+				var response map[string]any
+				Expect(json.Unmarshal(output, &response)).To(Succeed())
+				Expect(response["state"]).To(HaveKeyWithValue("previous", BeNil()))
+				Expect(response["instance_count"]).To(HaveKeyWithValue("previous", BeNil()))
+				Expect(response["memory_in_mb_per_instance"]).To(HaveKeyWithValue("previous", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForServiceUsageEvent", func() {
+		var record repositories.ServiceUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "usage-event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "service-instance-guid",
+				ServiceInstanceName: "my-service-instance",
+				ServiceInstanceType: "managed",
+				ServicePlanGUID:     "plan-guid",
+				SpaceGUID:           "space-guid",
+				SpaceName:           "my-space",
+				OrgGUID:             "org-guid",
+				CreatedAt:           time.UnixMilli(1000),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces expected service usage event json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "usage-event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": "CREATED",
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"service_instance": {
+					"guid": "service-instance-guid",
+					"name": "my-service-instance",
+					"type": "managed"
+				},
+				"service_plan": {
+					"guid": "plan-guid",
+					"name": null
+				},
+				"service_offering": {
+					"guid": null,
+					"name": null
+				},
+				"service_broker": {
+					"guid": null,
+					"name": null
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_usage_events/usage-event-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -113,6 +113,8 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	SSHEnabled            bool
+	RevisionsEnabled      bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -185,6 +187,8 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	SSHEnabled           *bool
+	RevisionsEnabled     *bool
 	MetadataPatch
 }
 
@@ -222,6 +226,7 @@ type ListAppsMessage struct {
 	Names         []string
 	Guids         []string
 	SpaceGUIDs    []string
+	Stacks        []string
 	LabelSelector string
 	OrderBy       string
 }
@@ -235,6 +240,10 @@ func (m *ListAppsMessage) toListOptions() []ListOption {
 	}
 }
 
+func (m *ListAppsMessage) matches(a korifiv1alpha1.CFApp) bool {
+	return tools.EmptyOrContains(m.Stacks, a.Spec.Lifecycle.Data.Stack)
+}
+
 func (f *AppRepo) GetApp(ctx context.Context, authInfo authorization.Info, appGUID string) (AppRecord, error) {
 	app := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -326,7 +335,7 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 		return []AppRecord{}, fmt.Errorf("failed to list apps: %w", apierrors.FromK8sError(err, AppResourceType))
 	}
 
-	appRecords := it.Map(itx.FromSlice(appList.Items), cfAppToAppRecord)
+	appRecords := it.Map(itx.FromSlice(appList.Items).Filter(message.matches), cfAppToAppRecord)
 
 	return f.sorter.Sort(slices.Collect(appRecords), message.OrderBy), nil
 }
@@ -609,6 +618,14 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.SSHEnabled != nil {
+		app.Spec.Features.SSH = m.SSHEnabled
+	}
+
+	if m.RevisionsEnabled != nil {
+		app.Spec.Features.Revisions = m.RevisionsEnabled
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -634,6 +651,8 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              cfApp.Spec.CurrentDropletRef.Name != "",
+		SSHEnabled:            cfApp.Spec.Features.SSH == nil || *cfApp.Spec.Features.SSH,
+		RevisionsEnabled:      cfApp.Spec.Features.Revisions == nil || *cfApp.Spec.Features.Revisions,
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -235,6 +235,25 @@ var _ = Describe("AppRepository", func() {
 				})))
 			})
 
+			When("filtering by stack", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp2, func() {
+						cfApp2.Spec.Lifecycle.Data.Stack = "my-stack"
+					})).To(Succeed())
+
+					message = repositories.ListAppsMessage{
+						Stacks: []string{"my-stack"},
+					}
+				})
+
+				It("returns the apps on the stack", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(appList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(cfApp2.Name),
+					})))
+				})
+			})
+
 			Describe("filter parameters to list options", func() {
 				var fakeKlient *fake.Klient
 
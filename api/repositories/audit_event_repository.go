@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+const (
+	AuditEventResourceType = "Audit Event"
+
+	AuditEventTypeAppStart        = "audit.app.start"
+	AuditEventTypeAppStop         = "audit.app.stop"
+	AuditEventTypeAppRestart      = "audit.app.restart"
+	AuditEventTypeAppProcessScale = "audit.app.process.scale"
+	AuditEventTypeAppBuildCreate  = "audit.app.build.create"
+
+	AuditEventTargetTypeApp = "app"
+
+	auditEventTimestampGranularity = time.Second
+)
+
+type AuditEventRecord struct {
+	GUID      string
+	Type      string
+	Actor     AuditEventParticipant
+	Target    AuditEventParticipant
+	Data      map[string]any
+	SpaceGUID string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type AuditEventParticipant struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type CreateAuditEventMessage struct {
+	Type      string
+	SpaceGUID string
+	Target    AuditEventParticipant
+	Data      map[string]any
+}
+
+// TimestampFilter matches timestamps against the CF API timestamp filters,
+// e.g. `created_ats=...` or `created_ats[lt]=...`. Timestamps are compared
+// with second granularity, as this is the precision they are presented with.
+type TimestampFilter struct {
+	Equals             []time.Time
+	LessThan           *time.Time
+	LessThanOrEqual    *time.Time
+	GreaterThan        *time.Time
+	GreaterThanOrEqual *time.Time
+}
+
+func (f TimestampFilter) Matches(t time.Time) bool {
+	t = t.Truncate(auditEventTimestampGranularity)
+
+	if len(f.Equals) > 0 && !slices.ContainsFunc(f.Equals, func(e time.Time) bool {
+		return e.Truncate(auditEventTimestampGranularity).Equal(t)
+	}) {
+		return false
+	}
+
+	if f.LessThan != nil && !t.Before(*f.LessThan) {
+		return false
+	}
+
+	if f.LessThanOrEqual != nil && t.After(*f.LessThanOrEqual) {
+		return false
+	}
+
+	if f.GreaterThan != nil && !t.After(*f.GreaterThan) {
+		return false
+	}
+
+	if f.GreaterThanOrEqual != nil && t.Before(*f.GreaterThanOrEqual) {
+		return false
+	}
+
+	return true
+}
+
+type ListAuditEventsMessage struct {
+	Types       []string
+	TargetGUIDs []string
+	SpaceGUIDs  []string
+	CreatedAts  TimestampFilter
+	OrderBy     string
+}
+
+func (m ListAuditEventsMessage) toListOptions() []ListOption {
+	return []ListOption{
+		WithLabelIn(korifiv1alpha1.CFAuditEventTypeLabelKey, m.Types),
+		WithLabelIn(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, m.TargetGUIDs),
+		WithLabelIn(korifiv1alpha1.SpaceGUIDKey, m.SpaceGUIDs),
+	}
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventSorter . AuditEventSorter
+type AuditEventSorter interface {
+	Sort(records []AuditEventRecord, order string) []AuditEventRecord
+}
+
+type auditEventSorter struct {
+	sorter *compare.Sorter[AuditEventRecord]
+}
+
+func NewAuditEventSorter() *auditEventSorter {
+	return &auditEventSorter{
+		sorter: compare.NewSorter(AuditEventComparator),
+	}
+}
+
+func (s *auditEventSorter) Sort(records []AuditEventRecord, order string) []AuditEventRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func AuditEventComparator(fieldName string) func(AuditEventRecord, AuditEventRecord) int {
+	return func(e1, e2 AuditEventRecord) int {
+		switch fieldName {
+		case "updated_at":
+			return tools.CompareTimePtr(e1.UpdatedAt, e2.UpdatedAt)
+		}
+		return tools.CompareTimePtr(&e1.CreatedAt, &e2.CreatedAt)
+	}
+}
+
+// AuditEventRepo lists the audit events visible to the user and records
+// audit events for the actions they perform. Events are created with the
+// privileged client, so that users cannot forge events by creating
+// CFAuditEvents themselves.
+type AuditEventRepo struct {
+	klient           Klient
+	privilegedClient client.Client
+	identityProvider authorization.IdentityProvider
+	sorter           AuditEventSorter
+}
+
+func NewAuditEventRepo(
+	klient Klient,
+	privilegedClient client.Client,
+	identityProvider authorization.IdentityProvider,
+	sorter AuditEventSorter,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		identityProvider: identityProvider,
+		sorter:           sorter,
+	}
+}
+
+func (r *AuditEventRepo) CreateAuditEvent(ctx context.Context, authInfo authorization.Info, message CreateAuditEventMessage) (AuditEventRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	data, err := korifiv1alpha1.AsRawExtension(message.Data)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       message.Type,
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: message.Target.GUID,
+				korifiv1alpha1.SpaceGUIDKey:                   message.SpaceGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: identity.Name,
+				Type: korifiv1alpha1.AuditEventActorTypeUser,
+				Name: identity.Name,
+			},
+			Target: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: message.Target.GUID,
+				Type: message.Target.Type,
+				Name: message.Target.Name,
+			},
+			Data: data,
+		},
+	}
+
+	err = r.privilegedClient.Create(ctx, auditEvent)
+	if err != nil {
+		return AuditEventRecord{}, apierrors.FromK8sError(err, AuditEventResourceType)
+	}
+
+	return cfAuditEventToRecord(*auditEvent), nil
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, auditEventGUID string) (AuditEventRecord, error) {
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: auditEventGUID,
+		},
+	}
+	err := r.klient.Get(ctx, auditEvent)
+	if err != nil {
+		return AuditEventRecord{}, apierrors.FromK8sError(err, AuditEventResourceType)
+	}
+
+	return cfAuditEventToRecord(*auditEvent), nil
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	auditEventList := &korifiv1alpha1.CFAuditEventList{}
+	err := r.klient.List(ctx, auditEventList, message.toListOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	filteredEvents := it.Filter(slices.Values(auditEventList.Items), func(e korifiv1alpha1.CFAuditEvent) bool {
+		return message.CreatedAts.Matches(e.CreationTimestamp.Time)
+	})
+
+	records := slices.Collect(it.Map(filteredEvents, cfAuditEventToRecord))
+	return r.sorter.Sort(records, message.OrderBy), nil
+}
+
+func cfAuditEventToRecord(auditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	data := map[string]any{}
+	if auditEvent.Spec.Data != nil && len(auditEvent.Spec.Data.Raw) > 0 {
+		// The data is only ever written as a JSON object, ignore anything else
+		_ = json.Unmarshal(auditEvent.Spec.Data.Raw, &data)
+	}
+
+	return AuditEventRecord{
+		GUID: auditEvent.Name,
+		Type: auditEvent.Spec.Type,
+		Actor: AuditEventParticipant{
+			GUID: auditEvent.Spec.Actor.GUID,
+			Type: auditEvent.Spec.Actor.Type,
+			Name: auditEvent.Spec.Actor.Name,
+		},
+		Target: AuditEventParticipant{
+			GUID: auditEvent.Spec.Target.GUID,
+			Type: auditEvent.Spec.Target.Type,
+			Name: auditEvent.Spec.Target.Name,
+		},
+		Data:      data,
+		SpaceGUID: auditEvent.Namespace,
+		CreatedAt: auditEvent.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&auditEvent),
+	}
+}
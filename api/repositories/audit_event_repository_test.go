@@ -0,0 +1,278 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *repositories.AuditEventRepo
+		sorter         *fake.AuditEventSorter
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+		crashEvent     *korifiv1alpha1.CFAuditEvent
+	)
+
+	createAuditEvent := func(eventType, targetGUID string) *korifiv1alpha1.CFAuditEvent {
+		data, err := korifiv1alpha1.AsRawExtension(map[string]any{"exit_status": 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		auditEvent := &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAuditEventTypeLabelKey:       eventType,
+					korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: targetGUID,
+					korifiv1alpha1.SpaceGUIDKey:                   cfSpace.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type:   eventType,
+				Actor:  korifiv1alpha1.CFAuditEventParticipant{GUID: targetGUID, Type: "app"},
+				Target: korifiv1alpha1.CFAuditEventParticipant{GUID: targetGUID, Type: "app"},
+				Data:   data,
+			},
+		}
+		Expect(k8sClient.Create(ctx, auditEvent)).To(Succeed())
+
+		return auditEvent
+	}
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+
+		crashEvent = createAuditEvent(korifiv1alpha1.AuditEventTypeAppCrash, "app-guid")
+		createAuditEvent(repositories.AuditEventTypeAppStart, "another-app-guid")
+
+		sorter = new(fake.AuditEventSorter)
+		sorter.SortStub = func(records []repositories.AuditEventRecord, _ string) []repositories.AuditEventRecord {
+			return records
+		}
+
+		auditEventRepo = repositories.NewAuditEventRepo(klient, k8sClient, idProvider, sorter)
+	})
+
+	Describe("CreateAuditEvent", func() {
+		var (
+			message    repositories.CreateAuditEventMessage
+			auditEvent repositories.AuditEventRecord
+			createErr  error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateAuditEventMessage{
+				Type:      repositories.AuditEventTypeAppStop,
+				SpaceGUID: cfSpace.Name,
+				Target: repositories.AuditEventParticipant{
+					GUID: "app-guid",
+					Type: repositories.AuditEventTargetTypeApp,
+					Name: "my-app",
+				},
+				Data: map[string]any{"foo": "bar"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			auditEvent, createErr = auditEventRepo.CreateAuditEvent(ctx, authInfo, message)
+		})
+
+		It("records the event on behalf of the user", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(auditEvent.Type).To(Equal(repositories.AuditEventTypeAppStop))
+			Expect(auditEvent.Actor).To(Equal(repositories.AuditEventParticipant{
+				GUID: userName,
+				Type: "user",
+				Name: userName,
+			}))
+			Expect(auditEvent.Target).To(Equal(message.Target))
+			Expect(auditEvent.Data).To(Equal(map[string]any{"foo": "bar"}))
+			Expect(auditEvent.SpaceGUID).To(Equal(cfSpace.Name))
+
+			cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      auditEvent.GUID,
+				},
+			}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+			Expect(cfAuditEvent.Labels).To(MatchAllKeys(Keys{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       Equal(repositories.AuditEventTypeAppStop),
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: Equal("app-guid"),
+				korifiv1alpha1.SpaceGUIDKey:                   Equal(cfSpace.Name),
+			}))
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			auditEvent repositories.AuditEventRecord
+			getErr     error
+		)
+
+		JustBeforeEach(func() {
+			auditEvent, getErr = auditEventRepo.GetAuditEvent(ctx, authInfo, crashEvent.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the audit event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(auditEvent.GUID).To(Equal(crashEvent.Name))
+				Expect(auditEvent.Type).To(Equal(korifiv1alpha1.AuditEventTypeAppCrash))
+				Expect(auditEvent.Target.GUID).To(Equal("app-guid"))
+				Expect(auditEvent.Data).To(Equal(map[string]any{"exit_status": float64(1)}))
+				Expect(auditEvent.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(auditEvent.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			message     repositories.ListAuditEventsMessage
+			auditEvents []repositories.AuditEventRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListAuditEventsMessage{
+				SpaceGUIDs: []string{cfSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			auditEvents, listErr = auditEventRepo.ListAuditEvents(ctx, authInfo, message)
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(auditEvents).To(BeEmpty())
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the audit events in the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(auditEvents).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(korifiv1alpha1.AuditEventTypeAppCrash)}),
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(repositories.AuditEventTypeAppStart)}),
+				))
+			})
+
+			It("sorts the audit events", func() {
+				Expect(sorter.SortCallCount()).To(Equal(1))
+			})
+
+			When("filtering by type", func() {
+				BeforeEach(func() {
+					message.Types = []string{korifiv1alpha1.AuditEventTypeAppCrash}
+				})
+
+				It("returns the matching audit events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(crashEvent.Name)})))
+				})
+			})
+
+			When("filtering by target guid", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{"another-app-guid"}
+				})
+
+				It("returns the matching audit events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Type": Equal(repositories.AuditEventTypeAppStart),
+					})))
+				})
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{"another-space"}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(BeEmpty())
+				})
+			})
+
+			When("filtering by creation time", func() {
+				BeforeEach(func() {
+					message.CreatedAts = repositories.TimestampFilter{
+						LessThan: tools.PtrTo(time.Now().Add(-time.Hour)),
+					}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("TimestampFilter", func() {
+		var (
+			now    time.Time
+			filter repositories.TimestampFilter
+		)
+
+		BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+			filter = repositories.TimestampFilter{}
+		})
+
+		It("matches any timestamp when empty", func() {
+			Expect(filter.Matches(now)).To(BeTrue())
+		})
+
+		It("matches exact timestamps with second granularity", func() {
+			filter.Equals = []time.Time{now}
+			Expect(filter.Matches(now.Add(500 * time.Millisecond))).To(BeTrue())
+			Expect(filter.Matches(now.Add(time.Second))).To(BeFalse())
+		})
+
+		It("matches timestamps in a range", func() {
+			filter.GreaterThanOrEqual = tools.PtrTo(now)
+			filter.LessThan = tools.PtrTo(now.Add(time.Minute))
+			Expect(filter.Matches(now)).To(BeTrue())
+			Expect(filter.Matches(now.Add(time.Minute))).To(BeFalse())
+			Expect(filter.Matches(now.Add(-time.Second))).To(BeFalse())
+		})
+
+		It("excludes the bounds of exclusive filters", func() {
+			filter.GreaterThan = tools.PtrTo(now)
+			filter.LessThanOrEqual = tools.PtrTo(now.Add(time.Minute))
+			Expect(filter.Matches(now)).To(BeFalse())
+			Expect(filter.Matches(now.Add(time.Minute))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const BuildCacheResourceType = "Build Cache"
+
+// BuildCacheRepo requests the build caches of apps to be cleared. Each
+// request is a CFBuildCacheClear, which the controllers fan out to the apps
+// it targets and which becomes ready once the builder has cleared the build
+// caches of all those apps. The GUID of the request is the name of the
+// CFBuildCacheClear.
+type BuildCacheRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewBuildCacheRepo(klient Klient, rootNamespace string) *BuildCacheRepo {
+	return &BuildCacheRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+// ClearAppBuildCache requests the build cache of the app to be cleared and
+// returns the GUID of the request
+func (r *BuildCacheRepo) ClearAppBuildCache(ctx context.Context, authInfo authorization.Info, appGUID string) (string, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: appGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfApp); err != nil {
+		return "", fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return r.requestClear(ctx, cfApp.Namespace, &corev1.LocalObjectReference{Name: cfApp.Name})
+}
+
+// ClearAllBuildCaches requests the build caches of all apps to be cleared and
+// returns the GUID of the request. Only admins are allowed to create requests
+// in the root namespace.
+func (r *BuildCacheRepo) ClearAllBuildCaches(ctx context.Context, authInfo authorization.Info) (string, error) {
+	return r.requestClear(ctx, r.rootNamespace, nil)
+}
+
+// GetState returns ResourceStateReady once the build caches of all the apps
+// targeted by the request have been cleared
+func (r *BuildCacheRepo) GetState(ctx context.Context, authInfo authorization.Info, requestGUID string) (ResourceState, error) {
+	cfBuildCacheClear := &korifiv1alpha1.CFBuildCacheClear{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: requestGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfBuildCacheClear); err != nil {
+		return ResourceStateUnknown, fmt.Errorf("failed to get build cache clear: %w", apierrors.FromK8sError(err, BuildCacheResourceType))
+	}
+
+	if meta.IsStatusConditionTrue(cfBuildCacheClear.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+func (r *BuildCacheRepo) requestClear(ctx context.Context, namespace string, appRef *corev1.LocalObjectReference) (string, error) {
+	cfBuildCacheClear := &korifiv1alpha1.CFBuildCacheClear{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFBuildCacheClearSpec{
+			AppRef: appRef,
+		},
+	}
+	if err := r.klient.Create(ctx, cfBuildCacheClear); err != nil {
+		return "", fmt.Errorf("failed to create build cache clear: %w", apierrors.FromK8sError(err, BuildCacheResourceType))
+	}
+
+	return cfBuildCacheClear.Name, nil
+}
@@ -0,0 +1,170 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuildCacheRepository", func() {
+	var (
+		buildCacheRepo *repositories.BuildCacheRepo
+		cfOrg          *korifiv1alpha1.CFOrg
+		cfSpace        *korifiv1alpha1.CFSpace
+		cfApp          *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		buildCacheRepo = repositories.NewBuildCacheRepo(klient, rootNamespace)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+	})
+
+	Describe("ClearAppBuildCache", func() {
+		var (
+			requestGUID string
+			clearErr    error
+		)
+
+		JustBeforeEach(func() {
+			requestGUID, clearErr = buildCacheRepo.ClearAppBuildCache(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(clearErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("creates a build cache clear for the app", func() {
+				Expect(clearErr).NotTo(HaveOccurred())
+				Expect(requestGUID).NotTo(BeEmpty())
+
+				cfBuildCacheClear := &korifiv1alpha1.CFBuildCacheClear{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: requestGUID}, cfBuildCacheClear)).To(Succeed())
+				Expect(cfBuildCacheClear.Spec.AppRef).NotTo(BeNil())
+				Expect(cfBuildCacheClear.Spec.AppRef.Name).To(Equal(cfApp.Name))
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				cfApp.Name = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(clearErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ClearAllBuildCaches", func() {
+		var (
+			requestGUID string
+			clearErr    error
+		)
+
+		JustBeforeEach(func() {
+			requestGUID, clearErr = buildCacheRepo.ClearAllBuildCaches(ctx, authInfo)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(clearErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(clearErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a build cache clear for all apps in the root namespace", func() {
+				Expect(clearErr).NotTo(HaveOccurred())
+				Expect(requestGUID).NotTo(BeEmpty())
+
+				cfBuildCacheClear := &korifiv1alpha1.CFBuildCacheClear{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: requestGUID}, cfBuildCacheClear)).To(Succeed())
+				Expect(cfBuildCacheClear.Spec.AppRef).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			cfBuildCacheClear *korifiv1alpha1.CFBuildCacheClear
+			requestGUID       string
+			state             repositories.ResourceState
+			stateErr          error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+
+			cfBuildCacheClear = &korifiv1alpha1.CFBuildCacheClear{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      prefixedGUID("clear"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfBuildCacheClear)).To(Succeed())
+			requestGUID = cfBuildCacheClear.Name
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = buildCacheRepo.GetState(ctx, authInfo, requestGUID)
+		})
+
+		It("returns unknown", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(repositories.ResourceStateUnknown))
+		})
+
+		When("the build cache clear is ready", func() {
+			BeforeEach(func() {
+				original := cfBuildCacheClear.DeepCopy()
+				meta.SetStatusCondition(&cfBuildCacheClear.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.StatusConditionReady,
+					Status: metav1.ConditionTrue,
+					Reason: "Ready",
+				})
+				Expect(k8sClient.Status().Patch(ctx, cfBuildCacheClear, client.MergeFrom(original))).To(Succeed())
+			})
+
+			It("returns ready", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceStateReady))
+			})
+		})
+
+		When("the build cache clear does not exist", func() {
+			BeforeEach(func() {
+				requestGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(stateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -129,7 +129,7 @@ func (b *BuildRepo) cfBuildToBuildRecord(cfBuild korifiv1alpha1.CFBuild) BuildRe
 		Annotations: cfBuild.Annotations,
 	}
 
-	if cfBuild.Spec.Lifecycle.Type == "docker" {
+	if cfBuild.Spec.Lifecycle.Type == "docker" || cfBuild.Spec.Lifecycle.Type == "dockerfile" {
 		toReturn.Lifecycle.Data = LifecycleData{}
 	}
 
@@ -175,6 +175,40 @@ func (b *BuildRepo) ListBuilds(ctx context.Context, authInfo authorization.Info,
 	return b.sorter.Sort(slices.Collect(it.Map(filteredBuilds, b.cfBuildToBuildRecord)), message.OrderBy), nil
 }
 
+type UpdateBuildMessage struct {
+	GUID          string
+	State         string
+	MetadataPatch MetadataPatch
+}
+
+func (b *BuildRepo) UpdateBuild(ctx context.Context, authInfo authorization.Info, message UpdateBuildMessage) (BuildRecord, error) {
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	if err := b.klient.Get(ctx, build); err != nil {
+		return BuildRecord{}, fmt.Errorf("failed to get build: %w", apierrors.FromK8sError(err, BuildResourceType))
+	}
+
+	if message.State != "" && b.cfBuildToBuildRecord(*build).State != BuildStateStaging {
+		return BuildRecord{}, apierrors.NewUnprocessableEntityError(nil, "Only builds in the STAGING state can be cancelled")
+	}
+
+	err := b.klient.Patch(ctx, build, func() error {
+		message.MetadataPatch.Apply(build)
+		if message.State != "" {
+			build.Spec.Cancelled = true
+		}
+		return nil
+	})
+	if err != nil {
+		return BuildRecord{}, fmt.Errorf("failed to update build: %w", apierrors.FromK8sError(err, BuildResourceType))
+	}
+
+	return b.cfBuildToBuildRecord(*build), nil
+}
+
 type CreateBuildMessage struct {
 	AppGUID         string
 	PackageGUID     string
@@ -13,12 +13,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 var _ = Describe("BuildRepository", func() {
@@ -512,6 +514,127 @@ var _ = Describe("BuildRepository", func() {
 		})
 	})
 
+	Describe("UpdateBuild", func() {
+		var (
+			spaceGUID      string
+			cfBuild        *korifiv1alpha1.CFBuild
+			updateMessage  repositories.UpdateBuildMessage
+			updatedBuild   repositories.BuildRecord
+			updateBuildErr error
+		)
+
+		BeforeEach(func() {
+			spaceGUID = uuid.NewString()
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: spaceGUID}})).To(Succeed())
+
+			cfBuild = &korifiv1alpha1.CFBuild{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: spaceGUID,
+					Labels: map[string]string{
+						korifiv1alpha1.SpaceGUIDKey: spaceGUID,
+					},
+				},
+				Spec: korifiv1alpha1.CFBuildSpec{
+					PackageRef: corev1.LocalObjectReference{Name: "package-guid"},
+					AppRef:     corev1.LocalObjectReference{Name: "app-guid"},
+					Lifecycle:  korifiv1alpha1.Lifecycle{Type: "buildpack"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfBuild)).To(Succeed())
+
+			updateMessage = repositories.UpdateBuildMessage{
+				GUID:  cfBuild.Name,
+				State: "FAILED",
+				MetadataPatch: repositories.MetadataPatch{
+					Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+					Annotations: map[string]*string{"baz": tools.PtrTo("qux")},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			updatedBuild, updateBuildErr = buildRepo.UpdateBuild(ctx, authInfo, updateMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateBuildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, spaceGUID)
+			})
+
+			It("cancels the build", func() {
+				Expect(updateBuildErr).NotTo(HaveOccurred())
+				Expect(updatedBuild.GUID).To(Equal(cfBuild.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Spec.Cancelled).To(BeTrue())
+			})
+
+			It("updates the build metadata", func() {
+				Expect(updateBuildErr).NotTo(HaveOccurred())
+				Expect(updatedBuild.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(updatedBuild.Annotations).To(HaveKeyWithValue("baz", "qux"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(cfBuild.Annotations).To(HaveKeyWithValue("baz", "qux"))
+			})
+
+			When("the state is not set", func() {
+				BeforeEach(func() {
+					updateMessage.State = ""
+				})
+
+				It("does not cancel the build", func() {
+					Expect(updateBuildErr).NotTo(HaveOccurred())
+					Expect(updatedBuild.State).To(Equal("STAGING"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					Expect(cfBuild.Spec.Cancelled).To(BeFalse())
+				})
+
+				It("updates the build metadata", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					Expect(cfBuild.Labels).To(HaveKeyWithValue("foo", "bar"))
+				})
+			})
+
+			When("the build is not staging", func() {
+				BeforeEach(func() {
+					meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+						Type:   korifiv1alpha1.StagingConditionType,
+						Status: metav1.ConditionFalse,
+						Reason: "BuildNotRunning",
+					})
+					meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+						Type:   korifiv1alpha1.SucceededConditionType,
+						Status: metav1.ConditionTrue,
+						Reason: "BuildSucceeded",
+					})
+					Expect(k8sClient.Status().Update(ctx, cfBuild)).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateBuildErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the build does not exist", func() {
+			BeforeEach(func() {
+				updateMessage.GUID = "i-dont-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(updateBuildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("ListBuilds", func() {
 		var (
 			app1GUID     string
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
@@ -12,29 +15,46 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
 	BuildpackResourceType = "Buildpack"
+
+	BuildpackStateAwaitingUpload = "AWAITING_UPLOAD"
+	BuildpackStateReady          = "READY"
 )
 
 type BuildpackRepository struct {
-	builderName   string
-	klient        Klient
-	rootNamespace string
-	sorter        BuildpackSorter
+	builderName       string
+	klient            Klient
+	rootNamespace     string
+	sorter            BuildpackSorter
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
 }
 
 type BuildpackRecord struct {
-	Name      string
-	Position  int
-	Stack     string
-	Version   string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	// Empty for buildpacks of the builder that are not managed via the API
+	GUID     string
+	Name     string
+	Position int
+	Stack    string
+	Version  string
+	Filename string
+	State    string
+	Enabled  bool
+	Locked   bool
+	// The repository uploaded buildpack images are pushed to
+	RepositoryRef string
+	Labels        map[string]string
+	Annotations   map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
 }
 
 //counterfeiter:generate -o fake -fake-name BuildpackSorter . BuildpackSorter
@@ -80,21 +100,372 @@ type ListBuildpacksMessage struct {
 	OrderBy string
 }
 
+type CreateBuildpackMessage struct {
+	Name     string
+	Stack    string
+	Position int
+	Enabled  bool
+	Locked   bool
+	Metadata Metadata
+}
+
+type UpdateBuildpackMessage struct {
+	GUID          string
+	Name          *string
+	Stack         *string
+	Position      *int
+	Enabled       *bool
+	Locked        *bool
+	MetadataPatch MetadataPatch
+}
+
+func (m UpdateBuildpackMessage) apply(buildpack *korifiv1alpha1.CFBuildpack) {
+	if m.Name != nil {
+		buildpack.Spec.DisplayName = *m.Name
+	}
+
+	if m.Stack != nil {
+		buildpack.Spec.Stack = *m.Stack
+	}
+
+	if m.Position != nil {
+		buildpack.Spec.Position = *m.Position
+	}
+
+	if m.Enabled != nil {
+		buildpack.Spec.Enabled = *m.Enabled
+	}
+
+	if m.Locked != nil {
+		buildpack.Spec.Locked = *m.Locked
+	}
+
+	m.MetadataPatch.Apply(buildpack)
+}
+
+type UpdateBuildpackSourceMessage struct {
+	GUID     string
+	Image    string
+	Filename string
+}
+
 func NewBuildpackRepository(
 	klient Klient,
 	builderName string,
 	rootNamespace string,
 	sorter BuildpackSorter,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *BuildpackRepository {
 	return &BuildpackRepository{
-		klient:        klient,
-		builderName:   builderName,
-		rootNamespace: rootNamespace,
-		sorter:        sorter,
+		klient:            klient,
+		builderName:       builderName,
+		rootNamespace:     rootNamespace,
+		sorter:            sorter,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
 	}
 }
 
 func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo authorization.Info, message ListBuildpacksMessage) ([]BuildpackRecord, error) {
+	builderBuildpacks, err := r.listBuilderBuildpacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfBuildpacks, err := r.listCFBuildpacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sorter.Sort(r.mergeBuildpacks(builderBuildpacks, cfBuildpacks), message.OrderBy), nil
+}
+
+func (r *BuildpackRepository) GetBuildpack(ctx context.Context, authInfo authorization.Info, guid string) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to get buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.toPositionedRecord(ctx, guid)
+}
+
+func (r *BuildpackRepository) CreateBuildpack(ctx context.Context, authInfo authorization.Info, message CreateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: message.Name,
+			Stack:       message.Stack,
+			Position:    message.Position,
+			Enabled:     message.Enabled,
+			Locked:      message.Locked,
+		},
+	}
+	if err := r.klient.Create(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to create buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	if err := r.repositionOthers(ctx, cfBuildpack.Name, 0, message.Position); err != nil {
+		return BuildpackRecord{}, err
+	}
+
+	if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(cfBuildpack.Name)); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to create buildpack repository: %w", err)
+	}
+
+	return r.toPositionedRecord(ctx, cfBuildpack.Name)
+}
+
+func (r *BuildpackRepository) UpdateBuildpack(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to get buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+	oldPosition := cfBuildpack.Spec.Position
+
+	err := r.klient.Patch(ctx, cfBuildpack, func() error {
+		message.apply(cfBuildpack)
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to patch buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	if cfBuildpack.Spec.Position != oldPosition {
+		if err = r.repositionOthers(ctx, cfBuildpack.Name, oldPosition, cfBuildpack.Spec.Position); err != nil {
+			return BuildpackRecord{}, errors.Join(err, r.setPositions(ctx, map[string]int{cfBuildpack.Name: oldPosition}))
+		}
+	}
+
+	return r.toPositionedRecord(ctx, cfBuildpack.Name)
+}
+
+func (r *BuildpackRepository) UpdateBuildpackSource(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackSourceMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfBuildpack, func() error {
+		cfBuildpack.Spec.Image = message.Image
+		cfBuildpack.Spec.Filename = message.Filename
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to update buildpack source: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.toPositionedRecord(ctx, cfBuildpack.Name)
+}
+
+func (r *BuildpackRepository) DeleteBuildpack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+		return fmt.Errorf("failed to get buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	if err := r.klient.Delete(ctx, cfBuildpack); err != nil {
+		return fmt.Errorf("failed to delete buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.repositionOthers(ctx, guid, cfBuildpack.Spec.Position, 0)
+}
+
+func (r *BuildpackRepository) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	_, err := r.GetBuildpack(ctx, authInfo, guid)
+	return nil, err
+}
+
+func (r *BuildpackRepository) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	buildpack, err := r.GetBuildpack(ctx, authInfo, guid)
+	if err != nil {
+		return ResourceStateUnknown, err
+	}
+
+	if buildpack.State == BuildpackStateReady {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+// repositionOthers moves the positions of the other buildpacks as if the
+// buildpack was taken out of oldPosition and put at newPosition. Zero
+// positions stand for buildpacks being created or deleted. All the new
+// positions are computed before any buildpack is patched. If a patch fails,
+// the buildpacks are moved back to their original positions, so that the
+// request can be retried.
+func (r *BuildpackRepository) repositionOthers(ctx context.Context, guid string, oldPosition, newPosition int) error {
+	cfBuildpacks, err := r.listCFBuildpacks(ctx)
+	if err != nil {
+		return err
+	}
+
+	originalPositions := map[string]int{}
+	newPositions := map[string]int{}
+	for _, cfBuildpack := range cfBuildpacks {
+		if cfBuildpack.Name == guid {
+			continue
+		}
+
+		position := cfBuildpack.Spec.Position
+		if oldPosition > 0 && position > oldPosition {
+			position--
+		}
+		if newPosition > 0 && position >= newPosition {
+			position++
+		}
+
+		if position != cfBuildpack.Spec.Position {
+			originalPositions[cfBuildpack.Name] = cfBuildpack.Spec.Position
+			newPositions[cfBuildpack.Name] = position
+		}
+	}
+
+	if err = r.setPositions(ctx, newPositions); err != nil {
+		return errors.Join(err, r.setPositions(ctx, originalPositions))
+	}
+
+	return nil
+}
+
+// setPositions sets the positions of the given buildpacks. Positions are
+// absolute, so setting them again has no further effect. Buildpacks that
+// have been deleted in the meantime are skipped.
+func (r *BuildpackRepository) setPositions(ctx context.Context, positions map[string]int) error {
+	for _, guid := range slices.Sorted(maps.Keys(positions)) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			cfBuildpack := &korifiv1alpha1.CFBuildpack{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: r.rootNamespace,
+					Name:      guid,
+				},
+			}
+			if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+				return err
+			}
+
+			if cfBuildpack.Spec.Position == positions[guid] {
+				return nil
+			}
+
+			return r.klient.PatchWithOptimisticLock(ctx, cfBuildpack, func() error {
+				cfBuildpack.Spec.Position = positions[guid]
+				return nil
+			})
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to reposition buildpack %q: %w", guid, apierrors.FromK8sError(err, BuildpackResourceType))
+		}
+	}
+
+	return nil
+}
+
+// toPositionedRecord returns the record of the buildpack with its position
+// among all buildpacks. Buildpacks of the builder are left out of the
+// position if the BuilderInfo is not available.
+func (r *BuildpackRepository) toPositionedRecord(ctx context.Context, guid string) (BuildpackRecord, error) {
+	builderBuildpacks, err := r.listBuilderBuildpacks(ctx)
+	if err != nil {
+		builderBuildpacks = []BuildpackRecord{}
+	}
+
+	cfBuildpacks, err := r.listCFBuildpacks(ctx)
+	if err != nil {
+		return BuildpackRecord{}, err
+	}
+
+	for _, record := range r.mergeBuildpacks(builderBuildpacks, cfBuildpacks) {
+		if record.GUID == guid {
+			return record, nil
+		}
+	}
+
+	return BuildpackRecord{}, apierrors.NewNotFoundError(nil, BuildpackResourceType)
+}
+
+func (r *BuildpackRepository) mergeBuildpacks(builderBuildpacks []BuildpackRecord, cfBuildpacks []korifiv1alpha1.CFBuildpack) []BuildpackRecord {
+	cfBuildpackRecords := slices.Collect(it.Map(slices.Values(cfBuildpacks), r.cfBuildpackToBuildpackRecord))
+	records := tools.InsertAtPositions(builderBuildpacks, cfBuildpackRecords, func(b BuildpackRecord) int {
+		return b.Position
+	})
+
+	for i := range records {
+		records[i].Position = i + 1
+	}
+
+	return records
+}
+
+// listCFBuildpacks returns the buildpacks managed via the API sorted by
+// position, older buildpacks go first when positions are equal
+func (r *BuildpackRepository) listCFBuildpacks(ctx context.Context) ([]korifiv1alpha1.CFBuildpack, error) {
+	cfBuildpackList := &korifiv1alpha1.CFBuildpackList{}
+	if err := r.klient.List(ctx, cfBuildpackList, InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list buildpacks: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	slices.SortStableFunc(cfBuildpackList.Items, func(a, b korifiv1alpha1.CFBuildpack) int {
+		return cmp.Or(
+			cmp.Compare(a.Spec.Position, b.Spec.Position),
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+		)
+	})
+
+	return cfBuildpackList.Items, nil
+}
+
+func (r *BuildpackRepository) cfBuildpackToBuildpackRecord(cfBuildpack korifiv1alpha1.CFBuildpack) BuildpackRecord {
+	state := BuildpackStateAwaitingUpload
+	if cfBuildpack.Spec.Image != "" {
+		state = BuildpackStateReady
+	}
+
+	return BuildpackRecord{
+		GUID:          cfBuildpack.Name,
+		Name:          cfBuildpack.Spec.DisplayName,
+		Position:      cfBuildpack.Spec.Position,
+		Stack:         cfBuildpack.Spec.Stack,
+		Filename:      cfBuildpack.Spec.Filename,
+		State:         state,
+		Enabled:       cfBuildpack.Spec.Enabled,
+		Locked:        cfBuildpack.Spec.Locked,
+		RepositoryRef: r.repositoryRef(cfBuildpack.Name),
+		Labels:        cfBuildpack.Labels,
+		Annotations:   cfBuildpack.Annotations,
+		CreatedAt:     cfBuildpack.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(&cfBuildpack),
+	}
+}
+
+func (r *BuildpackRepository) repositoryRef(guid string) string {
+	return r.repositoryPrefix + guid + "-buildpack"
+}
+
+func (r *BuildpackRepository) listBuilderBuildpacks(ctx context.Context) ([]BuildpackRecord, error) {
 	builderInfo := &korifiv1alpha1.BuilderInfo{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.rootNamespace,
@@ -104,7 +475,7 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 
 	err := r.klient.Get(ctx, builderInfo)
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", r.builderName, r.rootNamespace))
 		}
 
@@ -126,7 +497,7 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
 	}
 
-	return r.sorter.Sort(builderInfoToBuildpackRecords(*builderInfo), message.OrderBy), nil
+	return builderInfoToBuildpackRecords(*builderInfo), nil
 }
 
 func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackRecord {
@@ -136,6 +507,9 @@ func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackR
 			Version:   b.Version,
 			Position:  i + 1,
 			Stack:     b.Stack,
+			Filename:  b.Name + "@" + b.Version,
+			State:     BuildpackStateReady,
+			Enabled:   true,
 			CreatedAt: b.CreationTimestamp.Time,
 			UpdatedAt: &b.UpdatedTimestamp.Time,
 		}
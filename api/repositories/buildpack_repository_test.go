@@ -2,27 +2,33 @@ package repositories_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	gomega_types "github.com/onsi/gomega/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("BuildpackRepository", func() {
 	var (
 		buildpackRepo *BuildpackRepository
 		sorter        *fake.BuildpackSorter
+		repoCreator   *fake.RepositoryCreator
 	)
 
 	BeforeEach(func() {
@@ -30,8 +36,9 @@ var _ = Describe("BuildpackRepository", func() {
 		sorter.SortStub = func(records []BuildpackRecord, _ string) []BuildpackRecord {
 			return records
 		}
+		repoCreator = new(fake.RepositoryCreator)
 
-		buildpackRepo = NewBuildpackRepository(klientUnfiltered, builderName, rootNamespace, sorter)
+		buildpackRepo = NewBuildpackRepository(klientUnfiltered, builderName, rootNamespace, sorter, repoCreator, "my/prefix-")
 	})
 
 	Describe("ListBuildpacks", func() {
@@ -97,6 +104,52 @@ var _ = Describe("BuildpackRepository", func() {
 			})
 		})
 
+		When("there are buildpacks managed via the API", func() {
+			var buildpacks []BuildpackRecord
+
+			BeforeEach(func() {
+				createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", []buildpackInfo{
+					{name: "paketo-buildpacks/buildpack-1-1", version: "1.1"},
+					{name: "paketo-buildpacks/buildpack-2-1", version: "2.1"},
+				})
+				createCFBuildpack(ctx, "second", 2, "")
+				createCFBuildpack(ctx, "last", 10, "my/buildpack@sha256:abc")
+
+				var err error
+				buildpacks, err = buildpackRepo.ListBuildpacks(ctx, authInfo, message)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("places them at their positions among the builder buildpacks", func() {
+				Expect(buildpacks).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":     BeEmpty(),
+						"Name":     Equal("paketo-buildpacks/buildpack-1-1"),
+						"Position": Equal(1),
+						"Filename": Equal("paketo-buildpacks/buildpack-1-1@1.1"),
+						"State":    Equal(BuildpackStateReady),
+						"Enabled":  BeTrue(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":          Not(BeEmpty()),
+						"Name":          Equal("second"),
+						"Position":      Equal(2),
+						"State":         Equal(BuildpackStateAwaitingUpload),
+						"RepositoryRef": HavePrefix("my/prefix-"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Name":     Equal("paketo-buildpacks/buildpack-2-1"),
+						"Position": Equal(3),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Name":     Equal("last"),
+						"Position": Equal(4),
+						"State":    Equal(BuildpackStateReady),
+					}),
+				))
+			})
+		})
+
 		When("no build reconcilers exist", func() {
 			It("errors", func() {
 				_, err := buildpackRepo.ListBuildpacks(ctx, authInfo, message)
@@ -168,6 +221,269 @@ var _ = Describe("BuildpackRepository", func() {
 	})
 })
 
+var _ = Describe("BuildpackRepository CRUD", func() {
+	var (
+		buildpackRepo *BuildpackRepository
+		repoCreator   *fake.RepositoryCreator
+	)
+
+	BeforeEach(func() {
+		repoCreator = new(fake.RepositoryCreator)
+		buildpackRepo = NewBuildpackRepository(klientUnfiltered, builderName, rootNamespace, NewBuildpackSorter(), repoCreator, "my/prefix-")
+	})
+
+	positionsOf := func() map[string]int {
+		cfBuildpacks := &korifiv1alpha1.CFBuildpackList{}
+		Expect(k8sClient.List(ctx, cfBuildpacks, client.InNamespace(rootNamespace))).To(Succeed())
+
+		positions := map[string]int{}
+		for _, b := range cfBuildpacks.Items {
+			positions[b.Spec.DisplayName] = b.Spec.Position
+		}
+		return positions
+	}
+
+	Describe("CreateBuildpack", func() {
+		var (
+			record    BuildpackRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			createCFBuildpack(ctx, "first", 1, "")
+			createCFBuildpack(ctx, "second", 2, "")
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = buildpackRepo.CreateBuildpack(ctx, authInfo, CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "my-stack",
+				Position: 2,
+				Enabled:  true,
+				Locked:   true,
+				Metadata: Metadata{Labels: map[string]string{"foo": "bar"}},
+			})
+		})
+
+		It("fails without the admin role", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the buildpack", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("my-buildpack"))
+				Expect(record.Stack).To(Equal("my-stack"))
+				Expect(record.Position).To(Equal(2))
+				Expect(record.Enabled).To(BeTrue())
+				Expect(record.Locked).To(BeTrue())
+				Expect(record.State).To(Equal(BuildpackStateAwaitingUpload))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfBuildpack := &korifiv1alpha1.CFBuildpack{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, cfBuildpack)).To(Succeed())
+				Expect(cfBuildpack.Spec.DisplayName).To(Equal("my-buildpack"))
+			})
+
+			It("moves the buildpacks at and after its position down", func() {
+				Expect(positionsOf()).To(Equal(map[string]int{
+					"first":        1,
+					"my-buildpack": 2,
+					"second":       3,
+				}))
+			})
+
+			It("creates the image repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("my/prefix-" + record.GUID + "-buildpack"))
+				Expect(record.RepositoryRef).To(Equal(repoName))
+			})
+		})
+	})
+
+	Describe("GetBuildpack", func() {
+		var guid string
+
+		BeforeEach(func() {
+			guid = createCFBuildpack(ctx, "my-buildpack", 1, "").Name
+		})
+
+		It("returns the buildpack", func() {
+			record, err := buildpackRepo.GetBuildpack(ctx, authInfo, guid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(guid))
+			Expect(record.Name).To(Equal("my-buildpack"))
+		})
+
+		When("the buildpack does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := buildpackRepo.GetBuildpack(ctx, authInfo, "i-do-not-exist")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateBuildpack", func() {
+		var (
+			guid      string
+			record    BuildpackRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createCFBuildpack(ctx, "first", 1, "")
+			guid = createCFBuildpack(ctx, "second", 2, "").Name
+			createCFBuildpack(ctx, "third", 3, "")
+			createCFBuildpack(ctx, "fourth", 4, "")
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = buildpackRepo.UpdateBuildpack(ctx, authInfo, UpdateBuildpackMessage{
+				GUID:     guid,
+				Name:     tools.PtrTo("updated"),
+				Position: tools.PtrTo(4),
+				Enabled:  tools.PtrTo(false),
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+		})
+
+		It("updates the buildpack", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("updated"))
+			Expect(record.Position).To(Equal(4))
+			Expect(record.Enabled).To(BeFalse())
+			Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+		})
+
+		It("moves the other buildpacks to make room", func() {
+			Expect(positionsOf()).To(Equal(map[string]int{
+				"first":   1,
+				"third":   2,
+				"fourth":  3,
+				"updated": 4,
+			}))
+		})
+
+		When("moving another buildpack fails", func() {
+			BeforeEach(func() {
+				failingKlient := new(fake.Klient)
+				failingKlient.GetStub = klientUnfiltered.Get
+				failingKlient.ListStub = klientUnfiltered.List
+				failingKlient.PatchStub = klientUnfiltered.Patch
+				failingKlient.PatchWithOptimisticLockStub = func(ctx context.Context, obj client.Object, modify func() error) error {
+					if obj.(*korifiv1alpha1.CFBuildpack).Spec.DisplayName == "fourth" {
+						return errors.New("patch-err")
+					}
+					return klientUnfiltered.PatchWithOptimisticLock(ctx, obj, modify)
+				}
+				buildpackRepo = NewBuildpackRepository(failingKlient, builderName, rootNamespace, NewBuildpackSorter(), repoCreator, "my/prefix-")
+			})
+
+			It("returns the error", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("patch-err")))
+			})
+
+			It("moves all buildpacks back to their original positions", func() {
+				Expect(positionsOf()).To(Equal(map[string]int{
+					"first":   1,
+					"updated": 2,
+					"third":   3,
+					"fourth":  4,
+				}))
+			})
+		})
+	})
+
+	Describe("UpdateBuildpackSource", func() {
+		var guid string
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			guid = createCFBuildpack(ctx, "my-buildpack", 1, "").Name
+		})
+
+		It("sets the buildpack image", func() {
+			record, err := buildpackRepo.UpdateBuildpackSource(ctx, authInfo, UpdateBuildpackSourceMessage{
+				GUID:     guid,
+				Image:    "my/buildpack@sha256:abc",
+				Filename: "my-buildpack.cnb",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.State).To(Equal(BuildpackStateReady))
+			Expect(record.Filename).To(Equal("my-buildpack.cnb"))
+
+			state, err := buildpackRepo.GetState(ctx, authInfo, guid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal(ResourceStateReady))
+		})
+	})
+
+	Describe("DeleteBuildpack", func() {
+		var (
+			guid      string
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			createCFBuildpack(ctx, "first", 1, "")
+			guid = createCFBuildpack(ctx, "second", 2, "").Name
+			createCFBuildpack(ctx, "third", 3, "")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = buildpackRepo.DeleteBuildpack(ctx, authInfo, guid)
+		})
+
+		It("fails without the admin role", func() {
+			Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the buildpack and closes the gap", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(positionsOf()).To(Equal(map[string]int{
+					"first": 1,
+					"third": 2,
+				}))
+
+				_, err := buildpackRepo.GetDeletedAt(ctx, authInfo, guid)
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
+
+func createCFBuildpack(ctx context.Context, name string, position int, image string) *korifiv1alpha1.CFBuildpack {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: name,
+			Position:    position,
+			Enabled:     true,
+			Image:       image,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfBuildpack)).To(Succeed())
+
+	return cfBuildpack
+}
+
 type buildpackInfo struct {
 	name    string
 	version string
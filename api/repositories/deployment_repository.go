@@ -16,6 +16,7 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -23,8 +24,9 @@ import (
 const DeploymentResourceType = "Deployment"
 
 type DeploymentRepo struct {
-	klient Klient
-	sorter DeploymentSorter
+	klient              Klient
+	sorter              DeploymentSorter
+	dropletImageChecker DropletImageChecker
 }
 
 type DeploymentRecord struct {
@@ -46,6 +48,11 @@ type DeploymentSorter interface {
 	Sort(records []DeploymentRecord, order string) []DeploymentRecord
 }
 
+//counterfeiter:generate -o fake -fake-name DropletImageChecker . DropletImageChecker
+type DropletImageChecker interface {
+	DropletImageExists(ctx context.Context, imageRef string) (bool, error)
+}
+
 type deploymentSorter struct {
 	sorter *compare.Sorter[DeploymentRecord]
 }
@@ -92,8 +99,9 @@ type DeploymentStatus struct {
 }
 
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID      string
+	DropletGUID  string
+	RevisionGUID string
 }
 
 type ListDeploymentsMessage struct {
@@ -115,10 +123,12 @@ func (m ListDeploymentsMessage) matchesStatusValue(deployment DeploymentRecord)
 func NewDeploymentRepo(
 	klient Klient,
 	sorter DeploymentSorter,
+	dropletImageChecker DropletImageChecker,
 ) *DeploymentRepo {
 	return &DeploymentRepo{
-		klient: klient,
-		sorter: sorter,
+		klient:              klient,
+		sorter:              sorter,
+		dropletImageChecker: dropletImageChecker,
 	}
 }
 
@@ -156,6 +166,15 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		dropletGUID = message.DropletGUID
 	}
 
+	var revision *korifiv1alpha1.CFAppRevision
+	if message.RevisionGUID != "" {
+		revision, err = r.getRollbackRevision(ctx, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+		dropletGUID = revision.Spec.DropletRef.Name
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	newRev, err := bumpAppRev(appRev)
 	if err != nil {
@@ -168,6 +187,9 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 			app.Annotations = map[string]string{}
 		}
 		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+		if revision != nil {
+			app.Annotations[korifiv1alpha1.CFAppRollbackVersionKey] = strconv.Itoa(revision.Spec.Version)
+		}
 		app.Spec.DesiredState = korifiv1alpha1.StartedState
 
 		return nil
@@ -190,6 +212,57 @@ func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authoriza
 	return r.sorter.Sort(slices.Collect(deploymentRecords), message.OrderBy), nil
 }
 
+// getRollbackRevision returns the revision the app is rolled back to, making
+// sure that its droplet and the droplet image still exist. Registry retention
+// policies may delete old droplet images, so the rollback is rejected when the
+// image cannot be found or checked. The environment variables and process
+// commands of the revision are restored by the app controller when it sees
+// the rollback annotation, so that the rollback is a single update of the app.
+func (r *DeploymentRepo) getRollbackRevision(ctx context.Context, app *korifiv1alpha1.CFApp, revisionGUID string) (*korifiv1alpha1.CFAppRevision, error) {
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      revisionGUID,
+		},
+	}
+	err := r.klient.Get(ctx, revision)
+	if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) || (err == nil && revision.Spec.AppRef.Name != app.Name) {
+		return nil, apierrors.NewUnprocessableEntityError(err, "The revision does not exist")
+	}
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      revision.Spec.DropletRef.Name,
+		},
+	}
+	err = r.klient.Get(ctx, build)
+	if k8serrors.IsNotFound(err) {
+		return nil, apierrors.NewUnprocessableEntityError(err, "Unable to deploy this revision, the droplet for this revision no longer exists.")
+	}
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	// docker droplets refer to user images, which are not managed by Korifi
+	if build.Status.Droplet == nil || build.Spec.Lifecycle.Type == korifiv1alpha1.DockerLifecycle {
+		return revision, nil
+	}
+
+	exists, err := r.dropletImageChecker.DropletImageExists(ctx, build.Status.Droplet.Registry.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the droplet image of revision %q: %w", revision.Name, err)
+	}
+	if !exists {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Unable to deploy this revision, the droplet image for this revision no longer exists.")
+	}
+
+	return revision, nil
+}
+
 func bumpAppRev(appRev string) (string, error) {
 	r, err := strconv.Atoi(appRev)
 	if err != nil {
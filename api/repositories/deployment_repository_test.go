@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	"errors"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +31,7 @@ var _ = Describe("DeploymentRepository", func() {
 		cfSpace        *korifiv1alpha1.CFSpace
 		cfApp          *korifiv1alpha1.CFApp
 		sorter         *fake.DeploymentSorter
+		imageChecker   *fake.DropletImageChecker
 	)
 
 	BeforeEach(func() {
@@ -53,7 +56,10 @@ var _ = Describe("DeploymentRepository", func() {
 			},
 		})).To(Succeed())
 
-		deploymentRepo = repositories.NewDeploymentRepo(klient, sorter)
+		imageChecker = new(fake.DropletImageChecker)
+		imageChecker.DropletImageExistsReturns(true, nil)
+
+		deploymentRepo = repositories.NewDeploymentRepo(klient, sorter, imageChecker)
 	})
 
 	Describe("GetDeployment", func() {
@@ -222,6 +228,167 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			When("revision guid is set on the create message", func() {
+				var (
+					revision   *korifiv1alpha1.CFAppRevision
+					oldDroplet *korifiv1alpha1.CFBuild
+					appSecret  *corev1.Secret
+					webProcess *korifiv1alpha1.CFProcess
+				)
+
+				BeforeEach(func() {
+					appSecret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						Data: map[string][]byte{"FOO": []byte("new")},
+					}
+					Expect(k8sClient.Create(ctx, appSecret)).To(Succeed())
+
+					revisionSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Data: map[string][]byte{"FOO": []byte("old")},
+					}
+					Expect(k8sClient.Create(ctx, revisionSecret)).To(Succeed())
+
+					webProcess = &korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+							ProcessType: "web",
+							Command:     "new-command",
+						},
+					}
+					Expect(k8sClient.Create(ctx, webProcess)).To(Succeed())
+
+					oldDroplet = createBuild(ctx, k8sClient, cfSpace.Name, uuid.NewString(), uuid.NewString(), cfApp.Name)
+					Expect(k8s.Patch(ctx, k8sClient, oldDroplet, func() {
+						oldDroplet.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+							Registry: korifiv1alpha1.Registry{Image: "my-image-registry.com/old-droplet"},
+						}
+					})).To(Succeed())
+
+					revision = &korifiv1alpha1.CFAppRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFAppRevisionSpec{
+							AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:       3,
+							DropletRef:    corev1.LocalObjectReference{Name: oldDroplet.Name},
+							EnvSecretName: revisionSecret.Name,
+							Processes: []korifiv1alpha1.CFAppRevisionProcess{
+								{Type: "web", Command: "old-command"},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+					createDeploymentMessage.RevisionGUID = revision.Name
+				})
+
+				It("rolls the app back to the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal(revision.Spec.DropletRef.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(revision.Spec.DropletRef.Name))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRollbackVersionKey, "3"))
+				})
+
+				It("checks that the droplet image still exists", func() {
+					Expect(imageChecker.DropletImageExistsCallCount()).To(Equal(1))
+					_, imageRef := imageChecker.DropletImageExistsArgsForCall(0)
+					Expect(imageRef).To(Equal("my-image-registry.com/old-droplet"))
+				})
+
+				It("leaves restoring the env vars and process commands to the app controller", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appSecret), appSecret)).To(Succeed())
+					Expect(appSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("new")}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(webProcess), webProcess)).To(Succeed())
+					Expect(webProcess.Spec.Command).To(Equal("new-command"))
+				})
+
+				When("the revision belongs to another app", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, revision, func() {
+							revision.Spec.AppRef.Name = "another-app"
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr).To(MatchError(ContainSubstring("The revision does not exist")))
+					})
+				})
+
+				When("the revision droplet no longer exists", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, revision, func() {
+							revision.Spec.DropletRef.Name = "deleted-droplet"
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr).To(MatchError(ContainSubstring("the droplet for this revision no longer exists")))
+					})
+				})
+
+				When("the droplet image no longer exists", func() {
+					BeforeEach(func() {
+						imageChecker.DropletImageExistsReturns(false, nil)
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr).To(MatchError(ContainSubstring("the droplet image for this revision no longer exists")))
+					})
+
+					It("does not roll the app back", func() {
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.CurrentDropletRef.Name).NotTo(Equal(revision.Spec.DropletRef.Name))
+					})
+				})
+
+				When("checking the droplet image fails", func() {
+					BeforeEach(func() {
+						imageChecker.DropletImageExistsReturns(false, errors.New("check-image-err"))
+					})
+
+					It("returns an error", func() {
+						Expect(createErr).To(MatchError(ContainSubstring("check-image-err")))
+					})
+				})
+
+				When("the revision droplet is a docker droplet", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, oldDroplet, func() {
+							oldDroplet.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.DockerLifecycle}
+						})).To(Succeed())
+					})
+
+					It("does not check the droplet image", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(imageChecker.DropletImageExistsCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					createDeploymentMessage.AppGUID = "i-do-not-exist"
@@ -12,28 +12,42 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	DomainResourceType = "Domain"
 )
 
+// DomainRepo manages CFDomains. Shared domains live in the root namespace
+// and are available to every org. Private domains live in the namespace of
+// the org owning them and are only available to that org and to the orgs they
+// are shared with. As users of the orgs a domain is shared with have no roles
+// in the namespace of the owning org, private domains are read with the
+// privileged client and filtered by the orgs the user has a role in.
 type DomainRepo struct {
-	klient        Klient
-	rootNamespace string
+	klient           Klient
+	privilegedClient client.Reader
+	rootNamespace    string
+	nsPerms          *authorization.NamespacePermissions
 }
 
 func NewDomainRepo(
 	klient Klient,
+	privilegedClient client.Reader,
 	rootNamespace string,
+	nsPerms *authorization.NamespacePermissions,
 ) *DomainRepo {
 	return &DomainRepo{
-		klient:        klient,
-		rootNamespace: rootNamespace,
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		rootNamespace:    rootNamespace,
+		nsPerms:          nsPerms,
 	}
 }
 
@@ -43,18 +57,32 @@ type DomainRecord struct {
 	Labels      map[string]string
 	Annotations map[string]string
 	Namespace   string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DeletedAt   *time.Time
+	// The GUID of the org owning a private domain. Empty for shared domains
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	CreatedAt               time.Time
+	UpdatedAt               *time.Time
+	DeletedAt               *time.Time
 }
 
 func (r DomainRecord) GetResourceType() string {
 	return DomainResourceType
 }
 
+func (r DomainRecord) IsPrivate() bool {
+	return r.OrganizationGUID != ""
+}
+
+func (r DomainRecord) isAvailableInOrg(orgGUID string) bool {
+	return !r.IsPrivate() || r.OrganizationGUID == orgGUID || slices.Contains(r.SharedOrganizationGUIDs, orgGUID)
+}
+
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name string
+	// When set, a private domain owned by this org is created
+	OrganizationGUID        string
+	SharedOrganizationGUIDs []string
+	Metadata                Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -62,21 +90,64 @@ type UpdateDomainMessage struct {
 	MetadataPatch MetadataPatch
 }
 
+type ShareDomainMessage struct {
+	DomainGUID        string
+	OrganizationGUIDs []string
+}
+
+type UnshareDomainMessage struct {
+	DomainGUID       string
+	OrganizationGUID string
+}
+
 type ListDomainsMessage struct {
 	Names []string
+	// Filters by the orgs owning private domains
+	OrganizationGUIDs []string
+	// Only returns the domains (both shared and private) that can be used
+	// for routes in the given org
+	AvailableInOrgGUID string
 }
 
-func (m *ListDomainsMessage) toListOptions(rootNamespace string) []ListOption {
-	return []ListOption{
-		WithLabelIn(korifiv1alpha1.CFEncodedDomainNameLabelKey, tools.EncodeValuesToSha224(m.Names...)),
-		InNamespace(rootNamespace),
-	}
+func (m *ListDomainsMessage) matches(domain DomainRecord) bool {
+	return tools.EmptyOrContains(m.Names, domain.Name) &&
+		tools.EmptyOrContains(m.OrganizationGUIDs, domain.OrganizationGUID) &&
+		(m.AvailableInOrgGUID == "" || domain.isAvailableInOrg(m.AvailableInOrgGUID))
 }
 
 func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (DomainRecord, error) {
+	domainList := &korifiv1alpha1.CFDomainList{}
+	err := r.privilegedClient.List(ctx, domainList, client.MatchingFields{"metadata.name": domainGUID})
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	if len(domainList.Items) == 0 {
+		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.NewNotFoundError(nil, DomainResourceType))
+	}
+
+	domainRecord := r.cfDomainToDomainRecord(domainList.Items[0])
+	if !domainRecord.IsPrivate() {
+		return r.getSharedDomain(ctx, domainGUID)
+	}
+
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("get-domain failed to get authorized orgs: %w", err)
+	}
+
+	if !isVisible(domainRecord, authorizedOrgs) {
+		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.NewNotFoundError(nil, DomainResourceType))
+	}
+
+	return domainRecord, nil
+}
+
+func (r *DomainRepo) getSharedDomain(ctx context.Context, domainGUID string) (DomainRecord, error) {
 	domain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: domainGUID,
+			Namespace: r.rootNamespace,
+			Name:      domainGUID,
 		},
 	}
 	err := r.klient.Get(ctx, domain)
@@ -84,19 +155,20 @@ func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info,
 		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*domain), nil
+	return r.cfDomainToDomainRecord(*domain), nil
 }
 
 func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.Info, message CreateDomainMessage) (DomainRecord, error) {
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
-			Namespace:   r.rootNamespace,
+			Namespace:   tools.IfZero(message.OrganizationGUID, r.rootNamespace),
 			Labels:      message.Metadata.Labels,
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:                message.Name,
+			SharedOrganizations: message.SharedOrganizationGUIDs,
 		},
 	}
 
@@ -105,14 +177,13 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("create-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*cfDomain), nil
+	return r.cfDomainToDomainRecord(*cfDomain), nil
 }
 
 func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.Info, message UpdateDomainMessage) (DomainRecord, error) {
 	domain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      message.GUID,
-			Namespace: r.rootNamespace,
+			Name: message.GUID,
 		},
 	}
 
@@ -129,20 +200,61 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 		return DomainRecord{}, fmt.Errorf("failed to patch domain metadata: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
-	return cfDomainToDomainRecord(*domain), nil
+	return r.cfDomainToDomainRecord(*domain), nil
+}
+
+func (r *DomainRepo) ShareDomain(ctx context.Context, authInfo authorization.Info, message ShareDomainMessage) (DomainRecord, error) {
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.DomainGUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, domain, func() error {
+		domain.Spec.SharedOrganizations = tools.Uniq(append(domain.Spec.SharedOrganizations, message.OrganizationGUIDs...))
+		return nil
+	})
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to share domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return r.cfDomainToDomainRecord(*domain), nil
+}
+
+func (r *DomainRepo) UnshareDomain(ctx context.Context, authInfo authorization.Info, message UnshareDomainMessage) error {
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.DomainGUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, domain, func() error {
+		domain.Spec.SharedOrganizations = slices.DeleteFunc(domain.Spec.SharedOrganizations, func(orgGUID string) bool {
+			return orgGUID == message.OrganizationGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return nil
 }
 
 func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Info, message ListDomainsMessage) ([]DomainRecord, error) {
-	cfdomainList := &korifiv1alpha1.CFDomainList{}
-	err := r.klient.List(ctx, cfdomainList, message.toListOptions(r.rootNamespace)...)
+	sharedDomains, err := r.listSharedDomains(ctx, message)
 	if err != nil {
-		if k8serrors.IsForbidden(err) {
-			return []DomainRecord{}, nil
-		}
-		return []DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
+		return []DomainRecord{}, err
 	}
 
-	domainRecords := slices.Collect(it.Map(slices.Values(cfdomainList.Items), cfDomainToDomainRecord))
+	privateDomains, err := r.listPrivateDomains(ctx, authInfo)
+	if err != nil {
+		return []DomainRecord{}, err
+	}
+
+	domainRecords := itx.From(it.Map(slices.Values(append(sharedDomains, privateDomains...)), r.cfDomainToDomainRecord)).
+		Filter(message.matches).
+		Collect()
 	sort.Slice(domainRecords, func(i, j int) bool {
 		return domainRecords[i].CreatedAt.Before(domainRecords[j].CreatedAt)
 	})
@@ -150,15 +262,56 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 	return domainRecords, nil
 }
 
+func (r *DomainRepo) listSharedDomains(ctx context.Context, message ListDomainsMessage) ([]korifiv1alpha1.CFDomain, error) {
+	cfdomainList := &korifiv1alpha1.CFDomainList{}
+	err := r.klient.List(ctx, cfdomainList,
+		InNamespace(r.rootNamespace),
+		WithLabelIn(korifiv1alpha1.CFEncodedDomainNameLabelKey, tools.EncodeValuesToSha224(message.Names...)),
+	)
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []korifiv1alpha1.CFDomain{}, nil
+		}
+		return nil, fmt.Errorf("failed to list domains: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return cfdomainList.Items, nil
+}
+
+func (r *DomainRepo) listPrivateDomains(ctx context.Context, authInfo authorization.Info) ([]korifiv1alpha1.CFDomain, error) {
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorized orgs: %w", err)
+	}
+
+	if len(authorizedOrgs) == 0 {
+		return []korifiv1alpha1.CFDomain{}, nil
+	}
+
+	cfdomainList := &korifiv1alpha1.CFDomainList{}
+	err = r.privilegedClient.List(ctx, cfdomainList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return slices.DeleteFunc(cfdomainList.Items, func(d korifiv1alpha1.CFDomain) bool {
+		return d.Namespace == r.rootNamespace || !isVisible(r.cfDomainToDomainRecord(d), authorizedOrgs)
+	}), nil
+}
+
 func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) error {
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      domainGUID,
+			Name: domainGUID,
 		},
 	}
 
-	err := r.klient.Delete(ctx, cfDomain)
+	err := r.klient.Get(ctx, cfDomain)
+	if err != nil {
+		return apierrors.FromK8sError(err, DomainResourceType)
+	}
+
+	err = r.klient.Delete(ctx, cfDomain)
 	if err != nil {
 		return apierrors.FromK8sError(err, DomainResourceType)
 	}
@@ -171,15 +324,34 @@ func (r *DomainRepo) GetDeletedAt(ctx context.Context, authInfo authorization.In
 	return domain.DeletedAt, err
 }
 
-func cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
+// isVisible returns true for private domains that are owned by or shared
+// with an org the user has a role in
+func isVisible(domain DomainRecord, authorizedOrgs map[string]bool) bool {
+	if authorizedOrgs[domain.OrganizationGUID] {
+		return true
+	}
+
+	return slices.ContainsFunc(domain.SharedOrganizationGUIDs, func(orgGUID string) bool {
+		return authorizedOrgs[orgGUID]
+	})
+}
+
+func (r *DomainRepo) cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
+	organizationGUID := ""
+	if cfDomain.Namespace != r.rootNamespace {
+		organizationGUID = cfDomain.Namespace
+	}
+
 	return DomainRecord{
-		Name:        cfDomain.Spec.Name,
-		GUID:        cfDomain.Name,
-		Namespace:   cfDomain.Namespace,
-		CreatedAt:   cfDomain.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfDomain),
-		DeletedAt:   golangTime(cfDomain.DeletionTimestamp),
-		Labels:      cfDomain.Labels,
-		Annotations: cfDomain.Annotations,
+		Name:                    cfDomain.Spec.Name,
+		GUID:                    cfDomain.Name,
+		Namespace:               cfDomain.Namespace,
+		OrganizationGUID:        organizationGUID,
+		SharedOrganizationGUIDs: cfDomain.Spec.SharedOrganizations,
+		CreatedAt:               cfDomain.CreationTimestamp.Time,
+		UpdatedAt:               getLastUpdatedTime(&cfDomain),
+		DeletedAt:               golangTime(cfDomain.DeletionTimestamp),
+		Labels:                  cfDomain.Labels,
+		Annotations:             cfDomain.Annotations,
 	}
 }
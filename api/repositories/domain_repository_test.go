@@ -47,7 +47,7 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = NewDomainRepo(klientUnfiltered, rootNamespace)
+		domainRepo = NewDomainRepo(klientUnfiltered, k8sClient, rootNamespace, nsPerms)
 	})
 
 	AfterEach(func() {
@@ -76,6 +76,62 @@ var _ = Describe("DomainRepository", func() {
 			Expect(domain.Name).To(Equal("my-domain.com"))
 		})
 
+		When("the domain is private", func() {
+			var (
+				cfOrg           *korifiv1alpha1.CFOrg
+				privateCFDomain *korifiv1alpha1.CFDomain
+			)
+
+			BeforeEach(func() {
+				cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				privateCFDomain = &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfOrg.Name,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name: "private.domain.com",
+					},
+				}
+				Expect(k8sClient.Create(ctx, privateCFDomain)).To(Succeed())
+				searchGUID = privateCFDomain.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			When("the user has a role in the owning org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				})
+
+				It("returns the domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(privateCFDomain.Name))
+					Expect(domain.OrganizationGUID).To(Equal(cfOrg.Name))
+					Expect(domain.IsPrivate()).To(BeTrue())
+				})
+			})
+
+			When("the domain is shared with an org the user has a role in", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+					createRoleBinding(ctx, userName, orgUserRole.Name, otherOrg.Name)
+
+					Expect(k8s.Patch(ctx, k8sClient, privateCFDomain, func() {
+						privateCFDomain.Spec.SharedOrganizations = []string{otherOrg.Name}
+					})).To(Succeed())
+				})
+
+				It("returns the domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(privateCFDomain.Name))
+					Expect(domain.SharedOrganizationGUIDs).To(HaveLen(1))
+				})
+			})
+		})
+
 		When("no CFDomain exists", func() {
 			BeforeEach(func() {
 				searchGUID = "i-dont-exist"
@@ -143,6 +199,27 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
+
+			When("an organization is specified", func() {
+				var cfOrg *korifiv1alpha1.CFOrg
+
+				BeforeEach(func() {
+					cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+					createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+					domainCreate.OrganizationGUID = cfOrg.Name
+					domainCreate.SharedOrganizationGUIDs = []string{"other-org"}
+				})
+
+				It("creates a private domain in the org namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.OrganizationGUID).To(Equal(cfOrg.Name))
+					Expect(createdDomain.SharedOrganizationGUIDs).To(ConsistOf("other-org"))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: cfOrg.Name}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.SharedOrganizations).To(ConsistOf("other-org"))
+				})
+			})
 		})
 	})
 
@@ -289,6 +366,75 @@ var _ = Describe("DomainRepository", func() {
 			})
 		})
 
+		When("there are private domains", func() {
+			var (
+				ownerOrg, sharedWithOrg *korifiv1alpha1.CFOrg
+				privateCFDomain         *korifiv1alpha1.CFDomain
+			)
+
+			BeforeEach(func() {
+				ownerOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				sharedWithOrg = createOrgWithCleanup(ctx, uuid.NewString())
+
+				privateCFDomain = &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: ownerOrg.Name,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name:                "private.domain.com",
+						SharedOrganizations: []string{sharedWithOrg.Name},
+					},
+				}
+				Expect(k8sClient.Create(ctx, privateCFDomain)).To(Succeed())
+			})
+
+			It("does not return private domains of orgs the user has no role in", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(domainRecords).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateCFDomain.Name)})))
+			})
+
+			When("the user has a role in an org the domain is shared with", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, sharedWithOrg.Name)
+				})
+
+				It("returns the private domain", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(domainRecords).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"GUID":                    Equal(privateCFDomain.Name),
+						"OrganizationGUID":        Equal(ownerOrg.Name),
+						"SharedOrganizationGUIDs": ConsistOf(sharedWithOrg.Name),
+					})))
+				})
+
+				When("filtering by owning organization", func() {
+					BeforeEach(func() {
+						domainListMessage.OrganizationGUIDs = []string{ownerOrg.Name}
+					})
+
+					It("returns only the private domains owned by the org", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(domainRecords).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(privateCFDomain.Name)})))
+					})
+				})
+
+				When("filtering by availability in an org", func() {
+					BeforeEach(func() {
+						domainListMessage.AvailableInOrgGUID = "some-other-org"
+					})
+
+					It("returns only the shared domains", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(domainRecords).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)}),
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1)}),
+						))
+					})
+				})
+			})
+		})
+
 		When("the user has no permission to list domains in the root namespace", func() {
 			BeforeEach(func() {
 				userName = uuid.NewString()
@@ -303,6 +449,73 @@ var _ = Describe("DomainRepository", func() {
 		})
 	})
 
+	Describe("ShareDomain and UnshareDomain", func() {
+		var (
+			cfOrg           *korifiv1alpha1.CFOrg
+			privateCFDomain *korifiv1alpha1.CFDomain
+			sharedDomain    DomainRecord
+			shareErr        error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+			privateCFDomain = &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfOrg.Name,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:                "private.domain.com",
+					SharedOrganizations: []string{"org-1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, privateCFDomain)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			sharedDomain, shareErr = domainRepo.ShareDomain(ctx, authInfo, ShareDomainMessage{
+				DomainGUID:        privateCFDomain.Name,
+				OrganizationGUIDs: []string{"org-1", "org-2"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org manager of the owning org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, cfOrg.Name)
+			})
+
+			It("shares the domain with the orgs", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(sharedDomain.SharedOrganizationGUIDs).To(ConsistOf("org-1", "org-2"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(privateCFDomain), privateCFDomain)).To(Succeed())
+				Expect(privateCFDomain.Spec.SharedOrganizations).To(ConsistOf("org-1", "org-2"))
+			})
+
+			When("the domain is unshared from an org", func() {
+				var unshareErr error
+
+				JustBeforeEach(func() {
+					unshareErr = domainRepo.UnshareDomain(ctx, authInfo, UnshareDomainMessage{
+						DomainGUID:       privateCFDomain.Name,
+						OrganizationGUID: "org-1",
+					})
+				})
+
+				It("removes the org from the shared orgs", func() {
+					Expect(unshareErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(privateCFDomain), privateCFDomain)).To(Succeed())
+					Expect(privateCFDomain.Spec.SharedOrganizations).To(ConsistOf("org-2"))
+				})
+			})
+		})
+	})
+
 	Describe("Delete Domain", func() {
 		var (
 			deleteGUID string
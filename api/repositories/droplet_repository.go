@@ -3,16 +3,20 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,17 +24,30 @@ import (
 
 const (
 	DropletResourceType = "Droplet"
+
+	DropletStateAwaitingUpload   = "AWAITING_UPLOAD"
+	DropletStateProcessingUpload = "PROCESSING_UPLOAD"
+	DropletStateStaged           = "STAGED"
 )
 
 type DropletRepo struct {
-	klient Klient
+	klient              Klient
+	repositoryCreator   RepositoryCreator
+	repositoryPrefix    string
+	registrySecretNames []string
 }
 
 func NewDropletRepo(
 	klient Klient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
+	registrySecretNames []string,
 ) *DropletRepo {
 	return &DropletRepo{
-		klient: klient,
+		klient:              klient,
+		repositoryCreator:   repositoryCreator,
+		repositoryPrefix:    repositoryPrefix,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -44,11 +61,16 @@ type DropletRecord struct {
 	Stack           string
 	ProcessTypes    map[string]string
 	AppGUID         string
+	SpaceGUID       string
 	PackageGUID     string
 	Labels          map[string]string
 	Annotations     map[string]string
 	Image           string
 	Ports           []int32
+	// The droplet image in the registry, empty until the droplet is staged or uploaded
+	ImageRef string
+	// The registry repository droplet uploads are pushed to
+	RepositoryRef string
 }
 
 func (r DropletRecord) Relationships() map[string]string {
@@ -75,7 +97,7 @@ func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Inf
 		return DropletRecord{}, err
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, error) {
@@ -91,26 +113,56 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return build, nil
 }
 
-func cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+func (r *DropletRepo) cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+	if isUploadedDroplet(*cfBuild) {
+		return r.cfBuildToDropletRecord(*cfBuild), nil
+	}
+
 	stagingStatus := getConditionValue(&cfBuild.Status.Conditions, StagingConditionType)
 	succeededStatus := getConditionValue(&cfBuild.Status.Conditions, SucceededConditionType)
 	if stagingStatus == metav1.ConditionFalse &&
 		succeededStatus == metav1.ConditionTrue {
-		return cfBuildToDropletRecord(*cfBuild), nil
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
 	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
 }
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+// isUploadedDroplet tells whether the build is not staged from a package, i.e.
+// its droplet is either uploaded or copied from another droplet
+func isUploadedDroplet(cfBuild korifiv1alpha1.CFBuild) bool {
+	return cfBuild.Spec.PackageRef.Name == ""
+}
+
+func dropletState(cfBuild korifiv1alpha1.CFBuild) string {
+	if !isUploadedDroplet(cfBuild) || meta.IsStatusConditionTrue(cfBuild.Status.Conditions, SucceededConditionType) {
+		return DropletStateStaged
+	}
+
+	if cfBuild.Spec.Droplet != nil && cfBuild.Spec.Droplet.Registry.Image != "" {
+		return DropletStateProcessingUpload
+	}
+
+	return DropletStateAwaitingUpload
+}
+
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+	droplet := cfBuild.Status.Droplet
+	if droplet == nil {
+		droplet = cfBuild.Spec.Droplet
+	}
+	if droplet == nil {
+		droplet = &korifiv1alpha1.BuildDropletStatus{}
+	}
+
 	processTypesMap := make(map[string]string)
-	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
+	processTypesArrayObject := droplet.ProcessTypes
 	for index := range processTypesArrayObject {
 		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
 	}
 
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		State:     dropletState(cfBuild),
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -120,18 +172,21 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
-		ProcessTypes: processTypesMap,
-		AppGUID:      cfBuild.Spec.AppRef.Name,
-		PackageGUID:  cfBuild.Spec.PackageRef.Name,
-		Labels:       cfBuild.Labels,
-		Annotations:  cfBuild.Annotations,
-		Ports:        cfBuild.Status.Droplet.Ports,
+		Stack:         droplet.Stack,
+		ProcessTypes:  processTypesMap,
+		AppGUID:       cfBuild.Spec.AppRef.Name,
+		SpaceGUID:     cfBuild.Namespace,
+		PackageGUID:   cfBuild.Spec.PackageRef.Name,
+		Labels:        cfBuild.Labels,
+		Annotations:   cfBuild.Annotations,
+		Ports:         droplet.Ports,
+		ImageRef:      droplet.Registry.Image,
+		RepositoryRef: r.repositoryRef(cfBuild.Spec.AppRef.Name),
 	}
 
-	if cfBuild.Spec.Lifecycle.Type == "docker" {
+	if cfBuild.Spec.Lifecycle.Type == "docker" || cfBuild.Spec.Lifecycle.Type == "dockerfile" {
 		result.Lifecycle.Data = LifecycleData{}
-		result.Image = cfBuild.Status.Droplet.Registry.Image
+		result.Image = droplet.Registry.Image
 	}
 
 	return result
@@ -145,7 +200,7 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 	}
 
 	filteredBuilds := itx.FromSlice(buildList.Items)
-	return slices.Collect(it.Map(filteredBuilds, cfBuildToDropletRecord)), nil
+	return slices.Collect(it.Map(filteredBuilds, r.cfBuildToDropletRecord)), nil
 }
 
 type UpdateDropletMessage struct {
@@ -168,5 +223,165 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Lifecycle    Lifecycle
+	ProcessTypes map[string]string
+}
+
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	cfBuild := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.SpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: korifiv1alpha1.LifecycleType(message.Lifecycle.Type),
+				Data: korifiv1alpha1.LifecycleData{
+					Buildpacks: message.Lifecycle.Data.Buildpacks,
+					Stack:      message.Lifecycle.Data.Stack,
+				},
+			},
+			Droplet: &korifiv1alpha1.BuildDropletStatus{
+				Registry: korifiv1alpha1.Registry{
+					ImagePullSecrets: r.imagePullSecrets(),
+				},
+				ProcessTypes: toProcessTypes(message.ProcessTypes),
+			},
+		},
+	}
+
+	if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID)); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	if err := r.klient.Create(ctx, cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	return r.cfBuildToDropletRecord(*cfBuild), nil
+}
+
+func (r *DropletRepo) imagePullSecrets() []corev1.LocalObjectReference {
+	secrets := []corev1.LocalObjectReference{}
+	for _, secretName := range r.registrySecretNames {
+		secrets = append(secrets, corev1.LocalObjectReference{Name: secretName})
+	}
+
+	return secrets
+}
+
+func toProcessTypes(processTypes map[string]string) []korifiv1alpha1.ProcessType {
+	result := []korifiv1alpha1.ProcessType{}
+	for _, processType := range slices.Sorted(maps.Keys(processTypes)) {
+		result = append(result, korifiv1alpha1.ProcessType{
+			Type:    processType,
+			Command: processTypes[processType],
+		})
+	}
+
+	return result
+}
+
+type UpdateDropletImageMessage struct {
+	GUID     string
+	ImageRef string
+}
+
+func (r *DropletRepo) UpdateDropletImage(ctx context.Context, authInfo authorization.Info, message UpdateDropletImageMessage) (DropletRecord, error) {
+	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.GUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if !isUploadedDroplet(*build) || build.Spec.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Droplet was not created for upload")
+	}
+
+	err = r.klient.Patch(ctx, build, func() error {
+		build.Spec.Droplet.Registry.Image = message.ImageRef
+
+		return nil
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to patch droplet image: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfBuildToDropletRecord(*build), nil
+}
+
+type CopyDropletMessage struct {
+	SourceGUID string
+	AppGUID    string
+	SpaceGUID  string
+}
+
+func (r *DropletRepo) CopyDroplet(ctx context.Context, authInfo authorization.Info, message CopyDropletMessage) (DropletRecord, error) {
+	sourceBuild, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.SourceGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if !meta.IsStatusConditionTrue(sourceBuild.Status.Conditions, SucceededConditionType) || sourceBuild.Status.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source droplet is not staged")
+	}
+
+	// Docker droplets refer to the user image, which is used as it is. Other
+	// droplet images are copied into the repository of the destination app
+	// afterwards, see UpdateDropletImage
+	droplet := sourceBuild.Status.Droplet.DeepCopy()
+	if sourceBuild.Spec.Lifecycle.Type != korifiv1alpha1.DockerLifecycle {
+		droplet.Registry = korifiv1alpha1.Registry{
+			ImagePullSecrets: r.imagePullSecrets(),
+		}
+	}
+
+	cfBuild := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.SpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Lifecycle: sourceBuild.Spec.Lifecycle,
+			Droplet:   droplet,
+		},
+	}
+
+	if err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID)); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	if err = r.klient.Create(ctx, cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	return r.cfBuildToDropletRecord(*cfBuild), nil
+}
+
+func (r *DropletRepo) GetState(ctx context.Context, authInfo authorization.Info, dropletGUID string) (ResourceState, error) {
+	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err != nil {
+		return ResourceStateUnknown, err
+	}
+
+	if meta.IsStatusConditionTrue(build.Status.Conditions, SucceededConditionType) {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+func (r *DropletRepo) repositoryRef(appGUID string) string {
+	return r.repositoryPrefix + appGUID + "-droplets"
 }
@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	"errors"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -33,6 +34,7 @@ var _ = Describe("DropletRepository", func() {
 
 	var (
 		dropletRepo *repositories.DropletRepo
+		repoCreator *fake.RepositoryCreator
 		org         *korifiv1alpha1.CFOrg
 		space       *korifiv1alpha1.CFSpace
 		build       *korifiv1alpha1.CFBuild
@@ -48,7 +50,8 @@ var _ = Describe("DropletRepository", func() {
 		org = createOrgWithCleanup(ctx, orgName)
 		space = createSpaceWithCleanup(ctx, org.Name, spaceName)
 
-		dropletRepo = repositories.NewDropletRepo(klient)
+		repoCreator = new(fake.RepositoryCreator)
+		dropletRepo = repositories.NewDropletRepo(klient, repoCreator, "container.registry/foo/my/prefix-", []string{"registry-secret"})
 
 		build = &korifiv1alpha1.CFBuild{
 			ObjectMeta: metav1.ObjectMeta{
@@ -156,7 +159,10 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecord.Image).To(BeEmpty())
 					Expect(dropletRecord.Ports).To(ConsistOf(int32(1234), int32(2345)))
 					Expect(dropletRecord.AppGUID).To(Equal(build.Spec.AppRef.Name))
+					Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
 					Expect(dropletRecord.PackageGUID).To(Equal(build.Spec.PackageRef.Name))
+					Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
 					Expect(dropletRecord.Labels).To(Equal(map[string]string{
 						"key1":                               "val1",
 						"key2":                               "val2",
@@ -392,7 +398,7 @@ var _ = Describe("DropletRepository", func() {
 
 					BeforeEach(func() {
 						fakeKlient = new(fake.Klient)
-						dropletRepo = repositories.NewDropletRepo(fakeKlient)
+						dropletRepo = repositories.NewDropletRepo(fakeKlient, repoCreator, "container.registry/foo/my/prefix-", []string{"registry-secret"})
 
 						message = repositories.ListDropletsMessage{
 							PackageGUIDs: []string{"p1", "p2"},
@@ -683,4 +689,300 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			createErr     error
+		)
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(ctx, authInfo, repositories.CreateDropletMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+				ProcessTypes: map[string]string{
+					"web":    "bundle exec rackup",
+					"worker": "bundle exec work",
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a droplet awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(dropletRecord.AppGUID).To(Equal(appGUID))
+				Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(dropletRecord.PackageGUID).To(BeEmpty())
+				Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{
+					"web":    "bundle exec rackup",
+					"worker": "bundle exec work",
+				}))
+				Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+
+				cfBuild := &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      dropletRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(cfBuild.Spec.AppRef.Name).To(Equal(appGUID))
+				Expect(cfBuild.Spec.Lifecycle.Data.Stack).To(Equal("cflinuxfs4"))
+				Expect(cfBuild.Spec.Droplet).NotTo(BeNil())
+				Expect(cfBuild.Spec.Droplet.Registry.Image).To(BeEmpty())
+				Expect(cfBuild.Spec.Droplet.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "registry-secret"}))
+				Expect(cfBuild.Spec.Droplet.ProcessTypes).To(Equal([]korifiv1alpha1.ProcessType{
+					{Type: "web", Command: "bundle exec rackup"},
+					{Type: "worker", Command: "bundle exec work"},
+				}))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo-create-error"))
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("repo-create-error")))
+				})
+
+				It("does not create the droplet", func() {
+					buildList := &korifiv1alpha1.CFBuildList{}
+					Expect(k8sClient.List(ctx, buildList, client.InNamespace(space.Name))).To(Succeed())
+					Expect(buildList.Items).To(HaveEach(HaveField("Spec.PackageRef.Name", Not(BeEmpty()))))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateDropletImage", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, build, func() {
+				build.Spec.PackageRef.Name = ""
+				build.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletImage(ctx, authInfo, repositories.UpdateDropletImageMessage{
+				GUID:     buildGUID,
+				ImageRef: "my/droplet@sha256:abc",
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the droplet image", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal("PROCESSING_UPLOAD"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(build), build)).To(Succeed())
+				Expect(build.Spec.Droplet.Registry.Image).To(Equal("my/droplet@sha256:abc"))
+			})
+
+			When("the droplet was staged from a package", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Spec.PackageRef.Name = packageGUID
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("CopyDroplet", func() {
+		var (
+			targetSpace   *korifiv1alpha1.CFSpace
+			dropletRecord repositories.DropletRecord
+			copyErr       error
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("target-space-"))
+
+			Expect(k8s.Patch(ctx, k8sClient, build, func() {
+				meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+					Type:   "Succeeded",
+					Status: metav1.ConditionTrue,
+					Reason: "Succeeded",
+				})
+				build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+					Stack: dropletStack,
+					Registry: korifiv1alpha1.Registry{
+						Image: registryImage,
+					},
+					ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run"}},
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, copyErr = dropletRepo.CopyDroplet(ctx, authInfo, repositories.CopyDropletMessage{
+				SourceGUID: buildGUID,
+				AppGUID:    "target-app-guid",
+				SpaceGUID:  targetSpace.Name,
+			})
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+			})
+
+			It("creates a droplet awaiting the copied image", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).NotTo(Equal(buildGUID))
+				Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(dropletRecord.AppGUID).To(Equal("target-app-guid"))
+				Expect(dropletRecord.SpaceGUID).To(Equal(targetSpace.Name))
+				Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "run"}))
+				Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-target-app-guid-droplets"))
+
+				cfBuild := &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: targetSpace.Name,
+						Name:      dropletRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				Expect(cfBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(cfBuild.Spec.Lifecycle).To(Equal(build.Spec.Lifecycle))
+				Expect(cfBuild.Spec.Droplet).NotTo(BeNil())
+				Expect(cfBuild.Spec.Droplet.Stack).To(Equal(dropletStack))
+				Expect(cfBuild.Spec.Droplet.ProcessTypes).To(Equal(build.Status.Droplet.ProcessTypes))
+				Expect(cfBuild.Spec.Droplet.Registry).To(Equal(korifiv1alpha1.Registry{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+				}))
+			})
+
+			It("creates the droplet repository of the target app", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-target-app-guid-droplets"))
+			})
+
+			When("the source droplet is a docker droplet", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: "docker"}
+					})).To(Succeed())
+				})
+
+				It("keeps the docker image", func() {
+					Expect(copyErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.Image).To(Equal(registryImage))
+				})
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo-create-error"))
+				})
+
+				It("returns an error and does not create the droplet", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("repo-create-error")))
+
+					buildList := &korifiv1alpha1.CFBuildList{}
+					Expect(k8sClient.List(ctx, buildList, client.InNamespace(targetSpace.Name))).To(Succeed())
+					Expect(buildList.Items).To(BeEmpty())
+				})
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Status.Conditions = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the user cannot create builds in the target space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			state    repositories.ResourceState
+			stateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = dropletRepo.GetState(ctx, authInfo, buildGUID)
+		})
+
+		It("returns unknown state", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(repositories.ResourceStateUnknown))
+		})
+
+		When("the build has succeeded", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, build, func() {
+					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+						Type:   "Succeeded",
+						Status: metav1.ConditionTrue,
+						Reason: "Succeeded",
+					})
+				})).To(Succeed())
+			})
+
+			It("returns ready state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceStateReady))
+			})
+		})
+	})
 })
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const EnvVarGroupResourceType = "Environment Variable Group"
+
+type EnvVarGroupRecord struct {
+	Name      string
+	Var       map[string]string
+	UpdatedAt *time.Time
+}
+
+type PatchEnvVarGroupMessage struct {
+	Name string
+	// Env vars with a nil value are removed from the group
+	Var map[string]*string
+}
+
+func (m PatchEnvVarGroupMessage) apply(env map[string]string) map[string]string {
+	result := maps.Clone(env)
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	for name, value := range m.Var {
+		if value == nil {
+			delete(result, name)
+			continue
+		}
+		result[name] = *value
+	}
+
+	return result
+}
+
+type EnvVarGroupRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewEnvVarGroupRepo(klient Klient, rootNamespace string) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+// GetEnvVarGroup returns an empty group if it has never been set
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	envVarGroup := r.emptyEnvVarGroup(name)
+	err := r.klient.Get(ctx, envVarGroup)
+	if k8serrors.IsNotFound(err) {
+		return toEnvVarGroupRecord(envVarGroup), nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get env var group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(envVarGroup), nil
+}
+
+func (r *EnvVarGroupRepo) PatchEnvVarGroup(ctx context.Context, authInfo authorization.Info, message PatchEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	envVarGroup := r.emptyEnvVarGroup(message.Name)
+	err := r.klient.Get(ctx, envVarGroup)
+	if k8serrors.IsNotFound(err) {
+		envVarGroup.Spec.Env = message.apply(nil)
+		if err = r.klient.Create(ctx, envVarGroup); err != nil {
+			return EnvVarGroupRecord{}, fmt.Errorf("failed to create env var group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+		}
+
+		return toEnvVarGroupRecord(envVarGroup), nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get env var group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	err = r.klient.Patch(ctx, envVarGroup, func() error {
+		envVarGroup.Spec.Env = message.apply(envVarGroup.Spec.Env)
+		return nil
+	})
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to patch env var group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(envVarGroup), nil
+}
+
+func (r *EnvVarGroupRepo) emptyEnvVarGroup(name string) *korifiv1alpha1.CFEnvVarGroup {
+	return &korifiv1alpha1.CFEnvVarGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      name,
+		},
+	}
+}
+
+func toEnvVarGroupRecord(envVarGroup *korifiv1alpha1.CFEnvVarGroup) EnvVarGroupRecord {
+	env := envVarGroup.Spec.Env
+	if env == nil {
+		env = map[string]string{}
+	}
+
+	return EnvVarGroupRecord{
+		Name:      envVarGroup.Name,
+		Var:       env,
+		UpdatedAt: getLastUpdatedTime(envVarGroup),
+	}
+}
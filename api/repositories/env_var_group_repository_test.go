@@ -0,0 +1,141 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnvVarGroupRepository", func() {
+	var envVarGroupRepo *repositories.EnvVarGroupRepo
+
+	BeforeEach(func() {
+		envVarGroupRepo = repositories.NewEnvVarGroupRepo(klient, rootNamespace)
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		var (
+			envVarGroup repositories.EnvVarGroupRecord
+			getErr      error
+		)
+
+		JustBeforeEach(func() {
+			envVarGroup, getErr = envVarGroupRepo.GetEnvVarGroup(ctx, authInfo, korifiv1alpha1.RunningEnvVarGroupName)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has a role in the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns an empty group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Name).To(Equal(korifiv1alpha1.RunningEnvVarGroupName))
+				Expect(envVarGroup.Var).To(BeEmpty())
+			})
+
+			When("the group has been set", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.RunningEnvVarGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Env: map[string]string{"HTTP_PROXY": "http://proxy.example.com"},
+						},
+					})).To(Succeed())
+				})
+
+				It("returns the group", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(envVarGroup.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy.example.com"}))
+					Expect(envVarGroup.UpdatedAt).NotTo(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("PatchEnvVarGroup", func() {
+		var (
+			message     repositories.PatchEnvVarGroupMessage
+			envVarGroup repositories.EnvVarGroupRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchEnvVarGroupMessage{
+				Name: korifiv1alpha1.StagingEnvVarGroupName,
+				Var: map[string]*string{
+					"HTTP_PROXY": tools.PtrTo("http://proxy.example.com"),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			envVarGroup, patchErr = envVarGroupRepo.PatchEnvVarGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the group", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy.example.com"}))
+
+				cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupName,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfEnvVarGroup), cfEnvVarGroup)).To(Succeed())
+				Expect(cfEnvVarGroup.Spec.Env).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy.example.com"}))
+			})
+
+			When("the group already exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      korifiv1alpha1.StagingEnvVarGroupName,
+						},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Env: map[string]string{
+								"NO_PROXY": "localhost",
+								"REMOVE":   "me",
+							},
+						},
+					})).To(Succeed())
+
+					message.Var["REMOVE"] = nil
+				})
+
+				It("merges the env vars, removing the null ones", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(envVarGroup.Var).To(Equal(map[string]string{
+						"HTTP_PROXY": "http://proxy.example.com",
+						"NO_PROXY":   "localhost",
+					}))
+				})
+			})
+		})
+	})
+})
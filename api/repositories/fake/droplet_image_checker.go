@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type DropletImageChecker struct {
+	DropletImageExistsStub        func(context.Context, string) (bool, error)
+	dropletImageExistsMutex       sync.RWMutex
+	dropletImageExistsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	dropletImageExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	dropletImageExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DropletImageChecker) DropletImageExists(arg1 context.Context, arg2 string) (bool, error) {
+	fake.dropletImageExistsMutex.Lock()
+	ret, specificReturn := fake.dropletImageExistsReturnsOnCall[len(fake.dropletImageExistsArgsForCall)]
+	fake.dropletImageExistsArgsForCall = append(fake.dropletImageExistsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DropletImageExistsStub
+	fakeReturns := fake.dropletImageExistsReturns
+	fake.recordInvocation("DropletImageExists", []interface{}{arg1, arg2})
+	fake.dropletImageExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DropletImageChecker) DropletImageExistsCallCount() int {
+	fake.dropletImageExistsMutex.RLock()
+	defer fake.dropletImageExistsMutex.RUnlock()
+	return len(fake.dropletImageExistsArgsForCall)
+}
+
+func (fake *DropletImageChecker) DropletImageExistsCalls(stub func(context.Context, string) (bool, error)) {
+	fake.dropletImageExistsMutex.Lock()
+	defer fake.dropletImageExistsMutex.Unlock()
+	fake.DropletImageExistsStub = stub
+}
+
+func (fake *DropletImageChecker) DropletImageExistsArgsForCall(i int) (context.Context, string) {
+	fake.dropletImageExistsMutex.RLock()
+	defer fake.dropletImageExistsMutex.RUnlock()
+	argsForCall := fake.dropletImageExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *DropletImageChecker) DropletImageExistsReturns(result1 bool, result2 error) {
+	fake.dropletImageExistsMutex.Lock()
+	defer fake.dropletImageExistsMutex.Unlock()
+	fake.DropletImageExistsStub = nil
+	fake.dropletImageExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageChecker) DropletImageExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.dropletImageExistsMutex.Lock()
+	defer fake.dropletImageExistsMutex.Unlock()
+	fake.DropletImageExistsStub = nil
+	if fake.dropletImageExistsReturnsOnCall == nil {
+		fake.dropletImageExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.dropletImageExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.dropletImageExistsMutex.RLock()
+	defer fake.dropletImageExistsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DropletImageChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repositories.DropletImageChecker = new(DropletImageChecker)
@@ -0,0 +1,283 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+type ImageDownloader struct {
+	DownloadStub        func(context.Context, image.Creds, string, io.Writer) error
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Writer
+	}
+	downloadReturns struct {
+		result1 error
+	}
+	downloadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DownloadZipStub        func(context.Context, image.Creds, string, io.Writer) error
+	downloadZipMutex       sync.RWMutex
+	downloadZipArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Writer
+	}
+	downloadZipReturns struct {
+		result1 error
+	}
+	downloadZipReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExistsStub        func(context.Context, image.Creds, string) (bool, error)
+	existsMutex       sync.RWMutex
+	existsArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	existsReturns struct {
+		result1 bool
+		result2 error
+	}
+	existsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ImageDownloader) Download(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Writer) error {
+	fake.downloadMutex.Lock()
+	ret, specificReturn := fake.downloadReturnsOnCall[len(fake.downloadArgsForCall)]
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Writer
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadStub
+	fakeReturns := fake.downloadReturns
+	fake.recordInvocation("Download", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageDownloader) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *ImageDownloader) DownloadCalls(stub func(context.Context, image.Creds, string, io.Writer) error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = stub
+}
+
+func (fake *ImageDownloader) DownloadArgsForCall(i int) (context.Context, image.Creds, string, io.Writer) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	argsForCall := fake.downloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ImageDownloader) DownloadReturns(result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageDownloader) DownloadReturnsOnCall(i int, result1 error) {
+	fake.downloadMutex.Lock()
+	defer fake.downloadMutex.Unlock()
+	fake.DownloadStub = nil
+	if fake.downloadReturnsOnCall == nil {
+		fake.downloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageDownloader) DownloadZip(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Writer) error {
+	fake.downloadZipMutex.Lock()
+	ret, specificReturn := fake.downloadZipReturnsOnCall[len(fake.downloadZipArgsForCall)]
+	fake.downloadZipArgsForCall = append(fake.downloadZipArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Writer
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadZipStub
+	fakeReturns := fake.downloadZipReturns
+	fake.recordInvocation("DownloadZip", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadZipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ImageDownloader) DownloadZipCallCount() int {
+	fake.downloadZipMutex.RLock()
+	defer fake.downloadZipMutex.RUnlock()
+	return len(fake.downloadZipArgsForCall)
+}
+
+func (fake *ImageDownloader) DownloadZipCalls(stub func(context.Context, image.Creds, string, io.Writer) error) {
+	fake.downloadZipMutex.Lock()
+	defer fake.downloadZipMutex.Unlock()
+	fake.DownloadZipStub = stub
+}
+
+func (fake *ImageDownloader) DownloadZipArgsForCall(i int) (context.Context, image.Creds, string, io.Writer) {
+	fake.downloadZipMutex.RLock()
+	defer fake.downloadZipMutex.RUnlock()
+	argsForCall := fake.downloadZipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ImageDownloader) DownloadZipReturns(result1 error) {
+	fake.downloadZipMutex.Lock()
+	defer fake.downloadZipMutex.Unlock()
+	fake.DownloadZipStub = nil
+	fake.downloadZipReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageDownloader) DownloadZipReturnsOnCall(i int, result1 error) {
+	fake.downloadZipMutex.Lock()
+	defer fake.downloadZipMutex.Unlock()
+	fake.DownloadZipStub = nil
+	if fake.downloadZipReturnsOnCall == nil {
+		fake.downloadZipReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.downloadZipReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ImageDownloader) Exists(arg1 context.Context, arg2 image.Creds, arg3 string) (bool, error) {
+	fake.existsMutex.Lock()
+	ret, specificReturn := fake.existsReturnsOnCall[len(fake.existsArgsForCall)]
+	fake.existsArgsForCall = append(fake.existsArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ExistsStub
+	fakeReturns := fake.existsReturns
+	fake.recordInvocation("Exists", []interface{}{arg1, arg2, arg3})
+	fake.existsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageDownloader) ExistsCallCount() int {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	return len(fake.existsArgsForCall)
+}
+
+func (fake *ImageDownloader) ExistsCalls(stub func(context.Context, image.Creds, string) (bool, error)) {
+	fake.existsMutex.Lock()
+	defer fake.existsMutex.Unlock()
+	fake.ExistsStub = stub
+}
+
+func (fake *ImageDownloader) ExistsArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	argsForCall := fake.existsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageDownloader) ExistsReturns(result1 bool, result2 error) {
+	fake.existsMutex.Lock()
+	defer fake.existsMutex.Unlock()
+	fake.ExistsStub = nil
+	fake.existsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageDownloader) ExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.existsMutex.Lock()
+	defer fake.existsMutex.Unlock()
+	fake.ExistsStub = nil
+	if fake.existsReturnsOnCall == nil {
+		fake.existsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.existsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageDownloader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	fake.downloadZipMutex.RLock()
+	defer fake.downloadZipMutex.RUnlock()
+	fake.existsMutex.RLock()
+	defer fake.existsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ImageDownloader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repositories.ImageDownloader = new(ImageDownloader)
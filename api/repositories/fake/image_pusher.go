@@ -11,6 +11,23 @@ import (
 )
 
 type ImagePusher struct {
+	CopyStub        func(context.Context, image.Creds, string, string, ...string) (string, error)
+	copyMutex       sync.RWMutex
+	copyArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}
+	copyReturns struct {
+		result1 string
+		result2 error
+	}
+	copyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	PushStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
 	pushMutex       sync.RWMutex
 	pushArgsForCall []struct {
@@ -28,10 +45,112 @@ type ImagePusher struct {
 		result1 string
 		result2 error
 	}
+	PushBuildpackStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushBuildpackMutex       sync.RWMutex
+	pushBuildpackArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushBuildpackReturns struct {
+		result1 string
+		result2 error
+	}
+	pushBuildpackReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PushDropletStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushDropletMutex       sync.RWMutex
+	pushDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushDropletReturns struct {
+		result1 string
+		result2 error
+	}
+	pushDropletReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImagePusher) Copy(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string, arg5 ...string) (string, error) {
+	fake.copyMutex.Lock()
+	ret, specificReturn := fake.copyReturnsOnCall[len(fake.copyArgsForCall)]
+	fake.copyArgsForCall = append(fake.copyArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CopyStub
+	fakeReturns := fake.copyReturns
+	fake.recordInvocation("Copy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.copyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) CopyCallCount() int {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	return len(fake.copyArgsForCall)
+}
+
+func (fake *ImagePusher) CopyCalls(stub func(context.Context, image.Creds, string, string, ...string) (string, error)) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = stub
+}
+
+func (fake *ImagePusher) CopyArgsForCall(i int) (context.Context, image.Creds, string, string, []string) {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	argsForCall := fake.copyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) CopyReturns(result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	fake.copyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) CopyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	if fake.copyReturnsOnCall == nil {
+		fake.copyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Push(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
 	fake.pushMutex.Lock()
 	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
@@ -100,11 +219,153 @@ func (fake *ImagePusher) PushReturnsOnCall(i int, result1 string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *ImagePusher) PushBuildpack(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushBuildpackMutex.Lock()
+	ret, specificReturn := fake.pushBuildpackReturnsOnCall[len(fake.pushBuildpackArgsForCall)]
+	fake.pushBuildpackArgsForCall = append(fake.pushBuildpackArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushBuildpackStub
+	fakeReturns := fake.pushBuildpackReturns
+	fake.recordInvocation("PushBuildpack", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushBuildpackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushBuildpackCallCount() int {
+	fake.pushBuildpackMutex.RLock()
+	defer fake.pushBuildpackMutex.RUnlock()
+	return len(fake.pushBuildpackArgsForCall)
+}
+
+func (fake *ImagePusher) PushBuildpackCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushBuildpackMutex.Lock()
+	defer fake.pushBuildpackMutex.Unlock()
+	fake.PushBuildpackStub = stub
+}
+
+func (fake *ImagePusher) PushBuildpackArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushBuildpackMutex.RLock()
+	defer fake.pushBuildpackMutex.RUnlock()
+	argsForCall := fake.pushBuildpackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushBuildpackReturns(result1 string, result2 error) {
+	fake.pushBuildpackMutex.Lock()
+	defer fake.pushBuildpackMutex.Unlock()
+	fake.PushBuildpackStub = nil
+	fake.pushBuildpackReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushBuildpackReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushBuildpackMutex.Lock()
+	defer fake.pushBuildpackMutex.Unlock()
+	fake.PushBuildpackStub = nil
+	if fake.pushBuildpackReturnsOnCall == nil {
+		fake.pushBuildpackReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushBuildpackReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushDroplet(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushDropletMutex.Lock()
+	ret, specificReturn := fake.pushDropletReturnsOnCall[len(fake.pushDropletArgsForCall)]
+	fake.pushDropletArgsForCall = append(fake.pushDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushDropletStub
+	fakeReturns := fake.pushDropletReturns
+	fake.recordInvocation("PushDroplet", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushDropletCallCount() int {
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
+	return len(fake.pushDropletArgsForCall)
+}
+
+func (fake *ImagePusher) PushDropletCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = stub
+}
+
+func (fake *ImagePusher) PushDropletArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
+	argsForCall := fake.pushDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushDropletReturns(result1 string, result2 error) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = nil
+	fake.pushDropletReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushDropletReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = nil
+	if fake.pushDropletReturnsOnCall == nil {
+		fake.pushDropletReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushDropletReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
 	fake.pushMutex.RLock()
 	defer fake.pushMutex.RUnlock()
+	fake.pushBuildpackMutex.RLock()
+	defer fake.pushBuildpackMutex.RUnlock()
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -74,6 +74,19 @@ type Klient struct {
 	patchReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PatchWithOptimisticLockStub        func(context.Context, client.Object, func() error) error
+	patchWithOptimisticLockMutex       sync.RWMutex
+	patchWithOptimisticLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 client.Object
+		arg3 func() error
+	}
+	patchWithOptimisticLockReturns struct {
+		result1 error
+	}
+	patchWithOptimisticLockReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WatchStub        func(context.Context, client.ObjectList, ...repositories.ListOption) (watch.Interface, error)
 	watchMutex       sync.RWMutex
 	watchArgsForCall []struct {
@@ -406,6 +419,69 @@ func (fake *Klient) PatchReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Klient) PatchWithOptimisticLock(arg1 context.Context, arg2 client.Object, arg3 func() error) error {
+	fake.patchWithOptimisticLockMutex.Lock()
+	ret, specificReturn := fake.patchWithOptimisticLockReturnsOnCall[len(fake.patchWithOptimisticLockArgsForCall)]
+	fake.patchWithOptimisticLockArgsForCall = append(fake.patchWithOptimisticLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 client.Object
+		arg3 func() error
+	}{arg1, arg2, arg3})
+	stub := fake.PatchWithOptimisticLockStub
+	fakeReturns := fake.patchWithOptimisticLockReturns
+	fake.recordInvocation("PatchWithOptimisticLock", []interface{}{arg1, arg2, arg3})
+	fake.patchWithOptimisticLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Klient) PatchWithOptimisticLockCallCount() int {
+	fake.patchWithOptimisticLockMutex.RLock()
+	defer fake.patchWithOptimisticLockMutex.RUnlock()
+	return len(fake.patchWithOptimisticLockArgsForCall)
+}
+
+func (fake *Klient) PatchWithOptimisticLockCalls(stub func(context.Context, client.Object, func() error) error) {
+	fake.patchWithOptimisticLockMutex.Lock()
+	defer fake.patchWithOptimisticLockMutex.Unlock()
+	fake.PatchWithOptimisticLockStub = stub
+}
+
+func (fake *Klient) PatchWithOptimisticLockArgsForCall(i int) (context.Context, client.Object, func() error) {
+	fake.patchWithOptimisticLockMutex.RLock()
+	defer fake.patchWithOptimisticLockMutex.RUnlock()
+	argsForCall := fake.patchWithOptimisticLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Klient) PatchWithOptimisticLockReturns(result1 error) {
+	fake.patchWithOptimisticLockMutex.Lock()
+	defer fake.patchWithOptimisticLockMutex.Unlock()
+	fake.PatchWithOptimisticLockStub = nil
+	fake.patchWithOptimisticLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Klient) PatchWithOptimisticLockReturnsOnCall(i int, result1 error) {
+	fake.patchWithOptimisticLockMutex.Lock()
+	defer fake.patchWithOptimisticLockMutex.Unlock()
+	fake.PatchWithOptimisticLockStub = nil
+	if fake.patchWithOptimisticLockReturnsOnCall == nil {
+		fake.patchWithOptimisticLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.patchWithOptimisticLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Klient) Watch(arg1 context.Context, arg2 client.ObjectList, arg3 ...repositories.ListOption) (watch.Interface, error) {
 	fake.watchMutex.Lock()
 	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
@@ -485,6 +561,8 @@ func (fake *Klient) Invocations() map[string][][]interface{} {
 	defer fake.listMutex.RUnlock()
 	fake.patchMutex.RLock()
 	defer fake.patchMutex.RUnlock()
+	fake.patchWithOptimisticLockMutex.RLock()
+	defer fake.patchWithOptimisticLockMutex.RUnlock()
 	fake.watchMutex.RLock()
 	defer fake.watchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
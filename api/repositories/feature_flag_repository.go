@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const FeatureFlagResourceType = "Feature Flag"
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type PatchFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+func (m PatchFeatureFlagMessage) apply(featureFlag *korifiv1alpha1.CFFeatureFlag) {
+	if m.Enabled != nil {
+		featureFlag.Spec.Enabled = *m.Enabled
+	}
+
+	if m.CustomErrorMessage != nil {
+		featureFlag.Spec.CustomErrorMessage = *m.CustomErrorMessage
+	}
+}
+
+type FeatureFlagRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewFeatureFlagRepo(klient Klient, rootNamespace string) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	featureFlag, err := r.getFeatureFlag(ctx, name)
+	if err != nil {
+		return FeatureFlagRecord{}, err
+	}
+
+	return toFeatureFlagRecord(featureFlag), nil
+}
+
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	featureFlagList := &korifiv1alpha1.CFFeatureFlagList{}
+	if err := r.klient.List(ctx, featureFlagList, InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	records := map[string]FeatureFlagRecord{}
+	for name := range korifiv1alpha1.DefaultFeatureFlags {
+		records[name] = toFeatureFlagRecord(r.defaultFeatureFlag(name))
+	}
+	for i := range featureFlagList.Items {
+		if _, ok := records[featureFlagList.Items[i].Name]; ok {
+			records[featureFlagList.Items[i].Name] = toFeatureFlagRecord(&featureFlagList.Items[i])
+		}
+	}
+
+	result := make([]FeatureFlagRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, record)
+	}
+	slices.SortFunc(result, func(a, b FeatureFlagRecord) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+func (r *FeatureFlagRepo) PatchFeatureFlag(ctx context.Context, authInfo authorization.Info, message PatchFeatureFlagMessage) (FeatureFlagRecord, error) {
+	featureFlag, err := r.getFeatureFlag(ctx, message.Name)
+	if err != nil {
+		return FeatureFlagRecord{}, err
+	}
+
+	if featureFlag.CreationTimestamp.IsZero() {
+		message.apply(featureFlag)
+		if err = r.klient.Create(ctx, featureFlag); err != nil {
+			return FeatureFlagRecord{}, fmt.Errorf("failed to create feature flag: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+		}
+
+		return toFeatureFlagRecord(featureFlag), nil
+	}
+
+	err = r.klient.Patch(ctx, featureFlag, func() error {
+		message.apply(featureFlag)
+		return nil
+	})
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to patch feature flag: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return toFeatureFlagRecord(featureFlag), nil
+}
+
+// EnsureFeatureFlagEnabled returns a FeatureDisabledError if the feature flag
+// is disabled. Admins are not subject to feature flags.
+func (r *FeatureFlagRepo) EnsureFeatureFlagEnabled(ctx context.Context, authInfo authorization.Info, name string) error {
+	featureFlag, err := r.getFeatureFlag(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if featureFlag.Spec.Enabled {
+		return nil
+	}
+
+	isAdmin, err := r.canIPatchFeatureFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin {
+		return nil
+	}
+
+	return apierrors.NewFeatureDisabledError(nil, name, featureFlag.Spec.CustomErrorMessage)
+}
+
+func (r *FeatureFlagRepo) getFeatureFlag(ctx context.Context, name string) (*korifiv1alpha1.CFFeatureFlag, error) {
+	if _, ok := korifiv1alpha1.DefaultFeatureFlags[name]; !ok {
+		return nil, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	featureFlag := r.defaultFeatureFlag(name)
+	err := r.klient.Get(ctx, featureFlag)
+	if k8serrors.IsNotFound(err) {
+		return r.defaultFeatureFlag(name), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flag: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return featureFlag, nil
+}
+
+func (r *FeatureFlagRepo) canIPatchFeatureFlags(ctx context.Context) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cffeatureflags",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIPatchFeatureFlags: failed to create self subject access review: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *FeatureFlagRepo) defaultFeatureFlag(name string) *korifiv1alpha1.CFFeatureFlag {
+	return &korifiv1alpha1.CFFeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      name,
+		},
+		Spec: korifiv1alpha1.CFFeatureFlagSpec{
+			Enabled: korifiv1alpha1.DefaultFeatureFlags[name],
+		},
+	}
+}
+
+func toFeatureFlagRecord(featureFlag *korifiv1alpha1.CFFeatureFlag) FeatureFlagRecord {
+	return FeatureFlagRecord{
+		Name:               featureFlag.Name,
+		Enabled:            featureFlag.Spec.Enabled,
+		CustomErrorMessage: featureFlag.Spec.CustomErrorMessage,
+		UpdatedAt:          getLastUpdatedTime(featureFlag),
+	}
+}
@@ -0,0 +1,229 @@
+package repositories_test
+
+import (
+	"slices"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("FeatureFlagRepository", func() {
+	var featureFlagRepo *repositories.FeatureFlagRepo
+
+	BeforeEach(func() {
+		featureFlagRepo = repositories.NewFeatureFlagRepo(klient, rootNamespace)
+	})
+
+	createFeatureFlag := func(name string, enabled bool, customErrorMessage string) {
+		GinkgoHelper()
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      name,
+			},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled:            enabled,
+				CustomErrorMessage: customErrorMessage,
+			},
+		})).To(Succeed())
+	}
+
+	Describe("GetFeatureFlag", func() {
+		var (
+			featureFlagName string
+			featureFlag     repositories.FeatureFlagRecord
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			featureFlagName = korifiv1alpha1.FeatureFlagTaskCreation
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, getErr = featureFlagRepo.GetFeatureFlag(ctx, authInfo, featureFlagName)
+		})
+
+		It("returns the default value", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(featureFlag).To(Equal(repositories.FeatureFlagRecord{
+				Name:    korifiv1alpha1.FeatureFlagTaskCreation,
+				Enabled: true,
+			}))
+		})
+
+		When("the feature flag has been set", func() {
+			BeforeEach(func() {
+				createFeatureFlag(korifiv1alpha1.FeatureFlagTaskCreation, false, "no tasks")
+			})
+
+			It("returns it", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Enabled).To(BeFalse())
+				Expect(featureFlag.CustomErrorMessage).To(Equal("no tasks"))
+				Expect(featureFlag.UpdatedAt).NotTo(BeNil())
+			})
+		})
+
+		When("the feature flag is unknown", func() {
+			BeforeEach(func() {
+				featureFlagName = "not-a-flag"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListFeatureFlags", func() {
+		var (
+			featureFlags []repositories.FeatureFlagRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			createFeatureFlag(korifiv1alpha1.FeatureFlagRouteCreation, false, "")
+		})
+
+		JustBeforeEach(func() {
+			featureFlags, listErr = featureFlagRepo.ListFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns all the feature flags sorted by name", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(featureFlags).To(HaveLen(len(korifiv1alpha1.DefaultFeatureFlags)))
+			Expect(slices.IsSortedFunc(featureFlags, func(a, b repositories.FeatureFlagRecord) int {
+				return strings.Compare(a.Name, b.Name)
+			})).To(BeTrue())
+			Expect(featureFlags).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal(korifiv1alpha1.FeatureFlagRouteCreation),
+					"Enabled": BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal(korifiv1alpha1.FeatureFlagTaskCreation),
+					"Enabled": BeTrue(),
+				}),
+			))
+		})
+	})
+
+	Describe("PatchFeatureFlag", func() {
+		var (
+			message     repositories.PatchFeatureFlagMessage
+			featureFlag repositories.FeatureFlagRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchFeatureFlagMessage{
+				Name:               korifiv1alpha1.FeatureFlagServiceInstanceCreation,
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no services"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, patchErr = featureFlagRepo.PatchFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the feature flag", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Enabled).To(BeFalse())
+				Expect(featureFlag.CustomErrorMessage).To(Equal("no services"))
+
+				cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.FeatureFlagServiceInstanceCreation,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfFeatureFlag), cfFeatureFlag)).To(Succeed())
+				Expect(cfFeatureFlag.Spec.Enabled).To(BeFalse())
+				Expect(cfFeatureFlag.Spec.CustomErrorMessage).To(Equal("no services"))
+			})
+
+			When("the feature flag already exists", func() {
+				BeforeEach(func() {
+					createFeatureFlag(korifiv1alpha1.FeatureFlagServiceInstanceCreation, false, "old message")
+					message.Enabled = tools.PtrTo(true)
+					message.CustomErrorMessage = nil
+				})
+
+				It("patches it", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(featureFlag.Enabled).To(BeTrue())
+					Expect(featureFlag.CustomErrorMessage).To(Equal("old message"))
+				})
+			})
+
+			When("the feature flag is unknown", func() {
+				BeforeEach(func() {
+					message.Name = "not-a-flag"
+				})
+
+				It("returns a not found error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("EnsureFeatureFlagEnabled", func() {
+		var ensureErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			ensureErr = featureFlagRepo.EnsureFeatureFlagEnabled(ctx, authInfo, korifiv1alpha1.FeatureFlagTaskCreation)
+		})
+
+		It("succeeds", func() {
+			Expect(ensureErr).NotTo(HaveOccurred())
+		})
+
+		When("the feature flag is disabled", func() {
+			BeforeEach(func() {
+				createFeatureFlag(korifiv1alpha1.FeatureFlagTaskCreation, false, "")
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(ensureErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("succeeds", func() {
+					Expect(ensureErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
@@ -23,11 +23,23 @@ const SourceImageResourceType = "SourceImage"
 
 type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	PushDroplet(ctx context.Context, creds image.Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error)
+	PushBuildpack(ctx context.Context, creds image.Creds, repoRef string, archiveReader io.Reader, tags ...string) (string, error)
+	Copy(ctx context.Context, creds image.Creds, imageRef string, repoRef string, tags ...string) (string, error)
+}
+
+//counterfeiter:generate -o fake -fake-name ImageDownloader . ImageDownloader
+
+type ImageDownloader interface {
+	Download(ctx context.Context, creds image.Creds, imageRef string, w io.Writer) error
+	DownloadZip(ctx context.Context, creds image.Creds, imageRef string, w io.Writer) error
+	Exists(ctx context.Context, creds image.Creds, imageRef string) (bool, error)
 }
 
 type ImageRepository struct {
 	klient              Klient
 	pusher              ImagePusher
+	downloader          ImageDownloader
 	pushSecretNames     []string
 	pushSecretNamespace string
 }
@@ -35,12 +47,14 @@ type ImageRepository struct {
 func NewImageRepository(
 	klient Klient,
 	pusher ImagePusher,
+	downloader ImageDownloader,
 	pushSecretNames []string,
 	pushSecretNamespace string,
 ) *ImageRepository {
 	return &ImageRepository{
 		klient:              klient,
 		pusher:              pusher,
+		downloader:          downloader,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
 	}
@@ -61,10 +75,106 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
 	}
 
-	pushedRef, err := r.pusher.Push(ctx, image.Creds{
-		Namespace:   r.pushSecretNamespace,
-		SecretNames: r.pushSecretNames,
-	}, imageRef, srcReader, tags...)
+	return r.push(ctx, r.pusher.Push, imageRef, srcReader, tags...)
+}
+
+// CopySourceImage copies the bits of a package into the repository of another
+// package, without uploading them again
+func (r *ImageRepository) CopySourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, repoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy source image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	copiedRef, err := r.pusher.Copy(ctx, r.creds(), imageRef, repoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", imageRef, repoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+// DownloadSourceImage writes the package bits as a zip archive. Callers are
+// expected to have fetched the package with the user's permissions already
+func (r *ImageRepository) DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, w io.Writer) error {
+	if err := r.downloader.DownloadZip(ctx, r.creds(), imageRef, w); err != nil {
+		return apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return nil
+}
+
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatchCFBuild(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	return r.push(ctx, r.pusher.PushDroplet, imageRef, srcReader, tags...)
+}
+
+// CopyDropletImage copies a droplet image into the droplet repository of
+// another app, without uploading it again
+func (r *ImageRepository) CopyDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, repoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatchCFBuild(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	copiedRef, err := r.pusher.Copy(ctx, r.creds(), imageRef, repoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", imageRef, repoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+// UploadBuildpackImage pushes a `.cnb` buildpack archive. Buildpacks live in
+// the root namespace, which is also where the push secrets are.
+func (r *ImageRepository) UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, tags ...string) (string, error) {
+	authorized, err := r.canIPatchCFBuildpack(ctx, authInfo)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload buildpack image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuildpack"), BuildpackResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.push(ctx, r.pusher.PushBuildpack, imageRef, srcReader, tags...)
+	if errors.Is(err, image.ErrNotBuildpackArchive) {
+		return "", apierrors.NewUnprocessableEntityError(err, "Buildpacks must be uploaded as .cnb archives")
+	}
+
+	return pushedRef, err
+}
+
+type pushFunc func(ctx context.Context, creds image.Creds, repoRef string, reader io.Reader, tags ...string) (string, error)
+
+func (r *ImageRepository) push(ctx context.Context, push pushFunc, imageRef string, srcReader io.Reader, tags ...string) (string, error) {
+	pushedRef, err := push(ctx, r.creds(), imageRef, srcReader, tags...)
 	if err != nil {
 		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing image ref '%s' failed: %w", imageRef, err))
 	}
@@ -72,6 +182,34 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
+// DownloadDropletImage writes the droplet image as a tarball. Callers are
+// expected to have fetched the droplet with the user's permissions already
+func (r *ImageRepository) DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, w io.Writer) error {
+	if err := r.downloader.Download(ctx, r.creds(), imageRef, w); err != nil {
+		return apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return nil
+}
+
+// DropletImageExists tells whether the droplet image is still in the registry,
+// as registry retention policies may have deleted it
+func (r *ImageRepository) DropletImageExists(ctx context.Context, imageRef string) (bool, error) {
+	exists, err := r.downloader.Exists(ctx, r.creds(), imageRef)
+	if err != nil {
+		return false, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("checking image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return exists, nil
+}
+
+func (r *ImageRepository) creds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
 func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
@@ -89,3 +227,39 @@ func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo autho
 
 	return review.Status.Allowed, nil
 }
+
+func (r *ImageRepository) canIPatchCFBuild(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: spaceGUID,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfbuilds",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIPatchCFBuild: failed to create self subject access review: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *ImageRepository) canIPatchCFBuildpack(ctx context.Context, authInfo authorization.Info) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.pushSecretNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfbuildpacks",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIPatchCFBuildpack: failed to create self subject access review: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
@@ -2,13 +2,16 @@ package repositories_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,21 +19,25 @@ import (
 
 var _ = Describe("ImageRepository", func() {
 	var (
-		imagePusher *fake.ImagePusher
-		imageSource io.Reader
-		imageRepo   *repositories.ImageRepository
-		imageName   string
-		imageRef    string
-		tags        []string
-		uploadErr   error
-		org         *korifiv1alpha1.CFOrg
-		space       *korifiv1alpha1.CFSpace
+		imagePusher     *fake.ImagePusher
+		imageDownloader *fake.ImageDownloader
+		imageSource     io.Reader
+		imageRepo       *repositories.ImageRepository
+		imageName       string
+		imageRef        string
+		tags            []string
+		uploadErr       error
+		org             *korifiv1alpha1.CFOrg
+		space           *korifiv1alpha1.CFSpace
 	)
 
 	BeforeEach(func() {
 		imageName = "my-image"
 		imagePusher = new(fake.ImagePusher)
 		imagePusher.PushReturns("my-pushed-image", nil)
+		imagePusher.PushDropletReturns("my-pushed-image", nil)
+		imagePusher.PushBuildpackReturns("my-pushed-image", nil)
+		imageDownloader = new(fake.ImageDownloader)
 
 		imageSource = bytes.NewBufferString("")
 
@@ -45,61 +52,323 @@ var _ = Describe("ImageRepository", func() {
 		imageRepo = repositories.NewImageRepository(
 			klientUnfiltered,
 			imagePusher,
+			imageDownloader,
 			[]string{"push-secret-name"},
 			rootNamespace,
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+	Describe("UploadSourceImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imagePusher.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
 	})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	Describe("UploadDropletImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadDropletImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+
+				Expect(imagePusher.PushDropletCallCount()).To(Equal(1))
+				_, creds, actualRef, reader, actualTags := imagePusher.PushDropletArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(reader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushDropletReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+				})
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("UploadBuildpackImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadBuildpackImage(ctx, authInfo, imageName, imageSource, tags...)
+		})
+
+		It("fails with unauthorized error without the admin role", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+
+				Expect(imagePusher.PushBuildpackCallCount()).To(Equal(1))
+				_, creds, actualRef, reader, actualTags := imagePusher.PushBuildpackArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(reader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the archive is not a buildpack archive", func() {
+				BeforeEach(func() {
+					imagePusher.PushBuildpackReturns("", fmt.Errorf("pushing failed: %w", image.ErrNotBuildpackArchive))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DownloadDropletImage", func() {
+		var (
+			output      *bytes.Buffer
+			downloadErr error
+		)
+
 		BeforeEach(func() {
-			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			output = new(bytes.Buffer)
+			imageDownloader.DownloadStub = func(_ context.Context, _ image.Creds, _ string, w io.Writer) error {
+				_, err := w.Write([]byte("the-image-tarball"))
+				return err
+			}
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			downloadErr = imageRepo.DownloadDropletImage(ctx, authInfo, "my-image@sha256:abc", output)
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+		It("downloads the image from the registry", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("the-image-tarball"))
+
+			Expect(imageDownloader.DownloadCallCount()).To(Equal(1))
+			_, creds, actualRef, _ := imageDownloader.DownloadArgsForCall(0)
 			Expect(creds.Namespace).To(Equal(rootNamespace))
 			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+			Expect(actualRef).To(Equal("my-image@sha256:abc"))
 		})
 
-		When("the image name is invalid", func() {
+		When("downloading the image fails", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				imageDownloader.DownloadStub = nil
+				imageDownloader.DownloadReturns(errors.New("download-error"))
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			It("fails with a blobstore unavailable error", func() {
+				Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
+				var apiError apierrors.BlobstoreUnavailableError
+				Expect(errors.As(downloadErr, &apiError)).To(BeTrue())
 			})
 		})
+	})
+
+	Describe("CopySourceImage", func() {
+		var copyErr error
+
+		BeforeEach(func() {
+			imagePusher.CopyReturns("my-copied-image", nil)
+		})
+
+		JustBeforeEach(func() {
+			imageRef, copyErr = imageRepo.CopySourceImage(ctx, authInfo, "source-image@sha256:abc", imageName, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image within the registry", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-copied-image"))
+
+				Expect(imagePusher.CopyCallCount()).To(Equal(1))
+				_, creds, actualImageRef, actualRepoRef, actualTags := imagePusher.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualImageRef).To(Equal("source-image@sha256:abc"))
+				Expect(actualRepoRef).To(Equal("my-image"))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(copyErr, &apiError)).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("CopyDropletImage", func() {
+		var copyErr error
+
+		BeforeEach(func() {
+			imagePusher.CopyReturns("my-copied-image", nil)
+		})
+
+		JustBeforeEach(func() {
+			imageRef, copyErr = imageRepo.CopyDropletImage(ctx, authInfo, "source-image@sha256:abc", imageName, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image within the registry", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-copied-image"))
+
+				Expect(imagePusher.CopyCallCount()).To(Equal(1))
+				_, creds, actualImageRef, actualRepoRef, actualTags := imagePusher.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualImageRef).To(Equal("source-image@sha256:abc"))
+				Expect(actualRepoRef).To(Equal("my-image"))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(copyErr, &apiError)).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("DownloadSourceImage", func() {
+		var (
+			output      *bytes.Buffer
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			output = new(bytes.Buffer)
+			imageDownloader.DownloadZipStub = func(_ context.Context, _ image.Creds, _ string, w io.Writer) error {
+				_, err := w.Write([]byte("the-zip"))
+				return err
+			}
+		})
+
+		JustBeforeEach(func() {
+			downloadErr = imageRepo.DownloadSourceImage(ctx, authInfo, "my-image@sha256:abc", output)
+		})
+
+		It("downloads the package bits as a zip", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("the-zip"))
+
+			Expect(imageDownloader.DownloadZipCallCount()).To(Equal(1))
+			_, creds, actualRef, _ := imageDownloader.DownloadZipArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-image@sha256:abc"))
+		})
 
-		When("pushing the image fails", func() {
+		When("downloading the image fails", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				imageDownloader.DownloadZipStub = nil
+				imageDownloader.DownloadZipReturns(errors.New("download-error"))
 			})
 
 			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+				Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
 				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				Expect(errors.As(downloadErr, &apiError)).To(BeTrue())
 			})
 		})
 	})
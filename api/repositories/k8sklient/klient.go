@@ -63,6 +63,16 @@ func (k *K8sKlient) Create(ctx context.Context, obj client.Object) error {
 }
 
 func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func() error) error {
+	return k.patch(ctx, obj, modify)
+}
+
+// PatchWithOptimisticLock patches the object only if it has not been changed
+// since it was read, failing with a conflict error otherwise
+func (k *K8sKlient) PatchWithOptimisticLock(ctx context.Context, obj client.Object, modify func() error) error {
+	return k.patch(ctx, obj, modify, client.MergeFromWithOptimisticLock{})
+}
+
+func (k *K8sKlient) patch(ctx context.Context, obj client.Object, modify func() error, mergeOpts ...client.MergeFromOption) error {
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	userClient, err := k.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -76,7 +86,7 @@ func (k *K8sKlient) Patch(ctx context.Context, obj client.Object, modify func()
 		return err
 	}
 
-	err = userClient.Patch(ctx, obj, client.MergeFrom(oldObject))
+	err = userClient.Patch(ctx, obj, client.MergeFromWithOptions(oldObject, mergeOpts...))
 	if err != nil {
 		return fmt.Errorf("failed to patch: %w", err)
 	}
@@ -151,8 +161,14 @@ func getResourceType(obj client.Object) (string, error) {
 	switch obj.(type) {
 	case *korifiv1alpha1.CFApp:
 		return repositories.AppResourceType, nil
+	case *korifiv1alpha1.CFAppRevision:
+		return repositories.RevisionResourceType, nil
+	case *korifiv1alpha1.CFAuditEvent:
+		return repositories.AuditEventResourceType, nil
 	case *korifiv1alpha1.CFBuild:
 		return repositories.BuildResourceType, nil
+	case *korifiv1alpha1.CFBuildCacheClear:
+		return repositories.BuildCacheResourceType, nil
 	case *korifiv1alpha1.CFDomain:
 		return repositories.DomainResourceType, nil
 	case *korifiv1alpha1.CFPackage:
@@ -186,6 +202,8 @@ func toK8sListOptions(opts ...repositories.ListOption) (client.ListOptions, erro
 		LabelSelector: newLabelSelector(listOpts.Requrements),
 		FieldSelector: listOpts.FieldSelector,
 		Namespace:     listOpts.Namespace,
+		Limit:         listOpts.Limit,
+		Continue:      listOpts.Continue,
 	}, nil
 }
 
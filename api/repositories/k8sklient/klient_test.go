@@ -266,6 +266,41 @@ var _ = Describe("Klient", func() {
 		})
 	})
 
+	Describe("PatchWithOptimisticLock", func() {
+		BeforeEach(func() {
+			obj.SetResourceVersion("42")
+		})
+
+		JustBeforeEach(func() {
+			err = klient.PatchWithOptimisticLock(ctx, obj, func() error {
+				obj.SetLabels(map[string]string{"foo": "bar"})
+				return nil
+			})
+		})
+
+		It("patches the object with its resource version", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(userClient.PatchCallCount()).To(Equal(1))
+			_, _, actualPatch, _ := userClient.PatchArgsForCall(0)
+
+			var actualPatchData []byte
+			actualPatchData, err = actualPatch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualPatchData)).To(MatchJSON(`{"metadata":{"labels":{"foo":"bar"},"resourceVersion":"42"}}`))
+		})
+
+		When("the user client fails", func() {
+			BeforeEach(func() {
+				userClient.PatchReturns(errors.New("patch-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(ContainSubstring("patch-err")))
+			})
+		})
+	})
+
 	Describe("List", func() {
 		var (
 			objectList client.ObjectList
@@ -300,6 +335,23 @@ var _ = Describe("Klient", func() {
 			Expect(actualOpts).To(ConsistOf(PointTo(BeZero())))
 		})
 
+		When("paging the list", func() {
+			BeforeEach(func() {
+				listOpts = []repositories.ListOption{repositories.Limit(10), repositories.Continue("continue-token")}
+			})
+
+			It("passes the limit and continue token to the user client", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(userClient.ListCallCount()).To(Equal(1))
+				_, _, actualOpts := userClient.ListArgsForCall(0)
+				Expect(actualOpts).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Limit":    BeEquivalentTo(10),
+					"Continue": Equal("continue-token"),
+				}))))
+			})
+		})
+
 		When("creating the user client fails", func() {
 			BeforeEach(func() {
 				userClientFactory.BuildClientReturns(nil, errors.New("err-build-client"))
@@ -16,6 +16,7 @@ type Klient interface {
 	Get(ctx context.Context, obj client.Object) error
 	Create(ctx context.Context, obj client.Object) error
 	Patch(ctx context.Context, obj client.Object, modify func() error) error
+	PatchWithOptimisticLock(ctx context.Context, obj client.Object, modify func() error) error
 	List(ctx context.Context, list client.ObjectList, opts ...ListOption) error
 	Watch(ctx context.Context, obj client.ObjectList, opts ...ListOption) (watch.Interface, error)
 	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
@@ -25,6 +26,8 @@ type ListOptions struct {
 	Namespace     string
 	FieldSelector fields.Selector
 	Requrements   []labels.Requirement
+	Limit         int64
+	Continue      string
 }
 
 type ListOption interface {
@@ -119,6 +122,22 @@ func (o InNamespace) ApplyToList(opts *ListOptions) error {
 	return nil
 }
 
+// Limit caps the number of objects returned by a single list call. The
+// continue token of the returned list fetches the next objects.
+type Limit int64
+
+func (l Limit) ApplyToList(opts *ListOptions) error {
+	opts.Limit = int64(l)
+	return nil
+}
+
+type Continue string
+
+func (c Continue) ApplyToList(opts *ListOptions) error {
+	opts.Continue = string(c)
+	return nil
+}
+
 type MatchingFields fields.Set
 
 func (m MatchingFields) ApplyToList(opts *ListOptions) error {
@@ -3,25 +3,45 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapprevisions;cfapps;cfauditevents;cfbuildcacheclears;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
 
 var (
+	CFAppRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfapprevisions",
+	}
+
 	CFAppsGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
 		Resource: "cfapps",
 	}
 
+	CFAuditEventsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfauditevents",
+	}
+
+	CFBuildCacheClearsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfbuildcacheclears",
+	}
+
 	CFBuildsGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -84,11 +104,14 @@ var (
 
 	ResourceMap = map[string]schema.GroupVersionResource{
 		AppResourceType:             CFAppsGVR,
+		AuditEventResourceType:      CFAuditEventsGVR,
 		BuildResourceType:           CFBuildsGVR,
+		BuildCacheResourceType:      CFBuildCacheClearsGVR,
 		DropletResourceType:         CFDropletsGVR,
 		DomainResourceType:          CFDomainsGVR,
 		PackageResourceType:         CFPackagesGVR,
 		ProcessResourceType:         CFProcessesGVR,
+		RevisionResourceType:        CFAppRevisionsGVR,
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
@@ -126,11 +149,21 @@ func (nr NamespaceRetriever) NamespaceFor(ctx context.Context, resourceGUID, res
 		return "", apierrors.NewNotFoundError(fmt.Errorf("resource %q not found", resourceGUID), resourceType)
 	}
 
-	if len(list.Items) > 1 {
+	items := list.Items
+	if len(items) > 1 {
+		// a resource that is recreated in another namespace (e.g. a route
+		// being transferred to another space) exists twice until the
+		// original one is gone
+		items = slices.DeleteFunc(items, func(item unstructured.Unstructured) bool {
+			return item.GetDeletionTimestamp() != nil
+		})
+	}
+
+	if len(items) != 1 {
 		return "", fmt.Errorf("get-%s duplicate records exist", strings.ToLower(resourceType))
 	}
 
-	metadata := list.Items[0].Object["metadata"].(map[string]interface{})
+	metadata := items[0].Object["metadata"].(map[string]interface{})
 
 	ns := metadata["namespace"].(string)
 
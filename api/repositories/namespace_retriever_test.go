@@ -3,6 +3,7 @@ package repositories_test
 import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"k8s.io/client-go/dynamic"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -73,4 +74,26 @@ var _ = Describe("NamespaceRetriever", func() {
 			Expect(retErr).To(MatchError(ContainSubstring("duplicate records exist")))
 		})
 	})
+
+	When("a duplicate guid is being deleted", func() {
+		BeforeEach(func() {
+			space2 := createSpaceWithCleanup(ctx, orgGUID, prefixedGUID("space2"))
+			terminatingApp := createAppCR(ctx, k8sClient, "app2", appGUID, space2.Name, "STOPPED")
+			Expect(k8s.PatchResource(ctx, k8sClient, terminatingApp, func() {
+				terminatingApp.Finalizers = append(terminatingApp.Finalizers, "test/finalizer")
+			})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, terminatingApp)).To(Succeed())
+
+			DeferCleanup(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, terminatingApp, func() {
+					terminatingApp.Finalizers = nil
+				})).To(Succeed())
+			})
+		})
+
+		It("returns the namespace of the resource that is not being deleted", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retNS).To(Equal(spaceGUID))
+		})
+	})
 })
@@ -35,9 +35,10 @@ const (
 	PackageResourceType = "Package"
 )
 
-var packageTypeToLifecycleType = map[korifiv1alpha1.PackageType]korifiv1alpha1.LifecycleType{
-	"bits":   "buildpack",
-	"docker": "docker",
+var lifecycleTypeToPackageType = map[korifiv1alpha1.LifecycleType]korifiv1alpha1.PackageType{
+	korifiv1alpha1.BuildpackLifecycle:  korifiv1alpha1.BitsPackage,
+	korifiv1alpha1.DockerLifecycle:     korifiv1alpha1.DockerPackage,
+	korifiv1alpha1.DockerfileLifecycle: korifiv1alpha1.BitsPackage,
 }
 
 type PackageRepo struct {
@@ -76,6 +77,8 @@ type PackageRecord struct {
 	Labels      map[string]string
 	Annotations map[string]string
 	ImageRef    string
+	// The image holding the package bits, empty until they are uploaded
+	SourceImageRef string
 }
 
 func (r PackageRecord) Relationships() map[string]string {
@@ -226,7 +229,7 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
 	}
 
-	if packageTypeToLifecycleType[cfPackage.Spec.Type] != cfApp.Spec.Lifecycle.Type {
+	if lifecycleTypeToPackageType[cfApp.Spec.Lifecycle.Type] != cfPackage.Spec.Type {
 		return PackageRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("cannot create %s package for a %s app", cfPackage.Spec.Type, cfApp.Spec.Lifecycle.Type))
 	}
 
@@ -386,17 +389,18 @@ func (r *PackageRepo) cfPackageToPackageRecord(cfPackage korifiv1alpha1.CFPackag
 		state = PackageStateReady
 	}
 	return PackageRecord{
-		GUID:        cfPackage.Name,
-		UID:         cfPackage.UID,
-		SpaceGUID:   cfPackage.Namespace,
-		Type:        string(cfPackage.Spec.Type),
-		AppGUID:     cfPackage.Spec.AppRef.Name,
-		State:       state,
-		CreatedAt:   cfPackage.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfPackage),
-		Labels:      cfPackage.Labels,
-		Annotations: cfPackage.Annotations,
-		ImageRef:    r.repositoryRef(cfPackage),
+		GUID:           cfPackage.Name,
+		UID:            cfPackage.UID,
+		SpaceGUID:      cfPackage.Namespace,
+		Type:           string(cfPackage.Spec.Type),
+		AppGUID:        cfPackage.Spec.AppRef.Name,
+		State:          state,
+		CreatedAt:      cfPackage.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfPackage),
+		Labels:         cfPackage.Labels,
+		Annotations:    cfPackage.Annotations,
+		ImageRef:       r.repositoryRef(cfPackage),
+		SourceImageRef: cfPackage.Spec.Source.Registry.Image,
 	}
 }
 
@@ -221,6 +221,19 @@ var _ = Describe("PackageRepository", func() {
 						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
 					})
 				})
+
+				When("the referenced app has dockerfile lifecycle type", func() {
+					BeforeEach(func() {
+						app.Spec.Lifecycle = korifiv1alpha1.Lifecycle{
+							Type: "dockerfile",
+						}
+					})
+
+					It("creates a bits package", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(createdPackage.Type).To(Equal("bits"))
+					})
+				})
 			})
 
 			Describe("docker package", func() {
@@ -245,6 +258,7 @@ var _ = Describe("PackageRepository", func() {
 					Expect(createdPackage.Labels).To(HaveKeyWithValue("bob", "foo"))
 					Expect(createdPackage.Annotations).To(HaveKeyWithValue("jim", "bar"))
 					Expect(createdPackage.ImageRef).To(Equal("some/image"))
+					Expect(createdPackage.SourceImageRef).To(Equal("some/image"))
 
 					Expect(createdPackage.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 					Expect(createdPackage.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
@@ -736,6 +750,7 @@ var _ = Describe("PackageRepository", func() {
 				Expect(returnedPackageRecord.Type).To(Equal(string(existingCFPackage.Spec.Type)))
 				Expect(returnedPackageRecord.AppGUID).To(Equal(existingCFPackage.Spec.AppRef.Name))
 				Expect(returnedPackageRecord.SpaceGUID).To(Equal(existingCFPackage.Namespace))
+				Expect(returnedPackageRecord.SourceImageRef).To(Equal(packageSourceImageRef))
 
 				Expect(returnedPackageRecord.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(returnedPackageRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
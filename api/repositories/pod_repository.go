@@ -7,11 +7,17 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	corev1 "k8s.io/api/core/v1"
 )
 
+type PodRecord struct {
+	Name      string
+	SpaceGUID string
+}
+
 type PodRepo struct {
 	klient Klient
 }
@@ -52,3 +58,34 @@ func (r *PodRepo) DeletePod(ctx context.Context, authInfo authorization.Info, ap
 	}
 	return nil
 }
+
+func (r *PodRepo) GetRunningPod(ctx context.Context, authInfo authorization.Info, appRevision string, process ProcessRecord, instanceIndex string) (PodRecord, error) {
+	podList := corev1.PodList{}
+	err := r.klient.List(ctx, &podList,
+		InNamespace(process.SpaceGUID),
+		WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, process.AppGUID),
+		WithLabel("korifi.cloudfoundry.org/version", appRevision),
+		WithLabel(korifiv1alpha1.CFProcessTypeLabelKey, process.Type),
+		WithLabel(korifiv1alpha1.PodIndexLabelKey, instanceIndex),
+	)
+	if err != nil {
+		return PodRecord{}, fmt.Errorf("failed to list pods: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	runningPods := itx.FromSlice(podList.Items).Filter(func(pod corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil
+	}).Collect()
+
+	if len(runningPods) == 0 {
+		return PodRecord{}, apierrors.NewNotFoundError(nil, PodResourceType)
+	}
+
+	if len(runningPods) > 1 {
+		return PodRecord{}, apierrors.NewUnprocessableEntityError(nil, "multiple pods found")
+	}
+
+	return PodRecord{
+		Name:      runningPods[0].Name,
+		SpaceGUID: runningPods[0].Namespace,
+	}, nil
+}
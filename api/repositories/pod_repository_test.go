@@ -5,6 +5,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,4 +157,72 @@ var _ = Describe("PodRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetRunningPod", func() {
+		var (
+			podRecord repositories.PodRecord
+			err       error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+				pod.Labels[korifiv1alpha1.PodIndexLabelKey] = "2"
+				pod.Status.Phase = corev1.PodRunning
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			podRecord, err = podRepo.GetRunningPod(ctx, authInfo, appRevision, process, instance)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the pod of the instance", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podRecord).To(Equal(repositories.PodRecord{
+					Name:      "podname-2",
+					SpaceGUID: space.Name,
+				}))
+			})
+
+			When("the instance does not exist", func() {
+				BeforeEach(func() {
+					instance = "3"
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the pod is not running", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+						pod.Status.Phase = corev1.PodPending
+					})).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the pod belongs to another app revision", func() {
+				BeforeEach(func() {
+					appRevision = "2"
+				})
+
+				It("returns a not found error", func() {
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
 })
@@ -30,20 +30,21 @@ type ProcessRepo struct {
 }
 
 type ProcessRecord struct {
-	GUID             string
-	SpaceGUID        string
-	AppGUID          string
-	Type             string
-	Command          string
-	DesiredInstances int32
-	MemoryMB         int64
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	InstancesStatus  map[string]korifiv1alpha1.InstanceStatus
+	GUID                 string
+	SpaceGUID            string
+	AppGUID              string
+	Type                 string
+	Command              string
+	DesiredInstances     int32
+	MemoryMB             int64
+	DiskQuotaMB          int64
+	HealthCheck          HealthCheck
+	ReadinessHealthCheck ReadinessHealthCheck
+	Labels               map[string]string
+	Annotations          map[string]string
+	CreatedAt            time.Time
+	UpdatedAt            *time.Time
+	InstancesStatus      map[string]korifiv1alpha1.InstanceStatus
 }
 
 func (r ProcessRecord) Relationships() map[string]string {
@@ -67,6 +68,17 @@ type HealthCheckData struct {
 	TimeoutSeconds           int32
 }
 
+type ReadinessHealthCheck struct {
+	Type string
+	Data ReadinessHealthCheckData
+}
+
+type ReadinessHealthCheckData struct {
+	HTTPEndpoint             string
+	InvocationTimeoutSeconds int32
+	IntervalSeconds          int32
+}
+
 type ScaleProcessMessage struct {
 	GUID      string
 	SpaceGUID string
@@ -80,28 +92,33 @@ type ProcessScaleValues struct {
 }
 
 type CreateProcessMessage struct {
-	AppGUID          string
-	SpaceGUID        string
-	Type             string
-	Command          string
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	DesiredInstances *int32
-	MemoryMB         int64
+	AppGUID              string
+	SpaceGUID            string
+	Type                 string
+	Command              string
+	DiskQuotaMB          int64
+	HealthCheck          HealthCheck
+	ReadinessHealthCheck ReadinessHealthCheck
+	DesiredInstances     *int32
+	MemoryMB             int64
 }
 
 type PatchProcessMessage struct {
-	SpaceGUID                           string
-	ProcessGUID                         string
-	Command                             *string
-	DiskQuotaMB                         *int64
-	HealthCheckHTTPEndpoint             *string
-	HealthCheckInvocationTimeoutSeconds *int32
-	HealthCheckTimeoutSeconds           *int32
-	HealthCheckType                     *string
-	DesiredInstances                    *int32
-	MemoryMB                            *int64
-	MetadataPatch                       *MetadataPatch
+	SpaceGUID                                    string
+	ProcessGUID                                  string
+	Command                                      *string
+	DiskQuotaMB                                  *int64
+	HealthCheckHTTPEndpoint                      *string
+	HealthCheckInvocationTimeoutSeconds          *int32
+	HealthCheckTimeoutSeconds                    *int32
+	HealthCheckType                              *string
+	ReadinessHealthCheckHTTPEndpoint             *string
+	ReadinessHealthCheckInvocationTimeoutSeconds *int32
+	ReadinessHealthCheckIntervalSeconds          *int32
+	ReadinessHealthCheckType                     *string
+	DesiredInstances                             *int32
+	MemoryMB                                     *int64
+	MetadataPatch                                *MetadataPatch
 }
 
 type ListProcessesMessage struct {
@@ -189,6 +206,10 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 				Type: korifiv1alpha1.HealthCheckType(message.HealthCheck.Type),
 				Data: korifiv1alpha1.HealthCheckData(message.HealthCheck.Data),
 			},
+			ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+				Type: korifiv1alpha1.HealthCheckType(message.ReadinessHealthCheck.Type),
+				Data: korifiv1alpha1.ReadinessHealthCheckData(message.ReadinessHealthCheck.Data),
+			},
 			DesiredInstances: message.DesiredInstances,
 			MemoryMB:         message.MemoryMB,
 			DiskQuotaMB:      message.DiskQuotaMB,
@@ -250,6 +271,18 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.HealthCheckTimeoutSeconds != nil {
 			updatedProcess.Spec.HealthCheck.Data.TimeoutSeconds = *message.HealthCheckTimeoutSeconds
 		}
+		if message.ReadinessHealthCheckType != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.ReadinessHealthCheckType)
+		}
+		if message.ReadinessHealthCheckHTTPEndpoint != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint = *message.ReadinessHealthCheckHTTPEndpoint
+		}
+		if message.ReadinessHealthCheckInvocationTimeoutSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *message.ReadinessHealthCheckInvocationTimeoutSeconds
+		}
+		if message.ReadinessHealthCheckIntervalSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds = *message.ReadinessHealthCheckIntervalSeconds
+		}
 		if message.MetadataPatch != nil {
 			message.MetadataPatch.Apply(updatedProcess)
 		}
@@ -286,6 +319,14 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
 			},
 		},
+		ReadinessHealthCheck: ReadinessHealthCheck{
+			Type: string(cfProcess.Spec.ReadinessHealthCheck.Type),
+			Data: ReadinessHealthCheckData{
+				HTTPEndpoint:             cfProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint,
+				InvocationTimeoutSeconds: cfProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds,
+				IntervalSeconds:          cfProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds,
+			},
+		},
 		Labels:          cfProcess.Labels,
 		Annotations:     cfProcess.Annotations,
 		CreatedAt:       cfProcess.CreationTimestamp.Time,
@@ -57,6 +57,14 @@ var _ = Describe("ProcessRepo", func() {
 						TimeoutSeconds:           6,
 					},
 				},
+				ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          8,
+					},
+				},
 				DesiredInstances: tools.PtrTo[int32](1),
 				MemoryMB:         500,
 				DiskQuotaMB:      512,
@@ -112,6 +120,10 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(5))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(BeEquivalentTo(6))
 				Expect(processRecord.HealthCheck.Data.HTTPEndpoint).To(Equal("/healthz"))
+				Expect(processRecord.ReadinessHealthCheck.Type).To(Equal("http"))
+				Expect(processRecord.ReadinessHealthCheck.Data.HTTPEndpoint).To(Equal("/ready"))
+				Expect(processRecord.ReadinessHealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(2))
+				Expect(processRecord.ReadinessHealthCheck.Data.IntervalSeconds).To(BeEquivalentTo(8))
 				Expect(processRecord.InstancesStatus).To(Equal(map[string]korifiv1alpha1.InstanceStatus{
 					"1": {
 						State: korifiv1alpha1.InstanceStateDown,
@@ -399,6 +411,14 @@ var _ = Describe("ProcessRepo", func() {
 						TimeoutSeconds:           10,
 					},
 				},
+				ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 3,
+						IntervalSeconds:          7,
+					},
+				},
 				DesiredInstances: tools.PtrTo[int32](42),
 				MemoryMB:         456,
 			})
@@ -432,6 +452,14 @@ var _ = Describe("ProcessRepo", func() {
 							TimeoutSeconds:           10,
 						},
 					},
+					ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+						Type: "http",
+						Data: korifiv1alpha1.ReadinessHealthCheckData{
+							HTTPEndpoint:             "/ready",
+							InvocationTimeoutSeconds: 3,
+							IntervalSeconds:          7,
+						},
+					},
 					DesiredInstances: tools.PtrTo[int32](42),
 					MemoryMB:         456,
 					DiskQuotaMB:      123,
@@ -524,9 +552,13 @@ var _ = Describe("ProcessRepo", func() {
 				HealthCheckHTTPEndpoint:             tools.PtrTo("/healthz"),
 				HealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(20)),
 				HealthCheckTimeoutSeconds:           tools.PtrTo(int32(10)),
-				DesiredInstances:                    tools.PtrTo[int32](42),
-				MemoryMB:                            tools.PtrTo(int64(456)),
-				DiskQuotaMB:                         tools.PtrTo(int64(123)),
+				ReadinessHealthCheckType:            tools.PtrTo("http"),
+				ReadinessHealthCheckHTTPEndpoint:    tools.PtrTo("/ready"),
+				ReadinessHealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(3)),
+				ReadinessHealthCheckIntervalSeconds:          tools.PtrTo(int32(7)),
+				DesiredInstances:                             tools.PtrTo[int32](42),
+				MemoryMB:                                     tools.PtrTo(int64(456)),
+				DiskQuotaMB:                                  tools.PtrTo(int64(123)),
 				MetadataPatch: &repositories.MetadataPatch{
 					Labels:      map[string]*string{"fool": tools.PtrTo("fool")},
 					Annotations: map[string]*string{"fooa": tools.PtrTo("fooa")},
@@ -562,6 +594,14 @@ var _ = Describe("ProcessRepo", func() {
 							"TimeoutSeconds":           BeEquivalentTo(10),
 						}),
 					}),
+					"ReadinessHealthCheck": MatchAllFields(Fields{
+						"Type": BeEquivalentTo("http"),
+						"Data": MatchAllFields(Fields{
+							"HTTPEndpoint":             BeEquivalentTo("/ready"),
+							"InvocationTimeoutSeconds": BeEquivalentTo(3),
+							"IntervalSeconds":          BeEquivalentTo(7),
+						}),
+					}),
 					"DesiredInstances": PointTo(BeEquivalentTo(42)),
 					"MemoryMB":         BeEquivalentTo(456),
 					"DiskQuotaMB":      BeEquivalentTo(123),
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RevisionResourceType        = "Revision"
+	RevisionEnvVarsResourceType = "Revision Environment Variables"
+)
+
+type RevisionRepo struct {
+	klient Klient
+	sorter RevisionSorter
+}
+
+type RevisionRecord struct {
+	GUID        string
+	Version     int
+	AppGUID     string
+	SpaceGUID   string
+	DropletGUID string
+	Description string
+	Deployable  bool
+	// The custom start command per process type. Processes running their
+	// detected command map to an empty string
+	Processes   map[string]string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+func (r RevisionRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type RevisionEnvVarsRecord struct {
+	RevisionGUID         string
+	AppGUID              string
+	EnvironmentVariables map[string]string
+}
+
+//counterfeiter:generate -o fake -fake-name RevisionSorter . RevisionSorter
+type RevisionSorter interface {
+	Sort(records []RevisionRecord, order string) []RevisionRecord
+}
+
+type revisionSorter struct {
+	sorter *compare.Sorter[RevisionRecord]
+}
+
+func NewRevisionSorter() *revisionSorter {
+	return &revisionSorter{
+		sorter: compare.NewSorter(RevisionComparator),
+	}
+}
+
+func (s *revisionSorter) Sort(records []RevisionRecord, order string) []RevisionRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func RevisionComparator(fieldName string) func(RevisionRecord, RevisionRecord) int {
+	return func(r1, r2 RevisionRecord) int {
+		switch fieldName {
+		case "created_at":
+			return tools.CompareTimePtr(&r1.CreatedAt, &r2.CreatedAt)
+		case "updated_at":
+			return tools.CompareTimePtr(r1.UpdatedAt, r2.UpdatedAt)
+		}
+		return r1.Version - r2.Version
+	}
+}
+
+type ListRevisionsMessage struct {
+	AppGUID       string
+	Versions      []string
+	LabelSelector string
+	OrderBy       string
+}
+
+func (m ListRevisionsMessage) toListOptions() []ListOption {
+	return []ListOption{
+		WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUID),
+		WithLabelIn(korifiv1alpha1.CFAppRevisionVersionLabelKey, m.Versions),
+		WithLabelSelector(m.LabelSelector),
+	}
+}
+
+func NewRevisionRepo(
+	klient Klient,
+	sorter RevisionSorter,
+) *RevisionRepo {
+	return &RevisionRepo{
+		klient: klient,
+		sorter: sorter,
+	}
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	revision, err := r.getRevision(ctx, revisionGUID)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	deployable, err := r.dropletExists(ctx, revision.Namespace, revision.Spec.DropletRef.Name)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return cfAppRevisionToRecord(*revision, deployable), nil
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	revisionList := &korifiv1alpha1.CFAppRevisionList{}
+	err := r.klient.List(ctx, revisionList, message.toListOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	if len(revisionList.Items) == 0 {
+		return []RevisionRecord{}, nil
+	}
+
+	buildList := &korifiv1alpha1.CFBuildList{}
+	err = r.klient.List(ctx, buildList,
+		InNamespace(revisionList.Items[0].Namespace),
+		WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, message.AppGUID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	dropletGUIDs := slices.Collect(it.Map(slices.Values(buildList.Items), func(b korifiv1alpha1.CFBuild) string {
+		return b.Name
+	}))
+
+	records := slices.Collect(it.Map(slices.Values(revisionList.Items), func(revision korifiv1alpha1.CFAppRevision) RevisionRecord {
+		return cfAppRevisionToRecord(revision, slices.Contains(dropletGUIDs, revision.Spec.DropletRef.Name))
+	}))
+
+	return r.sorter.Sort(records, message.OrderBy), nil
+}
+
+func (r *RevisionRepo) ListDeployedRevisions(ctx context.Context, authInfo authorization.Info, appGUID string) ([]RevisionRecord, error) {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: appGUID,
+		},
+	}
+	err := r.klient.Get(ctx, app)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	if app.Status.DeployedRevisionName == "" {
+		return []RevisionRecord{}, nil
+	}
+
+	revision, err := r.GetRevision(ctx, authInfo, app.Status.DeployedRevisionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return []RevisionRecord{revision}, nil
+}
+
+func (r *RevisionRepo) GetRevisionEnvVars(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionEnvVarsRecord, error) {
+	revision, err := r.getRevision(ctx, revisionGUID)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	envVars, err := getRevisionEnvVars(ctx, r.klient, revision)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	return RevisionEnvVarsRecord{
+		RevisionGUID:         revision.Name,
+		AppGUID:              revision.Spec.AppRef.Name,
+		EnvironmentVariables: convertByteSliceValuesToStrings(envVars),
+	}, nil
+}
+
+func (r *RevisionRepo) getRevision(ctx context.Context, revisionGUID string) (*korifiv1alpha1.CFAppRevision, error) {
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: revisionGUID,
+		},
+	}
+	err := r.klient.Get(ctx, revision)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	return revision, nil
+}
+
+func (r *RevisionRepo) dropletExists(ctx context.Context, namespace, dropletGUID string) (bool, error) {
+	err := r.klient.Get(ctx, &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      dropletGUID,
+		},
+	})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	return true, nil
+}
+
+func getRevisionEnvVars(ctx context.Context, klient Klient, revision *korifiv1alpha1.CFAppRevision) (map[string][]byte, error) {
+	if revision.Spec.EnvSecretName == "" {
+		return map[string][]byte{}, nil
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: revision.Namespace,
+			Name:      revision.Spec.EnvSecretName,
+		},
+	}
+	err := klient.Get(ctx, envSecret)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionEnvVarsResourceType)
+	}
+
+	return envSecret.Data, nil
+}
+
+func cfAppRevisionToRecord(revision korifiv1alpha1.CFAppRevision, deployable bool) RevisionRecord {
+	processes := map[string]string{}
+	for _, p := range revision.Spec.Processes {
+		processes[p.Type] = p.Command
+	}
+
+	return RevisionRecord{
+		GUID:        revision.Name,
+		Version:     revision.Spec.Version,
+		AppGUID:     revision.Spec.AppRef.Name,
+		SpaceGUID:   revision.Namespace,
+		DropletGUID: revision.Spec.DropletRef.Name,
+		Description: revision.Spec.Description,
+		Deployable:  deployable,
+		Processes:   processes,
+		Labels:      revision.Labels,
+		Annotations: revision.Annotations,
+		CreatedAt:   revision.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&revision),
+	}
+}
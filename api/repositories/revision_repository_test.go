@@ -0,0 +1,333 @@
+package repositories_test
+
+import (
+	"strconv"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		sorter       *fake.RevisionSorter
+		cfOrg        *korifiv1alpha1.CFOrg
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		revision1    *korifiv1alpha1.CFAppRevision
+		revision2    *korifiv1alpha1.CFAppRevision
+	)
+
+	createRevision := func(version int, dropletGUID, envSecretName string) *korifiv1alpha1.CFAppRevision {
+		revision := &korifiv1alpha1.CFAppRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:            cfApp.Name,
+					korifiv1alpha1.CFAppRevisionVersionLabelKey: strconv.Itoa(version),
+				},
+			},
+			Spec: korifiv1alpha1.CFAppRevisionSpec{
+				AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+				Version:       version,
+				DropletRef:    corev1.LocalObjectReference{Name: dropletGUID},
+				EnvSecretName: envSecretName,
+				Processes: []korifiv1alpha1.CFAppRevisionProcess{
+					{Type: "web"},
+					{Type: "worker", Command: "run-worker"},
+				},
+				Description: "Initial revision.",
+			},
+		}
+		Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+		return revision
+	}
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+		createBuild(ctx, k8sClient, cfSpace.Name, cfApp.Spec.CurrentDropletRef.Name, uuid.NewString(), cfApp.Name)
+
+		envSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+			},
+			Data: map[string][]byte{"FOO": []byte("bar")},
+		}
+		Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+
+		revision1 = createRevision(1, cfApp.Spec.CurrentDropletRef.Name, envSecret.Name)
+		revision2 = createRevision(2, "deleted-droplet", "")
+
+		sorter = new(fake.RevisionSorter)
+		sorter.SortStub = func(records []repositories.RevisionRecord, _ string) []repositories.RevisionRecord {
+			return records
+		}
+
+		revisionRepo = repositories.NewRevisionRepo(klient, sorter)
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			revisionGUID string
+			revision     repositories.RevisionRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision1.Name
+		})
+
+		JustBeforeEach(func() {
+			revision, getErr = revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(revision.GUID).To(Equal(revision1.Name))
+				Expect(revision.Version).To(Equal(1))
+				Expect(revision.AppGUID).To(Equal(cfApp.Name))
+				Expect(revision.SpaceGUID).To(Equal(cfSpace.Name))
+				Expect(revision.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+				Expect(revision.Description).To(Equal("Initial revision."))
+				Expect(revision.Deployable).To(BeTrue())
+				Expect(revision.Processes).To(Equal(map[string]string{
+					"web":    "",
+					"worker": "run-worker",
+				}))
+				Expect(revision.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
+				Expect(revision.Relationships()).To(Equal(map[string]string{
+					"app": cfApp.Name,
+				}))
+			})
+
+			When("the revision droplet no longer exists", func() {
+				BeforeEach(func() {
+					revisionGUID = revision2.Name
+				})
+
+				It("returns a revision that is not deployable", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(revision.Deployable).To(BeFalse())
+				})
+			})
+
+			When("the revision does not exist", func() {
+				BeforeEach(func() {
+					revisionGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			message   repositories.ListRevisionsMessage
+			revisions []repositories.RevisionRecord
+			listErr   error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListRevisionsMessage{AppGUID: cfApp.Name}
+		})
+
+		JustBeforeEach(func() {
+			revisions, listErr = revisionRepo.ListRevisions(ctx, authInfo, message)
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(revisions).To(BeEmpty())
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the app revisions", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(revisions).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":       Equal(revision1.Name),
+						"Deployable": BeTrue(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":       Equal(revision2.Name),
+						"Deployable": BeFalse(),
+					}),
+				))
+			})
+
+			It("sorts the revisions", func() {
+				Expect(sorter.SortCallCount()).To(Equal(1))
+			})
+
+			When("filtering by version", func() {
+				BeforeEach(func() {
+					message.Versions = []string{"2"}
+				})
+
+				It("returns the matching revisions", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(revision2.Name),
+					})))
+				})
+			})
+
+			When("the app has no revisions", func() {
+				BeforeEach(func() {
+					message.AppGUID = uuid.NewString()
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisions).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("ListDeployedRevisions", func() {
+		var (
+			revisions []repositories.RevisionRecord
+			listErr   error
+		)
+
+		JustBeforeEach(func() {
+			revisions, listErr = revisionRepo.ListDeployedRevisions(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(revisions).To(BeEmpty())
+			})
+
+			When("the app is running a revision", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Status.DeployedRevisionName = revision1.Name
+					})).To(Succeed())
+				})
+
+				It("returns the deployed revision", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(revision1.Name),
+					})))
+				})
+			})
+		})
+	})
+
+	Describe("GetRevisionEnvVars", func() {
+		var (
+			revisionGUID string
+			envVars      repositories.RevisionEnvVarsRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision1.Name
+		})
+
+		JustBeforeEach(func() {
+			envVars, getErr = revisionRepo.GetRevisionEnvVars(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision environment variables", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envVars).To(Equal(repositories.RevisionEnvVarsRecord{
+					RevisionGUID:         revision1.Name,
+					AppGUID:              cfApp.Name,
+					EnvironmentVariables: map[string]string{"FOO": "bar"},
+				}))
+			})
+
+			When("the revision has no environment snapshot", func() {
+				BeforeEach(func() {
+					revisionGUID = revision2.Name
+				})
+
+				It("returns empty environment variables", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(envVars.EnvironmentVariables).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("RevisionSorter",
+	func(r1, r2 repositories.RevisionRecord, field string, match types.GomegaMatcher) {
+		Expect(repositories.RevisionComparator(field)(r1, r2)).To(match)
+	},
+	Entry("created_at",
+		repositories.RevisionRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.RevisionRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("updated_at",
+		repositories.RevisionRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.RevisionRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+	Entry("default",
+		repositories.RevisionRecord{Version: 1},
+		repositories.RevisionRecord{Version: 2},
+		"",
+		BeNumerically("<", 0),
+	),
+)
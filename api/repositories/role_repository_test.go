@@ -59,7 +59,7 @@ var _ = Describe("RoleRepository", func() {
 			*korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
-		]{})
+		]{}, k8sClient)
 		roleRepo = repositories.NewRoleRepo(
 			klient,
 			spaceRepo,
@@ -15,8 +15,10 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
@@ -36,6 +38,7 @@ func NewRouteRepo(klient Klient) *RouteRepo {
 type DestinationRecord struct {
 	GUID        string
 	AppGUID     string
+	SpaceGUID   string
 	ProcessType string
 	Port        *int32
 	Protocol    *string
@@ -43,18 +46,20 @@ type DestinationRecord struct {
 }
 
 type RouteRecord struct {
-	GUID         string
-	SpaceGUID    string
-	Domain       DomainRecord
-	Host         string
-	Path         string
-	Protocol     string
-	Destinations []DestinationRecord
-	Labels       map[string]string
-	Annotations  map[string]string
-	CreatedAt    time.Time
-	UpdatedAt    *time.Time
-	DeletedAt    *time.Time
+	GUID             string
+	SpaceGUID        string
+	Domain           DomainRecord
+	Host             string
+	Path             string
+	Protocol         string
+	Destinations     []DestinationRecord
+	SharedSpaceGUIDs []string
+	Options          RouteOptions
+	Labels           map[string]string
+	Annotations      map[string]string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *time.Time
 }
 
 func (r RouteRecord) Relationships() map[string]string {
@@ -66,6 +71,7 @@ func (r RouteRecord) Relationships() map[string]string {
 
 type DesiredDestination struct {
 	AppGUID     string
+	SpaceGUID   string
 	ProcessType string
 	Port        *int32
 	Protocol    *string
@@ -89,10 +95,97 @@ func (m *RemoveDestinationMessage) matches(dest korifiv1alpha1.Destination) bool
 	return dest.GUID == m.GUID
 }
 
-type PatchRouteMetadataMessage struct {
+type UpdateDestinationMessage struct {
+	RouteGUID string
+	SpaceGUID string
+	GUID      string
+	Protocol  *string
+}
+
+type RouteOptions struct {
+	LoadBalancing  string
+	Timeout        string
+	RequestHeaders RouteHeaderModifier
+}
+
+type RouteHeaderModifier struct {
+	Set    map[string]string
+	Add    map[string]string
+	Remove []string
+}
+
+func (m RouteHeaderModifier) isEmpty() bool {
+	return len(m.Set) == 0 && len(m.Add) == 0 && len(m.Remove) == 0
+}
+
+func (o RouteOptions) toCFRouteOptions() *korifiv1alpha1.RouteOptions {
+	if o.LoadBalancing == "" && o.Timeout == "" && o.RequestHeaders.isEmpty() {
+		return nil
+	}
+
+	options := &korifiv1alpha1.RouteOptions{
+		LoadBalancing: o.LoadBalancing,
+		Timeout:       o.Timeout,
+	}
+
+	if !o.RequestHeaders.isEmpty() {
+		options.RequestHeaders = &korifiv1alpha1.HeaderModifier{
+			Set:    o.RequestHeaders.Set,
+			Add:    o.RequestHeaders.Add,
+			Remove: o.RequestHeaders.Remove,
+		}
+	}
+
+	return options
+}
+
+// RouteOptionsPatch contains the route options to update. Nil fields are
+// left unchanged, empty ones are cleared
+type RouteOptionsPatch struct {
+	LoadBalancing  *string
+	Timeout        *string
+	RequestHeaders *RouteHeaderModifier
+}
+
+func (p RouteOptionsPatch) Apply(options RouteOptions) RouteOptions {
+	if p.LoadBalancing != nil {
+		options.LoadBalancing = *p.LoadBalancing
+	}
+
+	if p.Timeout != nil {
+		options.Timeout = *p.Timeout
+	}
+
+	if p.RequestHeaders != nil {
+		options.RequestHeaders = *p.RequestHeaders
+	}
+
+	return options
+}
+
+type PatchRouteMessage struct {
 	MetadataPatch
 	RouteGUID string
 	SpaceGUID string
+	Options   *RouteOptionsPatch
+}
+
+type ShareRouteMessage struct {
+	RouteGUID        string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
+type TransferRouteOwnershipMessage struct {
+	RouteGUID    string
+	SpaceGUID    string
+	NewSpaceGUID string
 }
 
 type ListRoutesMessage struct {
@@ -130,6 +223,7 @@ type CreateRouteMessage struct {
 	DomainGUID      string
 	DomainName      string
 	DomainNamespace string
+	Options         RouteOptions
 	Labels          map[string]string
 	Annotations     map[string]string
 }
@@ -155,6 +249,7 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
 			},
+			Options: m.Options.toCFRouteOptions(),
 		},
 	}
 }
@@ -180,7 +275,8 @@ func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info,
 		return []RouteRecord{}, fmt.Errorf("failed to list routes: %w", apierrors.FromK8sError(err, RouteResourceType))
 	}
 
-	appRecords := itx.FromSlice(cfRouteList.Items)
+	// routes being transferred are listed in their new space only
+	appRecords := itx.FromSlice(cfRouteList.Items).Exclude(isBeingTransferred)
 	return slices.Collect(it.Map(appRecords, cfRouteToRouteRecord)), nil
 }
 
@@ -191,16 +287,39 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		Domain: DomainRecord{
 			GUID: cfRoute.Spec.DomainRef.Name,
 		},
-		Host:         cfRoute.Spec.Host,
-		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
-		Destinations: cfRouteDestinationsToDestinationRecords(cfRoute),
-		CreatedAt:    cfRoute.CreationTimestamp.Time,
-		UpdatedAt:    getLastUpdatedTime(&cfRoute),
-		DeletedAt:    golangTime(cfRoute.DeletionTimestamp),
-		Labels:       cfRoute.Labels,
-		Annotations:  cfRoute.Annotations,
+		Host:             cfRoute.Spec.Host,
+		Path:             cfRoute.Spec.Path,
+		Protocol:         "http", // TODO: Create a mutating webhook to set this default on the CFRoute
+		Destinations:     cfRouteDestinationsToDestinationRecords(cfRoute),
+		SharedSpaceGUIDs: cfRoute.Spec.SharedSpaces,
+		Options:          cfRouteOptionsToRouteOptions(cfRoute.Spec.Options),
+		CreatedAt:        cfRoute.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfRoute),
+		DeletedAt:        golangTime(cfRoute.DeletionTimestamp),
+		Labels:           cfRoute.Labels,
+		Annotations:      cfRoute.Annotations,
+	}
+}
+
+func cfRouteOptionsToRouteOptions(cfOptions *korifiv1alpha1.RouteOptions) RouteOptions {
+	if cfOptions == nil {
+		return RouteOptions{}
+	}
+
+	options := RouteOptions{
+		LoadBalancing: cfOptions.LoadBalancing,
+		Timeout:       cfOptions.Timeout,
+	}
+
+	if cfOptions.RequestHeaders != nil {
+		options.RequestHeaders = RouteHeaderModifier{
+			Set:    cfOptions.RequestHeaders.Set,
+			Add:    cfOptions.RequestHeaders.Add,
+			Remove: cfOptions.RequestHeaders.Remove,
+		}
 	}
+
+	return options
 }
 
 func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []DestinationRecord {
@@ -208,6 +327,7 @@ func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []D
 		record := DestinationRecord{
 			GUID:        specDestination.GUID,
 			AppGUID:     specDestination.AppRef.Name,
+			SpaceGUID:   cfRoute.DestinationNamespace(specDestination),
 			ProcessType: specDestination.ProcessType,
 			Port:        specDestination.Port,
 			Protocol:    specDestination.Protocol,
@@ -308,7 +428,7 @@ func (r *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 		},
 	}
 	err := GetAndPatch(ctx, r.klient, cfRoute, func() error {
-		cfRoute.Spec.Destinations = mergeDestinations(message.ExistingDestinations, message.NewDestinations)
+		cfRoute.Spec.Destinations = mergeDestinations(cfRoute.Namespace, message.ExistingDestinations, message.NewDestinations)
 		return nil
 	})
 	if err != nil {
@@ -346,32 +466,72 @@ func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo aut
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
-func mergeDestinations(existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
-	destinations := destinationRecordsToCFDestinations(existingDestinations)
+func (r *RouteRepo) UpdateDestination(ctx context.Context, authInfo authorization.Info, message UpdateDestinationMessage) (RouteRecord, error) {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := GetAndPatch(ctx, r.klient, cfRoute, func() error {
+		destIdx := slices.IndexFunc(cfRoute.Spec.Destinations, func(dest korifiv1alpha1.Destination) bool {
+			return dest.GUID == message.GUID
+		})
+		if destIdx < 0 {
+			return apierrors.NewUnprocessableEntityError(nil, "Unable to update route destination. Ensure the route has a destination with this guid.")
+		}
+
+		cfRoute.Spec.Destinations[destIdx].Protocol = message.Protocol
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to update destination of route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
+func mergeDestinations(routeNamespace string, existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
+	destinations := destinationRecordsToCFDestinations(routeNamespace, existingDestinations)
 
 	for _, desired := range desiredDestinations {
 		if contains(destinations, desired) {
 			continue
 		}
 
-		destinations = append(destinations, destinationMessageToDestination(desired))
+		destinations = append(destinations, destinationMessageToDestination(routeNamespace, desired))
 	}
 
 	return destinations
 }
 
-func destinationMessageToDestination(m DesiredDestination) korifiv1alpha1.Destination {
+func destinationMessageToDestination(routeNamespace string, m DesiredDestination) korifiv1alpha1.Destination {
 	return korifiv1alpha1.Destination{
 		GUID: uuid.NewString(),
 		Port: m.Port,
 		AppRef: v1.LocalObjectReference{
 			Name: m.AppGUID,
 		},
-		ProcessType: m.ProcessType,
-		Protocol:    m.Protocol,
+		AppNamespace: appNamespace(routeNamespace, m.SpaceGUID),
+		ProcessType:  m.ProcessType,
+		Protocol:     m.Protocol,
 	}
 }
 
+// appNamespace returns the namespace to set on a route destination. It is
+// left empty for apps in the route space
+func isBeingTransferred(cfRoute korifiv1alpha1.CFRoute) bool {
+	return cfRoute.Spec.TransferTo != "" && !cfRoute.DeletionTimestamp.IsZero()
+}
+
+func appNamespace(routeNamespace, appSpaceGUID string) string {
+	if appSpaceGUID == routeNamespace {
+		return ""
+	}
+
+	return appSpaceGUID
+}
+
 func contains(existingDestinations []korifiv1alpha1.Destination, desired DesiredDestination) bool {
 	_, ok := itx.FromSlice(existingDestinations).Find(func(dest korifiv1alpha1.Destination) bool {
 		return desired.AppGUID == dest.AppRef.Name &&
@@ -413,7 +573,7 @@ func (r *RouteRepo) fetchRouteByFields(ctx context.Context, authInfo authorizati
 	return &matches[0], nil
 }
 
-func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord) []korifiv1alpha1.Destination {
+func destinationRecordsToCFDestinations(routeNamespace string, destinationRecords []DestinationRecord) []korifiv1alpha1.Destination {
 	return slices.Collect(it.Map(itx.FromSlice(destinationRecords), func(destinationRecord DestinationRecord) korifiv1alpha1.Destination {
 		return korifiv1alpha1.Destination{
 			GUID: destinationRecord.GUID,
@@ -421,13 +581,14 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 			AppRef: v1.LocalObjectReference{
 				Name: destinationRecord.AppGUID,
 			},
-			ProcessType: destinationRecord.ProcessType,
-			Protocol:    destinationRecord.Protocol,
+			AppNamespace: appNamespace(routeNamespace, destinationRecord.SpaceGUID),
+			ProcessType:  destinationRecord.ProcessType,
+			Protocol:     destinationRecord.Protocol,
 		}
 	}))
 }
 
-func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorization.Info, message PatchRouteMetadataMessage) (RouteRecord, error) {
+func (r *RouteRepo) PatchRoute(ctx context.Context, authInfo authorization.Info, message PatchRouteMessage) (RouteRecord, error) {
 	route := &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: message.SpaceGUID,
@@ -438,6 +599,10 @@ func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorizati
 	err := GetAndPatch(ctx, r.klient, route, func() error {
 		message.Apply(route)
 
+		if message.Options != nil {
+			route.Spec.Options = message.Options.Apply(cfRouteOptionsToRouteOptions(route.Spec.Options)).toCFRouteOptions()
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -447,6 +612,132 @@ func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorizati
 	return cfRouteToRouteRecord(*route), nil
 }
 
+func (r *RouteRepo) ShareRoute(ctx context.Context, authInfo authorization.Info, message ShareRouteMessage) (RouteRecord, error) {
+	route := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.RouteGUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, route, func() error {
+		for _, spaceGUID := range message.SharedSpaceGUIDs {
+			if spaceGUID != route.Namespace && !slices.Contains(route.Spec.SharedSpaces, spaceGUID) {
+				route.Spec.SharedSpaces = append(route.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to share route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*route), nil
+}
+
+// UnshareRoute removes a space from the route shared spaces. Routes that still
+// have destinations pointing to apps in that space cannot be unshared
+func (r *RouteRepo) UnshareRoute(ctx context.Context, authInfo authorization.Info, message UnshareRouteMessage) (RouteRecord, error) {
+	route := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.RouteGUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, route, func() error {
+		if slices.ContainsFunc(route.Spec.Destinations, func(dest korifiv1alpha1.Destination) bool {
+			return dest.AppNamespace == message.SharedSpaceGUID
+		}) {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to unshare route '%s' from space '%s'. Unmap the route from the apps in that space first.",
+				route.Spec.Host, message.SharedSpaceGUID,
+			))
+		}
+
+		route.Spec.SharedSpaces = slices.DeleteFunc(route.Spec.SharedSpaces, func(spaceGUID string) bool {
+			return spaceGUID == message.SharedSpaceGUID
+		})
+
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to unshare route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*route), nil
+}
+
+// TransferRouteOwnership moves the route to a new space. The original space
+// keeps access to the route as a shared space, so that its destinations are
+// preserved. As the space of a route is its namespace, the route is marked as
+// being transferred and deleted. The route controller then recreates it in
+// the new space and only lets the original route go once its replacement is
+// ready, so that the route keeps serving traffic during the transfer.
+// Transferring a route that is already being transferred is a no-op.
+func (r *RouteRepo) TransferRouteOwnership(ctx context.Context, authInfo authorization.Info, message TransferRouteOwnershipMessage) (RouteRecord, error) {
+	route := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.RouteGUID,
+		},
+	}
+
+	err := r.klient.Get(ctx, route)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	if message.NewSpaceGUID == route.Namespace {
+		return cfRouteToRouteRecord(*route), nil
+	}
+
+	// the route is recreated by the controller, make sure the user is allowed
+	// to create it in the new space
+	allowed, err := r.canICreateRoutes(ctx, message.NewSpaceGUID)
+	if err != nil {
+		return RouteRecord{}, err
+	}
+	if !allowed {
+		return RouteRecord{}, apierrors.NewForbiddenError(nil, RouteResourceType)
+	}
+
+	err = r.klient.Patch(ctx, route, func() error {
+		route.Spec.TransferTo = message.NewSpaceGUID
+		controllerutil.AddFinalizer(route, korifiv1alpha1.CFRouteTransferFinalizerName)
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to transfer route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = r.klient.Delete(ctx, route)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to delete route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(route.Transferred()), nil
+}
+
+func (r *RouteRepo) canICreateRoutes(ctx context.Context, spaceGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: spaceGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfroutes",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canICreateRoutes: failed to create self subject access review: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (r *RouteRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, routeGUID string) (*time.Time, error) {
 	route, err := r.GetRoute(ctx, authInfo, routeGUID)
 	return route.DeletedAt, err
@@ -384,10 +384,12 @@ var _ = Describe("RouteRepository", func() {
 			routeHost          string
 			routePath          string
 			routeNamespace     string
+			routeOptions       repositories.RouteOptions
 		)
 
 		BeforeEach(func() {
 			routeNamespace = space.Name
+			routeOptions = repositories.RouteOptions{}
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
 			createdRouteRecord = repositories.RouteRecord{}
@@ -401,6 +403,7 @@ var _ = Describe("RouteRepository", func() {
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
+				Options:         routeOptions,
 			})
 		})
 
@@ -438,6 +441,33 @@ var _ = Describe("RouteRepository", func() {
 				Expect(createdRouteRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
+			When("options are specified", func() {
+				BeforeEach(func() {
+					routeOptions = repositories.RouteOptions{
+						LoadBalancing: "least-connection",
+						Timeout:       "5m",
+						RequestHeaders: repositories.RouteHeaderModifier{
+							Remove: []string{"X-Foo"},
+						},
+					}
+				})
+
+				It("sets the options on the CFRoute", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					Expect(createdRouteRecord.Options).To(Equal(routeOptions))
+
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Options).To(PointTo(Equal(korifiv1alpha1.RouteOptions{
+						LoadBalancing: "least-connection",
+						Timeout:       "5m",
+						RequestHeaders: &korifiv1alpha1.HeaderModifier{
+							Remove: []string{"X-Foo"},
+						},
+					})))
+				})
+			})
+
 			When("target namespace isn't set", func() {
 				BeforeEach(func() {
 					routeNamespace = ""
@@ -1091,10 +1121,94 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
-	Describe("PatchRouteMetadata", func() {
+	Describe("UpdateDestination", func() {
+		var (
+			destinationGUID      string
+			updateDestinationErr error
+			routeRecord          repositories.RouteRecord
+		)
+
+		BeforeEach(func() {
+			destinationGUID = uuid.NewString()
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+					Labels: map[string]string{
+						korifiv1alpha1.SpaceGUIDKey: space.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host: "test-route-host",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: space.Name,
+					},
+					Destinations: []korifiv1alpha1.Destination{{
+						GUID: destinationGUID,
+						Port: tools.PtrTo[int32](8000),
+						AppRef: corev1.LocalObjectReference{
+							Name: uuid.NewString(),
+						},
+						ProcessType: "web",
+						Protocol:    tools.PtrTo("http1"),
+					}},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			routeRecord, updateDestinationErr = routeRepo.UpdateDestination(ctx, authInfo, repositories.UpdateDestinationMessage{
+				RouteGUID: routeGUID,
+				SpaceGUID: space.Name,
+				GUID:      destinationGUID,
+				Protocol:  tools.PtrTo("http2"),
+			})
+		})
+
+		It("returns an error as the user is not authorized", func() {
+			Expect(updateDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the destination protocol", func() {
+				Expect(updateDestinationErr).NotTo(HaveOccurred())
+				Expect(routeRecord.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":     Equal(destinationGUID),
+					"Protocol": PointTo(Equal("http2")),
+				})))
+
+				updatedCFRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: routeGUID, Namespace: space.Name}, updatedCFRoute)).To(Succeed())
+				Expect(updatedCFRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":     Equal(destinationGUID),
+					"Port":     PointTo(BeEquivalentTo(8000)),
+					"Protocol": PointTo(Equal("http2")),
+				})))
+			})
+
+			When("the destination isn't on the route", func() {
+				BeforeEach(func() {
+					destinationGUID = "some-bogus-guid"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("PatchRoute", func() {
 		var (
 			cfRoute                       *korifiv1alpha1.CFRoute
 			labelsPatch, annotationsPatch map[string]*string
+			optionsPatch                  *repositories.RouteOptionsPatch
 			patchErr                      error
 			routeRecord                   repositories.RouteRecord
 		)
@@ -1123,19 +1237,21 @@ var _ = Describe("RouteRepository", func() {
 
 			labelsPatch = nil
 			annotationsPatch = nil
+			optionsPatch = nil
 		})
 
 		JustBeforeEach(func() {
-			patchMsg := repositories.PatchRouteMetadataMessage{
+			patchMsg := repositories.PatchRouteMessage{
 				RouteGUID: routeGUID,
 				SpaceGUID: space.Name,
 				MetadataPatch: repositories.MetadataPatch{
 					Annotations: annotationsPatch,
 					Labels:      labelsPatch,
 				},
+				Options: optionsPatch,
 			}
 
-			routeRecord, patchErr = routeRepo.PatchRouteMetadata(ctx, authInfo, patchMsg)
+			routeRecord, patchErr = routeRepo.PatchRoute(ctx, authInfo, patchMsg)
 		})
 
 		It("return a forbidden error as the user is not authorized", func() {
@@ -1248,6 +1364,43 @@ var _ = Describe("RouteRepository", func() {
 				})
 			})
 
+			When("options are patched", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfRoute, func() {
+						cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+							LoadBalancing: "round-robin",
+							Timeout:       "30s",
+						}
+					})).To(Succeed())
+
+					optionsPatch = &repositories.RouteOptionsPatch{
+						Timeout: tools.PtrTo(""),
+						RequestHeaders: &repositories.RouteHeaderModifier{
+							Set: map[string]string{"X-Foo": "bar"},
+						},
+					}
+				})
+
+				It("updates the provided options only", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(routeRecord.Options).To(Equal(repositories.RouteOptions{
+						LoadBalancing: "round-robin",
+						RequestHeaders: repositories.RouteHeaderModifier{
+							Set: map[string]string{"X-Foo": "bar"},
+						},
+					}))
+
+					updatedCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), updatedCFRoute)).To(Succeed())
+					Expect(updatedCFRoute.Spec.Options).To(PointTo(Equal(korifiv1alpha1.RouteOptions{
+						LoadBalancing: "round-robin",
+						RequestHeaders: &korifiv1alpha1.HeaderModifier{
+							Set: map[string]string{"X-Foo": "bar"},
+						},
+					})))
+				})
+			})
+
 			When("an annotation is invalid", func() {
 				BeforeEach(func() {
 					annotationsPatch = map[string]*string{
@@ -1296,6 +1449,221 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("route sharing", func() {
+		var (
+			cfRoute     *korifiv1alpha1.CFRoute
+			sharedSpace *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("shared-space"))
+
+			cfRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+		})
+
+		Describe("ShareRoute", func() {
+			var (
+				routeRecord repositories.RouteRecord
+				shareErr    error
+			)
+
+			JustBeforeEach(func() {
+				routeRecord, shareErr = routeRepo.ShareRoute(ctx, authInfo, repositories.ShareRouteMessage{
+					RouteGUID:        routeGUID,
+					SpaceGUID:        space.Name,
+					SharedSpaceGUIDs: []string{sharedSpace.Name, space.Name},
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("shares the route with the spaces other than its own", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(routeRecord.SharedSpaceGUIDs).To(ConsistOf(sharedSpace.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name))
+				})
+			})
+		})
+
+		Describe("UnshareRoute", func() {
+			var unshareErr error
+
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+					cfRoute.Spec.SharedSpaces = []string{sharedSpace.Name}
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+						{
+							GUID:        "own-destination",
+							AppRef:      corev1.LocalObjectReference{Name: "own-app"},
+							ProcessType: "web",
+						},
+						{
+							GUID:         "shared-destination",
+							AppRef:       corev1.LocalObjectReference{Name: "shared-app"},
+							AppNamespace: sharedSpace.Name,
+							ProcessType:  "web",
+						},
+					}
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				_, unshareErr = routeRepo.UnshareRoute(ctx, authInfo, repositories.UnshareRouteMessage{
+					RouteGUID:       routeGUID,
+					SpaceGUID:       space.Name,
+					SharedSpaceGUID: sharedSpace.Name,
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				It("keeps the route shared", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name))
+					Expect(cfRoute.Spec.Destinations).To(HaveLen(2))
+				})
+
+				When("the route has no destinations in the shared space", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+							cfRoute.Spec.Destinations = cfRoute.Spec.Destinations[:1]
+						})).To(Succeed())
+					})
+
+					It("unshares the route", func() {
+						Expect(unshareErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+						Expect(cfRoute.Spec.SharedSpaces).To(BeEmpty())
+						Expect(cfRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal("own-destination"),
+						})))
+					})
+				})
+			})
+		})
+
+		Describe("TransferRouteOwnership", func() {
+			var (
+				routeRecord repositories.RouteRecord
+				transferErr error
+			)
+
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+						GUID:        "own-destination",
+						AppRef:      corev1.LocalObjectReference{Name: "own-app"},
+						ProcessType: "web",
+					}}
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				routeRecord, transferErr = routeRepo.TransferRouteOwnership(ctx, authInfo, repositories.TransferRouteOwnershipMessage{
+					RouteGUID:    routeGUID,
+					SpaceGUID:    space.Name,
+					NewSpaceGUID: sharedSpace.Name,
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in the route space only", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns a forbidden error", func() {
+					Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+
+				It("does not touch the route", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.TransferTo).To(BeEmpty())
+					Expect(cfRoute.DeletionTimestamp).To(BeNil())
+				})
+			})
+
+			When("the user is a space developer in both spaces", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+
+					// the controller finalizing the route is not running
+					DeferCleanup(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+							cfRoute.Finalizers = nil
+						})).To(Succeed())
+					})
+				})
+
+				It("returns the route as it is in the new space", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(routeRecord.SpaceGUID).To(Equal(sharedSpace.Name))
+					Expect(routeRecord.SharedSpaceGUIDs).To(ConsistOf(space.Name))
+					Expect(routeRecord.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal("own-destination"),
+						"SpaceGUID": Equal(space.Name),
+					})))
+				})
+
+				It("marks the route as being transferred and deletes it", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.TransferTo).To(Equal(sharedSpace.Name))
+					Expect(cfRoute.Finalizers).To(ContainElement(korifiv1alpha1.CFRouteTransferFinalizerName))
+					Expect(cfRoute.DeletionTimestamp).NotTo(BeNil())
+				})
+
+				It("does not list the route in its original space", func() {
+					routes, err := routeRepo.ListRoutes(ctx, authInfo, repositories.ListRoutesMessage{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(routes).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(routeGUID),
+					})))
+				})
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfRoute   *korifiv1alpha1.CFRoute
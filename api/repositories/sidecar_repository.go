@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	SidecarResourceType = "Sidecar"
+	SidecarOriginUser   = "user"
+
+	// SidecarGUIDLabelPrefix prefixes the labels that index the sidecars of
+	// an app by guid, as sidecars are not kubernetes resources on their own
+	SidecarGUIDLabelPrefix = "korifi.cloudfoundry.org/sidecar-"
+)
+
+type SidecarRepo struct {
+	klient Klient
+}
+
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+	Origin       string
+	AppGUID      string
+	SpaceGUID    string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+func (r SidecarRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type CreateSidecarMessage struct {
+	AppGUID      string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type PatchSidecarMessage struct {
+	GUID         string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type ListSidecarsMessage struct {
+	AppGUID     string
+	ProcessType string
+}
+
+func NewSidecarRepo(klient Klient) *SidecarRepo {
+	return &SidecarRepo{
+		klient: klient,
+	}
+}
+
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	sidecar := korifiv1alpha1.CFAppSidecar{
+		GUID:         uuid.NewString(),
+		Name:         message.Name,
+		Command:      message.Command,
+		ProcessTypes: message.ProcessTypes,
+		MemoryMB:     tools.ZeroIfNil(message.MemoryMB),
+	}
+
+	cfApp, err := r.patchSidecars(ctx, message.AppGUID, func(cfApp *korifiv1alpha1.CFApp) error {
+		if err := validateSidecarNameIsUnique(cfApp, sidecar); err != nil {
+			return err
+		}
+
+		cfApp.Spec.Sidecars = append(cfApp.Spec.Sidecars, sidecar)
+		return nil
+	})
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to create sidecar: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppSidecarToRecord(*cfApp, sidecar), nil
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, sidecarGUID string) (SidecarRecord, error) {
+	cfApp, sidecar, err := r.findSidecar(ctx, sidecarGUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	return cfAppSidecarToRecord(*cfApp, sidecar), nil
+}
+
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) ([]SidecarRecord, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.AppGUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfApp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	sidecars := it.Filter(slices.Values(cfApp.Spec.Sidecars), func(s korifiv1alpha1.CFAppSidecar) bool {
+		return message.ProcessType == "" || slices.Contains(s.ProcessTypes, message.ProcessType)
+	})
+
+	records := slices.Collect(it.Map(sidecars, func(s korifiv1alpha1.CFAppSidecar) SidecarRecord {
+		return cfAppSidecarToRecord(*cfApp, s)
+	}))
+	slices.SortFunc(records, func(r1, r2 SidecarRecord) int {
+		return strings.Compare(r1.Name, r2.Name)
+	})
+
+	return records, nil
+}
+
+func (r *SidecarRepo) PatchSidecar(ctx context.Context, authInfo authorization.Info, message PatchSidecarMessage) (SidecarRecord, error) {
+	app, _, err := r.findSidecar(ctx, message.GUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	var sidecar korifiv1alpha1.CFAppSidecar
+	cfApp, err := r.patchSidecars(ctx, app.Name, func(cfApp *korifiv1alpha1.CFApp) error {
+		index := slices.IndexFunc(cfApp.Spec.Sidecars, func(s korifiv1alpha1.CFAppSidecar) bool {
+			return s.GUID == message.GUID
+		})
+		if index < 0 {
+			return apierrors.NewNotFoundError(nil, SidecarResourceType)
+		}
+
+		sidecar = cfApp.Spec.Sidecars[index]
+		message.apply(&sidecar)
+		if err := validateSidecarNameIsUnique(cfApp, sidecar); err != nil {
+			return err
+		}
+
+		cfApp.Spec.Sidecars[index] = sidecar
+		return nil
+	})
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to patch sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfAppSidecarToRecord(*cfApp, sidecar), nil
+}
+
+func (m PatchSidecarMessage) apply(sidecar *korifiv1alpha1.CFAppSidecar) {
+	if m.Name != nil {
+		sidecar.Name = *m.Name
+	}
+
+	if m.Command != nil {
+		sidecar.Command = *m.Command
+	}
+
+	if m.ProcessTypes != nil {
+		sidecar.ProcessTypes = m.ProcessTypes
+	}
+
+	if m.MemoryMB != nil {
+		sidecar.MemoryMB = *m.MemoryMB
+	}
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, sidecarGUID string) error {
+	app, _, err := r.findSidecar(ctx, sidecarGUID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.patchSidecars(ctx, app.Name, func(cfApp *korifiv1alpha1.CFApp) error {
+		cfApp.Spec.Sidecars = slices.DeleteFunc(cfApp.Spec.Sidecars, func(s korifiv1alpha1.CFAppSidecar) bool {
+			return s.GUID == sidecarGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return nil
+}
+
+// patchSidecars modifies the sidecars of the app and keeps the sidecar guid
+// labels in sync. The whole sidecars list is patched, so the patch fails if
+// the app has changed since it was read and is retried on the latest version
+// of the app to avoid losing concurrent sidecar changes
+func (r *SidecarRepo) patchSidecars(ctx context.Context, appGUID string, modify func(*korifiv1alpha1.CFApp) error) (*korifiv1alpha1.CFApp, error) {
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: appGUID,
+			},
+		}
+		if err := r.klient.Get(ctx, cfApp); err != nil {
+			return err
+		}
+
+		return r.klient.PatchWithOptimisticLock(ctx, cfApp, func() error {
+			if err := modify(cfApp); err != nil {
+				return err
+			}
+
+			setSidecarLabels(cfApp)
+			return nil
+		})
+	})
+
+	return cfApp, err
+}
+
+func setSidecarLabels(cfApp *korifiv1alpha1.CFApp) {
+	labels := map[string]string{}
+	for key, value := range cfApp.Labels {
+		if !strings.HasPrefix(key, SidecarGUIDLabelPrefix) {
+			labels[key] = value
+		}
+	}
+
+	for _, sidecar := range cfApp.Spec.Sidecars {
+		labels[SidecarGUIDLabelPrefix+sidecar.GUID] = "true"
+	}
+
+	cfApp.Labels = labels
+}
+
+// findSidecar looks up the app the sidecar belongs to via its sidecar guid
+// label
+func (r *SidecarRepo) findSidecar(ctx context.Context, sidecarGUID string) (*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppSidecar, error) {
+	labelKey := SidecarGUIDLabelPrefix + sidecarGUID
+	if len(validation.IsQualifiedName(labelKey)) > 0 {
+		return nil, korifiv1alpha1.CFAppSidecar{}, apierrors.NewNotFoundError(nil, SidecarResourceType)
+	}
+
+	appList := &korifiv1alpha1.CFAppList{}
+	err := r.klient.List(ctx, appList, WithLabel(labelKey, "true"))
+	if err != nil {
+		return nil, korifiv1alpha1.CFAppSidecar{}, fmt.Errorf("failed to list apps: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	for _, app := range appList.Items {
+		for _, sidecar := range app.Spec.Sidecars {
+			if sidecar.GUID == sidecarGUID {
+				return &app, sidecar, nil
+			}
+		}
+	}
+
+	return nil, korifiv1alpha1.CFAppSidecar{}, apierrors.NewNotFoundError(nil, SidecarResourceType)
+}
+
+func validateSidecarNameIsUnique(cfApp *korifiv1alpha1.CFApp, sidecar korifiv1alpha1.CFAppSidecar) error {
+	if slices.ContainsFunc(cfApp.Spec.Sidecars, func(s korifiv1alpha1.CFAppSidecar) bool {
+		return s.Name == sidecar.Name && s.GUID != sidecar.GUID
+	}) {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Sidecar with name '%s' already exists for given app", sidecar.Name))
+	}
+
+	return nil
+}
+
+func cfAppSidecarToRecord(cfApp korifiv1alpha1.CFApp, sidecar korifiv1alpha1.CFAppSidecar) SidecarRecord {
+	var memoryMB *int64
+	if sidecar.MemoryMB != 0 {
+		memoryMB = tools.PtrTo(sidecar.MemoryMB)
+	}
+
+	return SidecarRecord{
+		GUID:         sidecar.GUID,
+		Name:         sidecar.Name,
+		Command:      sidecar.Command,
+		ProcessTypes: sidecar.ProcessTypes,
+		MemoryMB:     memoryMB,
+		Origin:       SidecarOriginUser,
+		AppGUID:      cfApp.Name,
+		SpaceGUID:    cfApp.Namespace,
+		CreatedAt:    cfApp.CreationTimestamp.Time,
+		UpdatedAt:    getLastUpdatedTime(&cfApp),
+	}
+}
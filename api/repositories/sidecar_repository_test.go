@@ -0,0 +1,335 @@
+package repositories_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SidecarRepository", func() {
+	var (
+		sidecarRepo *repositories.SidecarRepo
+		cfOrg       *korifiv1alpha1.CFOrg
+		cfSpace     *korifiv1alpha1.CFSpace
+		cfApp       *korifiv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+		Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+			cfApp.Spec.Sidecars = []korifiv1alpha1.CFAppSidecar{
+				{
+					GUID:         "worker-sidecar-guid",
+					Name:         "worker-sidecar",
+					Command:      "run-worker-sidecar",
+					ProcessTypes: []string{"worker"},
+				},
+				{
+					GUID:         "apm-sidecar-guid",
+					Name:         "apm-sidecar",
+					Command:      "run-apm",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     64,
+				},
+			}
+			cfApp.Labels = map[string]string{
+				repositories.SidecarGUIDLabelPrefix + "worker-sidecar-guid": "true",
+				repositories.SidecarGUIDLabelPrefix + "apm-sidecar-guid":    "true",
+			}
+		})).To(Succeed())
+
+		sidecarRepo = repositories.NewSidecarRepo(klient)
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			message   repositories.CreateSidecarMessage
+			sidecar   repositories.SidecarRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSidecarMessage{
+				AppGUID:      cfApp.Name,
+				Name:         "new-sidecar",
+				Command:      "run-new-sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](32),
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, createErr = sidecarRepo.CreateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the sidecar record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(sidecar.GUID).NotTo(BeEmpty())
+				Expect(sidecar.Name).To(Equal("new-sidecar"))
+				Expect(sidecar.Command).To(Equal("run-new-sidecar"))
+				Expect(sidecar.ProcessTypes).To(ConsistOf("web"))
+				Expect(sidecar.MemoryMB).To(PointTo(BeEquivalentTo(32)))
+				Expect(sidecar.Origin).To(Equal("user"))
+				Expect(sidecar.AppGUID).To(Equal(cfApp.Name))
+				Expect(sidecar.SpaceGUID).To(Equal(cfSpace.Name))
+			})
+
+			It("adds the sidecar to the app", func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Sidecars).To(ContainElement(korifiv1alpha1.CFAppSidecar{
+					GUID:         sidecar.GUID,
+					Name:         "new-sidecar",
+					Command:      "run-new-sidecar",
+					ProcessTypes: []string{"web"},
+					MemoryMB:     32,
+				}))
+				Expect(cfApp.Labels).To(HaveKeyWithValue(repositories.SidecarGUIDLabelPrefix+sidecar.GUID, "true"))
+			})
+
+			When("sidecars are created concurrently", func() {
+				JustBeforeEach(func() {
+					var wg sync.WaitGroup
+					for i := range 5 {
+						wg.Add(1)
+						go func() {
+							defer GinkgoRecover()
+							defer wg.Done()
+
+							concurrentMessage := message
+							concurrentMessage.Name = fmt.Sprintf("concurrent-sidecar-%d", i)
+							_, err := sidecarRepo.CreateSidecar(ctx, authInfo, concurrentMessage)
+							Expect(err).NotTo(HaveOccurred())
+						}()
+					}
+					wg.Wait()
+				})
+
+				It("keeps all of them", func() {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Sidecars).To(HaveLen(8))
+				})
+			})
+
+			When("the app already has a sidecar with the same name", func() {
+				BeforeEach(func() {
+					message.Name = "apm-sidecar"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					var unprocessableEntityError apierrors.UnprocessableEntityError
+					Expect(errors.As(createErr, &unprocessableEntityError)).To(BeTrue())
+					Expect(unprocessableEntityError.Detail()).To(Equal("Sidecar with name 'apm-sidecar' already exists for given app"))
+				})
+			})
+
+			When("the app does not exist", func() {
+				BeforeEach(func() {
+					message.AppGUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSidecar", func() {
+		var (
+			sidecarGUID string
+			sidecar     repositories.SidecarRecord
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			sidecarGUID = "apm-sidecar-guid"
+		})
+
+		JustBeforeEach(func() {
+			sidecar, getErr = sidecarRepo.GetSidecar(ctx, authInfo, sidecarGUID)
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the sidecar", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(sidecar.GUID).To(Equal("apm-sidecar-guid"))
+				Expect(sidecar.Name).To(Equal("apm-sidecar"))
+				Expect(sidecar.Command).To(Equal("run-apm"))
+				Expect(sidecar.ProcessTypes).To(ConsistOf("web", "worker"))
+				Expect(sidecar.MemoryMB).To(PointTo(BeEquivalentTo(64)))
+				Expect(sidecar.AppGUID).To(Equal(cfApp.Name))
+				Expect(sidecar.SpaceGUID).To(Equal(cfSpace.Name))
+			})
+
+			When("the sidecar does not exist", func() {
+				BeforeEach(func() {
+					sidecarGUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the sidecar guid is not a valid label key", func() {
+				BeforeEach(func() {
+					sidecarGUID = "not a/valid guid"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			message  repositories.ListSidecarsMessage
+			sidecars []repositories.SidecarRecord
+			listErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			message = repositories.ListSidecarsMessage{AppGUID: cfApp.Name}
+		})
+
+		JustBeforeEach(func() {
+			sidecars, listErr = sidecarRepo.ListSidecars(ctx, authInfo, message)
+		})
+
+		It("returns the app sidecars ordered by name", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(sidecars).To(HaveLen(2))
+			Expect(sidecars[0].Name).To(Equal("apm-sidecar"))
+			Expect(sidecars[1].Name).To(Equal("worker-sidecar"))
+		})
+
+		When("filtering by process type", func() {
+			BeforeEach(func() {
+				message.ProcessType = "web"
+			})
+
+			It("returns the sidecars attached to the process type", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(sidecars).To(HaveLen(1))
+				Expect(sidecars[0].Name).To(Equal("apm-sidecar"))
+			})
+		})
+	})
+
+	Describe("PatchSidecar", func() {
+		var (
+			message  repositories.PatchSidecarMessage
+			sidecar  repositories.SidecarRecord
+			patchErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.PatchSidecarMessage{
+				GUID:    "apm-sidecar-guid",
+				Command: tools.PtrTo("run-apm --verbose"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			sidecar, patchErr = sidecarRepo.PatchSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a not found error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("updates the sidecar", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(sidecar.Name).To(Equal("apm-sidecar"))
+				Expect(sidecar.Command).To(Equal("run-apm --verbose"))
+				Expect(sidecar.ProcessTypes).To(ConsistOf("web", "worker"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Sidecars).To(ContainElement(korifiv1alpha1.CFAppSidecar{
+					GUID:         "apm-sidecar-guid",
+					Name:         "apm-sidecar",
+					Command:      "run-apm --verbose",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     64,
+				}))
+			})
+
+			When("the sidecar is renamed to the name of another app sidecar", func() {
+				BeforeEach(func() {
+					message.Name = tools.PtrTo("worker-sidecar")
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = sidecarRepo.DeleteSidecar(ctx, authInfo, "apm-sidecar-guid")
+		})
+
+		It("returns a not found error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("removes the sidecar from the app", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Sidecars).To(HaveLen(1))
+				Expect(cfApp.Spec.Sidecars[0].GUID).To(Equal("worker-sidecar-guid"))
+				Expect(cfApp.Labels).NotTo(HaveKey(repositories.SidecarGUIDLabelPrefix + "apm-sidecar-guid"))
+				Expect(cfApp.Labels).To(HaveKey(repositories.SidecarGUIDLabelPrefix + "worker-sidecar-guid"))
+			})
+		})
+	})
+})
@@ -10,13 +10,16 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -55,10 +58,22 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type PatchSpaceFeaturesMessage struct {
+	GUID       string
+	SSHEnabled *bool
+}
+
+func (m PatchSpaceFeaturesMessage) apply(space *korifiv1alpha1.CFSpace) {
+	if m.SSHEnabled != nil {
+		space.Spec.Features.SSH = tools.PtrTo(*m.SSHEnabled)
+	}
+}
+
 type SpaceRecord struct {
 	Name             string
 	GUID             string
 	OrganizationGUID string
+	SSHEnabled       bool
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -77,6 +92,7 @@ type SpaceRepo struct {
 	orgRepo          *OrgRepo
 	nsPerms          *authorization.NamespacePermissions
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFSpace]
+	privilegedClient client.Client
 }
 
 func NewSpaceRepo(
@@ -84,12 +100,14 @@ func NewSpaceRepo(
 	orgRepo *OrgRepo,
 	nsPerms *authorization.NamespacePermissions,
 	conditionAwaiter Awaiter[*korifiv1alpha1.CFSpace],
+	privilegedClient client.Client,
 ) *SpaceRepo {
 	return &SpaceRepo{
 		klient:           klient,
 		orgRepo:          orgRepo,
 		nsPerms:          nsPerms,
 		conditionAwaiter: conditionAwaiter,
+		privilegedClient: privilegedClient,
 	}
 }
 
@@ -173,6 +191,7 @@ func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 		Name:             cfSpace.Spec.DisplayName,
 		GUID:             cfSpace.Name,
 		OrganizationGUID: cfSpace.Namespace,
+		SSHEnabled:       cfSpace.Spec.Features.SSH == nil || *cfSpace.Spec.Features.SSH,
 		Annotations:      cfSpace.Annotations,
 		Labels:           cfSpace.Labels,
 		CreatedAt:        cfSpace.CreationTimestamp.Time,
@@ -215,6 +234,59 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(*cfSpace), nil
 }
 
+// PatchSpaceFeatures updates the feature toggles of a space. The CFSpace lives
+// in the org namespace where space managers have no write access, so the
+// permission is checked against the `cfspaces/features` subresource in the
+// space namespace and the patch is applied with the privileged client.
+func (r *SpaceRepo) PatchSpaceFeatures(ctx context.Context, authInfo authorization.Info, message PatchSpaceFeaturesMessage) (SpaceRecord, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	allowed, err := r.canIPatchSpaceFeatures(ctx, message.GUID)
+	if err != nil {
+		return SpaceRecord{}, err
+	}
+
+	if !allowed {
+		return SpaceRecord{}, apierrors.NewForbiddenError(nil, SpaceResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, r.privilegedClient, cfSpace, func() {
+		message.apply(cfSpace)
+	})
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to patch space features: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
+func (r *SpaceRepo) canIPatchSpaceFeatures(ctx context.Context, spaceGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   spaceGUID,
+				Verb:        "patch",
+				Group:       "korifi.cloudfoundry.org",
+				Resource:    "cfspaces",
+				Subresource: "features",
+			},
+		},
+	}
+	if err := r.klient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canIPatchSpaceFeatures: failed to create self subject access review: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
@@ -45,7 +45,7 @@ var _ = Describe("SpaceRepository", func() {
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
 		]{}
-		spaceRepo = repositories.NewSpaceRepo(klient, orgRepo, nsPerms, conditionAwaiter)
+		spaceRepo = repositories.NewSpaceRepo(klient, orgRepo, nsPerms, conditionAwaiter, k8sClient)
 	})
 
 	Describe("CreateSpace", func() {
@@ -470,6 +470,65 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("PatchSpaceFeatures", func() {
+		var (
+			cfSpace     *korifiv1alpha1.CFSpace
+			spaceRecord repositories.SpaceRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, patchErr = spaceRepo.PatchSpaceFeatures(ctx, authInfo, repositories.PatchSpaceFeaturesMessage{
+				GUID:       cfSpace.Name,
+				SSHEnabled: tools.PtrTo(false),
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceManagerRole.Name, cfSpace.Name)
+			})
+
+			It("updates the space features", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.SSHEnabled).To(BeFalse())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.Features.SSH).To(PointTo(BeFalse()))
+			})
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				cfSpace.Name = "does-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("PatchSpaceMetadata", func() {
 		var (
 			spaceGUID                     string
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;get;list;delete,namespace=ROOT_NAMESPACE
+
+const (
+	SSHCodeResourceType = "SSH Code"
+
+	SSHCodeLabelKey          = "korifi.cloudfoundry.org/ssh-code"
+	SSHCodeExpiresAtKey      = "korifi.cloudfoundry.org/ssh-code-expires-at"
+	sshCodeSecretNamePrefix  = "ssh-code-"
+	sshCodeIdentityNameKey   = "identityName"
+	sshCodeIdentityKindKey   = "identityKind"
+	sshCodeIdentityGroupsKey = "identityGroups"
+	sshCodeRandomBytesLength = 24
+)
+
+// SSHCodeRepo issues one-time codes that the SSH proxy exchanges for the
+// identity of the user who requested them. Codes are stored as short-lived
+// secrets in the root namespace so that any API replica can redeem them. The
+// secrets only hold the identity of the user and its groups, never their
+// credentials.
+type SSHCodeRepo struct {
+	privilegedClient client.Client
+	identityProvider authorization.IdentityProvider
+	rootNamespace    string
+	ttl              time.Duration
+}
+
+func NewSSHCodeRepo(
+	privilegedClient client.Client,
+	identityProvider authorization.IdentityProvider,
+	rootNamespace string,
+	ttl time.Duration,
+) *SSHCodeRepo {
+	return &SSHCodeRepo{
+		privilegedClient: privilegedClient,
+		identityProvider: identityProvider,
+		rootNamespace:    rootNamespace,
+		ttl:              ttl,
+	}
+}
+
+func (r *SSHCodeRepo) CreateCode(ctx context.Context, authInfo authorization.Info) (string, error) {
+	if authInfo.Scheme() != authorization.BearerScheme && authInfo.Scheme() != authorization.CertScheme {
+		return "", apierrors.NewNotAuthenticatedError(errors.New("unsupported authentication scheme"))
+	}
+
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	identityGroups, err := json.Marshal(identity.Groups)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal identity groups: %w", err)
+	}
+
+	r.deleteExpiredCodes(ctx)
+
+	randomBytes := make([]byte, sshCodeRandomBytesLength)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate ssh code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	err = r.privilegedClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      sshCodeSecretName(code),
+			Labels: map[string]string{
+				SSHCodeLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				SSHCodeExpiresAtKey: time.Now().Add(r.ttl).UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{
+			sshCodeIdentityNameKey:   []byte(identity.Name),
+			sshCodeIdentityKindKey:   []byte(identity.Kind),
+			sshCodeIdentityGroupsKey: identityGroups,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store ssh code: %w", apierrors.FromK8sError(err, SSHCodeResourceType))
+	}
+
+	return code, nil
+}
+
+// RedeemCode returns authorization info impersonating the identity the code
+// was issued to. Callers act with the permissions that identity has at
+// redemption time. A code can only be redeemed once and is rejected after it
+// expires.
+func (r *SSHCodeRepo) RedeemCode(ctx context.Context, code string) (authorization.Info, error) {
+	secret := &corev1.Secret{}
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: sshCodeSecretName(code)}, secret)
+	if err != nil {
+		return authorization.Info{}, invalidSSHCodeError(err)
+	}
+
+	// Only one of the concurrent redeemers manages to delete the secret
+	err = r.privilegedClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if err != nil {
+		return authorization.Info{}, invalidSSHCodeError(err)
+	}
+
+	if isSSHCodeExpired(*secret, time.Now()) {
+		return authorization.Info{}, invalidSSHCodeError(errors.New("ssh code expired"))
+	}
+
+	identity := authorization.Identity{
+		Name: string(secret.Data[sshCodeIdentityNameKey]),
+		Kind: string(secret.Data[sshCodeIdentityKindKey]),
+	}
+	if identity.Name == "" {
+		return authorization.Info{}, invalidSSHCodeError(errors.New("ssh code has no identity"))
+	}
+
+	if err = json.Unmarshal(secret.Data[sshCodeIdentityGroupsKey], &identity.Groups); err != nil {
+		return authorization.Info{}, invalidSSHCodeError(fmt.Errorf("failed to unmarshal identity groups: %w", err))
+	}
+
+	return authorization.Info{Impersonate: &identity}, nil
+}
+
+func (r *SSHCodeRepo) deleteExpiredCodes(ctx context.Context) {
+	secrets := &corev1.SecretList{}
+	err := r.privilegedClient.List(ctx, secrets, client.InNamespace(r.rootNamespace), client.MatchingLabels{SSHCodeLabelKey: "true"})
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, secret := range secrets.Items {
+		if isSSHCodeExpired(secret, now) {
+			err = r.privilegedClient.Delete(ctx, &secret)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return
+			}
+		}
+	}
+}
+
+func isSSHCodeExpired(secret corev1.Secret, now time.Time) bool {
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[SSHCodeExpiresAtKey])
+	if err != nil {
+		return true
+	}
+
+	return now.After(expiresAt)
+}
+
+func sshCodeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return sshCodeSecretNamePrefix + hex.EncodeToString(hash[:])
+}
+
+func invalidSSHCodeError(err error) error {
+	return apierrors.NewNotAuthenticatedError(fmt.Errorf("invalid ssh code: %w", err))
+}
@@ -0,0 +1,195 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/testhelpers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SSHCodeRepository", func() {
+	var sshCodeRepo *repositories.SSHCodeRepo
+
+	BeforeEach(func() {
+		sshCodeRepo = repositories.NewSSHCodeRepo(k8sClient, idProvider, rootNamespace, time.Minute)
+	})
+
+	listCodeSecrets := func(g Gomega) []corev1.Secret {
+		secrets := &corev1.SecretList{}
+		g.Expect(k8sClient.List(ctx, secrets,
+			client.InNamespace(rootNamespace),
+			client.MatchingLabels{repositories.SSHCodeLabelKey: "true"},
+		)).To(Succeed())
+		return secrets.Items
+	}
+
+	Describe("CreateCode", func() {
+		var (
+			code string
+			err  error
+		)
+
+		JustBeforeEach(func() {
+			code, err = sshCodeRepo.CreateCode(ctx, authInfo)
+		})
+
+		It("stores the identity of the user for the code", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(code).NotTo(BeEmpty())
+
+			secrets := listCodeSecrets(Default)
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].Name).NotTo(ContainSubstring(code))
+			Expect(secrets[0].Annotations).To(HaveKey(repositories.SSHCodeExpiresAtKey))
+		})
+
+		It("does not store the user credentials", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			secrets := listCodeSecrets(Default)
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].Data).To(MatchAllKeys(Keys{
+				"identityName":   BeEquivalentTo(userName),
+				"identityKind":   BeEquivalentTo(rbacv1.UserKind),
+				"identityGroups": MatchJSON(`null`),
+			}))
+		})
+
+		When("an expired code exists", func() {
+			var expiredSecret corev1.Secret
+
+			BeforeEach(func() {
+				_, err := sshCodeRepo.CreateCode(ctx, authInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				expiredSecret = listCodeSecrets(Default)[0]
+				Expect(k8s.PatchResource(ctx, k8sClient, &expiredSecret, func() {
+					expiredSecret.Annotations[repositories.SSHCodeExpiresAtKey] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+				})).To(Succeed())
+			})
+
+			It("deletes it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					secrets := listCodeSecrets(g)
+					g.Expect(secrets).To(HaveLen(1))
+					g.Expect(secrets[0].Name).NotTo(Equal(expiredSecret.Name))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the user is not authenticated", func() {
+			JustBeforeEach(func() {
+				code, err = sshCodeRepo.CreateCode(ctx, authorization.Info{})
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+	})
+
+	Describe("RedeemCode", func() {
+		var (
+			code         string
+			redeemedInfo authorization.Info
+			err          error
+		)
+
+		BeforeEach(func() {
+			code, err = sshCodeRepo.CreateCode(ctx, authInfo)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			redeemedInfo, err = sshCodeRepo.RedeemCode(ctx, code)
+		})
+
+		It("impersonates the identity the code was issued to", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redeemedInfo.Token).To(BeEmpty())
+			Expect(redeemedInfo.CertData).To(BeEmpty())
+			Expect(redeemedInfo.Impersonate).To(PointTo(Equal(authorization.Identity{
+				Name: userName,
+				Kind: rbacv1.UserKind,
+			})))
+		})
+
+		It("deletes the code", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(listCodeSecrets).Should(BeEmpty())
+		})
+
+		When("the user belongs to groups", func() {
+			var groupUserName string
+
+			BeforeEach(func() {
+				groupUserName = uuid.NewString()
+				groupUser, addErr := testEnv.ControlPlane.AddUser(envtest.User{
+					Name:   groupUserName,
+					Groups: []string{"space-developers"},
+				}, testEnv.Config)
+				Expect(addErr).NotTo(HaveOccurred())
+
+				code, err = sshCodeRepo.CreateCode(ctx, authorization.Info{
+					CertData: testhelpers.JoinCertAndKey(groupUser.Config().CertData, groupUser.Config().KeyData),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("impersonates the groups of the identity", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(redeemedInfo.Impersonate).To(PointTo(Equal(authorization.Identity{
+					Name:   groupUserName,
+					Kind:   rbacv1.UserKind,
+					Groups: []string{"space-developers"},
+				})))
+			})
+		})
+
+		When("the code has already been redeemed", func() {
+			JustBeforeEach(func() {
+				_, err = sshCodeRepo.RedeemCode(ctx, code)
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+
+		When("the code has expired", func() {
+			BeforeEach(func() {
+				sshCodeRepo = repositories.NewSSHCodeRepo(k8sClient, idProvider, rootNamespace, -time.Minute)
+				code, err = sshCodeRepo.CreateCode(ctx, authInfo)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+
+		When("the code does not exist", func() {
+			BeforeEach(func() {
+				code = "not-a-code"
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+	})
+})
@@ -2,12 +2,15 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -26,11 +29,26 @@ type StackRepository struct {
 }
 
 type StackRecord struct {
+	// Derived from the builder and stack names for the stacks of the builder
+	// that are not managed via the API
 	GUID        string
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
 	Name        string
 	Description string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type CreateStackMessage struct {
+	Name        string
+	Description string
+	Metadata    Metadata
+}
+
+type UpdateStackMessage struct {
+	GUID          string
+	MetadataPatch MetadataPatch
 }
 
 func NewStackRepository(
@@ -45,7 +63,123 @@ func NewStackRepository(
 	}
 }
 
+// ListStacks returns the stacks of the builder along with the stacks managed
+// via the API. Managed stacks take the place of the builder stacks with the
+// same name.
 func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization.Info) ([]StackRecord, error) {
+	builderStacks, err := r.listBuilderStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfStacks, err := r.listCFStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := slices.DeleteFunc(builderStacks, func(s StackRecord) bool {
+		return slices.ContainsFunc(cfStacks, func(cfStack korifiv1alpha1.CFStack) bool {
+			return cfStack.Spec.DisplayName == s.Name
+		})
+	})
+
+	return append(records, slices.Collect(it.Map(slices.Values(cfStacks), cfStackToStackRecord))...), nil
+}
+
+func (r *StackRepository) GetStack(ctx context.Context, authInfo authorization.Info, guid string) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	err := r.klient.Get(ctx, cfStack)
+	if err == nil {
+		return cfStackToStackRecord(*cfStack), nil
+	}
+
+	err = apierrors.FromK8sError(err, StackResourceType)
+	if !errors.As(err, &apierrors.NotFoundError{}) {
+		return StackRecord{}, fmt.Errorf("failed to get stack: %w", err)
+	}
+
+	builderStacks, builderErr := r.listBuilderStacks(ctx)
+	if builderErr != nil {
+		return StackRecord{}, builderErr
+	}
+
+	builderStackIdx := slices.IndexFunc(builderStacks, func(s StackRecord) bool {
+		return s.GUID == guid
+	})
+	if builderStackIdx == -1 {
+		return StackRecord{}, fmt.Errorf("failed to get stack: %w", err)
+	}
+
+	return builderStacks[builderStackIdx], nil
+}
+
+func (r *StackRepository) CreateStack(ctx context.Context, authInfo authorization.Info, message CreateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFStackSpec{
+			DisplayName: message.Name,
+			Description: message.Description,
+		},
+	}
+	if err := r.klient.Create(ctx, cfStack); err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return StackRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
+		return StackRecord{}, fmt.Errorf("failed to create stack: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) UpdateStack(ctx context.Context, authInfo authorization.Info, message UpdateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfStack, func() error {
+		message.MetadataPatch.Apply(cfStack)
+		return nil
+	})
+	if err != nil {
+		return StackRecord{}, fmt.Errorf("failed to patch stack: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	if err := r.klient.Delete(ctx, cfStack); err != nil {
+		return fmt.Errorf("failed to delete stack: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return nil
+}
+
+// listBuilderStacks returns the stacks reported by the BuilderInfo of the
+// builder
+func (r *StackRepository) listBuilderStacks(ctx context.Context) ([]StackRecord, error) {
 	builderInfo := &korifiv1alpha1.BuilderInfo{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.rootNamespace,
@@ -65,9 +199,31 @@ func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization
 	return builderInfoToStackRecords(*builderInfo), nil
 }
 
+func (r *StackRepository) listCFStacks(ctx context.Context) ([]korifiv1alpha1.CFStack, error) {
+	cfStackList := &korifiv1alpha1.CFStackList{}
+	if err := r.klient.List(ctx, cfStackList, InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return cfStackList.Items, nil
+}
+
+func cfStackToStackRecord(cfStack korifiv1alpha1.CFStack) StackRecord {
+	return StackRecord{
+		GUID:        cfStack.Name,
+		Name:        cfStack.Spec.DisplayName,
+		Description: cfStack.Spec.Description,
+		Labels:      cfStack.Labels,
+		Annotations: cfStack.Annotations,
+		CreatedAt:   cfStack.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfStack),
+	}
+}
+
 func builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
 	return slices.Collect(it.Map(slices.Values(info.Status.Stacks), func(s korifiv1alpha1.BuilderInfoStatusStack) StackRecord {
 		return StackRecord{
+			GUID:        BuilderStackGUID(info.Name, s.Name),
 			Name:        s.Name,
 			Description: s.Description,
 			CreatedAt:   s.CreationTimestamp.Time,
@@ -75,3 +231,9 @@ func builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
 		}
 	}))
 }
+
+// BuilderStackGUID returns a stable GUID for a stack of the builder, so that
+// builder stacks can be addressed like the stacks managed via the API
+func BuilderStackGUID(builderName, stackName string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(builderName+"/"+stackName)).String()
+}
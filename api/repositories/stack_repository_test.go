@@ -0,0 +1,259 @@
+package repositories_test
+
+import (
+	"context"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("StackRepository", func() {
+	var stackRepo *StackRepository
+
+	BeforeEach(func() {
+		stackRepo = NewStackRepository(klientUnfiltered, builderName, rootNamespace)
+	})
+
+	Describe("ListStacks", func() {
+		var (
+			stacks  []StackRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.jammy", nil)
+		})
+
+		JustBeforeEach(func() {
+			stacks, listErr = stackRepo.ListStacks(ctx, authInfo)
+		})
+
+		It("returns the stack of the builder", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(stacks).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(BuilderStackGUID(builderName, "io.buildpacks.stacks.jammy")),
+					"Name": Equal("io.buildpacks.stacks.jammy"),
+				}),
+			))
+		})
+
+		When("there are stacks managed via the API", func() {
+			var cfStack *korifiv1alpha1.CFStack
+
+			BeforeEach(func() {
+				cfStack = createCFStack(ctx, "io.buildpacks.stacks.noble", "the noble stack")
+			})
+
+			It("returns them along with the builder stack", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(stacks).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(BuilderStackGUID(builderName, "io.buildpacks.stacks.jammy")),
+						"Name": Equal("io.buildpacks.stacks.jammy"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":        Equal(cfStack.Name),
+						"Name":        Equal("io.buildpacks.stacks.noble"),
+						"Description": Equal("the noble stack"),
+					}),
+				))
+			})
+
+			When("a managed stack has the name of the builder stack", func() {
+				var jammyStack *korifiv1alpha1.CFStack
+
+				BeforeEach(func() {
+					jammyStack = createCFStack(ctx, "io.buildpacks.stacks.jammy", "the jammy stack")
+				})
+
+				It("returns the managed stack in its place", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(stacks).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":        Equal(jammyStack.Name),
+							"Name":        Equal("io.buildpacks.stacks.jammy"),
+							"Description": Equal("the jammy stack"),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(cfStack.Name),
+						}),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("GetStack", func() {
+		var guid string
+
+		BeforeEach(func() {
+			guid = createCFStack(ctx, "my-stack", "my description").Name
+		})
+
+		It("returns the stack", func() {
+			record, err := stackRepo.GetStack(ctx, authInfo, guid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(guid))
+			Expect(record.Name).To(Equal("my-stack"))
+			Expect(record.Description).To(Equal("my description"))
+		})
+
+		When("the stack is a stack of the builder", func() {
+			BeforeEach(func() {
+				createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.jammy", nil)
+				guid = BuilderStackGUID(builderName, "io.buildpacks.stacks.jammy")
+			})
+
+			It("returns the builder stack", func() {
+				record, err := stackRepo.GetStack(ctx, authInfo, guid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(guid))
+				Expect(record.Name).To(Equal("io.buildpacks.stacks.jammy"))
+			})
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.jammy", nil)
+			})
+
+			It("returns a not found error", func() {
+				_, err := stackRepo.GetStack(ctx, authInfo, "i-do-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("CreateStack", func() {
+		var (
+			record    StackRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = stackRepo.CreateStack(ctx, authInfo, CreateStackMessage{
+				Name:        "my-stack",
+				Description: "my description",
+				Metadata:    Metadata{Labels: map[string]string{"foo": "bar"}},
+			})
+		})
+
+		It("fails without the admin role", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the stack", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.Name).To(Equal("my-stack"))
+				Expect(record.Description).To(Equal("my description"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				cfStack := &korifiv1alpha1.CFStack{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, cfStack)).To(Succeed())
+				Expect(cfStack.Spec.DisplayName).To(Equal("my-stack"))
+			})
+		})
+	})
+
+	Describe("UpdateStack", func() {
+		var (
+			guid      string
+			record    StackRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			guid = createCFStack(ctx, "my-stack", "").Name
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = stackRepo.UpdateStack(ctx, authInfo, UpdateStackMessage{
+				GUID: guid,
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+		})
+
+		It("fails without the admin role", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the stack metadata", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+		})
+	})
+
+	Describe("DeleteStack", func() {
+		var (
+			guid      string
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			guid = createCFStack(ctx, "my-stack", "").Name
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = stackRepo.DeleteStack(ctx, authInfo, guid)
+		})
+
+		It("fails without the admin role", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the stack", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: guid}, &korifiv1alpha1.CFStack{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
+
+func createCFStack(ctx context.Context, name, description string) *korifiv1alpha1.CFStack {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFStackSpec{
+			DisplayName: name,
+			Description: description,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfStack)).To(Succeed())
+
+	return cfStack
+}
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageEventResourceType     = "App Usage Event"
+	ServiceUsageEventResourceType = "Service Usage Event"
+)
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	State                         string
+	PreviousState                 string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrgGUID                       string
+	InstanceCount                 int32
+	PreviousInstanceCount         *int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance *int64
+	CreatedAt                     time.Time
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	State               string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	ServicePlanGUID     string
+	SpaceGUID           string
+	SpaceName           string
+	OrgGUID             string
+	CreatedAt           time.Time
+}
+
+// usageEventListChunkSize is the number of usage events fetched from the API
+// server at a time
+const usageEventListChunkSize = 500
+
+// ListUsageEventsMessage filters usage events. Usage event GUIDs are time
+// ordered, so AfterGUID selects the events recorded after the given one.
+// PerPage caps the number of events returned; zero means no cap.
+type ListUsageEventsMessage struct {
+	GUIDs     []string
+	AfterGUID string
+	PerPage   int
+}
+
+func (m ListUsageEventsMessage) matches(e korifiv1alpha1.CFUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name) &&
+		(m.AfterGUID == "" || e.Name > m.AfterGUID)
+}
+
+// UsageEventRepo reads the usage events recorded by the controllers. Usage
+// events live in the root namespace, so that they outlive the spaces they
+// are about, and can therefore only be read by admins.
+type UsageEventRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewUsageEventRepo(klient Klient, rootNamespace string) *UsageEventRepo {
+	return &UsageEventRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+func (r *UsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	usageEvent, err := r.getUsageEvent(ctx, guid, korifiv1alpha1.UsageEventTypeApp, AppUsageEventResourceType)
+	if err != nil {
+		return AppUsageEventRecord{}, err
+	}
+
+	return toAppUsageEventRecord(usageEvent), nil
+}
+
+func (r *UsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListUsageEventsMessage) ([]AppUsageEventRecord, error) {
+	usageEvents, err := r.listUsageEvents(ctx, message, korifiv1alpha1.UsageEventTypeApp, AppUsageEventResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Collect(it.Map(slices.Values(usageEvents), toAppUsageEventRecord)), nil
+}
+
+func (r *UsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	usageEvent, err := r.getUsageEvent(ctx, guid, korifiv1alpha1.UsageEventTypeService, ServiceUsageEventResourceType)
+	if err != nil {
+		return ServiceUsageEventRecord{}, err
+	}
+
+	return toServiceUsageEventRecord(usageEvent), nil
+}
+
+func (r *UsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListUsageEventsMessage) ([]ServiceUsageEventRecord, error) {
+	usageEvents, err := r.listUsageEvents(ctx, message, korifiv1alpha1.UsageEventTypeService, ServiceUsageEventResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Collect(it.Map(slices.Values(usageEvents), toServiceUsageEventRecord)), nil
+}
+
+func (r *UsageEventRepo) getUsageEvent(ctx context.Context, guid, eventType, resourceType string) (korifiv1alpha1.CFUsageEvent, error) {
+	usageEvent := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	err := r.klient.Get(ctx, usageEvent)
+	if err != nil {
+		return korifiv1alpha1.CFUsageEvent{}, apierrors.FromK8sError(err, resourceType)
+	}
+
+	if usageEvent.Spec.Type != eventType {
+		return korifiv1alpha1.CFUsageEvent{}, apierrors.NewNotFoundError(nil, resourceType)
+	}
+
+	return *usageEvent, nil
+}
+
+// listUsageEvents pages through the usage events, which the API server
+// returns ordered by GUID, until it has collected a page of matching events
+func (r *UsageEventRepo) listUsageEvents(ctx context.Context, message ListUsageEventsMessage, eventType, resourceType string) ([]korifiv1alpha1.CFUsageEvent, error) {
+	usageEvents := []korifiv1alpha1.CFUsageEvent{}
+	continueToken := ""
+	for {
+		usageEventList := &korifiv1alpha1.CFUsageEventList{}
+		err := r.klient.List(ctx, usageEventList,
+			InNamespace(r.rootNamespace),
+			WithLabel(korifiv1alpha1.CFUsageEventTypeLabelKey, eventType),
+			Limit(usageEventListChunkSize),
+			Continue(continueToken),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list usage events: %w", apierrors.FromK8sError(err, resourceType))
+		}
+
+		for _, usageEvent := range usageEventList.Items {
+			if !message.matches(usageEvent) {
+				continue
+			}
+
+			usageEvents = append(usageEvents, usageEvent)
+			if len(usageEvents) == message.PerPage {
+				return usageEvents, nil
+			}
+		}
+
+		continueToken = usageEventList.Continue
+		if continueToken == "" {
+			return usageEvents, nil
+		}
+	}
+}
+
+func toAppUsageEventRecord(usageEvent korifiv1alpha1.CFUsageEvent) AppUsageEventRecord {
+	app := tools.ZeroIfNil(usageEvent.Spec.App)
+
+	return AppUsageEventRecord{
+		GUID:                          usageEvent.Name,
+		State:                         usageEvent.Spec.State,
+		PreviousState:                 usageEvent.Spec.PreviousState,
+		AppGUID:                       app.AppGUID,
+		AppName:                       app.AppName,
+		ProcessGUID:                   app.ProcessGUID,
+		ProcessType:                   app.ProcessType,
+		SpaceGUID:                     usageEvent.Spec.SpaceGUID,
+		SpaceName:                     usageEvent.Spec.SpaceName,
+		OrgGUID:                       usageEvent.Spec.OrgGUID,
+		InstanceCount:                 app.InstanceCount,
+		PreviousInstanceCount:         app.PreviousInstanceCount,
+		MemoryInMBPerInstance:         app.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: app.PreviousMemoryInMBPerInstance,
+		CreatedAt:                     usageEvent.CreationTimestamp.Time,
+	}
+}
+
+func toServiceUsageEventRecord(usageEvent korifiv1alpha1.CFUsageEvent) ServiceUsageEventRecord {
+	serviceInstance := tools.ZeroIfNil(usageEvent.Spec.ServiceInstance)
+
+	return ServiceUsageEventRecord{
+		GUID:                usageEvent.Name,
+		State:               usageEvent.Spec.State,
+		ServiceInstanceGUID: serviceInstance.GUID,
+		ServiceInstanceName: serviceInstance.Name,
+		ServiceInstanceType: string(serviceInstance.Type),
+		ServicePlanGUID:     serviceInstance.PlanGUID,
+		SpaceGUID:           usageEvent.Spec.SpaceGUID,
+		SpaceName:           usageEvent.Spec.SpaceName,
+		OrgGUID:             usageEvent.Spec.OrgGUID,
+		CreatedAt:           usageEvent.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,253 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("UsageEventRepository", func() {
+	var (
+		usageEventRepo    *repositories.UsageEventRepo
+		appEventGUIDs     []string
+		serviceEventGUIDs []string
+	)
+
+	createUsageEvent := func(spec korifiv1alpha1.CFUsageEventSpec) string {
+		guid, err := uuid.NewV7()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      guid.String(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFUsageEventTypeLabelKey: spec.Type,
+				},
+			},
+			Spec: spec,
+		})).To(Succeed())
+
+		return guid.String()
+	}
+
+	createAppUsageEvent := func(state string, instances int32) string {
+		return createUsageEvent(korifiv1alpha1.CFUsageEventSpec{
+			Type:      korifiv1alpha1.UsageEventTypeApp,
+			State:     state,
+			SpaceGUID: "space-guid",
+			SpaceName: "my-space",
+			OrgGUID:   "org-guid",
+			App: &korifiv1alpha1.CFAppUsage{
+				AppGUID:               "app-guid",
+				AppName:               "my-app",
+				ProcessGUID:           "process-guid",
+				ProcessType:           "web",
+				InstanceCount:         instances,
+				PreviousInstanceCount: tools.PtrTo[int32](1),
+				MemoryInMBPerInstance: 256,
+			},
+		})
+	}
+
+	BeforeEach(func() {
+		usageEventRepo = repositories.NewUsageEventRepo(klient, rootNamespace)
+
+		appEventGUIDs = []string{
+			createAppUsageEvent(korifiv1alpha1.UsageEventStateStarted, 1),
+			createAppUsageEvent(korifiv1alpha1.UsageEventStateStarted, 2),
+			createAppUsageEvent(korifiv1alpha1.UsageEventStateStopped, 2),
+		}
+
+		serviceEventGUIDs = []string{
+			createUsageEvent(korifiv1alpha1.CFUsageEventSpec{
+				Type:      korifiv1alpha1.UsageEventTypeService,
+				State:     korifiv1alpha1.UsageEventStateCreated,
+				SpaceGUID: "space-guid",
+				ServiceInstance: &korifiv1alpha1.CFServiceInstanceUsage{
+					GUID:     "service-instance-guid",
+					Name:     "my-service-instance",
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: "plan-guid",
+				},
+			}),
+		}
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			guid       string
+			usageEvent repositories.AppUsageEventRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			guid = appEventGUIDs[1]
+		})
+
+		JustBeforeEach(func() {
+			usageEvent, getErr = usageEventRepo.GetAppUsageEvent(ctx, authInfo, guid)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the app usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(usageEvent).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  Equal(guid),
+					"State":                 Equal(korifiv1alpha1.UsageEventStateStarted),
+					"AppGUID":               Equal("app-guid"),
+					"AppName":               Equal("my-app"),
+					"ProcessType":           Equal("web"),
+					"SpaceName":             Equal("my-space"),
+					"OrgGUID":               Equal("org-guid"),
+					"InstanceCount":         BeEquivalentTo(2),
+					"PreviousInstanceCount": PointTo(BeEquivalentTo(1)),
+					"MemoryInMBPerInstance": BeEquivalentTo(256),
+					"CreatedAt":             Not(BeZero()),
+				}))
+			})
+
+			When("the event is a service usage event", func() {
+				BeforeEach(func() {
+					guid = serviceEventGUIDs[0]
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			message     repositories.ListUsageEventsMessage
+			usageEvents []repositories.AppUsageEventRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			usageEvents, listErr = usageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the app usage events in the order they were recorded", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(usageEvents).To(HaveLen(3))
+				Expect(usageEvents[0].GUID).To(Equal(appEventGUIDs[0]))
+				Expect(usageEvents[1].GUID).To(Equal(appEventGUIDs[1]))
+				Expect(usageEvents[2].GUID).To(Equal(appEventGUIDs[2]))
+			})
+
+			When("filtering by guid", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{appEventGUIDs[2]}
+				})
+
+				It("returns the matching app usage events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEventGUIDs[2])})))
+				})
+			})
+
+			When("listing the events after a given guid", func() {
+				BeforeEach(func() {
+					message.AfterGUID = appEventGUIDs[0]
+				})
+
+				It("returns the app usage events recorded after it", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(usageEvents).To(HaveLen(2))
+					Expect(usageEvents[0].GUID).To(Equal(appEventGUIDs[1]))
+					Expect(usageEvents[1].GUID).To(Equal(appEventGUIDs[2]))
+				})
+
+				When("limiting the number of events per page", func() {
+					BeforeEach(func() {
+						message.PerPage = 1
+					})
+
+					It("returns the first app usage event recorded after it", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEventGUIDs[1])})))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GetServiceUsageEvent", func() {
+		var (
+			usageEvent repositories.ServiceUsageEventRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			usageEvent, getErr = usageEventRepo.GetServiceUsageEvent(ctx, authInfo, serviceEventGUIDs[0])
+		})
+
+		It("returns the service usage event", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(usageEvent).To(MatchFields(IgnoreExtras, Fields{
+				"GUID":                Equal(serviceEventGUIDs[0]),
+				"State":               Equal(korifiv1alpha1.UsageEventStateCreated),
+				"ServiceInstanceGUID": Equal("service-instance-guid"),
+				"ServiceInstanceName": Equal("my-service-instance"),
+				"ServiceInstanceType": Equal("managed"),
+				"ServicePlanGUID":     Equal("plan-guid"),
+			}))
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			usageEvents []repositories.ServiceUsageEventRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			usageEvents, listErr = usageEventRepo.ListServiceUsageEvents(ctx, authInfo, repositories.ListUsageEventsMessage{})
+		})
+
+		It("returns the service usage events only", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(usageEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(serviceEventGUIDs[0])})))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -16,6 +17,9 @@ type Response struct {
 	httpStatus int
 	body       interface{}
 	headers    map[string][]string
+
+	contentType string
+	stream      func(io.Writer) error
 }
 
 func NewResponse(httpStatus int) *Response {
@@ -35,6 +39,15 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+// WithStream makes the response write a non JSON body of the given content
+// type. The stream func is called after the status code has been written, so
+// it should only fail on unexpected errors
+func (r *Response) WithStream(contentType string, stream func(io.Writer) error) *Response {
+	r.contentType = contentType
+	r.stream = stream
+	return r
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -86,6 +99,17 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.stream != nil {
+		w.Header().Set("Content-Type", response.contentType)
+		w.WriteHeader(response.httpStatus)
+
+		if err := response.stream(w); err != nil {
+			return fmt.Errorf("failed to stream response: %w", err)
+		}
+
+		return nil
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
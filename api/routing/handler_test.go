@@ -2,6 +2,7 @@ package routing_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -59,6 +60,23 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response streams its body", func() {
+		BeforeEach(func() {
+			response = response.WithStream("application/x-tar", func(w io.Writer) error {
+				_, err := w.Write([]byte("some-bytes"))
+				return err
+			})
+		})
+
+		It("sets the stream content type in the response", func() {
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+		})
+
+		It("writes the stream into the body", func() {
+			Expect(rr).To(HaveHTTPBody("some-bytes"))
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
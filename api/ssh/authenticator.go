@@ -0,0 +1,136 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// The cf cli connects as `cf:<process-guid>/<instance-index>`
+var userRegex = regexp.MustCompile(`^cf:([^/]+)/(\d+)$`)
+
+// Instance is the app instance an SSH session is proxied to
+type Instance struct {
+	AuthInfo  authorization.Info
+	Namespace string
+	PodName   string
+}
+
+//counterfeiter:generate -o fake -fake-name CodeRedeemer . CodeRedeemer
+type CodeRedeemer interface {
+	RedeemCode(context.Context, string) (authorization.Info, error)
+}
+
+//counterfeiter:generate -o fake -fake-name ProcessRepository . ProcessRepository
+type ProcessRepository interface {
+	GetProcess(context.Context, authorization.Info, string) (repositories.ProcessRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AppRepository . AppRepository
+type AppRepository interface {
+	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name SpaceRepository . SpaceRepository
+type SpaceRepository interface {
+	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
+type PodRepository interface {
+	GetRunningPod(context.Context, authorization.Info, string, repositories.ProcessRecord, string) (repositories.PodRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name PodExecPermissions . PodExecPermissions
+type PodExecPermissions interface {
+	CanExec(context.Context, authorization.Info, string) (bool, error)
+}
+
+// InstanceAuthenticator resolves the app instance an SSH user asks for, and
+// checks that the user is allowed to SSH into it
+type InstanceAuthenticator struct {
+	codeRedeemer       CodeRedeemer
+	processRepo        ProcessRepository
+	appRepo            AppRepository
+	spaceRepo          SpaceRepository
+	podRepo            PodRepository
+	podExecPermissions PodExecPermissions
+}
+
+func NewInstanceAuthenticator(
+	codeRedeemer CodeRedeemer,
+	processRepo ProcessRepository,
+	appRepo AppRepository,
+	spaceRepo SpaceRepository,
+	podRepo PodRepository,
+	podExecPermissions PodExecPermissions,
+) *InstanceAuthenticator {
+	return &InstanceAuthenticator{
+		codeRedeemer:       codeRedeemer,
+		processRepo:        processRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		podRepo:            podRepo,
+		podExecPermissions: podExecPermissions,
+	}
+}
+
+func (a *InstanceAuthenticator) Authenticate(ctx context.Context, user string, code string) (Instance, error) {
+	matches := userRegex.FindStringSubmatch(user)
+	if matches == nil {
+		return Instance{}, fmt.Errorf("invalid user %q, expected cf:<process-guid>/<instance-index>", user)
+	}
+	processGUID, instanceIndex := matches[1], matches[2]
+
+	authInfo, err := a.codeRedeemer.RedeemCode(ctx, code)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	process, err := a.processRepo.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to get process: %w", err)
+	}
+
+	app, err := a.appRepo.GetApp(ctx, authInfo, process.AppGUID)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to get app: %w", err)
+	}
+
+	space, err := a.spaceRepo.GetSpace(ctx, authInfo, process.SpaceGUID)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	if !space.SSHEnabled {
+		return Instance{}, errors.New("ssh is disabled for the space")
+	}
+
+	if !app.SSHEnabled {
+		return Instance{}, errors.New("ssh is disabled for the app")
+	}
+
+	canExec, err := a.podExecPermissions.CanExec(ctx, authInfo, process.SpaceGUID)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	if !canExec {
+		return Instance{}, errors.New("user is not allowed to ssh into the app instances")
+	}
+
+	pod, err := a.podRepo.GetRunningPod(ctx, authInfo, app.Revision, process, instanceIndex)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to get pod for instance %s: %w", instanceIndex, err)
+	}
+
+	return Instance{
+		AuthInfo:  authInfo,
+		Namespace: pod.SpaceGUID,
+		PodName:   pod.Name,
+	}, nil
+}
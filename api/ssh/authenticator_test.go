@@ -0,0 +1,207 @@
+package ssh_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/ssh"
+	"code.cloudfoundry.org/korifi/api/ssh/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InstanceAuthenticator", func() {
+	var (
+		codeRedeemer       *fake.CodeRedeemer
+		processRepo        *fake.ProcessRepository
+		appRepo            *fake.AppRepository
+		spaceRepo          *fake.SpaceRepository
+		podRepo            *fake.PodRepository
+		podExecPermissions *fake.PodExecPermissions
+		authenticator      *ssh.InstanceAuthenticator
+
+		authInfo authorization.Info
+		process  repositories.ProcessRecord
+		user     string
+		instance ssh.Instance
+		authErr  error
+	)
+
+	BeforeEach(func() {
+		authInfo = authorization.Info{Token: "the-token"}
+		process = repositories.ProcessRecord{
+			GUID:      "process-guid",
+			AppGUID:   "app-guid",
+			SpaceGUID: "space-guid",
+			Type:      "web",
+		}
+
+		codeRedeemer = new(fake.CodeRedeemer)
+		codeRedeemer.RedeemCodeReturns(authInfo, nil)
+
+		processRepo = new(fake.ProcessRepository)
+		processRepo.GetProcessReturns(process, nil)
+
+		appRepo = new(fake.AppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:       "app-guid",
+			SpaceGUID:  "space-guid",
+			Revision:   "3",
+			SSHEnabled: true,
+		}, nil)
+
+		spaceRepo = new(fake.SpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:       "space-guid",
+			SSHEnabled: true,
+		}, nil)
+
+		podRepo = new(fake.PodRepository)
+		podRepo.GetRunningPodReturns(repositories.PodRecord{
+			Name:      "the-pod",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		podExecPermissions = new(fake.PodExecPermissions)
+		podExecPermissions.CanExecReturns(true, nil)
+
+		authenticator = ssh.NewInstanceAuthenticator(codeRedeemer, processRepo, appRepo, spaceRepo, podRepo, podExecPermissions)
+		user = "cf:process-guid/1"
+	})
+
+	JustBeforeEach(func() {
+		instance, authErr = authenticator.Authenticate(ctx, user, "the-code")
+	})
+
+	It("returns the pod of the instance", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(instance).To(Equal(ssh.Instance{
+			AuthInfo:  authInfo,
+			Namespace: "space-guid",
+			PodName:   "the-pod",
+		}))
+	})
+
+	It("redeems the code", func() {
+		Expect(codeRedeemer.RedeemCodeCallCount()).To(Equal(1))
+		_, actualCode := codeRedeemer.RedeemCodeArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+	})
+
+	It("looks up everything with the identity of the code", func() {
+		Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualProcessGUID).To(Equal("process-guid"))
+
+		Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualAppGUID).To(Equal("app-guid"))
+
+		Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(podExecPermissions.CanExecCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualNamespace := podExecPermissions.CanExecArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualNamespace).To(Equal("space-guid"))
+	})
+
+	It("gets the running pod of the requested instance of the current app revision", func() {
+		Expect(podRepo.GetRunningPodCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualRevision, actualProcess, actualIndex := podRepo.GetRunningPodArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualRevision).To(Equal("3"))
+		Expect(actualProcess).To(Equal(process))
+		Expect(actualIndex).To(Equal("1"))
+	})
+
+	When("the user is malformed", func() {
+		BeforeEach(func() {
+			user = "someone"
+		})
+
+		It("returns an error without redeeming the code", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("invalid user")))
+			Expect(codeRedeemer.RedeemCodeCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code is invalid", func() {
+		BeforeEach(func() {
+			codeRedeemer.RedeemCodeReturns(authorization.Info{}, errors.New("invalid-code"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("invalid-code"))
+		})
+	})
+
+	When("getting the process fails", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{}, errors.New("get-process"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("get-process")))
+		})
+	})
+
+	When("ssh is disabled for the space", func() {
+		BeforeEach(func() {
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "space-guid"}, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("ssh is disabled for the space"))
+			Expect(podRepo.GetRunningPodCallCount()).To(BeZero())
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("ssh is disabled for the app"))
+			Expect(podRepo.GetRunningPodCallCount()).To(BeZero())
+		})
+	})
+
+	When("the user cannot exec into pods", func() {
+		BeforeEach(func() {
+			podExecPermissions.CanExecReturns(false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("not allowed")))
+			Expect(podRepo.GetRunningPodCallCount()).To(BeZero())
+		})
+	})
+
+	When("checking the exec permission fails", func() {
+		BeforeEach(func() {
+			podExecPermissions.CanExecReturns(false, errors.New("can-exec"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError("can-exec"))
+		})
+	})
+
+	When("the instance has no running pod", func() {
+		BeforeEach(func() {
+			podRepo.GetRunningPodReturns(repositories.PodRecord{}, errors.New("no-pod"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("no-pod")))
+		})
+	})
+})
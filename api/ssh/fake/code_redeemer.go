@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/ssh"
+)
+
+type CodeRedeemer struct {
+	RedeemCodeStub        func(context.Context, string) (authorization.Info, error)
+	redeemCodeMutex       sync.RWMutex
+	redeemCodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	redeemCodeReturns struct {
+		result1 authorization.Info
+		result2 error
+	}
+	redeemCodeReturnsOnCall map[int]struct {
+		result1 authorization.Info
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CodeRedeemer) RedeemCode(arg1 context.Context, arg2 string) (authorization.Info, error) {
+	fake.redeemCodeMutex.Lock()
+	ret, specificReturn := fake.redeemCodeReturnsOnCall[len(fake.redeemCodeArgsForCall)]
+	fake.redeemCodeArgsForCall = append(fake.redeemCodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RedeemCodeStub
+	fakeReturns := fake.redeemCodeReturns
+	fake.recordInvocation("RedeemCode", []interface{}{arg1, arg2})
+	fake.redeemCodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CodeRedeemer) RedeemCodeCallCount() int {
+	fake.redeemCodeMutex.RLock()
+	defer fake.redeemCodeMutex.RUnlock()
+	return len(fake.redeemCodeArgsForCall)
+}
+
+func (fake *CodeRedeemer) RedeemCodeCalls(stub func(context.Context, string) (authorization.Info, error)) {
+	fake.redeemCodeMutex.Lock()
+	defer fake.redeemCodeMutex.Unlock()
+	fake.RedeemCodeStub = stub
+}
+
+func (fake *CodeRedeemer) RedeemCodeArgsForCall(i int) (context.Context, string) {
+	fake.redeemCodeMutex.RLock()
+	defer fake.redeemCodeMutex.RUnlock()
+	argsForCall := fake.redeemCodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CodeRedeemer) RedeemCodeReturns(result1 authorization.Info, result2 error) {
+	fake.redeemCodeMutex.Lock()
+	defer fake.redeemCodeMutex.Unlock()
+	fake.RedeemCodeStub = nil
+	fake.redeemCodeReturns = struct {
+		result1 authorization.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CodeRedeemer) RedeemCodeReturnsOnCall(i int, result1 authorization.Info, result2 error) {
+	fake.redeemCodeMutex.Lock()
+	defer fake.redeemCodeMutex.Unlock()
+	fake.RedeemCodeStub = nil
+	if fake.redeemCodeReturnsOnCall == nil {
+		fake.redeemCodeReturnsOnCall = make(map[int]struct {
+			result1 authorization.Info
+			result2 error
+		})
+	}
+	fake.redeemCodeReturnsOnCall[i] = struct {
+		result1 authorization.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CodeRedeemer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.redeemCodeMutex.RLock()
+	defer fake.redeemCodeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CodeRedeemer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ssh.CodeRedeemer = new(CodeRedeemer)
@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/ssh"
+)
+
+type PodExecPermissions struct {
+	CanExecStub        func(context.Context, authorization.Info, string) (bool, error)
+	canExecMutex       sync.RWMutex
+	canExecArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	canExecReturns struct {
+		result1 bool
+		result2 error
+	}
+	canExecReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PodExecPermissions) CanExec(arg1 context.Context, arg2 authorization.Info, arg3 string) (bool, error) {
+	fake.canExecMutex.Lock()
+	ret, specificReturn := fake.canExecReturnsOnCall[len(fake.canExecArgsForCall)]
+	fake.canExecArgsForCall = append(fake.canExecArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CanExecStub
+	fakeReturns := fake.canExecReturns
+	fake.recordInvocation("CanExec", []interface{}{arg1, arg2, arg3})
+	fake.canExecMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodExecPermissions) CanExecCallCount() int {
+	fake.canExecMutex.RLock()
+	defer fake.canExecMutex.RUnlock()
+	return len(fake.canExecArgsForCall)
+}
+
+func (fake *PodExecPermissions) CanExecCalls(stub func(context.Context, authorization.Info, string) (bool, error)) {
+	fake.canExecMutex.Lock()
+	defer fake.canExecMutex.Unlock()
+	fake.CanExecStub = stub
+}
+
+func (fake *PodExecPermissions) CanExecArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.canExecMutex.RLock()
+	defer fake.canExecMutex.RUnlock()
+	argsForCall := fake.canExecArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PodExecPermissions) CanExecReturns(result1 bool, result2 error) {
+	fake.canExecMutex.Lock()
+	defer fake.canExecMutex.Unlock()
+	fake.CanExecStub = nil
+	fake.canExecReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodExecPermissions) CanExecReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.canExecMutex.Lock()
+	defer fake.canExecMutex.Unlock()
+	fake.CanExecStub = nil
+	if fake.canExecReturnsOnCall == nil {
+		fake.canExecReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.canExecReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodExecPermissions) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.canExecMutex.RLock()
+	defer fake.canExecMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PodExecPermissions) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ssh.PodExecPermissions = new(PodExecPermissions)
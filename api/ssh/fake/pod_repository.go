@@ -0,0 +1,127 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/ssh"
+)
+
+type PodRepository struct {
+	GetRunningPodStub        func(context.Context, authorization.Info, string, repositories.ProcessRecord, string) (repositories.PodRecord, error)
+	getRunningPodMutex       sync.RWMutex
+	getRunningPodArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 repositories.ProcessRecord
+		arg5 string
+	}
+	getRunningPodReturns struct {
+		result1 repositories.PodRecord
+		result2 error
+	}
+	getRunningPodReturnsOnCall map[int]struct {
+		result1 repositories.PodRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PodRepository) GetRunningPod(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 repositories.ProcessRecord, arg5 string) (repositories.PodRecord, error) {
+	fake.getRunningPodMutex.Lock()
+	ret, specificReturn := fake.getRunningPodReturnsOnCall[len(fake.getRunningPodArgsForCall)]
+	fake.getRunningPodArgsForCall = append(fake.getRunningPodArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 repositories.ProcessRecord
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetRunningPodStub
+	fakeReturns := fake.getRunningPodReturns
+	fake.recordInvocation("GetRunningPod", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getRunningPodMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodRepository) GetRunningPodCallCount() int {
+	fake.getRunningPodMutex.RLock()
+	defer fake.getRunningPodMutex.RUnlock()
+	return len(fake.getRunningPodArgsForCall)
+}
+
+func (fake *PodRepository) GetRunningPodCalls(stub func(context.Context, authorization.Info, string, repositories.ProcessRecord, string) (repositories.PodRecord, error)) {
+	fake.getRunningPodMutex.Lock()
+	defer fake.getRunningPodMutex.Unlock()
+	fake.GetRunningPodStub = stub
+}
+
+func (fake *PodRepository) GetRunningPodArgsForCall(i int) (context.Context, authorization.Info, string, repositories.ProcessRecord, string) {
+	fake.getRunningPodMutex.RLock()
+	defer fake.getRunningPodMutex.RUnlock()
+	argsForCall := fake.getRunningPodArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *PodRepository) GetRunningPodReturns(result1 repositories.PodRecord, result2 error) {
+	fake.getRunningPodMutex.Lock()
+	defer fake.getRunningPodMutex.Unlock()
+	fake.GetRunningPodStub = nil
+	fake.getRunningPodReturns = struct {
+		result1 repositories.PodRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) GetRunningPodReturnsOnCall(i int, result1 repositories.PodRecord, result2 error) {
+	fake.getRunningPodMutex.Lock()
+	defer fake.getRunningPodMutex.Unlock()
+	fake.GetRunningPodStub = nil
+	if fake.getRunningPodReturnsOnCall == nil {
+		fake.getRunningPodReturnsOnCall = make(map[int]struct {
+			result1 repositories.PodRecord
+			result2 error
+		})
+	}
+	fake.getRunningPodReturnsOnCall[i] = struct {
+		result1 repositories.PodRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRunningPodMutex.RLock()
+	defer fake.getRunningPodMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PodRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ssh.PodRepository = new(PodRepository)
@@ -0,0 +1,202 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/ssh"
+)
+
+type PodStreamer struct {
+	ExecStub        func(context.Context, ssh.Instance, ssh.ExecRequest) (int, error)
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 context.Context
+		arg2 ssh.Instance
+		arg3 ssh.ExecRequest
+	}
+	execReturns struct {
+		result1 int
+		result2 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	PortForwardStub        func(context.Context, ssh.Instance, uint16, io.ReadWriter) error
+	portForwardMutex       sync.RWMutex
+	portForwardArgsForCall []struct {
+		arg1 context.Context
+		arg2 ssh.Instance
+		arg3 uint16
+		arg4 io.ReadWriter
+	}
+	portForwardReturns struct {
+		result1 error
+	}
+	portForwardReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PodStreamer) Exec(arg1 context.Context, arg2 ssh.Instance, arg3 ssh.ExecRequest) (int, error) {
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 context.Context
+		arg2 ssh.Instance
+		arg3 ssh.ExecRequest
+	}{arg1, arg2, arg3})
+	stub := fake.ExecStub
+	fakeReturns := fake.execReturns
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3})
+	fake.execMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodStreamer) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *PodStreamer) ExecCalls(stub func(context.Context, ssh.Instance, ssh.ExecRequest) (int, error)) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *PodStreamer) ExecArgsForCall(i int) (context.Context, ssh.Instance, ssh.ExecRequest) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PodStreamer) ExecReturns(result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodStreamer) ExecReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodStreamer) PortForward(arg1 context.Context, arg2 ssh.Instance, arg3 uint16, arg4 io.ReadWriter) error {
+	fake.portForwardMutex.Lock()
+	ret, specificReturn := fake.portForwardReturnsOnCall[len(fake.portForwardArgsForCall)]
+	fake.portForwardArgsForCall = append(fake.portForwardArgsForCall, struct {
+		arg1 context.Context
+		arg2 ssh.Instance
+		arg3 uint16
+		arg4 io.ReadWriter
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PortForwardStub
+	fakeReturns := fake.portForwardReturns
+	fake.recordInvocation("PortForward", []interface{}{arg1, arg2, arg3, arg4})
+	fake.portForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PodStreamer) PortForwardCallCount() int {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	return len(fake.portForwardArgsForCall)
+}
+
+func (fake *PodStreamer) PortForwardCalls(stub func(context.Context, ssh.Instance, uint16, io.ReadWriter) error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = stub
+}
+
+func (fake *PodStreamer) PortForwardArgsForCall(i int) (context.Context, ssh.Instance, uint16, io.ReadWriter) {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	argsForCall := fake.portForwardArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PodStreamer) PortForwardReturns(result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	fake.portForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PodStreamer) PortForwardReturnsOnCall(i int, result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	if fake.portForwardReturnsOnCall == nil {
+		fake.portForwardReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.portForwardReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PodStreamer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PodStreamer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ssh.PodStreamer = new(PodStreamer)
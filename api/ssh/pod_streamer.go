@@ -0,0 +1,296 @@
+package ssh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"golang.org/x/net/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	ApplicationContainerName = "application"
+
+	// The channel protocols of the kubernetes streaming API, see
+	// https://github.com/kubernetes/enhancements/tree/master/keps/sig-api-machinery/4006-transition-spdy-to-websockets
+	channelProtocolV5 = "v5.channel.k8s.io"
+	channelProtocolV4 = "v4.channel.k8s.io"
+
+	stdinChannel  byte = 0
+	stdoutChannel byte = 1
+	stderrChannel byte = 2
+	errorChannel  byte = 3
+	resizeChannel byte = 4
+	closeChannel  byte = 255
+
+	portForwardDataChannel  byte = 0
+	portForwardErrorChannel byte = 1
+
+	streamBufferSize = 32 * 1024
+)
+
+// TerminalSize matches the resize messages of the kubernetes exec protocol
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+type ExecRequest struct {
+	Command []string
+	TTY     bool
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+	Resize  <-chan TerminalSize
+}
+
+// WebsocketPodStreamer runs commands in and forwards ports to pods with the
+// identity of the SSH user, using the websocket flavour of the kubernetes
+// streaming API
+type WebsocketPodStreamer struct {
+	restConfigFactory authorization.UserRestConfigFactory
+}
+
+func NewWebsocketPodStreamer(restConfigFactory authorization.UserRestConfigFactory) *WebsocketPodStreamer {
+	return &WebsocketPodStreamer{
+		restConfigFactory: restConfigFactory,
+	}
+}
+
+// Exec runs the command in the application container of the instance and
+// returns its exit code
+func (s *WebsocketPodStreamer) Exec(ctx context.Context, instance Instance, req ExecRequest) (int, error) {
+	query := url.Values{}
+	query.Set("container", ApplicationContainerName)
+	for _, arg := range req.Command {
+		query.Add("command", arg)
+	}
+	query.Set("stdin", "true")
+	query.Set("stdout", "true")
+	query.Set("stderr", strconv.FormatBool(!req.TTY))
+	query.Set("tty", strconv.FormatBool(req.TTY))
+
+	ws, err := s.dial(ctx, instance, "exec", query, channelProtocolV5, channelProtocolV4)
+	if err != nil {
+		return 0, err
+	}
+	defer ws.Close()
+
+	conn := newChannelConn(ws)
+	canCloseStdin := ws.Config().Protocol[0] == channelProtocolV5
+
+	go func() {
+		_ = conn.copyFrom(stdinChannel, req.Stdin)
+		if canCloseStdin {
+			_ = conn.writeRaw([]byte{closeChannel, stdinChannel})
+		}
+	}()
+
+	go func() {
+		for size := range req.Resize {
+			data, err := json.Marshal(size)
+			if err != nil {
+				continue
+			}
+			if err = conn.write(resizeChannel, data); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		channel, data, err := conn.read()
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch channel {
+		case stdoutChannel:
+			_, err = req.Stdout.Write(data)
+		case stderrChannel:
+			_, err = req.Stderr.Write(data)
+		case errorChannel:
+			return exitCodeFromStatus(data)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// PortForward proxies the connection to the port of the instance
+func (s *WebsocketPodStreamer) PortForward(ctx context.Context, instance Instance, port uint16, rw io.ReadWriter) error {
+	query := url.Values{}
+	query.Set("ports", strconv.Itoa(int(port)))
+
+	ws, err := s.dial(ctx, instance, "portforward", query, channelProtocolV4)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	conn := newChannelConn(ws)
+
+	go func() {
+		_ = conn.copyFrom(portForwardDataChannel, rw)
+		ws.Close()
+	}()
+
+	// The first message on each channel carries the forwarded port
+	portPrefixPending := map[byte]bool{portForwardDataChannel: true, portForwardErrorChannel: true}
+	for {
+		channel, data, err := conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if portPrefixPending[channel] {
+			portPrefixPending[channel] = false
+			if len(data) < 2 {
+				return fmt.Errorf("unexpected port forward message on channel %d", channel)
+			}
+			data = data[2:]
+		}
+
+		switch channel {
+		case portForwardDataChannel:
+			if _, err = rw.Write(data); err != nil {
+				return err
+			}
+		case portForwardErrorChannel:
+			if len(data) > 0 {
+				return fmt.Errorf("port forward failed: %s", data)
+			}
+		}
+	}
+}
+
+func (s *WebsocketPodStreamer) dial(ctx context.Context, instance Instance, subresource string, query url.Values, protocols ...string) (*websocket.Conn, error) {
+	restConfig, err := s.restConfigFactory.BuildRestConfig(instance.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, _, err := rest.DefaultServerUrlFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubernetes api url: %w", err)
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the kubernetes api tls config: %w", err)
+	}
+
+	location := *serverURL
+	location.Path = path.Join(location.Path, "api", "v1", "namespaces", instance.Namespace, "pods", instance.PodName, subresource)
+	location.RawQuery = query.Encode()
+	origin := location
+
+	location.Scheme = "wss"
+	if serverURL.Scheme == "http" {
+		location.Scheme = "ws"
+	}
+
+	wsConfig, err := websocket.NewConfig(location.String(), origin.String())
+	if err != nil {
+		return nil, err
+	}
+	wsConfig.TlsConfig = tlsConfig
+	wsConfig.Protocol = protocols
+	if restConfig.BearerToken != "" {
+		wsConfig.Header.Set("Authorization", "Bearer "+restConfig.BearerToken)
+	}
+
+	ws, err := wsConfig.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s stream to pod %s/%s: %w", subresource, instance.Namespace, instance.PodName, err)
+	}
+
+	return ws, nil
+}
+
+func exitCodeFromStatus(data []byte) (int, error) {
+	status := metav1.Status{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return 0, fmt.Errorf("failed to decode exec status: %w", err)
+	}
+
+	if status.Status == metav1.StatusSuccess {
+		return 0, nil
+	}
+
+	if status.Reason == "NonZeroExitCode" && status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			if cause.Type == "ExitCode" {
+				return strconv.Atoi(cause.Message)
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("exec failed: %s", status.Message)
+}
+
+// channelConn multiplexes streams over a websocket by prefixing each message
+// with the number of its channel
+type channelConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+}
+
+func newChannelConn(ws *websocket.Conn) *channelConn {
+	return &channelConn{ws: ws}
+}
+
+func (c *channelConn) read() (byte, []byte, error) {
+	for {
+		var message []byte
+		if err := websocket.Message.Receive(c.ws, &message); err != nil {
+			return 0, nil, err
+		}
+
+		if len(message) > 0 {
+			return message[0], message[1:], nil
+		}
+	}
+}
+
+func (c *channelConn) write(channel byte, data []byte) error {
+	return c.writeRaw(append([]byte{channel}, data...))
+}
+
+func (c *channelConn) writeRaw(message []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return websocket.Message.Send(c.ws, message)
+}
+
+func (c *channelConn) copyFrom(channel byte, r io.Reader) error {
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if writeErr := c.write(channel, buf[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
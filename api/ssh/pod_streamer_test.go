@@ -0,0 +1,184 @@
+package ssh_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/ssh"
+	"golang.org/x/net/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebsocketPodStreamer", func() {
+	var (
+		apiServer    *httptest.Server
+		handler      func(*websocket.Conn)
+		protocol     string
+		requestPath  string
+		requestQuery url.Values
+		authHeader   string
+		streamer     *ssh.WebsocketPodStreamer
+		instance     ssh.Instance
+	)
+
+	BeforeEach(func() {
+		protocol = "v5.channel.k8s.io"
+
+		apiServer = httptest.NewServer(websocket.Server{
+			Handshake: func(config *websocket.Config, req *http.Request) error {
+				requestPath = req.URL.Path
+				requestQuery = req.URL.Query()
+				authHeader = req.Header.Get("Authorization")
+				config.Protocol = []string{protocol}
+				return nil
+			},
+			Handler: func(ws *websocket.Conn) {
+				defer GinkgoRecover()
+				handler(ws)
+			},
+		})
+		DeferCleanup(apiServer.Close)
+
+		streamer = ssh.NewWebsocketPodStreamer(authorization.NewUnprivilegedRestConfigFactory(&rest.Config{Host: apiServer.URL}))
+		instance = ssh.Instance{
+			AuthInfo:  authorization.Info{Token: "the-token"},
+			Namespace: "space-guid",
+			PodName:   "the-pod",
+		}
+	})
+
+	Describe("Exec", func() {
+		var (
+			stdin    string
+			stdout   *bytes.Buffer
+			exitCode int
+			execErr  error
+		)
+
+		BeforeEach(func() {
+			stdin = "the-input"
+			stdout = new(bytes.Buffer)
+
+			handler = func(ws *websocket.Conn) {
+				Expect(websocket.Message.Send(ws, append([]byte{1}, "the-output"...))).To(Succeed())
+
+				var message []byte
+				Expect(websocket.Message.Receive(ws, &message)).To(Succeed())
+				Expect(message).To(Equal(append([]byte{0}, "the-input"...)))
+
+				if protocol == "v5.channel.k8s.io" {
+					Expect(websocket.Message.Receive(ws, &message)).To(Succeed())
+					Expect(message).To(Equal([]byte{255, 0}))
+				}
+
+				status, err := json.Marshal(metav1.Status{
+					Status: metav1.StatusFailure,
+					Reason: "NonZeroExitCode",
+					Details: &metav1.StatusDetails{
+						Causes: []metav1.StatusCause{{Type: "ExitCode", Message: "7"}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(websocket.Message.Send(ws, append([]byte{3}, status...))).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			exitCode, execErr = streamer.Exec(ctx, instance, ssh.ExecRequest{
+				Command: []string{"/bin/sh", "-c", "cat"},
+				Stdin:   strings.NewReader(stdin),
+				Stdout:  stdout,
+				Stderr:  new(bytes.Buffer),
+			})
+		})
+
+		It("execs the command in the application container of the pod as the user", func() {
+			Expect(execErr).NotTo(HaveOccurred())
+			Expect(requestPath).To(Equal("/api/v1/namespaces/space-guid/pods/the-pod/exec"))
+			Expect(requestQuery["command"]).To(Equal([]string{"/bin/sh", "-c", "cat"}))
+			Expect(requestQuery.Get("container")).To(Equal("application"))
+			Expect(requestQuery.Get("tty")).To(Equal("false"))
+			Expect(authHeader).To(Equal("Bearer the-token"))
+		})
+
+		It("streams the output and returns the exit code", func() {
+			Expect(execErr).NotTo(HaveOccurred())
+			Expect(stdout.String()).To(Equal("the-output"))
+			Expect(exitCode).To(Equal(7))
+		})
+
+		When("the api server only supports the v4 protocol", func() {
+			BeforeEach(func() {
+				protocol = "v4.channel.k8s.io"
+			})
+
+			It("does not close stdin explicitly", func() {
+				Expect(execErr).NotTo(HaveOccurred())
+				Expect(exitCode).To(Equal(7))
+			})
+		})
+	})
+
+	Describe("PortForward", func() {
+		var (
+			received   *bytes.Buffer
+			forwardErr error
+		)
+
+		BeforeEach(func() {
+			protocol = "v4.channel.k8s.io"
+			received = new(bytes.Buffer)
+			handler = func(ws *websocket.Conn) {
+				Expect(websocket.Message.Send(ws, []byte{0, 0x90, 0x1f})).To(Succeed())
+				Expect(websocket.Message.Send(ws, []byte{1, 0x90, 0x1f})).To(Succeed())
+				Expect(websocket.Message.Send(ws, append([]byte{0}, "the-response"...))).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			// The client stays connected until the pod closes the stream
+			clientReader, clientWriter := io.Pipe()
+			defer clientWriter.Close()
+
+			forwardErr = streamer.PortForward(ctx, instance, 8080, struct {
+				io.Reader
+				io.Writer
+			}{clientReader, received})
+		})
+
+		It("forwards the port of the pod as the user", func() {
+			Expect(forwardErr).NotTo(HaveOccurred())
+			Expect(requestPath).To(Equal("/api/v1/namespaces/space-guid/pods/the-pod/portforward"))
+			Expect(requestQuery.Get("ports")).To(Equal("8080"))
+			Expect(authHeader).To(Equal("Bearer the-token"))
+		})
+
+		It("strips the port prefixes from the stream", func() {
+			Expect(forwardErr).NotTo(HaveOccurred())
+			Expect(received.String()).To(Equal("the-response"))
+		})
+
+		When("the api server reports an error", func() {
+			BeforeEach(func() {
+				handler = func(ws *websocket.Conn) {
+					Expect(websocket.Message.Send(ws, []byte{0, 0x90, 0x1f})).To(Succeed())
+					Expect(websocket.Message.Send(ws, append([]byte{1, 0x90, 0x1f}, "connection refused"...))).To(Succeed())
+				}
+			})
+
+			It("returns the error", func() {
+				Expect(forwardErr).To(MatchError(ContainSubstring("connection refused")))
+			})
+		})
+	})
+})
@@ -0,0 +1,286 @@
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+// How long resolving and authorizing the instance may take during the handshake
+const authenticationTimeout = 30 * time.Second
+
+// The shell started for interactive sessions, preferring bash when the image has it
+var shellCommand = []string{"/bin/sh", "-c", "if [ -x /bin/bash ]; then exec /bin/bash -l; else exec /bin/sh -l; fi"}
+
+//counterfeiter:generate -o fake -fake-name Authenticator . Authenticator
+type Authenticator interface {
+	Authenticate(context.Context, string, string) (Instance, error)
+}
+
+//counterfeiter:generate -o fake -fake-name PodStreamer . PodStreamer
+type PodStreamer interface {
+	Exec(context.Context, Instance, ExecRequest) (int, error)
+	PortForward(context.Context, Instance, uint16, io.ReadWriter) error
+}
+
+// Server is an SSH server proxying sessions and port forwards to app instances
+type Server struct {
+	config        *ssh.ServerConfig
+	authenticator Authenticator
+	podStreamer   PodStreamer
+	logger        logr.Logger
+
+	// instances authenticated during the handshake, keyed by SSH session id
+	instances sync.Map
+}
+
+func NewServer(hostKey ssh.Signer, authenticator Authenticator, podStreamer PodStreamer, logger logr.Logger) *Server {
+	server := &Server{
+		authenticator: authenticator,
+		podStreamer:   podStreamer,
+		logger:        logger,
+	}
+
+	server.config = &ssh.ServerConfig{
+		PasswordCallback: server.authenticate,
+	}
+	server.config.AddHostKey(hostKey)
+
+	return server
+}
+
+// HostKeyFingerprint returns the fingerprint the cf cli uses to verify the host key
+func HostKeyFingerprint(hostKey ssh.PublicKey) string {
+	sum := sha256.Sum256(hostKey.Marshal())
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) authenticate(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), authenticationTimeout)
+	defer cancel()
+
+	instance, err := s.authenticator.Authenticate(ctx, conn.User(), string(password))
+	if err != nil {
+		s.logger.Info("ssh authentication failed", "user", conn.User(), "remoteAddr", conn.RemoteAddr().String(), "reason", err)
+		return nil, errors.New("authentication failed")
+	}
+
+	s.instances.Store(string(conn.SessionID()), instance)
+	return &ssh.Permissions{}, nil
+}
+
+func (s *Server) handleConn(netConn net.Conn) {
+	defer netConn.Close()
+
+	sshConn, channels, requests, err := ssh.NewServerConn(netConn, s.config)
+	if err != nil {
+		s.logger.V(1).Info("ssh handshake failed", "remoteAddr", netConn.RemoteAddr().String(), "reason", err)
+		return
+	}
+	defer sshConn.Close()
+
+	value, ok := s.instances.LoadAndDelete(string(sshConn.SessionID()))
+	if !ok {
+		return
+	}
+	instance := value.(Instance)
+
+	logger := s.logger.WithValues("namespace", instance.Namespace, "pod", instance.PodName)
+	logger.Info("ssh connection established", "remoteAddr", sshConn.RemoteAddr().String())
+
+	ctx, cancel := context.WithCancel(logr.NewContext(context.Background(), logger))
+	defer cancel()
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go s.handleSession(ctx, instance, newChannel)
+		case "direct-tcpip":
+			go s.handleDirectTCPIP(ctx, instance, newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, fmt.Sprintf("unsupported channel type %q", newChannel.ChannelType()))
+		}
+	}
+}
+
+type ptyRequest struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	Width    uint32
+	Height   uint32
+	Modelist string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatusRequest struct {
+	Status uint32
+}
+
+type directTCPIPRequest struct {
+	HostToConnect  string
+	PortToConnect  uint32
+	OriginatorIP   string
+	OriginatorPort uint32
+}
+
+func (s *Server) handleSession(ctx context.Context, instance Instance, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept session channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+
+	resize := make(chan TerminalSize, 1)
+	defer close(resize)
+
+	tty := false
+	started := false
+
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			payload := ptyRequest{}
+			if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			tty = true
+			sendResize(resize, payload.Columns, payload.Rows)
+			_ = req.Reply(true, nil)
+
+		case "window-change":
+			payload := windowChangeRequest{}
+			if err = ssh.Unmarshal(req.Payload, &payload); err == nil {
+				sendResize(resize, payload.Columns, payload.Rows)
+			}
+			_ = req.Reply(err == nil, nil)
+
+		case "env":
+			// The app environment is defined by the app, not by the SSH client
+			_ = req.Reply(true, nil)
+
+		case "shell", "exec":
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			command := shellCommand
+			if req.Type == "exec" {
+				payload := execRequest{}
+				if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				command = []string{"/bin/sh", "-c", payload.Command}
+			}
+
+			started = true
+			_ = req.Reply(true, nil)
+
+			go s.exec(ctx, instance, channel, ExecRequest{
+				Command: command,
+				TTY:     tty,
+				Stdin:   channel,
+				Stdout:  channel,
+				Stderr:  channel.Stderr(),
+				Resize:  resize,
+			})
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *Server) exec(ctx context.Context, instance Instance, channel ssh.Channel, req ExecRequest) {
+	logger := logr.FromContextOrDiscard(ctx)
+	defer channel.Close()
+
+	exitCode, err := s.podStreamer.Exec(ctx, instance, req)
+	if err != nil {
+		logger.Info("exec failed", "reason", err)
+		_, _ = fmt.Fprintf(channel.Stderr(), "%v\r\n", err)
+		exitCode = 255
+	}
+
+	_, err = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatusRequest{Status: uint32(exitCode)}))
+	if err != nil {
+		logger.V(1).Info("failed to send exit status", "reason", err)
+	}
+}
+
+func (s *Server) handleDirectTCPIP(ctx context.Context, instance Instance, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	payload := directTCPIPRequest{}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil || payload.PortToConnect > 65535 {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid port forward request")
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept direct-tcpip channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	// Forwarded connections always target the app instance, whatever host the client asked for
+	err = s.podStreamer.PortForward(ctx, instance, uint16(payload.PortToConnect), channel)
+	if err != nil {
+		logger.Info("port forward failed", "port", payload.PortToConnect, "reason", err)
+	}
+}
+
+func sendResize(resize chan TerminalSize, columns, rows uint32) {
+	size := TerminalSize{Width: uint16(columns), Height: uint16(rows)}
+
+	// Only the latest size matters, drop any pending one
+	select {
+	case <-resize:
+	default:
+	}
+
+	select {
+	case resize <- size:
+	default:
+	}
+}
@@ -0,0 +1,207 @@
+package ssh_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/ssh"
+	"code.cloudfoundry.org/korifi/api/ssh/fake"
+	"github.com/go-logr/logr"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		authenticator *fake.Authenticator
+		podStreamer   *fake.PodStreamer
+		hostKey       cryptossh.Signer
+		listener      net.Listener
+		instance      ssh.Instance
+		clientConfig  *cryptossh.ClientConfig
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = cryptossh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		instance = ssh.Instance{
+			AuthInfo:  authorization.Info{Token: "the-token"},
+			Namespace: "space-guid",
+			PodName:   "the-pod",
+		}
+
+		authenticator = new(fake.Authenticator)
+		authenticator.AuthenticateReturns(instance, nil)
+
+		podStreamer = new(fake.PodStreamer)
+		podStreamer.ExecStub = func(_ context.Context, _ ssh.Instance, req ssh.ExecRequest) (int, error) {
+			_, err := fmt.Fprintf(req.Stdout, "ran %q", req.Command[len(req.Command)-1])
+			Expect(err).NotTo(HaveOccurred())
+			return 3, nil
+		}
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		server := ssh.NewServer(hostKey, authenticator, podStreamer, logr.Discard())
+		go func() {
+			defer GinkgoRecover()
+			_ = server.Serve(listener)
+		}()
+
+		clientConfig = &cryptossh.ClientConfig{
+			User:            "cf:process-guid/0",
+			Auth:            []cryptossh.AuthMethod{cryptossh.Password("the-code")},
+			HostKeyCallback: cryptossh.FixedHostKey(hostKey.PublicKey()),
+		}
+	})
+
+	dial := func() (*cryptossh.Client, error) {
+		return cryptossh.Dial("tcp", listener.Addr().String(), clientConfig)
+	}
+
+	It("authenticates the user with the passcode", func() {
+		client, err := dial()
+		Expect(err).NotTo(HaveOccurred())
+		defer client.Close()
+
+		Expect(authenticator.AuthenticateCallCount()).To(Equal(1))
+		actualCtx, actualUser, actualCode := authenticator.AuthenticateArgsForCall(0)
+		Expect(actualUser).To(Equal("cf:process-guid/0"))
+		Expect(actualCode).To(Equal("the-code"))
+		_, hasDeadline := actualCtx.Deadline()
+		Expect(hasDeadline).To(BeTrue())
+	})
+
+	When("authentication fails", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(ssh.Instance{}, errors.New("nope"))
+		})
+
+		It("rejects the connection", func() {
+			_, err := dial()
+			Expect(err).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	Describe("exec", func() {
+		It("runs the command in the instance and returns its exit status", func() {
+			client, err := dial()
+			Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+
+			session, err := client.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+			defer session.Close()
+
+			output, err := session.Output("echo hello")
+			Expect(string(output)).To(Equal(`ran "echo hello"`))
+
+			var exitErr *cryptossh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(3))
+
+			Expect(podStreamer.ExecCallCount()).To(Equal(1))
+			_, actualInstance, actualReq := podStreamer.ExecArgsForCall(0)
+			Expect(actualInstance).To(Equal(instance))
+			Expect(actualReq.Command).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+			Expect(actualReq.TTY).To(BeFalse())
+		})
+
+		When("the session requests a pty and a shell", func() {
+			It("starts an interactive shell with a tty", func() {
+				client, err := dial()
+				Expect(err).NotTo(HaveOccurred())
+				defer client.Close()
+
+				session, err := client.NewSession()
+				Expect(err).NotTo(HaveOccurred())
+				defer session.Close()
+
+				Expect(session.RequestPty("xterm", 40, 80, cryptossh.TerminalModes{})).To(Succeed())
+				Expect(session.Shell()).To(Succeed())
+				Expect(session.Wait()).To(HaveOccurred())
+
+				Expect(podStreamer.ExecCallCount()).To(Equal(1))
+				_, _, actualReq := podStreamer.ExecArgsForCall(0)
+				Expect(actualReq.TTY).To(BeTrue())
+				Expect(actualReq.Command[0]).To(Equal("/bin/sh"))
+				Expect(actualReq.Command[2]).To(ContainSubstring("/bin/bash"))
+			})
+		})
+
+		When("the exec fails", func() {
+			BeforeEach(func() {
+				podStreamer.ExecReturns(0, errors.New("exec-failed"))
+				podStreamer.ExecStub = nil
+			})
+
+			It("reports the error and exits with 255", func() {
+				client, err := dial()
+				Expect(err).NotTo(HaveOccurred())
+				defer client.Close()
+
+				session, err := client.NewSession()
+				Expect(err).NotTo(HaveOccurred())
+				defer session.Close()
+
+				output, err := session.CombinedOutput("ls")
+				Expect(string(output)).To(ContainSubstring("exec-failed"))
+
+				var exitErr *cryptossh.ExitError
+				Expect(errors.As(err, &exitErr)).To(BeTrue())
+				Expect(exitErr.ExitStatus()).To(Equal(255))
+			})
+		})
+	})
+
+	Describe("port forwarding", func() {
+		BeforeEach(func() {
+			podStreamer.PortForwardStub = func(_ context.Context, _ ssh.Instance, _ uint16, rw io.ReadWriter) error {
+				_, err := io.Copy(rw, rw)
+				return err
+			}
+		})
+
+		It("forwards the connection to the port of the instance", func() {
+			client, err := dial()
+			Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+
+			conn, err := client.Dial("tcp", "localhost:8080")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			Expect(err).NotTo(HaveOccurred())
+
+			buf := make([]byte, 4)
+			_, err = io.ReadFull(conn, buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("ping"))
+
+			Expect(podStreamer.PortForwardCallCount()).To(Equal(1))
+			_, actualInstance, actualPort, _ := podStreamer.PortForwardArgsForCall(0)
+			Expect(actualInstance).To(Equal(instance))
+			Expect(actualPort).To(BeEquivalentTo(8080))
+		})
+	})
+
+	Describe("HostKeyFingerprint", func() {
+		It("returns the base64 encoded sha256 of the host key", func() {
+			Expect(ssh.HostKeyFingerprint(hostKey.PublicKey())).To(HaveLen(44))
+		})
+	})
+})
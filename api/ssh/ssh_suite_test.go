@@ -0,0 +1,20 @@
+package ssh_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var ctx context.Context
+
+func TestSSH(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SSH Suite")
+}
+
+var _ = BeforeEach(func() {
+	ctx = context.Background()
+})
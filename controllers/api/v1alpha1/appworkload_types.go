@@ -56,6 +56,19 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// Additional containers running the app image alongside the app process
+	//+kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+}
+
+type AppWorkloadSidecar struct {
+	// The name of the sidecar, unique within the AppWorkload
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -37,6 +37,11 @@ type BuildWorkloadSpec struct {
 	// If no values are specified, then all available buildpacks will be used for auto-detection
 	Buildpacks []string `json:"buildpacks,omitempty"`
 
+	// The stack to build the app image on. If no value is specified, then the
+	// stack of the default builder will be used
+	// +kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
 	// The environment variables to set on the container that builds the image
 	Env []v1.EnvVar `json:"env,omitempty"`
 
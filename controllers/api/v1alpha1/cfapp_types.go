@@ -44,6 +44,45 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef corev1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Toggles for the optional features of the app
+	//+kubebuilder:validation:Optional
+	Features CFAppFeatures `json:"features,omitempty"`
+
+	// Additional processes that run alongside the instances of the app processes they are attached to
+	//+kubebuilder:validation:Optional
+	Sidecars []CFAppSidecar `json:"sidecars,omitempty"`
+}
+
+// CFAppSidecar defines a process that runs in the same pod as the instances of some of the app processes
+type CFAppSidecar struct {
+	// The unique identifier of the sidecar
+	GUID string `json:"guid"`
+
+	// The name of the sidecar. Sidecar names are unique within the app
+	Name string `json:"name"`
+
+	// The command used to start the sidecar
+	Command string `json:"command"`
+
+	// The types of the app processes (e.g. "web") that the sidecar runs alongside
+	//+kubebuilder:validation:MinItems=1
+	ProcessTypes []string `json:"processTypes"`
+
+	// The amount of memory in MiB reserved for the sidecar. The sidecar memory is not limited if unset
+	//+kubebuilder:validation:Optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+// CFAppFeatures defines the optional features of a CFApp
+type CFAppFeatures struct {
+	// Whether users can SSH into the app instances. Defaults to true
+	//+kubebuilder:validation:Optional
+	SSH *bool `json:"ssh,omitempty"`
+
+	// Whether a CFAppRevision is recorded every time the app starts with a changed droplet, environment or process commands. Defaults to true
+	//+kubebuilder:validation:Optional
+	Revisions *bool `json:"revisions,omitempty"`
 }
 
 // AppState defines the desired state of CFApp.
@@ -76,6 +115,10 @@ type CFAppStatus struct {
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	//+kubebuilder:validation:Optional
 	ServiceBindings []ServiceBinding `json:"serviceBindings,omitempty"`
+
+	// The name of the CFAppRevision the app instances are currently running. Empty when the app is stopped or revisions are disabled
+	//+kubebuilder:validation:Optional
+	DeployedRevisionName string `json:"deployedRevisionName,omitempty"`
 }
 
 //+kubebuilder:object:root=true
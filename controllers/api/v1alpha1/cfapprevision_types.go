@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFAppRevisionVersionLabelKey = "korifi.cloudfoundry.org/revision-version"
+	CFAppRollbackVersionKey      = "korifi.cloudfoundry.org/rollback-revision-version"
+)
+
+// CFAppRevisionSpec defines the desired state of CFAppRevision
+type CFAppRevisionSpec struct {
+	// A reference to the CFApp that this revision belongs to. The CFApp must be in the same namespace.
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The version of the revision. Versions are sequential per app, starting at 1
+	Version int `json:"version"`
+
+	// A reference to the CFBuild whose droplet was running in this revision. The CFBuild must be in the same namespace.
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of a Secret in the same namespace, which contains a snapshot of the app environment variables at the time of the revision
+	//+kubebuilder:validation:Optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+
+	// The custom start commands of the app processes at the time of the revision
+	//+kubebuilder:validation:Optional
+	Processes []CFAppRevisionProcess `json:"processes,omitempty"`
+
+	// A human readable description of what changed in this revision
+	//+kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+}
+
+type CFAppRevisionProcess struct {
+	// The name of the process within the CFApp (e.g. "web")
+	Type string `json:"type"`
+
+	// The custom start command of the process. Empty if the process runs its detected command
+	//+kubebuilder:validation:Optional
+	Command string `json:"command,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppRevision is the Schema for the cfapprevisions API
+type CFAppRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppRevisionList contains a list of CFAppRevision
+type CFAppRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppRevision{}, &CFAppRevisionList{})
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	CFAuditEventTypeLabelKey       = "korifi.cloudfoundry.org/audit-event-type"
+	CFAuditEventTargetGUIDLabelKey = "korifi.cloudfoundry.org/audit-event-target-guid"
+
+	AuditEventTypeAppCrash = "app.crash"
+
+	AuditEventActorTypeApp  = "app"
+	AuditEventActorTypeUser = "user"
+)
+
+// CFAuditEventSpec defines the desired state of CFAuditEvent
+type CFAuditEventSpec struct {
+	// The type of the event, e.g. "audit.app.start" or "app.crash"
+	Type string `json:"type"`
+
+	// The entity that caused the event
+	Actor CFAuditEventParticipant `json:"actor"`
+
+	// The entity the event is about
+	Target CFAuditEventParticipant `json:"target"`
+
+	// Event type specific details, e.g. the exit status of a crashed instance
+	// +kubebuilder:validation:Optional
+	Data *runtime.RawExtension `json:"data,omitempty"`
+}
+
+type CFAuditEventParticipant struct {
+	// The GUID of the participant
+	GUID string `json:"guid"`
+
+	// The type of the participant, e.g. "user" or "app"
+	Type string `json:"type"`
+
+	// The name of the participant at the time of the event
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// CFAuditEventStatus defines the observed state of CFAuditEvent
+type CFAuditEventStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAuditEvent that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.guid`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEvent is the Schema for the cfauditevents API
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAuditEventSpec   `json:"spec,omitempty"`
+	Status CFAuditEventStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
+
+func (e *CFAuditEvent) StatusConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
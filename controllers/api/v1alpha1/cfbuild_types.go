@@ -23,7 +23,8 @@ import (
 
 // CFBuildSpec defines the desired state of CFBuild
 type CFBuildSpec struct {
-	// The CFPackage associated with this build. Must be in the same namespace
+	// The CFPackage associated with this build. Must be in the same namespace.
+	// Builds without a package are not staged, they use the droplet in the spec instead
 	PackageRef v1.LocalObjectReference `json:"packageRef"`
 	// The CFApp associated with this build. Must be in the same namespace
 	AppRef v1.LocalObjectReference `json:"appRef"`
@@ -35,6 +36,16 @@ type CFBuildSpec struct {
 
 	// Specifies the buildpacks and stack for the build
 	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// The droplet of a build without a package, e.g. an uploaded or a copied
+	// droplet. The build succeeds as soon as the droplet image is set
+	//+kubebuilder:validation:Optional
+	Droplet *BuildDropletStatus `json:"droplet,omitempty"`
+
+	// Cancels staging when set. The build workload is deleted and the build
+	// fails, unless it has already completed
+	//+kubebuilder:validation:Optional
+	Cancelled bool `json:"cancelled,omitempty"`
 }
 
 // CFBuildStatus defines the observed state of CFBuild
@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClearBuildCacheRequestAnnotationKey is set on CFApps together with
+	// ClearBuildCacheLabelKey and holds the name of the latest
+	// CFBuildCacheClear requesting the build cache of the app to be cleared
+	ClearBuildCacheRequestAnnotationKey = "korifi.cloudfoundry.org/clear-build-cache-request"
+
+	BuildCacheClearAppsRequestedConditionType = "AppsRequested"
+)
+
+// CFBuildCacheClearSpec defines the desired state of CFBuildCacheClear
+type CFBuildCacheClearSpec struct {
+	// A reference to the CFApp whose build cache is cleared. The CFApp must be in the same namespace.
+	// When not set, the build caches of all the apps in the namespace are cleared, or of all the apps
+	// in all namespaces if the CFBuildCacheClear is in the root namespace.
+	//+kubebuilder:validation:Optional
+	AppRef *corev1.LocalObjectReference `json:"appRef,omitempty"`
+}
+
+// CFBuildCacheClearStatus defines the observed state of CFBuildCacheClear
+type CFBuildCacheClearStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The apps whose build cache has not been cleared yet
+	//+kubebuilder:validation:Optional
+	PendingApps []CFBuildCacheClearApp `json:"pendingApps,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFBuildCacheClear that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+type CFBuildCacheClearApp struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildCacheClear is the Schema for the cfbuildcacheclears API. Each
+// CFBuildCacheClear is a request to clear build caches, which is complete
+// once it is ready.
+type CFBuildCacheClear struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFBuildCacheClearSpec   `json:"spec,omitempty"`
+	Status CFBuildCacheClearStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildCacheClearList contains a list of CFBuildCacheClear
+type CFBuildCacheClearList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFBuildCacheClear `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFBuildCacheClear{}, &CFBuildCacheClearList{})
+}
+
+func (c *CFBuildCacheClear) StatusConditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFBuildpackSpec defines the desired state of CFBuildpack
+type CFBuildpackSpec struct {
+	// The buildpack name as seen by CF users
+	DisplayName string `json:"displayName"`
+
+	// The 1-based position of the buildpack in the builder order. Builder
+	// buildpacks that are not managed via CFBuildpacks fill the positions
+	// left unused.
+	// +kubebuilder:validation:Minimum=1
+	Position int `json:"position"`
+
+	// Disabled buildpacks are left out of the builder order
+	Enabled bool `json:"enabled"`
+
+	// The bits of locked buildpacks cannot be updated
+	Locked bool `json:"locked"`
+
+	// +kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
+	// The CNB buildpack image. Buildpacks without an image are awaiting
+	// upload and are left out of the builder order.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// The name of the uploaded archive, or the image reference the buildpack
+	// was created from
+	// +kubebuilder:validation:Optional
+	Filename string `json:"filename,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Name",type="string",JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Position",type="integer",JSONPath=`.spec.position`
+//+kubebuilder:printcolumn:name="Enabled",type="boolean",JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildpack is the Schema for the cfbuildpacks API. Buildpacks live in the
+// root namespace and are added to the order of the kpack ClusterBuilder by
+// the kpack-image-builder.
+type CFBuildpack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFBuildpackSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildpackList contains a list of CFBuildpack
+type CFBuildpackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFBuildpack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFBuildpack{}, &CFBuildpackList{})
+}
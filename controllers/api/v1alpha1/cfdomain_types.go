@@ -28,6 +28,12 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// The GUIDs of the CFOrgs a private domain is shared with. Private
+	// domains are the ones that live in an org namespace; that org owns the
+	// domain. Shared domains (living in the root namespace) cannot be shared
+	//+kubebuilder:validation:Optional
+	SharedOrganizations []string `json:"sharedOrganizations,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunningEnvVarGroupName = "running"
+	StagingEnvVarGroupName = "staging"
+)
+
+// CFEnvVarGroupSpec defines the desired state of CFEnvVarGroup
+type CFEnvVarGroupSpec struct {
+	// Environment variables set for all apps on the platform. App
+	// environment variables take precedence over the group ones
+	// +kubebuilder:validation:Optional
+	Env map[string]string `json:"env,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvVarGroup is the Schema for the cfenvvargroups API. The platform has
+// two env var groups in the root namespace: `running`, which is applied to
+// app processes and tasks, and `staging`, which is applied to builds.
+type CFEnvVarGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFEnvVarGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvVarGroupList contains a list of CFEnvVarGroup
+type CFEnvVarGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFEnvVarGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFEnvVarGroup{}, &CFEnvVarGroupList{})
+}
@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	FeatureFlagPrivateDomainCreation          = "private_domain_creation"
+	FeatureFlagAppBitsUpload                  = "app_bits_upload"
+	FeatureFlagAppScaling                     = "app_scaling"
+	FeatureFlagRouteCreation                  = "route_creation"
+	FeatureFlagRouteSharing                   = "route_sharing"
+	FeatureFlagServiceInstanceCreation        = "service_instance_creation"
+	FeatureFlagDiegoDocker                    = "diego_docker"
+	FeatureFlagSetRolesByUsername             = "set_roles_by_username"
+	FeatureFlagTaskCreation                   = "task_creation"
+	FeatureFlagEnvVarVisibility               = "env_var_visibility"
+	FeatureFlagSpaceDeveloperEnvVarVisibility = "space_developer_env_var_visibility"
+)
+
+// DefaultFeatureFlags contains the standard CF feature flags that Korifi
+// enforces and the value they have when no CFFeatureFlag has been created for
+// them. Korifi enables the flags of features it supports out of the box (e.g.
+// `diego_docker` and `route_sharing`) so that setting no flags keeps the
+// existing behaviour. CF flags of features Korifi does not implement (e.g.
+// `user_org_creation` or `service_instance_sharing`) are not listed.
+var DefaultFeatureFlags = map[string]bool{
+	FeatureFlagPrivateDomainCreation:          true,
+	FeatureFlagAppBitsUpload:                  true,
+	FeatureFlagAppScaling:                     true,
+	FeatureFlagRouteCreation:                  true,
+	FeatureFlagRouteSharing:                   true,
+	FeatureFlagServiceInstanceCreation:        true,
+	FeatureFlagDiegoDocker:                    true,
+	FeatureFlagSetRolesByUsername:             true,
+	FeatureFlagTaskCreation:                   true,
+	FeatureFlagEnvVarVisibility:               true,
+	FeatureFlagSpaceDeveloperEnvVarVisibility: true,
+}
+
+// CFFeatureFlagSpec defines the desired state of CFFeatureFlag
+type CFFeatureFlagSpec struct {
+	Enabled bool `json:"enabled"`
+
+	// The message returned to users when they try to use the disabled feature
+	// +kubebuilder:validation:Optional
+	CustomErrorMessage string `json:"customErrorMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Enabled",type="boolean",JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFFeatureFlag is the Schema for the cffeatureflags API. Feature flags live
+// in the root namespace and are named after the flag they override. Flags
+// without a CFFeatureFlag have the value in DefaultFeatureFlags.
+type CFFeatureFlag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFFeatureFlagSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFFeatureFlagList contains a list of CFFeatureFlag
+type CFFeatureFlagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFFeatureFlag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFFeatureFlag{}, &CFFeatureFlagList{})
+}
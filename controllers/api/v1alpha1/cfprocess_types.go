@@ -39,9 +39,13 @@ type CFProcessSpec struct {
 	// The default command for this process as defined by the build. This field is ignored when the Command field is set
 	DetectedCommand string `json:"detectedCommand,omitempty"`
 
-	// Used to build the Liveness and Readiness Probes for the process' AppWorkload.
+	// Used to build the Startup and Liveness Probes for the process' AppWorkload.
 	HealthCheck HealthCheck `json:"healthCheck"`
 
+	// Used to build the Readiness Probe for the process' AppWorkload. Failing readiness checks take instances out of routing without restarting them.
+	// +kubebuilder:validation:Optional
+	ReadinessHealthCheck ReadinessHealthCheck `json:"readinessHealthCheck,omitempty"`
+
 	// The desired number of replicas to deploy
 	DesiredInstances *int32 `json:"desiredInstances,omitempty"`
 
@@ -80,6 +84,26 @@ type HealthCheckData struct {
 	TimeoutSeconds           int32 `json:"timeoutSeconds"`
 }
 
+type ReadinessHealthCheck struct {
+	// The type of Readiness Health Check the App process will use
+	// Valid values are "http", "port", and "process". The default type is "process", which disables the readiness probe.
+	// +kubebuilder:validation:Optional
+	Type HealthCheckType `json:"type,omitempty"`
+
+	// The input parameters for the readiness probe in kubernetes
+	// +kubebuilder:validation:Optional
+	Data ReadinessHealthCheckData `json:"data,omitempty"`
+}
+
+// ReadinessHealthCheckData used to pass through input parameters to readiness probe
+type ReadinessHealthCheckData struct {
+	// The http endpoint to use with "http" readiness checks
+	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
+
+	InvocationTimeoutSeconds int32 `json:"invocationTimeoutSeconds,omitempty"`
+	IntervalSeconds          int32 `json:"intervalSeconds,omitempty"`
+}
+
 // CFProcessStatus defines the observed state of CFProcess
 type CFProcessStatus struct {
 	//+kubebuilder:validation:Optional
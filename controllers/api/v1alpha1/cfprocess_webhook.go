@@ -59,6 +59,7 @@ func (d *CFProcessDefaulter) Default(ctx context.Context, obj runtime.Object) er
 	d.defaultResources(process)
 	d.defaultInstances(process)
 	d.defaultHealthCheck(process)
+	d.defaultReadinessHealthCheck(process)
 
 	return nil
 }
@@ -115,3 +116,9 @@ func (d *CFProcessDefaulter) defaultHealthCheck(process *CFProcess) {
 
 	process.Spec.HealthCheck.Type = "process"
 }
+
+func (d *CFProcessDefaulter) defaultReadinessHealthCheck(process *CFProcess) {
+	if process.Spec.ReadinessHealthCheck.Type == "" {
+		process.Spec.ReadinessHealthCheck.Type = ProcessHealthCheckType
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -27,9 +28,14 @@ import (
 const (
 	// Deprecated. Used for removing leftover finalizers
 	CFRouteFinalizerName = "cfRoute.korifi.cloudfoundry.org"
+	// Ensures that the resources created in shared spaces are cleaned up
+	CFRouteSharedSpacesFinalizerName = "korifi.cloudfoundry.org/cfRouteSharedSpaces"
+	// Ensures that a route being transferred is recreated in its new space
+	CFRouteTransferFinalizerName = "korifi.cloudfoundry.org/cfRouteTransfer"
 
 	DestinationAppGUIDLabelPrefix = "korifi.cloudfoundry.org/destination-app-guid-"
 	CFRouteIsUnmappedLabelKey     = "korifi.cloudfoundry.org/unmapped"
+	CFRouteNamespaceLabelKey      = "korifi.cloudfoundry.org/route-namespace"
 )
 
 // Destination defines a target for a CFRoute, does not carry meaning outside of a CF context
@@ -42,11 +48,17 @@ type Destination struct {
 	//+kubebuilder:validation:Optional
 	Port *int32 `json:"port,omitempty"`
 	// A required reference to the CFApp that will receive traffic. The CFApp must be in the same namespace
+	// as the CFRoute, unless AppNamespace is set
 	AppRef v1.LocalObjectReference `json:"appRef"`
+	// The namespace of the CFApp. AppNamespace is optional and defaults to
+	// the CFRoute namespace. When set, it must be one of the route shared spaces
+	//+kubebuilder:validation:Optional
+	AppNamespace string `json:"appNamespace,omitempty"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is optional, when set must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is optional, when set must be either "http1" or "http2". Traffic to
+	// "http2" destinations is sent as cleartext HTTP/2 (h2c), which supports gRPC
+	// +kubebuilder:validation:Enum=http1;http2
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
 }
@@ -68,6 +80,47 @@ type CFRouteSpec struct {
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
 	Destinations []Destination `json:"destinations,omitempty"`
+	// The GUIDs of the spaces the route is shared with. Apps in shared spaces can be route destinations
+	//+kubebuilder:validation:Optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
+	// Options are optional settings applied to the traffic sent to the route destinations
+	//+kubebuilder:validation:Optional
+	Options *RouteOptions `json:"options,omitempty"`
+	// The GUID of the space the route is being transferred to. When the
+	// route is deleted, it is recreated in that space before it goes away.
+	// Only honoured along with the transfer finalizer
+	//+kubebuilder:validation:Optional
+	TransferTo string `json:"transferTo,omitempty"`
+}
+
+// RouteOptions defines how the traffic is routed to the route destinations
+type RouteOptions struct {
+	// The load balancing algorithm used to distribute requests among the
+	// route destinations. Applied through a gateway implementation specific
+	// backend policy, ignored if none is configured
+	// +kubebuilder:validation:Enum=round-robin;least-connection
+	//+kubebuilder:validation:Optional
+	LoadBalancing string `json:"loadBalancing,omitempty"`
+	// The maximum time the gateway waits for a response to a request, e.g. "5m"
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	//+kubebuilder:validation:Optional
+	Timeout string `json:"timeout,omitempty"`
+	// Modifications applied to the request headers before the request is sent to the destinations
+	//+kubebuilder:validation:Optional
+	RequestHeaders *HeaderModifier `json:"requestHeaders,omitempty"`
+}
+
+// HeaderModifier defines modifications of HTTP headers
+type HeaderModifier struct {
+	// Headers to set, overwriting any existing values
+	//+kubebuilder:validation:Optional
+	Set map[string]string `json:"set,omitempty"`
+	// Headers to add to any existing values
+	//+kubebuilder:validation:Optional
+	Add map[string]string `json:"add,omitempty"`
+	// Names of the headers to remove
+	//+kubebuilder:validation:Optional
+	Remove []string `json:"remove,omitempty"`
 }
 
 // CFRouteStatus defines the observed state of CFRoute
@@ -131,6 +184,45 @@ func (r CFRoute) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("Route already exists with host '%s'%s for domain '%s'.", r.Spec.Host, pathDetails, r.Status.FQDN)
 }
 
+// DestinationNamespace returns the namespace of the CFApp the destination
+// points to
+func (r CFRoute) DestinationNamespace(destination Destination) string {
+	if destination.AppNamespace != "" {
+		return destination.AppNamespace
+	}
+
+	return r.Namespace
+}
+
+// Transferred returns the route as it is recreated in the space it is
+// transferred to. The original space becomes a shared space of the route, so
+// that its destinations are preserved
+func (r CFRoute) Transferred() CFRoute {
+	transferred := CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        r.Name,
+			Namespace:   r.Spec.TransferTo,
+			Labels:      r.Labels,
+			Annotations: r.Annotations,
+		},
+		Spec: *r.Spec.DeepCopy(),
+	}
+	transferred.Spec.TransferTo = ""
+
+	transferred.Spec.SharedSpaces = slices.DeleteFunc(append(transferred.Spec.SharedSpaces, r.Namespace), func(spaceGUID string) bool {
+		return spaceGUID == r.Spec.TransferTo
+	})
+
+	for i, destination := range transferred.Spec.Destinations {
+		transferred.Spec.Destinations[i].AppNamespace = ""
+		if namespace := r.DestinationNamespace(destination); namespace != r.Spec.TransferTo {
+			transferred.Spec.Destinations[i].AppNamespace = namespace
+		}
+	}
+
+	return transferred
+}
+
 func (r *CFRoute) StatusConditions() *[]metav1.Condition {
 	return &r.Status.Conditions
 }
@@ -76,6 +76,10 @@ type CFServiceBindingStatus struct {
 	// +optional
 	EnvSecretRef v1.LocalObjectReference `json:"envSecretRef"`
 
+	// Volumes the service broker requested to be mounted onto the bound app
+	//+kubebuilder:validation:Optional
+	VolumeMounts []ServiceBindingVolumeMount `json:"volumeMounts,omitempty"`
+
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -35,6 +35,17 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// Toggles for the optional features of the space
+	//+kubebuilder:validation:Optional
+	Features CFSpaceFeatures `json:"features,omitempty"`
+}
+
+// CFSpaceFeatures defines the optional features of a CFSpace
+type CFSpaceFeatures struct {
+	// Whether users can SSH into the instances of the apps in the space. Defaults to true
+	//+kubebuilder:validation:Optional
+	SSH *bool `json:"ssh,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
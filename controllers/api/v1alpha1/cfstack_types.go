@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFStackSpec defines the desired state of CFStack
+type CFStackSpec struct {
+	// The stack name as seen by CF users. Apps choosing the stack are built
+	// with the kpack ClusterBuilder or ClusterStack that has this id.
+	DisplayName string `json:"displayName"`
+
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Name",type="string",JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFStack is the Schema for the cfstacks API. Stacks live in the root
+// namespace and make stacks other than the one of the default kpack
+// ClusterBuilder available to apps.
+type CFStack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFStackSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFStackList contains a list of CFStack
+type CFStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFStack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFStack{}, &CFStackList{})
+}
+
+func (s CFStack) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFStack) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Stack with name %q already exists", s.Spec.DisplayName)
+}
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFUsageEventTypeLabelKey         = "korifi.cloudfoundry.org/usage-event-type"
+	CFUsageEventResourceGUIDLabelKey = "korifi.cloudfoundry.org/usage-event-resource-guid"
+
+	CFLastUsageEventGUIDAnnotationKey = "korifi.cloudfoundry.org/last-usage-event-guid"
+	CFUsageEventsFinalizerName        = "korifi.cloudfoundry.org/usage-events"
+
+	UsageEventTypeApp     = "app"
+	UsageEventTypeService = "service"
+
+	UsageEventStateStarted = "STARTED"
+	UsageEventStateStopped = "STOPPED"
+	UsageEventStateCreated = "CREATED"
+	UsageEventStateDeleted = "DELETED"
+)
+
+// CFUsageEventSpec defines the desired state of CFUsageEvent
+type CFUsageEventSpec struct {
+	// The type of the resource the event is about. Must be `app` or `service`
+	// +kubebuilder:validation:Enum=app;service
+	Type string `json:"type"`
+
+	// The state of the resource at the time of the event, e.g. "STARTED" or "DELETED"
+	State string `json:"state"`
+
+	// The state of the resource as of the previous event for it, if any
+	// +kubebuilder:validation:Optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	// The GUID of the space the resource is in
+	SpaceGUID string `json:"spaceGUID"`
+
+	// The name of the space the resource is in at the time of the event
+	// +kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+
+	// The GUID of the org the resource is in
+	// +kubebuilder:validation:Optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+
+	// Details about the process, set for `app` usage events
+	// +kubebuilder:validation:Optional
+	App *CFAppUsage `json:"app,omitempty"`
+
+	// Details about the service instance, set for `service` usage events
+	// +kubebuilder:validation:Optional
+	ServiceInstance *CFServiceInstanceUsage `json:"serviceInstance,omitempty"`
+}
+
+type CFAppUsage struct {
+	AppGUID     string `json:"appGUID"`
+	AppName     string `json:"appName"`
+	ProcessGUID string `json:"processGUID"`
+	ProcessType string `json:"processType"`
+
+	// The desired number of instances of the process
+	InstanceCount int32 `json:"instanceCount"`
+
+	// The desired number of instances as of the previous event, if any
+	// +kubebuilder:validation:Optional
+	PreviousInstanceCount *int32 `json:"previousInstanceCount,omitempty"`
+
+	// The memory limit of each instance in MiB
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+
+	// The memory limit of each instance as of the previous event, if any
+	// +kubebuilder:validation:Optional
+	PreviousMemoryInMBPerInstance *int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+}
+
+type CFServiceInstanceUsage struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+
+	// Type of the Service Instance. Must be `user-provided` or `managed`
+	Type InstanceType `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	PlanGUID string `json:"planGUID,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEvent is the Schema for the cfusageevents API. Usage events are
+// only ever created, never updated, and are named with time-ordered GUIDs so
+// that consumers can page through them in the order they were recorded.
+type CFUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEventList contains a list of CFUsageEvent
+type CFUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUsageEvent{}, &CFUsageEventList{})
+}
@@ -1,8 +1,11 @@
 package v1alpha1
 
 const (
-	BuildpackLifecycle LifecycleType = "buildpack"
-	DockerPackage      PackageType   = "docker"
+	BuildpackLifecycle  LifecycleType = "buildpack"
+	DockerLifecycle     LifecycleType = "docker"
+	DockerfileLifecycle LifecycleType = "dockerfile"
+	BitsPackage         PackageType   = "bits"
+	DockerPackage       PackageType   = "docker"
 
 	StartedState AppState = "STARTED"
 	StoppedState AppState = "STOPPED"
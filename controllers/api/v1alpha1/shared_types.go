@@ -26,6 +26,7 @@ const (
 	CFRouteHostLabelKey         = "korifi.cloudfoundry.org/route-host"
 	CFRoutePathLabelKey         = "korifi.cloudfoundry.org/route-path"
 	CFTaskGUIDLabelKey          = "korifi.cloudfoundry.org/task-guid"
+	ClearBuildCacheLabelKey     = "korifi.cloudfoundry.org/clear-build-cache"
 
 	GUIDLabelKey            = "korifi.cloudfoundry.org/guid"
 	SpaceGUIDKey            = "korifi.cloudfoundry.org/space-guid"
@@ -55,15 +56,15 @@ const (
 
 type Lifecycle struct {
 	// The CF Lifecycle type.
-	// Only "buildpack" and "docker" are currently allowed
+	// Only "buildpack", "docker" and "dockerfile" are currently allowed
 	Type LifecycleType `json:"type"`
 	// Data used to specify details for the Lifecycle
 	Data LifecycleData `json:"data"`
 }
 
 // LifecycleType inform the platform of how to build droplets and run apps
-// allow only values "buildpack" or "docker"
-// +kubebuilder:validation:Enum=buildpack;docker
+// allow only values "buildpack", "docker" or "dockerfile"
+// +kubebuilder:validation:Enum=buildpack;docker;dockerfile
 type LifecycleType string
 
 // LifecycleData is shared by CFApp and CFBuild
@@ -111,6 +112,43 @@ type ServiceBinding struct {
 
 	// Name of the binding secret
 	Secret string `json:"secret"`
+
+	// Volumes to be mounted onto the workload, as requested by the service broker
+	//+kubebuilder:validation:Optional
+	VolumeMounts []ServiceBindingVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+const (
+	VolumeMountModeReadOnly  = "r"
+	VolumeMountModeReadWrite = "rw"
+
+	NFSVolumeDriver = "nfs"
+)
+
+// ServiceBindingVolumeMount is a volume mount returned by the service broker
+// when binding to an offering that requires `volume_mount`
+type ServiceBindingVolumeMount struct {
+	// The name of the volume driver. Volumes with the `nfs` driver are mounted
+	// via NFS persistent volumes, any other driver is expected to be the name
+	// of a CSI driver supporting inline ephemeral volumes
+	Driver string `json:"driver"`
+
+	// The absolute path in the app container to mount the volume at
+	ContainerDir string `json:"containerDir"`
+
+	// Whether the volume is mounted read-only (`r`) or read-write (`rw`)
+	// +kubebuilder:validation:Enum=r;rw
+	Mode string `json:"mode"`
+
+	// The type of the device. Only `shared` devices are supported
+	DeviceType string `json:"deviceType"`
+
+	// The ID of the shared device
+	VolumeID string `json:"volumeID"`
+
+	// Driver specific configuration, e.g. the `source` of an NFS volume
+	//+kubebuilder:validation:Optional
+	MountConfig map[string]string `json:"mountConfig,omitempty"`
 }
 
 func AsMap(obj *runtime.RawExtension) (map[string]any, error) {
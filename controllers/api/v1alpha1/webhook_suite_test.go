@@ -110,7 +110,7 @@ var _ = BeforeSuite(func() {
 		uncachedClient,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(domains.NewValidator(uncachedClient).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(domains.NewValidator(uncachedClient, namespace).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(korifiv1alpha1.NewCFPackageDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
 
@@ -85,6 +85,27 @@ func (in *AppWorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWorkloadSidecar) DeepCopyInto(out *AppWorkloadSidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSidecar.
+func (in *AppWorkloadSidecar) DeepCopy() *AppWorkloadSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWorkloadSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	*out = *in
@@ -129,7 +150,16 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]AppWorkloadSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -519,6 +549,31 @@ func (in *CFAppDefaulter) DeepCopy() *CFAppDefaulter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppFeatures) DeepCopyInto(out *CFAppFeatures) {
+	*out = *in
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppFeatures.
+func (in *CFAppFeatures) DeepCopy() *CFAppFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFAppList) DeepCopyInto(out *CFAppList) {
 	*out = *in
@@ -551,11 +606,134 @@ func (in *CFAppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevision) DeepCopyInto(out *CFAppRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevision.
+func (in *CFAppRevision) DeepCopy() *CFAppRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevisionList) DeepCopyInto(out *CFAppRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFAppRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevisionList.
+func (in *CFAppRevisionList) DeepCopy() *CFAppRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevisionProcess) DeepCopyInto(out *CFAppRevisionProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevisionProcess.
+func (in *CFAppRevisionProcess) DeepCopy() *CFAppRevisionProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevisionProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevisionSpec) DeepCopyInto(out *CFAppRevisionSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.DropletRef = in.DropletRef
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]CFAppRevisionProcess, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevisionSpec.
+func (in *CFAppRevisionSpec) DeepCopy() *CFAppRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppSidecar) DeepCopyInto(out *CFAppSidecar) {
+	*out = *in
+	if in.ProcessTypes != nil {
+		in, out := &in.ProcessTypes, &out.ProcessTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSidecar.
+func (in *CFAppSidecar) DeepCopy() *CFAppSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	out.CurrentDropletRef = in.CurrentDropletRef
+	in.Features.DeepCopyInto(&out.Features)
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]CFAppSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSpec.
@@ -581,7 +759,9 @@ func (in *CFAppStatus) DeepCopyInto(out *CFAppStatus) {
 	if in.ServiceBindings != nil {
 		in, out := &in.ServiceBindings, &out.ServiceBindings
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -596,73 +776,83 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuild) DeepCopyInto(out *CFBuild) {
+func (in *CFAppUsage) DeepCopyInto(out *CFAppUsage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.PreviousInstanceCount != nil {
+		in, out := &in.PreviousInstanceCount, &out.PreviousInstanceCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreviousMemoryInMBPerInstance != nil {
+		in, out := &in.PreviousMemoryInMBPerInstance, &out.PreviousMemoryInMBPerInstance
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
-func (in *CFBuild) DeepCopy() *CFBuild {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsage.
+func (in *CFAppUsage) DeepCopy() *CFAppUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuild)
+	out := new(CFAppUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuild) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildDefaulter) DeepCopyInto(out *CFBuildDefaulter) {
+func (in *CFAuditEvent) DeepCopyInto(out *CFAuditEvent) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildDefaulter.
-func (in *CFBuildDefaulter) DeepCopy() *CFBuildDefaulter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEvent.
+func (in *CFAuditEvent) DeepCopy() *CFAuditEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildDefaulter)
+	out := new(CFAuditEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAuditEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
+func (in *CFAuditEventList) DeepCopyInto(out *CFAuditEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFBuild, len(*in))
+		*out = make([]CFAuditEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
-func (in *CFBuildList) DeepCopy() *CFBuildList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventList.
+func (in *CFAuditEventList) DeepCopy() *CFAuditEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildList)
+	out := new(CFAuditEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuildList) DeepCopyObject() runtime.Object {
+func (in *CFAuditEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -670,31 +860,45 @@ func (in *CFBuildList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
+func (in *CFAuditEventParticipant) DeepCopyInto(out *CFAuditEventParticipant) {
 	*out = *in
-	out.PackageRef = in.PackageRef
-	out.AppRef = in.AppRef
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
-func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventParticipant.
+func (in *CFAuditEventParticipant) DeepCopy() *CFAuditEventParticipant {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildSpec)
+	out := new(CFAuditEventParticipant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
+func (in *CFAuditEventSpec) DeepCopyInto(out *CFAuditEventSpec) {
 	*out = *in
-	if in.Droplet != nil {
-		in, out := &in.Droplet, &out.Droplet
-		*out = new(BuildDropletStatus)
+	out.Actor = in.Actor
+	out.Target = in.Target
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventSpec.
+func (in *CFAuditEventSpec) DeepCopy() *CFAuditEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAuditEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAuditEventStatus) DeepCopyInto(out *CFAuditEventStatus) {
+	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -704,37 +908,37 @@ func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
-func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventStatus.
+func (in *CFAuditEventStatus) DeepCopy() *CFAuditEventStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildStatus)
+	out := new(CFAuditEventStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomain) DeepCopyInto(out *CFDomain) {
+func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
-func (in *CFDomain) DeepCopy() *CFDomain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
+func (in *CFBuild) DeepCopy() *CFBuild {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomain)
+	out := new(CFBuild)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomain) DeepCopyObject() runtime.Object {
+func (in *CFBuild) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -742,46 +946,73 @@ func (in *CFDomain) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainDefaulter) DeepCopyInto(out *CFDomainDefaulter) {
+func (in *CFBuildCacheClear) DeepCopyInto(out *CFBuildCacheClear) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainDefaulter.
-func (in *CFDomainDefaulter) DeepCopy() *CFDomainDefaulter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildCacheClear.
+func (in *CFBuildCacheClear) DeepCopy() *CFBuildCacheClear {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainDefaulter)
+	out := new(CFBuildCacheClear)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFBuildCacheClear) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
+func (in *CFBuildCacheClearApp) DeepCopyInto(out *CFBuildCacheClearApp) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildCacheClearApp.
+func (in *CFBuildCacheClearApp) DeepCopy() *CFBuildCacheClearApp {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildCacheClearApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildCacheClearList) DeepCopyInto(out *CFBuildCacheClearList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFDomain, len(*in))
+		*out = make([]CFBuildCacheClear, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
-func (in *CFDomainList) DeepCopy() *CFDomainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildCacheClearList.
+func (in *CFBuildCacheClearList) DeepCopy() *CFBuildCacheClearList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainList)
+	out := new(CFBuildCacheClearList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomainList) DeepCopyObject() runtime.Object {
+func (in *CFBuildCacheClearList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -789,38 +1020,487 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
+func (in *CFBuildCacheClearSpec) DeepCopyInto(out *CFBuildCacheClearSpec) {
 	*out = *in
+	if in.AppRef != nil {
+		in, out := &in.AppRef, &out.AppRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildCacheClearSpec.
+func (in *CFBuildCacheClearSpec) DeepCopy() *CFBuildCacheClearSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildCacheClearSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildCacheClearStatus) DeepCopyInto(out *CFBuildCacheClearStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingApps != nil {
+		in, out := &in.PendingApps, &out.PendingApps
+		*out = make([]CFBuildCacheClearApp, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildCacheClearStatus.
+func (in *CFBuildCacheClearStatus) DeepCopy() *CFBuildCacheClearStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildCacheClearStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildDefaulter) DeepCopyInto(out *CFBuildDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildDefaulter.
+func (in *CFBuildDefaulter) DeepCopy() *CFBuildDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFBuild, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
+func (in *CFBuildList) DeepCopy() *CFBuildList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFBuildList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
+	*out = *in
+	out.PackageRef = in.PackageRef
+	out.AppRef = in.AppRef
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
+func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
+	*out = *in
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
+func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildpack) DeepCopyInto(out *CFBuildpack) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpack.
+func (in *CFBuildpack) DeepCopy() *CFBuildpack {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildpack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFBuildpack) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildpackList) DeepCopyInto(out *CFBuildpackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFBuildpack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackList.
+func (in *CFBuildpackList) DeepCopy() *CFBuildpackList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildpackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFBuildpackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFBuildpackSpec) DeepCopyInto(out *CFBuildpackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackSpec.
+func (in *CFBuildpackSpec) DeepCopy() *CFBuildpackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFBuildpackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomain) DeepCopyInto(out *CFDomain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
+func (in *CFDomain) DeepCopy() *CFDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFDomain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainDefaulter) DeepCopyInto(out *CFDomainDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainDefaulter.
+func (in *CFDomainDefaulter) DeepCopy() *CFDomainDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
+func (in *CFDomainList) DeepCopy() *CFDomainList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFDomainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
+	*out = *in
+	if in.SharedOrganizations != nil {
+		in, out := &in.SharedOrganizations, &out.SharedOrganizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
+func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
+func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFEnvVarGroup) DeepCopyInto(out *CFEnvVarGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroup.
+func (in *CFEnvVarGroup) DeepCopy() *CFEnvVarGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CFEnvVarGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFEnvVarGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFEnvVarGroupList) DeepCopyInto(out *CFEnvVarGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFEnvVarGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroupList.
+func (in *CFEnvVarGroupList) DeepCopy() *CFEnvVarGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFEnvVarGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFEnvVarGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFEnvVarGroupSpec) DeepCopyInto(out *CFEnvVarGroupSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroupSpec.
+func (in *CFEnvVarGroupSpec) DeepCopy() *CFEnvVarGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFEnvVarGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFFeatureFlag) DeepCopyInto(out *CFFeatureFlag) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlag.
+func (in *CFFeatureFlag) DeepCopy() *CFFeatureFlag {
+	if in == nil {
+		return nil
+	}
+	out := new(CFFeatureFlag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFFeatureFlag) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFFeatureFlagList) DeepCopyInto(out *CFFeatureFlagList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFFeatureFlag, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
-func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagList.
+func (in *CFFeatureFlagList) DeepCopy() *CFFeatureFlagList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainSpec)
+	out := new(CFFeatureFlagList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFFeatureFlagList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+func (in *CFFeatureFlagSpec) DeepCopyInto(out *CFFeatureFlagSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
-func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagSpec.
+func (in *CFFeatureFlagSpec) DeepCopy() *CFFeatureFlagSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainStatus)
+	out := new(CFFeatureFlagSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1113,6 +1793,7 @@ func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
 	*out = *in
 	out.AppRef = in.AppRef
 	out.HealthCheck = in.HealthCheck
+	out.ReadinessHealthCheck = in.ReadinessHealthCheck
 	if in.DesiredInstances != nil {
 		in, out := &in.DesiredInstances, &out.DesiredInstances
 		*out = new(int32)
@@ -1249,6 +1930,16 @@ func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = new(RouteOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
@@ -1486,6 +2177,13 @@ func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
 	*out = *in
 	out.MountSecretRef = in.MountSecretRef
 	out.EnvSecretRef = in.EnvSecretRef
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceBindingVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1712,6 +2410,21 @@ func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceUsage) DeepCopyInto(out *CFServiceInstanceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceUsage.
+func (in *CFServiceInstanceUsage) DeepCopy() *CFServiceInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFServiceOffering) DeepCopyInto(out *CFServiceOffering) {
 	*out = *in
@@ -1883,7 +2596,7 @@ func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1905,6 +2618,26 @@ func (in *CFSpace) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceFeatures) DeepCopyInto(out *CFSpaceFeatures) {
+	*out = *in
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceFeatures.
+func (in *CFSpaceFeatures) DeepCopy() *CFSpaceFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
 	*out = *in
@@ -1940,6 +2673,7 @@ func (in *CFSpaceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
 	*out = *in
+	in.Features.DeepCopyInto(&out.Features)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
@@ -1974,6 +2708,79 @@ func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStack) DeepCopyInto(out *CFStack) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStack.
+func (in *CFStack) DeepCopy() *CFStack {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFStack) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStackList) DeepCopyInto(out *CFStackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFStack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackList.
+func (in *CFStackList) DeepCopy() *CFStackList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFStackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStackSpec) DeepCopyInto(out *CFStackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackSpec.
+func (in *CFStackSpec) DeepCopy() *CFStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFTask) DeepCopyInto(out *CFTask) {
 	*out = *in
@@ -2072,6 +2879,89 @@ func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUsageEvent) DeepCopyInto(out *CFUsageEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEvent.
+func (in *CFUsageEvent) DeepCopy() *CFUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUsageEventList) DeepCopyInto(out *CFUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFUsageEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventList.
+func (in *CFUsageEventList) DeepCopy() *CFUsageEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUsageEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUsageEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUsageEventSpec) DeepCopyInto(out *CFUsageEventSpec) {
+	*out = *in
+	if in.App != nil {
+		in, out := &in.App, &out.App
+		*out = new(CFAppUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceInstance != nil {
+		in, out := &in.ServiceInstance, &out.ServiceInstance
+		*out = new(CFServiceInstanceUsage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventSpec.
+func (in *CFUsageEventSpec) DeepCopy() *CFUsageEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUsageEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -2098,6 +2988,40 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderModifier) DeepCopyInto(out *HeaderModifier) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderModifier.
+func (in *HeaderModifier) DeepCopy() *HeaderModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -2265,6 +3189,37 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheck) DeepCopyInto(out *ReadinessHealthCheck) {
+	*out = *in
+	out.Data = in.Data
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheck.
+func (in *ReadinessHealthCheck) DeepCopy() *ReadinessHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheckData) DeepCopyInto(out *ReadinessHealthCheckData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheckData.
+func (in *ReadinessHealthCheckData) DeepCopy() *ReadinessHealthCheckData {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheckData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -2300,6 +3255,26 @@ func (in *RequiredLocalObjectReference) DeepCopy() *RequiredLocalObjectReference
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteOptions) DeepCopyInto(out *RouteOptions) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(HeaderModifier)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteOptions.
+func (in *RouteOptions) DeepCopy() *RouteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerInfo) DeepCopyInto(out *RunnerInfo) {
 	*out = *in
@@ -2445,6 +3420,13 @@ func (in *SecurityGroupWorkloads) DeepCopy() *SecurityGroupWorkloads {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBinding) DeepCopyInto(out *ServiceBinding) {
 	*out = *in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceBindingVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBinding.
@@ -2473,6 +3455,28 @@ func (in *ServiceBindingSchema) DeepCopy() *ServiceBindingSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingVolumeMount) DeepCopyInto(out *ServiceBindingVolumeMount) {
+	*out = *in
+	if in.MountConfig != nil {
+		in, out := &in.MountConfig, &out.MountConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingVolumeMount.
+func (in *ServiceBindingVolumeMount) DeepCopy() *ServiceBindingVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerCatalog) DeepCopyInto(out *ServiceBrokerCatalog) {
 	*out = *in
@@ -41,12 +41,33 @@ func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error
 		return err
 	}
 
+	// Builds whose droplet is referenced by a revision must be kept so that
+	// the app can be rolled back to any retained revision
+	var cfAppRevisions korifiv1alpha1.CFAppRevisionList
+	err = c.k8sClient.List(ctx, &cfAppRevisions,
+		client.InNamespace(app.Namespace),
+		client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey: app.Name,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	revisionDroplets := map[string]bool{}
+	for _, revision := range cfAppRevisions.Items {
+		revisionDroplets[revision.Spec.DropletRef.Name] = true
+	}
+
 	var deletableBuilds []korifiv1alpha1.CFBuild
 	log.Info("processing builds", "count", len(cfBuilds.Items))
 	for _, cfBuild := range cfBuilds.Items {
 		if cfBuild.Name == cfApp.Spec.CurrentDropletRef.Name {
 			continue
 		}
+		if revisionDroplets[cfBuild.Name] {
+			continue
+		}
 		if !meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType) {
 			continue
 		}
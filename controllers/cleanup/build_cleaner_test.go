@@ -1,6 +1,7 @@
 package cleanup_test
 
 import (
+	"fmt"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -82,6 +83,39 @@ var _ = Describe("BuildCleaner", func() {
 		Expect(bldDeletable).To(BeNotFound())
 	})
 
+	When("an app revision references the droplet of a build", func() {
+		BeforeEach(func() {
+			createRevision(namespace, appGUID, 1, bldDeletable.Name)
+		})
+
+		It("keeps the build", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			Expect(bldDeletable).To(BeFound())
+		})
+	})
+
+	When("the app can be rolled back past the retained builds", func() {
+		var revisionBuilds []*korifiv1alpha1.CFBuild
+
+		BeforeEach(func() {
+			cleaner = cleanup.NewBuildCleaner(controllersClient, 5)
+
+			revisionBuilds = nil
+			for i := 1; i <= 7; i++ {
+				bld := createSucceededBuild(namespace, appGUID, fmt.Sprintf("revision-%d", i))
+				createRevision(namespace, appGUID, i, bld.Name)
+				revisionBuilds = append(revisionBuilds, bld)
+			}
+		})
+
+		It("keeps the builds of all revisions", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+			for _, bld := range revisionBuilds {
+				Expect(bld).To(BeFound())
+			}
+		})
+	})
+
 	When("the current droplet is not set on the app", func() {
 		BeforeEach(func() {
 			cfApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{}
@@ -126,3 +160,26 @@ func createSucceededBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBui
 	Expect(k8sClient.Status().Update(ctx, bld)).To(Succeed())
 	return bld
 }
+
+func createRevision(namespace, appGUID string, version int, dropletGUID string) *korifiv1alpha1.CFAppRevision {
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFAppRevisionSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: appGUID,
+			},
+			Version: version,
+			DropletRef: corev1.LocalObjectReference{
+				Name: dropletGUID,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+	return revision
+}
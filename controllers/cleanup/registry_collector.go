@@ -0,0 +1,345 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	packagesRepositorySuffix = "-packages"
+	dropletsRepositorySuffix = "-droplets"
+)
+
+//counterfeiter:generate -o fake -fake-name RegistryClient . RegistryClient
+
+type RegistryClient interface {
+	ListRepositories(ctx context.Context, creds image.Creds, registryHost string) ([]string, error)
+	ListTags(ctx context.Context, creds image.Creds, repoRef string) (map[string]string, error)
+	Delete(ctx context.Context, creds image.Creds, imageRef string, tagsToDelete ...string) error
+}
+
+// RegistryCollectionReport lists the items reclaimed (or, in dry-run mode,
+// that would have been reclaimed) by a collection
+type RegistryCollectionReport struct {
+	Repositories []string
+	Tags         []string
+	Manifests    []string
+}
+
+// RegistryCollector periodically deletes the package and droplet images in
+// the container registry that are no longer referenced by any CFPackage or
+// CFBuild, including the builds used as the current droplet of apps. The
+// referencing objects are read bypassing the cache, so that objects created
+// after the registry tags have been listed are always seen.
+type RegistryCollector struct {
+	reader           client.Reader
+	registryClient   RegistryClient
+	log              logr.Logger
+	creds            image.Creds
+	repositoryPrefix string
+	interval         time.Duration
+	dryRun           bool
+}
+
+func NewRegistryCollector(
+	reader client.Reader,
+	registryClient RegistryClient,
+	log logr.Logger,
+	rootNamespace string,
+	registrySecretNames []string,
+	repositoryPrefix string,
+	interval time.Duration,
+	dryRun bool,
+) *RegistryCollector {
+	return &RegistryCollector{
+		reader:         reader,
+		registryClient: registryClient,
+		log:            log,
+		creds: image.Creds{
+			Namespace:   rootNamespace,
+			SecretNames: registrySecretNames,
+		},
+		repositoryPrefix: repositoryPrefix,
+		interval:         interval,
+		dryRun:           dryRun,
+	}
+}
+
+// Start collects the registry once right away, so that restarts do not
+// delay the collection by a whole interval, and then on every interval
+func (c *RegistryCollector) Start(ctx context.Context) error {
+	ctx = logr.NewContext(ctx, c.log)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.collectAndReport(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *RegistryCollector) collectAndReport(ctx context.Context) {
+	log := c.log.WithName("RegistryCollector")
+
+	report, err := c.Collect(ctx)
+	if err != nil {
+		log.Error(err, "registry collection failed")
+		return
+	}
+
+	log.Info("registry collection finished",
+		"dryRun", c.dryRun,
+		"repositories", report.Repositories,
+		"tags", report.Tags,
+		"manifests", report.Manifests,
+	)
+}
+
+// Collect deletes the unreferenced images. The registry tags are listed
+// before the referencing objects, so that images pushed during the
+// collection are never considered unreferenced.
+func (c *RegistryCollector) Collect(ctx context.Context) (RegistryCollectionReport, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("RegistryCollector").WithValues("dryRun", c.dryRun)
+	report := RegistryCollectionReport{}
+
+	repositoryPrefix, registryHost, err := c.normalizedPrefix()
+	if err != nil {
+		return report, err
+	}
+
+	repositories, err := c.listRepositories(ctx, repositoryPrefix, registryHost)
+	if err != nil {
+		return report, err
+	}
+
+	repositoryTags := map[string]map[string]string{}
+	for _, repository := range repositories {
+		var tags map[string]string
+		tags, err = c.registryClient.ListTags(ctx, c.creds, repository)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return report, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+		}
+		repositoryTags[repository] = tags
+	}
+
+	refs, err := c.listReferences(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, repository := range repositories {
+		tags, ok := repositoryTags[repository]
+		if !ok {
+			continue
+		}
+
+		appGUID := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(repository, repositoryPrefix), packagesRepositorySuffix), dropletsRepositorySuffix)
+		if refs.stagingApps[appGUID] {
+			log.V(1).Info("skipping repository of app being staged", "repository", repository)
+			continue
+		}
+
+		reclaimed := false
+		for digest, digestTags := range tagsByDigest(tags) {
+			if refs.isReferenced(repository, digest, digestTags) {
+				continue
+			}
+
+			manifest := repository + "@" + digest
+			log.Info("reclaiming unreferenced image", "manifest", manifest, "tags", digestTags)
+			if !c.dryRun {
+				if err = c.registryClient.Delete(ctx, c.creds, manifest, digestTags...); err != nil {
+					return report, fmt.Errorf("failed to delete %s: %w", manifest, err)
+				}
+			}
+
+			reclaimed = true
+			report.Manifests = append(report.Manifests, manifest)
+			for _, tag := range digestTags {
+				report.Tags = append(report.Tags, repository+":"+tag)
+			}
+		}
+
+		if reclaimed {
+			report.Repositories = append(report.Repositories, repository)
+		}
+	}
+
+	return report, nil
+}
+
+func (c *RegistryCollector) normalizedPrefix() (string, string, error) {
+	// the prefix may end in the middle of a repository path component, so
+	// parse it with a placeholder appended
+	const placeholder = "x"
+	repo, err := name.NewRepository(c.repositoryPrefix + placeholder)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid container repository prefix %q: %w", c.repositoryPrefix, err)
+	}
+
+	return strings.TrimSuffix(repo.Name(), placeholder), repo.RegistryStr(), nil
+}
+
+// listRepositories returns the package and droplet repositories of existing
+// apps, and of deleted apps if the registry catalog can be listed
+func (c *RegistryCollector) listRepositories(ctx context.Context, repositoryPrefix, registryHost string) ([]string, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	var cfApps korifiv1alpha1.CFAppList
+	if err := c.reader.List(ctx, &cfApps); err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	repositories := []string{}
+	for _, cfApp := range cfApps.Items {
+		repositories = append(repositories,
+			repositoryPrefix+cfApp.Name+packagesRepositorySuffix,
+			repositoryPrefix+cfApp.Name+dropletsRepositorySuffix,
+		)
+	}
+
+	catalog, err := c.registryClient.ListRepositories(ctx, c.creds, registryHost)
+	if err != nil {
+		log.Info("failed to list registry catalog - only collecting repositories of existing apps", "reason", err)
+	}
+
+	for _, repository := range catalog {
+		if !strings.HasPrefix(repository, repositoryPrefix) {
+			continue
+		}
+
+		if !strings.HasSuffix(repository, packagesRepositorySuffix) && !strings.HasSuffix(repository, dropletsRepositorySuffix) {
+			continue
+		}
+
+		repositories = append(repositories, repository)
+	}
+
+	slices.Sort(repositories)
+	return slices.Compact(repositories), nil
+}
+
+type imageReferences struct {
+	// tags named after the referencing objects, i.e. package and build GUIDs
+	names map[string]bool
+	// fully qualified repo:tag and repo@digest references
+	images      map[string]bool
+	stagingApps map[string]bool
+}
+
+func (r imageReferences) isReferenced(repository, digest string, tags []string) bool {
+	if r.images[repository+"@"+digest] {
+		return true
+	}
+
+	for _, tag := range tags {
+		if r.names[tag] || r.images[repository+":"+tag] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r imageReferences) addImage(imageRef string) {
+	if imageRef == "" {
+		return
+	}
+
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return
+	}
+
+	separator := ":"
+	if _, ok := ref.(name.Digest); ok {
+		separator = "@"
+	}
+
+	r.images[ref.Context().Name()+separator+ref.Identifier()] = true
+}
+
+func (c *RegistryCollector) listReferences(ctx context.Context) (imageReferences, error) {
+	refs := imageReferences{
+		names:       map[string]bool{},
+		images:      map[string]bool{},
+		stagingApps: map[string]bool{},
+	}
+
+	var cfPackages korifiv1alpha1.CFPackageList
+	if err := c.reader.List(ctx, &cfPackages); err != nil {
+		return refs, fmt.Errorf("failed to list packages: %w", err)
+	}
+
+	for _, cfPackage := range cfPackages.Items {
+		refs.names[cfPackage.Name] = true
+		refs.addImage(cfPackage.Spec.Source.Registry.Image)
+	}
+
+	var cfBuilds korifiv1alpha1.CFBuildList
+	if err := c.reader.List(ctx, &cfBuilds); err != nil {
+		return refs, fmt.Errorf("failed to list builds: %w", err)
+	}
+
+	for _, cfBuild := range cfBuilds.Items {
+		refs.names[cfBuild.Name] = true
+
+		if cfBuild.Spec.Droplet != nil {
+			refs.addImage(cfBuild.Spec.Droplet.Registry.Image)
+		}
+
+		if cfBuild.Status.Droplet != nil {
+			refs.addImage(cfBuild.Status.Droplet.Registry.Image)
+		}
+
+		// droplets of builds in progress may already be pushed under tags
+		// that are not referenced yet
+		succeeded := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+		if succeeded == nil || succeeded.Status == metav1.ConditionUnknown {
+			refs.stagingApps[cfBuild.Spec.AppRef.Name] = true
+		}
+	}
+
+	return refs, nil
+}
+
+func tagsByDigest(tags map[string]string) map[string][]string {
+	result := map[string][]string{}
+	for tag, digest := range tags {
+		result[digest] = append(result[digest], tag)
+	}
+
+	for _, digestTags := range result {
+		slices.Sort(digestTags)
+	}
+
+	return result
+}
+
+func isNotFound(err error) bool {
+	var structuredErr *transport.Error
+	return errors.As(err, &structuredErr) && structuredErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,314 @@
+package cleanup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	"code.cloudfoundry.org/korifi/controllers/cleanup/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RegistryCollector", func() {
+	const prefix = "my.registry/korifi/"
+
+	var (
+		registryClient *fake.RegistryClient
+		dryRun         bool
+		namespace      string
+		appGUID        string
+		packagesRepo   string
+		dropletsRepo   string
+		deletedRepo    string
+		liveDigest     string
+		goneDigest     string
+		dropletDigest  string
+		oldDigest      string
+		deletedDigest  string
+		repoTags       map[string]map[string]string
+		report         cleanup.RegistryCollectionReport
+		collectErr     error
+	)
+
+	digest := func(c string) string {
+		return "sha256:" + strings.Repeat(c, 64)
+	}
+
+	BeforeEach(func() {
+		dryRun = false
+		namespace = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		appGUID = uuid.NewString()
+		packagesRepo = prefix + appGUID + "-packages"
+		dropletsRepo = prefix + appGUID + "-droplets"
+		deletedRepo = prefix + uuid.NewString() + "-packages"
+		liveDigest = digest("a")
+		goneDigest = digest("b")
+		dropletDigest = digest("c")
+		oldDigest = digest("d")
+		deletedDigest = digest("e")
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{Name: appGUID, Namespace: namespace},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "an-app",
+				Lifecycle:    korifiv1alpha1.Lifecycle{Type: "buildpack"},
+				DesiredState: "STOPPED",
+			},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "pkg-live-" + appGUID, Namespace: namespace},
+			Spec: korifiv1alpha1.CFPackageSpec{
+				Type:   "bits",
+				AppRef: corev1.LocalObjectReference{Name: appGUID},
+				Source: korifiv1alpha1.PackageSource{
+					Registry: korifiv1alpha1.Registry{Image: packagesRepo + "@" + liveDigest},
+				},
+			},
+		})).To(Succeed())
+
+		createRegistryBuild(namespace, appGUID, "build-live-"+appGUID, dropletsRepo+"@"+dropletDigest, metav1.ConditionTrue)
+
+		repoTags = map[string]map[string]string{
+			packagesRepo: {
+				"pkg-live-" + appGUID: liveDigest,
+				"pkg-gone":            goneDigest,
+			},
+			dropletsRepo: {
+				"latest": dropletDigest,
+				"b1":     oldDigest,
+			},
+			deletedRepo: {
+				"pkg-deleted": deletedDigest,
+			},
+		}
+
+		registryClient = new(fake.RegistryClient)
+		registryClient.ListRepositoriesReturns([]string{
+			packagesRepo,
+			deletedRepo,
+			"my.registry/other/" + uuid.NewString() + "-packages",
+			prefix + "not-an-app-repo",
+		}, nil)
+		registryClient.ListTagsStub = func(_ context.Context, _ image.Creds, repoRef string) (map[string]string, error) {
+			tags, ok := repoTags[repoRef]
+			if !ok {
+				return map[string]string{}, nil
+			}
+			return tags, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		collector := cleanup.NewRegistryCollector(controllersClient, registryClient, GinkgoLogr, "root-ns", []string{"registry-secret"}, prefix, time.Hour, dryRun)
+		report, collectErr = collector.Collect(ctx)
+	})
+
+	deletedImages := func() []string {
+		result := []string{}
+		for i := range registryClient.DeleteCallCount() {
+			_, creds, imageRef, tags := registryClient.DeleteArgsForCall(i)
+			Expect(creds).To(Equal(image.Creds{Namespace: "root-ns", SecretNames: []string{"registry-secret"}}))
+			result = append(result, imageRef+" "+strings.Join(tags, ","))
+		}
+		return result
+	}
+
+	Describe("Start", func() {
+		var stopCollector context.CancelFunc
+
+		BeforeEach(func() {
+			var collectorCtx context.Context
+			collectorCtx, stopCollector = context.WithCancel(ctx)
+
+			collector := cleanup.NewRegistryCollector(controllersClient, registryClient, GinkgoLogr, "root-ns", []string{"registry-secret"}, prefix, time.Hour, dryRun)
+			go func() {
+				defer GinkgoRecover()
+				Expect(collector.Start(collectorCtx)).To(Succeed())
+			}()
+		})
+
+		AfterEach(func() {
+			stopCollector()
+		})
+
+		It("collects right away rather than after the first interval", func() {
+			// one collection is run by the JustBeforeEach, the other by Start
+			Eventually(registryClient.ListRepositoriesCallCount).Should(Equal(2))
+		})
+	})
+
+	It("lists the catalog of the prefix registry", func() {
+		Expect(collectErr).NotTo(HaveOccurred())
+		Expect(registryClient.ListRepositoriesCallCount()).To(Equal(1))
+		_, _, registryHost := registryClient.ListRepositoriesArgsForCall(0)
+		Expect(registryHost).To(Equal("my.registry"))
+	})
+
+	It("deletes the unreferenced images", func() {
+		Expect(collectErr).NotTo(HaveOccurred())
+		Expect(deletedImages()).To(ConsistOf(
+			packagesRepo+"@"+goneDigest+" pkg-gone",
+			dropletsRepo+"@"+oldDigest+" b1",
+			deletedRepo+"@"+deletedDigest+" pkg-deleted",
+		))
+	})
+
+	It("reports the reclaimed items", func() {
+		Expect(collectErr).NotTo(HaveOccurred())
+		Expect(report.Repositories).To(ConsistOf(packagesRepo, dropletsRepo, deletedRepo))
+		Expect(report.Tags).To(ConsistOf(
+			packagesRepo+":pkg-gone",
+			dropletsRepo+":b1",
+			deletedRepo+":pkg-deleted",
+		))
+		Expect(report.Manifests).To(ConsistOf(
+			packagesRepo+"@"+goneDigest,
+			dropletsRepo+"@"+oldDigest,
+			deletedRepo+"@"+deletedDigest,
+		))
+	})
+
+	When("in dry-run mode", func() {
+		BeforeEach(func() {
+			dryRun = true
+		})
+
+		It("does not delete anything", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(registryClient.DeleteCallCount()).To(BeZero())
+		})
+
+		It("reports what would have been reclaimed", func() {
+			Expect(report.Manifests).To(ConsistOf(
+				packagesRepo+"@"+goneDigest,
+				dropletsRepo+"@"+oldDigest,
+				deletedRepo+"@"+deletedDigest,
+			))
+		})
+	})
+
+	When("a droplet is only referenced by the spec of a build", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFBuild{
+				ObjectMeta: metav1.ObjectMeta{Name: uuid.NewString(), Namespace: namespace},
+				Spec: korifiv1alpha1.CFBuildSpec{
+					AppRef:    corev1.LocalObjectReference{Name: "another-app"},
+					Lifecycle: korifiv1alpha1.Lifecycle{Type: "buildpack"},
+					Droplet: &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{Image: dropletsRepo + "@" + oldDigest},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		It("keeps it", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(deletedImages()).NotTo(ContainElement(HavePrefix(dropletsRepo)))
+		})
+	})
+
+	When("the app is being staged", func() {
+		BeforeEach(func() {
+			createRegistryBuild(namespace, appGUID, "build-staging-"+appGUID, "", metav1.ConditionUnknown)
+		})
+
+		It("skips the repositories of the app", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(deletedImages()).To(ConsistOf(deletedRepo + "@" + deletedDigest + " pkg-deleted"))
+		})
+	})
+
+	When("the registry catalog cannot be listed", func() {
+		BeforeEach(func() {
+			registryClient.ListRepositoriesReturns(nil, errors.New("unsupported"))
+		})
+
+		It("only collects the repositories of existing apps", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(deletedImages()).To(ConsistOf(
+				packagesRepo+"@"+goneDigest+" pkg-gone",
+				dropletsRepo+"@"+oldDigest+" b1",
+			))
+		})
+	})
+
+	When("a repository does not exist", func() {
+		BeforeEach(func() {
+			listTags := registryClient.ListTagsStub
+			registryClient.ListTagsStub = func(ctx context.Context, creds image.Creds, repoRef string) (map[string]string, error) {
+				if repoRef == dropletsRepo {
+					return nil, &transport.Error{StatusCode: 404}
+				}
+				return listTags(ctx, creds, repoRef)
+			}
+		})
+
+		It("skips it", func() {
+			Expect(collectErr).NotTo(HaveOccurred())
+			Expect(deletedImages()).To(ConsistOf(
+				packagesRepo+"@"+goneDigest+" pkg-gone",
+				deletedRepo+"@"+deletedDigest+" pkg-deleted",
+			))
+		})
+	})
+
+	When("listing tags fails", func() {
+		BeforeEach(func() {
+			registryClient.ListTagsReturns(nil, errors.New("list-tags-err"))
+			registryClient.ListTagsStub = nil
+		})
+
+		It("returns the error", func() {
+			Expect(collectErr).To(MatchError(ContainSubstring("list-tags-err")))
+			Expect(registryClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("deleting an image fails", func() {
+		BeforeEach(func() {
+			registryClient.DeleteReturns(errors.New("delete-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(collectErr).To(MatchError(ContainSubstring("delete-err")))
+		})
+	})
+})
+
+func createRegistryBuild(namespace, appGUID, name, dropletImage string, succeeded metav1.ConditionStatus) {
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef:    corev1.LocalObjectReference{Name: appGUID},
+			Lifecycle: korifiv1alpha1.Lifecycle{Type: "buildpack"},
+		},
+	}
+	Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+	if dropletImage != "" {
+		build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+			Registry: korifiv1alpha1.Registry{Image: dropletImage},
+		}
+	}
+	meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+		Type:   korifiv1alpha1.SucceededConditionType,
+		Status: succeeded,
+		Reason: "Test",
+	})
+	Expect(k8sClient.Status().Update(ctx, build)).To(Succeed())
+}
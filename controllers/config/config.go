@@ -15,7 +15,11 @@ type ControllerConfig struct {
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	AuditEventTTL                    string             `yaml:"auditEventTTL"`
+	UsageEventTTL                    string             `yaml:"usageEventTTL"`
+	StagingTimeout                   string             `yaml:"stagingTimeout"`
 	BuilderName                      string             `yaml:"builderName"`
+	DockerfileBuilderName            string             `yaml:"dockerfileBuilderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
 	ExtraVCAPApplicationValues       map[string]any     `yaml:"extraVCAPApplicationValues"`
@@ -23,6 +27,8 @@ type ControllerConfig struct {
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int32             `yaml:"spaceFinalizerAppDeletionTimeout"`
+	ContainerRepositoryPrefix        string             `yaml:"containerRepositoryPrefix"`
+	RegistryGC                       RegistryGC         `yaml:"registryGC"`
 
 	Networking Networking `yaml:"networking"`
 
@@ -46,13 +52,32 @@ type CFStagingResources struct {
 type Networking struct {
 	GatewayName      string `yaml:"gatewayName"`
 	GatewayNamespace string `yaml:"gatewayNamespace"`
+	// The gateway implementation specific policy used to apply the route
+	// loadbalancing option. When empty, the option is ignored
+	BackendPolicy string `yaml:"backendPolicy"`
 }
 
+// RegistryGC configures the periodic deletion of package and droplet images
+// that are no longer referenced by any CFPackage or CFBuild
+type RegistryGC struct {
+	Enabled  bool   `yaml:"enabled"`
+	Interval string `yaml:"interval"`
+	// When set, unreferenced images are only logged
+	DryRun bool `yaml:"dryRun"`
+}
+
+const EnvoyGatewayBackendPolicy = "envoy-gateway"
+
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL             = 30 * 24 * time.Hour
+	defaultAuditEventTTL       = 31 * 24 * time.Hour
+	defaultUsageEventTTL       = 31 * 24 * time.Hour
+	defaultTimeout       int32 = 60
+	defaultJobTTL              = 24 * time.Hour
+	defaultBuildCacheMB        = 2048
+
+	defaultDockerfileBuilderName = "dockerfile-image-builder"
+	defaultRegistryGCInterval    = 24 * time.Hour
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -74,6 +99,10 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFStagingResources.BuildCacheMB = defaultBuildCacheMB
 	}
 
+	if config.DockerfileBuilderName == "" {
+		config.DockerfileBuilderName = defaultDockerfileBuilderName
+	}
+
 	return &config, nil
 }
 
@@ -93,3 +122,36 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseAuditEventTTL() (time.Duration, error) {
+	if c.AuditEventTTL == "" {
+		return defaultAuditEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventTTL)
+}
+
+func (c ControllerConfig) ParseUsageEventTTL() (time.Duration, error) {
+	if c.UsageEventTTL == "" {
+		return defaultUsageEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.UsageEventTTL)
+}
+
+// ParseStagingTimeout returns zero, i.e. no timeout, when unset
+func (c ControllerConfig) ParseStagingTimeout() (time.Duration, error) {
+	if c.StagingTimeout == "" {
+		return 0, nil
+	}
+
+	return tools.ParseDuration(c.StagingTimeout)
+}
+
+func (c ControllerConfig) ParseRegistryGCInterval() (time.Duration, error) {
+	if c.RegistryGC.Interval == "" {
+		return defaultRegistryGCInterval, nil
+	}
+
+	return tools.ParseDuration(c.RegistryGC.Interval)
+}
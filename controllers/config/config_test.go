@@ -43,10 +43,18 @@ var _ = Describe("LoadFromPath", func() {
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
+			StagingTimeout:                   "15m",
 			BuilderName:                      "buildReconciler",
+			DockerfileBuilderName:            "dockerfileBuildReconciler",
 			RunnerName:                       "statefulset-runner",
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int32(42)),
+			ContainerRepositoryPrefix:        "my.registry/korifi/",
+			RegistryGC: config.RegistryGC{
+				Enabled:  true,
+				Interval: "12h",
+				DryRun:   true,
+			},
 			Networking: config.Networking{
 				GatewayName:      "gw-name",
 				GatewayNamespace: "gw-ns",
@@ -84,12 +92,20 @@ var _ = Describe("LoadFromPath", func() {
 			CFRootNamespace:                  "rootNamespace",
 			ContainerRegistrySecretNames:     []string{"packageRegistrySecretName"},
 			TaskTTL:                          "taskTTL",
+			StagingTimeout:                   "15m",
 			BuilderName:                      "buildReconciler",
+			DockerfileBuilderName:            "dockerfileBuildReconciler",
 			RunnerName:                       "statefulset-runner",
 			NamespaceLabels:                  map[string]string{},
 			ExtraVCAPApplicationValues:       map[string]any{},
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int32(42)),
+			ContainerRepositoryPrefix:        "my.registry/korifi/",
+			RegistryGC: config.RegistryGC{
+				Enabled:  true,
+				Interval: "12h",
+				DryRun:   true,
+			},
 			Networking: config.Networking{
 				GatewayName:      "gw-name",
 				GatewayNamespace: "gw-ns",
@@ -147,6 +163,16 @@ var _ = Describe("LoadFromPath", func() {
 			Expect(retConfig.CFStagingResources.BuildCacheMB).To(Equal(int64(2048)))
 		})
 	})
+
+	When("the dockerfile builder name is not set", func() {
+		BeforeEach(func() {
+			cfg.DockerfileBuilderName = ""
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.DockerfileBuilderName).To(Equal("dockerfile-image-builder"))
+		})
+	})
 })
 
 var _ = Describe("ParseTaskTTL", func() {
@@ -194,3 +220,187 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseAuditEventTTL", func() {
+	var (
+		auditEventTTLString string
+		auditEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		auditEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventTTL: auditEventTTLString,
+		}
+
+		auditEventTTL, parseErr = cfg.ParseAuditEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(auditEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(auditEventTTL).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseUsageEventTTL", func() {
+	var (
+		usageEventTTLString string
+		usageEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		usageEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			UsageEventTTL: usageEventTTLString,
+		}
+
+		usageEventTTL, parseErr = cfg.ParseUsageEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(usageEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(usageEventTTL).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseStagingTimeout", func() {
+	var (
+		timeoutString string
+		timeout       time.Duration
+		parseErr      error
+	)
+
+	BeforeEach(func() {
+		timeoutString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			StagingTimeout: timeoutString,
+		}
+
+		timeout, parseErr = cfg.ParseStagingTimeout()
+	})
+
+	It("returns no timeout by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(timeout).To(BeZero())
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			timeoutString = "1h30m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(timeout).To(Equal(90 * time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			timeoutString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseRegistryGCInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			RegistryGC: config.RegistryGC{Interval: intervalString},
+		}
+
+		interval, parseErr = cfg.ParseRegistryGCInterval()
+	})
+
+	It("returns 24 hours by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			intervalString = "2d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(48 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -19,7 +19,10 @@ package routes
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -29,9 +32,13 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -43,6 +50,19 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
+var (
+	envoyBackendTrafficPolicyGVK = schema.GroupVersionKind{
+		Group:   "gateway.envoyproxy.io",
+		Version: "v1alpha1",
+		Kind:    "BackendTrafficPolicy",
+	}
+
+	envoyLoadBalancerTypes = map[string]string{
+		"round-robin":      "RoundRobin",
+		"least-connection": "LeastRequest",
+	}
+)
+
 type Reconciler struct {
 	client           client.Client
 	scheme           *runtime.Scheme
@@ -81,7 +101,6 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 	err := r.client.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -106,9 +125,12 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;patch;delete
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
+//+kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;create;patch;delete
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -124,6 +146,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if len(cfRoute.Spec.SharedSpaces) > 0 {
+		controllerutil.AddFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedSpacesFinalizerName)
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{}
 	err := r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, cfDomain)
 	if err != nil {
@@ -135,11 +161,21 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
 	}
 
+	err = r.reconcileReferenceGrants(ctx, cfRoute)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileReferenceGrants")
+	}
+
 	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
 	if err != nil {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
 	}
 
+	err = r.reconcileBackendPolicy(ctx, cfRoute)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileBackendPolicy")
+	}
+
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	cfRoute.Status.FQDN = fqdn
 	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
@@ -163,8 +199,34 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCRRoute")
 
-	if !controllerutil.ContainsFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName) {
-		return nil
+	if controllerutil.ContainsFinalizer(cfRoute, korifiv1alpha1.CFRouteTransferFinalizerName) {
+		if cfRoute.Spec.TransferTo != "" && cfRoute.Spec.TransferTo != cfRoute.Namespace {
+			err := r.transferCFRoute(ctx, cfRoute)
+			if err != nil {
+				return err
+			}
+
+			// the resources in the shared spaces have been handed over to
+			// the transferred route
+			if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedSpacesFinalizerName) {
+				log.V(1).Info("shared spaces finalizer removed")
+			}
+		}
+
+		if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteTransferFinalizerName) {
+			log.V(1).Info("transfer finalizer removed")
+		}
+	}
+
+	if controllerutil.ContainsFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedSpacesFinalizerName) {
+		err := r.deleteSharedSpacesResources(ctx, cfRoute)
+		if err != nil {
+			return err
+		}
+
+		if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedSpacesFinalizerName) {
+			log.V(1).Info("shared spaces finalizer removed")
+		}
 	}
 
 	if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName) {
@@ -174,6 +236,135 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 	return nil
 }
 
+// transferCFRoute recreates a route that is being transferred in its new
+// space. The services of the original route are handed over to the
+// transferred route, and the original route is only let go once the
+// transferred route is ready, so that the route keeps serving traffic
+// throughout the transfer. Every step is idempotent, so a failed transfer is
+// resumed on the next reconcile.
+func (r *Reconciler) transferCFRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("transferCFRoute").WithValues("newNamespace", cfRoute.Spec.TransferTo)
+
+	err := r.releaseServices(ctx, cfRoute)
+	if err != nil {
+		return k8s.NewNotReadyError().WithCause(err).WithReason("TransferFailed")
+	}
+
+	transferredRoute := cfRoute.Transferred()
+	err = r.client.Create(ctx, &transferredRoute)
+	if k8serrors.IsAlreadyExists(err) {
+		err = r.client.Get(ctx, client.ObjectKeyFromObject(&transferredRoute), &transferredRoute)
+	}
+	if err != nil {
+		log.Info("failed to create the transferred route", "reason", err)
+		return k8s.NewNotReadyError().WithCause(err).WithReason("TransferFailed")
+	}
+
+	if transferredRoute.Status.ObservedGeneration != transferredRoute.Generation ||
+		!meta.IsStatusConditionTrue(transferredRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
+		return k8s.NewNotReadyError().
+			WithReason("TransferInProgress").
+			WithMessage(fmt.Sprintf("Waiting for the route in space %q to become ready", cfRoute.Spec.TransferTo)).
+			WithRequeueAfter(time.Second)
+	}
+
+	// the transferred route does not need a reference grant in its own
+	// namespace
+	err = r.client.Delete(ctx, &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Spec.TransferTo,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete reference grant", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("route transferred")
+
+	return nil
+}
+
+// releaseServices removes the route owner reference from its services, so
+// that they are not garbage collected along with the route and can be taken
+// over by the transferred route
+func (r *Reconciler) releaseServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("releaseServices")
+
+	serviceList, err := r.fetchServicesByMatchingLabels(ctx, map[string]string{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name}, cfRoute.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for i := range serviceList.Items {
+		service := &serviceList.Items[i]
+		if !metav1.IsControlledBy(service, cfRoute) {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, r.client, service, func() {
+			service.OwnerReferences = slices.DeleteFunc(service.OwnerReferences, func(ref metav1.OwnerReference) bool {
+				return ref.UID == cfRoute.UID
+			})
+		})
+		if err != nil {
+			log.Info("failed to release service", "service", service.Name, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteSharedSpacesResources deletes the services and reference grants the
+// route owns in other namespaces, as those cannot be garbage collected
+// through owner references
+func (r *Reconciler) deleteSharedSpacesResources(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteSharedSpacesResources")
+
+	sharedSpacesLabels := client.MatchingLabels{
+		korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+		korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
+	}
+
+	serviceList := corev1.ServiceList{}
+	err := r.client.List(ctx, &serviceList, sharedSpacesLabels)
+	if err != nil {
+		log.Info("failed to list services", "reason", err)
+		return err
+	}
+
+	grantList := gatewayv1beta1.ReferenceGrantList{}
+	err = r.client.List(ctx, &grantList, sharedSpacesLabels)
+	if err != nil {
+		log.Info("failed to list reference grants", "reason", err)
+		return err
+	}
+
+	for i := range serviceList.Items {
+		if serviceList.Items[i].Namespace == cfRoute.Namespace {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &serviceList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete service", "service", serviceList.Items[i].Name, "reason", err)
+			return err
+		}
+	}
+
+	for i := range grantList.Items {
+		err = r.client.Delete(ctx, &grantList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete reference grant", "namespace", grantList.Items[i].Namespace, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
 
@@ -188,24 +379,34 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 		service := &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
+				Namespace: cfRoute.DestinationNamespace(destination),
 			},
 		}
 
 		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
 			service.Labels = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
-				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+				korifiv1alpha1.CFAppGUIDLabelKey:        destination.AppRef.Name,
+				korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+				korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
 			}
 
-			err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
-			if err != nil {
-				loopLog.Info("failed to set OwnerRef on Service", "reason", err)
-				return err
+			if service.Namespace != cfRoute.Namespace {
+				// owner references cannot cross namespaces, services in
+				// shared spaces are cleaned up by the route instead
+				if owner := metav1.GetControllerOf(service); owner != nil {
+					return fmt.Errorf("service %s/%s is controlled by %s %s", service.Namespace, service.Name, owner.Kind, owner.Name)
+				}
+			} else {
+				err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+				if err != nil {
+					loopLog.Info("failed to set OwnerRef on Service", "reason", err)
+					return err
+				}
 			}
 
 			service.Spec.Ports = []corev1.ServicePort{{
-				Port: int32(*destination.Port),
+				Port:        int32(*destination.Port),
+				AppProtocol: toAppProtocol(destination.Protocol),
 			}}
 
 			service.Spec.Selector = map[string]string{
@@ -237,7 +438,7 @@ func (r *Reconciler) buildEffectiveDestinations(ctx context.Context, cfRoute *ko
 		}
 
 		if effectiveDest.Port == nil {
-			droplet, err := r.getAppCurrentDroplet(ctx, cfRoute.Namespace, dest.AppRef.Name)
+			droplet, err := r.getAppCurrentDroplet(ctx, cfRoute.DestinationNamespace(dest), dest.AppRef.Name)
 			if err != nil {
 				return []korifiv1alpha1.Destination{}, err
 			}
@@ -321,7 +522,9 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: toBackendRefs(cfRoute),
+			Filters:     toFilters(cfRoute.Spec.Options),
+			Timeouts:    toTimeouts(cfRoute.Spec.Options),
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
@@ -343,6 +546,121 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
+// reconcileReferenceGrants allows the route HTTPRoute to reference the
+// destination services in the route shared spaces
+func (r *Reconciler) reconcileReferenceGrants(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileReferenceGrants")
+
+	grantNamespaces := map[string]bool{}
+	for _, destination := range cfRoute.Status.Destinations {
+		if namespace := cfRoute.DestinationNamespace(destination); namespace != cfRoute.Namespace {
+			grantNamespaces[namespace] = true
+		}
+	}
+
+	for _, namespace := range slices.Sorted(maps.Keys(grantNamespaces)) {
+		grant := &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfRoute.Name,
+				Namespace: namespace,
+			},
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.client, grant, func() error {
+			grant.Labels = map[string]string{
+				korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+				korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
+			}
+
+			grant.Spec.From = []gatewayv1beta1.ReferenceGrantFrom{{
+				Group:     gatewayv1beta1.GroupName,
+				Kind:      "HTTPRoute",
+				Namespace: gatewayv1beta1.Namespace(cfRoute.Namespace),
+			}}
+			grant.Spec.To = []gatewayv1beta1.ReferenceGrantTo{{
+				Group: "",
+				Kind:  "Service",
+			}}
+
+			return nil
+		})
+		if err != nil {
+			log.Info("failed to create/patch ReferenceGrant", "namespace", namespace, "reason", err)
+			return err
+		}
+
+		log.V(1).Info("ReferenceGrant reconciled", "namespace", namespace, "operation", result)
+	}
+
+	grantList := gatewayv1beta1.ReferenceGrantList{}
+	err := r.client.List(ctx, &grantList, client.MatchingLabels{
+		korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+		korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
+	})
+	if err != nil {
+		log.Info("failed to list ReferenceGrants", "reason", err)
+		return err
+	}
+
+	for i, grant := range grantList.Items {
+		if grantNamespaces[grant.Namespace] {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &grantList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete orphaned ReferenceGrant", "namespace", grant.Namespace, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileBackendPolicy(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	if r.controllerConfig.Networking.BackendPolicy != config.EnvoyGatewayBackendPolicy {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileBackendPolicy")
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(envoyBackendTrafficPolicyGVK)
+	policy.SetNamespace(cfRoute.Namespace)
+	policy.SetName(cfRoute.Name)
+
+	if len(cfRoute.Status.Destinations) == 0 || cfRoute.Spec.Options == nil || cfRoute.Spec.Options.LoadBalancing == "" {
+		err := r.client.Delete(ctx, policy)
+		if client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			log.Info("failed to delete BackendTrafficPolicy", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, policy, func() error {
+		policy.Object["spec"] = map[string]any{
+			"targetRefs": []any{map[string]any{
+				"group": "gateway.networking.k8s.io",
+				"kind":  "HTTPRoute",
+				"name":  cfRoute.Name,
+			}},
+			"loadBalancer": map[string]any{
+				"type": envoyLoadBalancerTypes[cfRoute.Spec.Options.LoadBalancing],
+			},
+		}
+
+		return controllerutil.SetControllerReference(cfRoute, policy, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch BackendTrafficPolicy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("BackendTrafficPolicy reconciled", "operation", result)
+	return nil
+}
+
 func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
@@ -350,18 +668,22 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
 	}
 
-	serviceList, err := r.fetchServicesByMatchingLabels(ctx, matchingLabelSet, cfRoute.Namespace)
+	serviceList, err := r.fetchServicesByMatchingLabels(ctx, matchingLabelSet, "")
 	if err != nil {
 		log.Info("failed to fetch services using label", "label", korifiv1alpha1.CFRouteGUIDLabelKey, "value", cfRoute.Name, "reason", err)
 		return err
 	}
 
 	for i, service := range serviceList.Items {
-		loopLog := log.WithValues("serviceName", service.Name)
+		loopLog := log.WithValues("serviceName", service.Name, "serviceNamespace", service.Namespace)
+
+		if service.Namespace != cfRoute.Namespace && service.Labels[korifiv1alpha1.CFRouteNamespaceLabelKey] != cfRoute.Namespace {
+			continue
+		}
 
 		isOrphan := true
 		for _, destination := range cfRoute.Status.Destinations {
-			if service.Name == generateServiceName(destination) {
+			if service.Name == generateServiceName(destination) && service.Namespace == cfRoute.DestinationNamespace(destination) {
 				isOrphan = false
 				break
 			}
@@ -402,15 +724,25 @@ func generateServiceName(destination korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
 
+// toAppProtocol tells the gateway which protocol to use when talking to the
+// destination, see https://gateway-api.sigs.k8s.io/geps/gep-1911
+func toAppProtocol(protocol *string) *string {
+	if protocol != nil && *protocol == "http2" {
+		return tools.PtrTo("kubernetes.io/h2c")
+	}
+
+	return nil
+}
+
 func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) string {
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
+func toBackendRefs(cfRoute *korifiv1alpha1.CFRoute) []gatewayv1beta1.HTTPBackendRef {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
-	for _, destination := range destinations {
-		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
+	for _, destination := range cfRoute.Status.Destinations {
+		backendRef := gatewayv1beta1.HTTPBackendRef{
 			BackendRef: gatewayv1beta1.BackendRef{
 				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
 					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
@@ -418,8 +750,56 @@ func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.H
 					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
 				},
 			},
-		})
+		}
+
+		if namespace := cfRoute.DestinationNamespace(destination); namespace != cfRoute.Namespace {
+			backendRef.Namespace = tools.PtrTo(gatewayv1beta1.Namespace(namespace))
+		}
+
+		backendRefs = append(backendRefs, backendRef)
 	}
 
 	return backendRefs
 }
+
+func toFilters(options *korifiv1alpha1.RouteOptions) []gatewayv1beta1.HTTPRouteFilter {
+	if options == nil || options.RequestHeaders == nil {
+		return nil
+	}
+
+	headers := options.RequestHeaders
+	if len(headers.Set) == 0 && len(headers.Add) == 0 && len(headers.Remove) == 0 {
+		return nil
+	}
+
+	return []gatewayv1beta1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set:    toHTTPHeaders(headers.Set),
+			Add:    toHTTPHeaders(headers.Add),
+			Remove: headers.Remove,
+		},
+	}}
+}
+
+func toHTTPHeaders(headers map[string]string) []gatewayv1.HTTPHeader {
+	httpHeaders := []gatewayv1.HTTPHeader{}
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		httpHeaders = append(httpHeaders, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: headers[name],
+		})
+	}
+
+	return httpHeaders
+}
+
+func toTimeouts(options *korifiv1alpha1.RouteOptions) *gatewayv1.HTTPRouteTimeouts {
+	if options == nil || options.Timeout == "" {
+		return nil
+	}
+
+	return &gatewayv1.HTTPRouteTimeouts{
+		Request: tools.PtrTo(gatewayv1.Duration(options.Timeout)),
+	}
+}
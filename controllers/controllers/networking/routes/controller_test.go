@@ -176,6 +176,61 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			}))
 		})
 
+		It("does not set timeouts or filters on the HTTPRoute", func() {
+			httpRoute := getHTTPRoute()
+
+			Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+			Expect(httpRoute.Spec.Rules[0].Timeouts).To(BeNil())
+			Expect(httpRoute.Spec.Rules[0].Filters).To(BeEmpty())
+		})
+
+		When("the route has options", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing: "least-connection",
+					Timeout:       "5m",
+					RequestHeaders: &korifiv1alpha1.HeaderModifier{
+						Set:    map[string]string{"X-Set-B": "b", "X-Set-A": "a"},
+						Add:    map[string]string{"X-Add": "add"},
+						Remove: []string{"X-Remove"},
+					},
+				}
+			})
+
+			It("sets the request timeout on the HTTPRoute", func() {
+				httpRoute := getHTTPRoute()
+
+				Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].Timeouts).To(PointTo(Equal(gatewayv1.HTTPRouteTimeouts{
+					Request: tools.PtrTo(gatewayv1.Duration("5m")),
+				})))
+			})
+
+			It("adds a request header modifier filter to the HTTPRoute", func() {
+				httpRoute := getHTTPRoute()
+
+				Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].Filters).To(ConsistOf(gatewayv1.HTTPRouteFilter{
+					Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{
+							{Name: "X-Set-A", Value: "a"},
+							{Name: "X-Set-B", Value: "b"},
+						},
+						Add:    []gatewayv1.HTTPHeader{{Name: "X-Add", Value: "add"}},
+						Remove: []string{"X-Remove"},
+					},
+				}))
+			})
+
+			It("ignores the loadbalancing option as no backend policy is configured", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
 		When("the route's path contains upper case characters", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Path = "/Hello"
@@ -233,6 +288,24 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		When("the destination protocol is http2", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("sets the h2c app protocol on the service port", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Port":        BeEquivalentTo(80),
+						"AppProtocol": PointTo(Equal("kubernetes.io/h2c")),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		It("sets effective destinations to the cfroute status", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
@@ -386,6 +459,161 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the destination app is in a shared space", func() {
+			var sharedNs *corev1.Namespace
+
+			BeforeEach(func() {
+				sharedNs = &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedNs)).To(Succeed())
+
+				sharedApp := &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: sharedNs.Name,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+						DesiredState: "STARTED",
+						DisplayName:  uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedApp)).To(Succeed())
+
+				cfRoute.Spec.SharedSpaces = []string{sharedNs.Name}
+				cfRoute.Spec.Destinations[0].AppRef.Name = sharedApp.Name
+				cfRoute.Spec.Destinations[0].AppNamespace = sharedNs.Name
+			})
+
+			It("creates the destination service in the shared space", func() {
+				Eventually(func(g Gomega) {
+					svc := new(corev1.Service)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{
+						Name:      "s-" + cfRoute.Spec.Destinations[0].GUID,
+						Namespace: sharedNs.Name,
+					}, svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteNamespaceLabelKey, ns.Name))
+					g.Expect(svc.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("creates a reference grant in the shared space", func() {
+				Eventually(func(g Gomega) {
+					grant := new(gatewayv1beta1.ReferenceGrant)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfRoute.Name, Namespace: sharedNs.Name}, grant)).To(Succeed())
+					g.Expect(grant.Spec.From).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Kind":      BeEquivalentTo("HTTPRoute"),
+						"Namespace": BeEquivalentTo(ns.Name),
+					})))
+					g.Expect(grant.Spec.To).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Kind": BeEquivalentTo("Service"),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("references the service namespace in the HTTPRoute backend", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute()
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Namespace": PointTo(BeEquivalentTo(sharedNs.Name)),
+							}),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+						g.Expect(cfRoute.Finalizers).To(ContainElement(korifiv1alpha1.CFRouteSharedSpacesFinalizerName))
+					}).Should(Succeed())
+					Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the resources in the shared space", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: cfRoute.Name, Namespace: sharedNs.Name}, new(gatewayv1beta1.ReferenceGrant))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+						services := &corev1.ServiceList{}
+						g.Expect(adminClient.List(ctx, services, client.InNamespace(sharedNs.Name))).To(Succeed())
+						g.Expect(services.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the route is transferred to another space", func() {
+			var newNs *corev1.Namespace
+
+			BeforeEach(func() {
+				newNs = &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, newNs)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+					cfRoute.Spec.TransferTo = newNs.Name
+					cfRoute.Finalizers = append(cfRoute.Finalizers, korifiv1alpha1.CFRouteTransferFinalizerName)
+				})).To(Succeed())
+				Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+			})
+
+			It("recreates the route in the new space, shared with the original space", func() {
+				Eventually(func(g Gomega) {
+					transferredRoute := new(korifiv1alpha1.CFRoute)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfRoute.Name, Namespace: newNs.Name}, transferredRoute)).To(Succeed())
+					g.Expect(transferredRoute.Spec.TransferTo).To(BeEmpty())
+					g.Expect(transferredRoute.Spec.SharedSpaces).To(ConsistOf(ns.Name))
+					g.Expect(transferredRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":         Equal(cfRoute.Spec.Destinations[0].GUID),
+						"AppNamespace": Equal(ns.Name),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("hands the destination service over to the transferred route", func() {
+				Eventually(func(g Gomega) {
+					svc := new(corev1.Service)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{
+						Name:      "s-" + cfRoute.Spec.Destinations[0].GUID,
+						Namespace: ns.Name,
+					}, svc)).To(Succeed())
+					g.Expect(svc.OwnerReferences).To(BeEmpty())
+					g.Expect(svc.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteNamespaceLabelKey, newNs.Name))
+				}).Should(Succeed())
+			})
+
+			It("deletes the original route once the transferred route is ready", func() {
+				Eventually(func(g Gomega) {
+					transferredRoute := new(korifiv1alpha1.CFRoute)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfRoute.Name, Namespace: newNs.Name}, transferredRoute)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(transferredRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), new(korifiv1alpha1.CFRoute))
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
 		When("the destinations are deleted from the route", func() {
 			var (
 				httpRoute   *gatewayv1beta1.HTTPRoute
@@ -571,6 +571,88 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the broker returns volume mounts", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, serviceOffering, func() {
+					serviceOffering.Spec.Requires = []string{"volume_mount"}
+				})).To(Succeed())
+
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{"foo": "bar"},
+					VolumeMounts: []osbapi.VolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.VolumeMountDevice{
+							VolumeID: "volume-id",
+							MountConfig: map[string]any{
+								"source": "nfs://nfs.example.com/export",
+								"uid":    1000,
+							},
+						},
+					}},
+				}, nil)
+			})
+
+			It("sets the volume mounts on the binding status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.VolumeMounts).To(ConsistOf(korifiv1alpha1.ServiceBindingVolumeMount{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						VolumeID:     "volume-id",
+						MountConfig: map[string]string{
+							"source": "nfs://nfs.example.com/export",
+							"uid":    "1000",
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			When("the volume mount is invalid", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{
+						VolumeMounts: []osbapi.VolumeMount{{
+							Driver:       "nfs",
+							ContainerDir: "relative/dir",
+							Mode:         "rw",
+							DeviceType:   "shared",
+						}},
+					}, nil)
+				})
+
+				It("fails the binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("InvalidVolumeMounts")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the service offering does not require volume mounts", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, serviceOffering, func() {
+						serviceOffering.Spec.Requires = nil
+					})).To(Succeed())
+				})
+
+				It("ignores the volume mounts", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.EnvSecretRef.Name).NotTo(BeEmpty())
+						g.Expect(binding.Status.VolumeMounts).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the credentials contain type key", func() {
 			BeforeEach(func() {
 				brokerClient.BindReturns(osbapi.BindResponse{
@@ -2,7 +2,10 @@ package managed
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
@@ -82,6 +85,20 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return r.processBindOperation(cfServiceBinding, lastOpResponse)
 	}
 
+	cfServiceBinding.Status.VolumeMounts, err = toVolumeMounts(assets.ServiceOffering, bindResponse.VolumeMounts)
+	if err != nil {
+		log.Error(err, "invalid volume mounts")
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.BindingFailedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cfServiceBinding.Generation,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+			Reason:             "InvalidVolumeMounts",
+			Message:            err.Error(),
+		})
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("InvalidVolumeMounts").WithNoRequeue()
+	}
+
 	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, bindResponse.Credentials)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -395,6 +412,68 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 	return unbindResponse, nil
 }
 
+// toVolumeMounts converts the volume mounts returned by the broker. Brokers
+// are only allowed to return volume mounts for offerings that require
+// `volume_mount`, any other volume mounts are ignored.
+func toVolumeMounts(serviceOffering *korifiv1alpha1.CFServiceOffering, volumeMounts []osbapi.VolumeMount) ([]korifiv1alpha1.ServiceBindingVolumeMount, error) {
+	if !slices.Contains(serviceOffering.Spec.Requires, osbapi.VolumeMountRequirement) {
+		return nil, nil
+	}
+
+	var result []korifiv1alpha1.ServiceBindingVolumeMount
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.Mode != korifiv1alpha1.VolumeMountModeReadOnly && volumeMount.Mode != korifiv1alpha1.VolumeMountModeReadWrite {
+			return nil, fmt.Errorf("volume mount %q has unsupported mode %q", volumeMount.ContainerDir, volumeMount.Mode)
+		}
+
+		if volumeMount.DeviceType != osbapi.SharedDeviceType {
+			return nil, fmt.Errorf("volume mount %q has unsupported device type %q", volumeMount.ContainerDir, volumeMount.DeviceType)
+		}
+
+		if !filepath.IsAbs(volumeMount.ContainerDir) {
+			return nil, fmt.Errorf("volume mount container dir %q is not an absolute path", volumeMount.ContainerDir)
+		}
+
+		mountConfig, err := toMountConfig(volumeMount.Device.MountConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, korifiv1alpha1.ServiceBindingVolumeMount{
+			Driver:       volumeMount.Driver,
+			ContainerDir: volumeMount.ContainerDir,
+			Mode:         volumeMount.Mode,
+			DeviceType:   volumeMount.DeviceType,
+			VolumeID:     volumeMount.Device.VolumeID,
+			MountConfig:  mountConfig,
+		})
+	}
+
+	return result, nil
+}
+
+func toMountConfig(mountConfig map[string]any) (map[string]string, error) {
+	if len(mountConfig) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]string{}
+	for key, value := range mountConfig {
+		if stringValue, ok := value.(string); ok {
+			result[key] = stringValue
+			continue
+		}
+
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal mount config %q: %w", key, err)
+		}
+		result[key] = string(jsonValue)
+	}
+
+	return result, nil
+}
+
 func isFailed(binding *korifiv1alpha1.CFServiceBinding) bool {
 	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition)
 }
@@ -540,6 +540,46 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("the broker returns volume mounts", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"credentials": map[string]string{},
+							"volume_mounts": []map[string]any{{
+								"driver":        "nfs",
+								"container_dir": "/data",
+								"mode":          "rw",
+								"device_type":   "shared",
+								"device": map[string]any{
+									"volume_id": "volume-id",
+									"mount_config": map[string]any{
+										"source": "nfs://nfs.example.com/export",
+									},
+								},
+							}},
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns the volume mounts", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp.VolumeMounts).To(ConsistOf(osbapi.VolumeMount{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.VolumeMountDevice{
+							VolumeID: "volume-id",
+							MountConfig: map[string]any{
+								"source": "nfs://nfs.example.com/export",
+							},
+						},
+					}))
+				})
+			})
+
 			When("bind is asynchronous", func() {
 				BeforeEach(func() {
 					brokerServer.WithResponse(
@@ -135,9 +135,28 @@ type BindPayload struct {
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	IsAsync     bool
+	Credentials  map[string]any `json:"credentials"`
+	VolumeMounts []VolumeMount  `json:"volume_mounts"`
+	Operation    string         `json:"operation"`
+	IsAsync      bool
+}
+
+const (
+	VolumeMountRequirement = "volume_mount"
+	SharedDeviceType       = "shared"
+)
+
+type VolumeMount struct {
+	Driver       string            `json:"driver"`
+	ContainerDir string            `json:"container_dir"`
+	Mode         string            `json:"mode"`
+	DeviceType   string            `json:"device_type"`
+	Device       VolumeMountDevice `json:"device"`
+}
+
+type VolumeMountDevice struct {
+	VolumeID    string         `json:"volume_id"`
+	MountConfig map[string]any `json:"mount_config"`
 }
 
 type BindingResponse struct {
@@ -111,6 +111,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 	log.V(1).Info("set observed generation", "generation", cfApp.Status.ObservedGeneration)
 
 	cfApp.Status.ActualState = korifiv1alpha1.StoppedState
+	cfApp.Status.DeployedRevisionName = ""
 
 	if !cfApp.GetDeletionTimestamp().IsZero() {
 		return r.finalizeCFApp(ctx, cfApp)
@@ -159,7 +160,19 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, err
 	}
 
+	var revision *korifiv1alpha1.CFAppRevision
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState && revisionsEnabled(cfApp) {
+		revision, err = r.reconcileRevision(ctx, cfApp, reconciledProcesses)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	cfApp.Status.ActualState = getActualState(reconciledProcesses)
+	if cfApp.Status.ActualState == korifiv1alpha1.StartedState && revision != nil {
+		cfApp.Status.DeployedRevisionName = revision.Name
+	}
+
 	if cfApp.Status.ActualState != cfApp.Spec.DesiredState {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DesiredStateNotReached")
 	}
@@ -205,9 +218,10 @@ func bindingObjectRefs(bindings []korifiv1alpha1.CFServiceBinding) []korifiv1alp
 		}
 
 		return korifiv1alpha1.ServiceBinding{
-			GUID:   binding.Name,
-			Name:   bindingName,
-			Secret: binding.Status.MountSecretRef.Name,
+			GUID:         binding.Name,
+			Name:         bindingName,
+			Secret:       binding.Status.MountSecretRef.Name,
+			VolumeMounts: binding.Status.VolumeMounts,
 		}
 	}))
 }
@@ -377,7 +391,7 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 }
 
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
-	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
+	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name)
 	if err != nil {
 		return err
 	}
@@ -443,12 +457,12 @@ func (r *Reconciler) updateRouteDestinations(ctx context.Context, cfAppGUID stri
 	return nil
 }
 
-func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string, cfAppNamespace string) ([]korifiv1alpha1.CFRoute, error) {
+func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string) ([]korifiv1alpha1.CFRoute, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getCFRoutes")
 
 	var foundRoutes korifiv1alpha1.CFRouteList
 	matchingFields := client.MatchingFields{shared.IndexRouteDestinationAppName: cfAppGUID}
-	err := r.k8sClient.List(context.Background(), &foundRoutes, client.InNamespace(cfAppNamespace), matchingFields)
+	err := r.k8sClient.List(context.Background(), &foundRoutes, matchingFields)
 	if err != nil {
 		log.Info("failed to List CFRoutes", "reason", err)
 		return []korifiv1alpha1.CFRoute{}, err
@@ -1,6 +1,8 @@
 package apps_test
 
 import (
+	"strconv"
+
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -505,6 +507,235 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("revisions", func() {
+		listRevisions := func(g Gomega) []korifiv1alpha1.CFAppRevision {
+			revisions := &korifiv1alpha1.CFAppRevisionList{}
+			g.Expect(adminClient.List(ctx, revisions,
+				client.InNamespace(testNamespace),
+				client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+			)).To(Succeed())
+
+			return revisions.Items
+		}
+
+		It("does not record revisions for a stopped app", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(listRevisions(g)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the app is started", func() {
+			var envSecret *corev1.Secret
+
+			BeforeEach(func() {
+				envSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Data: map[string][]byte{"FOO": []byte("bar")},
+				}
+				Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+					defaultWebProcess.Spec.Command = "run-web"
+					defaultWebProcess.Status.ActualInstances = 1
+				})).To(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.EnvSecretName = envSecret.Name
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+			})
+
+			It("records an initial revision", func() {
+				Eventually(func(g Gomega) {
+					revisions := listRevisions(g)
+					g.Expect(revisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Name":   Equal(tools.NamespacedUUID(cfApp.Name, "1")),
+							"Labels": HaveKeyWithValue(korifiv1alpha1.CFAppRevisionVersionLabelKey, "1"),
+						}),
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"AppRef":      Equal(corev1.LocalObjectReference{Name: cfApp.Name}),
+							"Version":     Equal(1),
+							"DropletRef":  Equal(corev1.LocalObjectReference{Name: cfBuild.Name}),
+							"Description": Equal("Initial revision."),
+							"Processes": ConsistOf(korifiv1alpha1.CFAppRevisionProcess{
+								Type:    "web",
+								Command: "run-web",
+							}),
+						}),
+					})))
+
+					revisionEnvSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{
+						Namespace: testNamespace,
+						Name:      revisions[0].Spec.EnvSecretName,
+					}, revisionEnvSecret)).To(Succeed())
+					g.Expect(revisionEnvSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+				}).Should(Succeed())
+			})
+
+			It("sets the deployed revision in the app status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Status.DeployedRevisionName).To(Equal(tools.NamespacedUUID(cfApp.Name, "1")))
+				}).Should(Succeed())
+			})
+
+			When("the app environment changes", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+						envSecret.Data = map[string][]byte{"FOO": []byte("baz")}
+					})).To(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+					})).To(Succeed())
+				})
+
+				It("records a new revision", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":     Equal(2),
+								"Description": Equal("New environment variables deployed."),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+
+				When("the app is rolled back", func() {
+					BeforeEach(func() {
+						Eventually(func(g Gomega) {
+							g.Expect(listRevisions(g)).To(HaveLen(2))
+						}).Should(Succeed())
+
+						Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+							defaultWebProcess.Spec.Command = "run-something-else"
+						})).To(Succeed())
+
+						Eventually(func(g Gomega) {
+							g.Expect(listRevisions(g)).To(HaveLen(3))
+						}).Should(Succeed())
+
+						Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "44"
+							cfApp.Annotations[korifiv1alpha1.CFAppRollbackVersionKey] = "1"
+						})).To(Succeed())
+					})
+
+					It("restores the environment variables of the revision", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(envSecret), envSecret)).To(Succeed())
+							g.Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+						}).Should(Succeed())
+					})
+
+					It("restores the process commands of the revision", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(defaultWebProcess), defaultWebProcess)).To(Succeed())
+							g.Expect(defaultWebProcess.Spec.Command).To(Equal("run-web"))
+						}).Should(Succeed())
+					})
+
+					It("records a single rollback revision", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(listRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"Version":     Equal(4),
+									"Description": Equal("Rolled back to revision 1."),
+								}),
+							})))
+						}).Should(Succeed())
+
+						Consistently(func(g Gomega) {
+							g.Expect(listRevisions(g)).To(HaveLen(4))
+						}).Should(Succeed())
+					})
+
+					It("removes the rollback annotation", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+							g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.CFAppRollbackVersionKey))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the app has as many revisions as are retained", func() {
+				var firstRevision korifiv1alpha1.CFAppRevision
+
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						revisions := listRevisions(g)
+						g.Expect(revisions).To(HaveLen(1))
+						firstRevision = revisions[0]
+					}).Should(Succeed())
+
+					for version := 2; version <= 100; version++ {
+						Expect(adminClient.Create(ctx, &korifiv1alpha1.CFAppRevision{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      tools.NamespacedUUID(cfApp.Name, strconv.Itoa(version)),
+								Namespace: testNamespace,
+								Labels: map[string]string{
+									korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+								},
+							},
+							Spec: korifiv1alpha1.CFAppRevisionSpec{
+								AppRef:     corev1.LocalObjectReference{Name: cfApp.Name},
+								Version:    version,
+								DropletRef: corev1.LocalObjectReference{Name: cfBuild.Name},
+								Processes:  firstRevision.Spec.Processes,
+							},
+						})).To(Succeed())
+					}
+
+					Expect(k8s.Patch(ctx, adminClient, envSecret, func() {
+						envSecret.Data = map[string][]byte{"FOO": []byte("baz")}
+					})).To(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+					})).To(Succeed())
+				})
+
+				It("deletes the oldest revision together with its env secret", func() {
+					Eventually(func(g Gomega) {
+						revisions := listRevisions(g)
+						g.Expect(revisions).To(HaveLen(100))
+						g.Expect(revisions).NotTo(ContainElement(HaveField("Name", firstRevision.Name)))
+						g.Expect(revisions).To(ContainElement(HaveField("Spec.Version", 101)))
+
+						err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: firstRevision.Spec.EnvSecretName}, &corev1.Secret{})
+						g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+
+		})
+
+		When("the app is started with the revisions feature disabled", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					cfApp.Spec.Features.Revisions = tools.PtrTo(false)
+				})).To(Succeed())
+			})
+
+			It("does not record revisions", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			cfDomainGUID string
@@ -0,0 +1,337 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapprevisions,verbs=get;list;watch;create;patch;delete
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+
+// maxRetainedRevisions matches the number of revisions the cloud controller
+// keeps per app by default
+const maxRetainedRevisions = 100
+
+func revisionsEnabled(cfApp *korifiv1alpha1.CFApp) bool {
+	return cfApp.Spec.Features.Revisions == nil || *cfApp.Spec.Features.Revisions
+}
+
+// reconcileRevision returns the revision matching the current droplet,
+// environment variables and process commands of the app, recording a new one
+// if any of them has changed since the latest revision. When the app is being
+// rolled back, the environment variables and process commands of the target
+// revision are restored first, so that the rollback is recorded as a single
+// revision.
+func (r *Reconciler) reconcileRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) (*korifiv1alpha1.CFAppRevision, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRevision")
+
+	rollbackVersion := cfApp.Annotations[korifiv1alpha1.CFAppRollbackVersionKey]
+
+	revisions := &korifiv1alpha1.CFAppRevisionList{}
+	err := r.k8sClient.List(ctx, revisions,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		log.Info("failed to list app revisions", "reason", err)
+		return nil, err
+	}
+
+	envVars, err := r.getSecretData(ctx, cfApp.Namespace, cfApp.Spec.EnvSecretName)
+	if err != nil {
+		log.Info("failed to get app env secret", "reason", err)
+		return nil, err
+	}
+
+	if rollbackVersion != "" {
+		envVars, err = r.restoreRevision(ctx, cfApp, revisions.Items, rollbackVersion, envVars, processes)
+		if err != nil {
+			log.Info("failed to restore revision", "version", rollbackVersion, "reason", err)
+			return nil, err
+		}
+	}
+	delete(cfApp.Annotations, korifiv1alpha1.CFAppRollbackVersionKey)
+
+	revisionProcesses := toRevisionProcesses(processes)
+
+	version := 1
+	changes := []string{"Initial revision."}
+	latest := latestRevision(revisions.Items)
+	if latest != nil {
+		var latestEnvVars map[string][]byte
+		latestEnvVars, err = r.getSecretData(ctx, cfApp.Namespace, latest.Spec.EnvSecretName)
+		if err != nil {
+			log.Info("failed to get revision env secret", "reason", err)
+			return nil, err
+		}
+
+		changes = describeChanges(latest, cfApp.Spec.CurrentDropletRef.Name, latestEnvVars, envVars, revisionProcesses)
+		if len(changes) == 0 {
+			return latest, nil
+		}
+		version = latest.Spec.Version + 1
+	}
+
+	if rollbackVersion != "" {
+		changes = []string{fmt.Sprintf("Rolled back to revision %s.", rollbackVersion)}
+	}
+
+	revisionName := tools.NamespacedUUID(cfApp.Name, strconv.Itoa(version))
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName + "-env",
+			Namespace: cfApp.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		envSecret.Data = envVars
+
+		return controllerutil.SetControllerReference(cfApp, envSecret, r.scheme)
+	})
+	if err != nil {
+		log.Info("unable to create or patch revision env Secret", "reason", err)
+		return nil, err
+	}
+
+	revision := &korifiv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName,
+			Namespace: cfApp.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, revision, func() error {
+		revision.Labels = tools.SetMapValue(revision.Labels, korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name)
+		revision.Labels = tools.SetMapValue(revision.Labels, korifiv1alpha1.CFAppRevisionVersionLabelKey, strconv.Itoa(version))
+		revision.Spec = korifiv1alpha1.CFAppRevisionSpec{
+			AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:       version,
+			DropletRef:    cfApp.Spec.CurrentDropletRef,
+			EnvSecretName: envSecret.Name,
+			Processes:     revisionProcesses,
+			Description:   strings.Join(changes, " "),
+		}
+
+		return controllerutil.SetControllerReference(cfApp, revision, r.scheme)
+	})
+	if err != nil {
+		log.Info("unable to create or patch CFAppRevision", "reason", err)
+		return nil, err
+	}
+
+	log.V(1).Info("recorded app revision", "revision", revision.Name, "version", version)
+
+	err = r.pruneRevisions(ctx, revisions.Items, version)
+	if err != nil {
+		log.Info("failed to prune old revisions", "reason", err)
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// restoreRevision sets the app environment variables and process commands
+// back to the ones recorded in the revision with the given version, returning
+// the restored environment variables
+func (r *Reconciler) restoreRevision(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	revisions []korifiv1alpha1.CFAppRevision,
+	version string,
+	envVars map[string][]byte,
+	processes []*korifiv1alpha1.CFProcess,
+) (map[string][]byte, error) {
+	idx := slices.IndexFunc(revisions, func(rev korifiv1alpha1.CFAppRevision) bool {
+		return strconv.Itoa(rev.Spec.Version) == version
+	})
+	if idx < 0 {
+		logr.FromContextOrDiscard(ctx).Info("rollback revision no longer exists, recording the current state", "version", version)
+		return envVars, nil
+	}
+	revision := revisions[idx]
+
+	commands := map[string]string{}
+	for _, p := range revision.Spec.Processes {
+		commands[p.Type] = p.Command
+	}
+
+	for _, process := range processes {
+		command, ok := commands[process.Spec.ProcessType]
+		if !ok || command == process.Spec.Command {
+			continue
+		}
+
+		err := k8s.Patch(ctx, r.k8sClient, process, func() {
+			process.Spec.Command = command
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfApp.Spec.EnvSecretName == "" {
+		return envVars, nil
+	}
+
+	revisionEnvVars, err := r.getSecretData(ctx, cfApp.Namespace, revision.Spec.EnvSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	if maps.EqualFunc(envVars, revisionEnvVars, bytes.Equal) {
+		return envVars, nil
+	}
+
+	envSecret := &corev1.Secret{}
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = k8s.Patch(ctx, r.k8sClient, envSecret, func() {
+		envSecret.Data = revisionEnvVars
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revisionEnvVars, nil
+}
+
+// pruneRevisions deletes the oldest revisions of the app, together with their
+// environment variable snapshots, so that at most maxRetainedRevisions remain
+// once the revision with the given version has been recorded
+func (r *Reconciler) pruneRevisions(ctx context.Context, revisions []korifiv1alpha1.CFAppRevision, latestVersion int) error {
+	oldRevisions := slices.DeleteFunc(slices.Clone(revisions), func(rev korifiv1alpha1.CFAppRevision) bool {
+		return rev.Spec.Version >= latestVersion
+	})
+	if len(oldRevisions) < maxRetainedRevisions {
+		return nil
+	}
+
+	slices.SortFunc(oldRevisions, func(r1, r2 korifiv1alpha1.CFAppRevision) int {
+		return r1.Spec.Version - r2.Spec.Version
+	})
+
+	for _, revision := range oldRevisions[:len(oldRevisions)-maxRetainedRevisions+1] {
+		if revision.Spec.EnvSecretName != "" {
+			err := r.k8sClient.Delete(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: revision.Namespace,
+					Name:      revision.Spec.EnvSecretName,
+				},
+			})
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+
+		err := r.k8sClient.Delete(ctx, &revision)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) getSecretData(ctx context.Context, namespace, secretName string) (map[string][]byte, error) {
+	if secretName == "" {
+		return map[string][]byte{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, secret)
+	if k8serrors.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return secret.Data, nil
+}
+
+func latestRevision(revisions []korifiv1alpha1.CFAppRevision) *korifiv1alpha1.CFAppRevision {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	latest := slices.MaxFunc(revisions, func(r1, r2 korifiv1alpha1.CFAppRevision) int {
+		return r1.Spec.Version - r2.Spec.Version
+	})
+
+	return &latest
+}
+
+func toRevisionProcesses(processes []*korifiv1alpha1.CFProcess) []korifiv1alpha1.CFAppRevisionProcess {
+	revisionProcesses := []korifiv1alpha1.CFAppRevisionProcess{}
+	for _, p := range processes {
+		revisionProcesses = append(revisionProcesses, korifiv1alpha1.CFAppRevisionProcess{
+			Type:    p.Spec.ProcessType,
+			Command: p.Spec.Command,
+		})
+	}
+
+	slices.SortFunc(revisionProcesses, func(p1, p2 korifiv1alpha1.CFAppRevisionProcess) int {
+		return strings.Compare(p1.Type, p2.Type)
+	})
+
+	return revisionProcesses
+}
+
+func describeChanges(
+	latest *korifiv1alpha1.CFAppRevision,
+	dropletGUID string,
+	latestEnvVars map[string][]byte,
+	envVars map[string][]byte,
+	processes []korifiv1alpha1.CFAppRevisionProcess,
+) []string {
+	changes := []string{}
+
+	if latest.Spec.DropletRef.Name != dropletGUID {
+		changes = append(changes, "New droplet deployed.")
+	}
+
+	if !maps.EqualFunc(latestEnvVars, envVars, bytes.Equal) {
+		changes = append(changes, "New environment variables deployed.")
+	}
+
+	latestCommands := map[string]string{}
+	for _, p := range latest.Spec.Processes {
+		latestCommands[p.Type] = p.Command
+	}
+
+	for _, p := range processes {
+		latestCommand := latestCommands[p.Type]
+		switch {
+		case latestCommand == p.Command:
+			continue
+		case latestCommand == "":
+			changes = append(changes, fmt.Sprintf("Custom start command added for '%s' process.", p.Type))
+		case p.Command == "":
+			changes = append(changes, fmt.Sprintf("Custom start command removed for '%s' process.", p.Type))
+		default:
+			changes = append(changes, fmt.Sprintf("Custom start command updated for '%s' process.", p.Type))
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,66 @@
+package auditevents
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reconciler deletes CFAuditEvents once they are older than the configured
+// TTL, so that the number of recorded events stays bounded
+type Reconciler struct {
+	k8sClient             client.Client
+	log                   logr.Logger
+	auditEventTTLDuration time.Duration
+}
+
+func NewReconciler(
+	client client.Client,
+	log logr.Logger,
+	auditEventTTLDuration time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAuditEvent] {
+	auditEventReconciler := Reconciler{
+		k8sClient:             client,
+		log:                   log,
+		auditEventTTLDuration: auditEventTTLDuration,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAuditEvent](log, client, &auditEventReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAuditEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents/status,verbs=get;update;patch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfAuditEvent *korifiv1alpha1.CFAuditEvent) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfAuditEvent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfAuditEvent.Status.ObservedGeneration = cfAuditEvent.Generation
+	log.V(1).Info("set observed generation", "generation", cfAuditEvent.Status.ObservedGeneration)
+
+	expiresIn := time.Until(cfAuditEvent.CreationTimestamp.Add(r.auditEventTTLDuration))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	log.V(1).Info("deleting-expired-audit-event", "namespace", cfAuditEvent.Namespace, "name", cfAuditEvent.Name)
+	err := r.k8sClient.Delete(ctx, cfAuditEvent)
+	if err != nil {
+		log.Info("error-deleting-audit-event", "reason", err)
+	}
+	return ctrl.Result{}, client.IgnoreNotFound(err)
+}
@@ -0,0 +1,51 @@
+package auditevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAuditEventReconciler Integration Tests", func() {
+	var cfAuditEvent *korifiv1alpha1.CFAuditEvent
+
+	BeforeEach(func() {
+		cfAuditEvent = &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: korifiv1alpha1.AuditEventTypeAppCrash,
+				Actor: korifiv1alpha1.CFAuditEventParticipant{
+					GUID: "app-guid",
+					Type: korifiv1alpha1.AuditEventActorTypeApp,
+				},
+				Target: korifiv1alpha1.CFAuditEventParticipant{
+					GUID: "app-guid",
+					Type: korifiv1alpha1.AuditEventActorTypeApp,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfAuditEvent)).To(Succeed())
+	})
+
+	It("sets the observed generation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+			g.Expect(cfAuditEvent.Status.ObservedGeneration).To(Equal(cfAuditEvent.Generation))
+		}).Should(Succeed())
+	})
+
+	It("deletes the audit event after it expires", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), &korifiv1alpha1.CFAuditEvent{})
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		}).Should(Succeed())
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -54,6 +55,7 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder BuildpackEnvBuilder,
+	stagingTimeout time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild] {
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild](
 		log,
@@ -69,6 +71,7 @@ func NewReconciler(
 				envBuilder:       envBuilder,
 				scheme:           scheme,
 			},
+			stagingTimeout,
 		))
 }
 
@@ -87,13 +90,13 @@ func (r *buildpackBuildReconciler) SetupWithManager(mgr ctrl.Manager) *builder.B
 			&korifiv1alpha1.BuildWorkload{},
 			handler.EnqueueRequestsFromMapFunc(buildworkloadToBuild),
 		).
-		WithEventFilter(predicate.NewPredicateFuncs(buildpackBuildFilter))
+		WithEventFilter(predicate.NewPredicateFuncs(r.buildpackBuildFilter))
 }
 
-func buildpackBuildFilter(object client.Object) bool {
-	_, ok := object.(*korifiv1alpha1.BuildWorkload)
+func (r *buildpackBuildReconciler) buildpackBuildFilter(object client.Object) bool {
+	buildWorkload, ok := object.(*korifiv1alpha1.BuildWorkload)
 	if ok {
-		return true
+		return buildWorkload.Spec.BuilderName == r.controllerConfig.BuilderName
 	}
 
 	cfBuild, ok := object.(*korifiv1alpha1.CFBuild)
@@ -231,6 +234,7 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 			},
 			BuilderName: r.controllerConfig.BuilderName,
 			Buildpacks:  cfBuild.Spec.Lifecycle.Data.Buildpacks,
+			Stack:       cfBuild.Spec.Lifecycle.Data.Stack,
 			Services: slices.Collect(it.Map(slices.Values(cfApp.Status.ServiceBindings),
 				func(binding korifiv1alpha1.ServiceBinding) corev1.ObjectReference {
 					return corev1.ObjectReference{
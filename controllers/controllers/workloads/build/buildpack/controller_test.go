@@ -127,6 +127,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 					Type: "buildpack",
 					Data: korifiv1alpha1.LifecycleData{
 						Buildpacks: []string{"first-buildpack", "second-buildpack"},
+						Stack:      "my-stack",
 					},
 				},
 			},
@@ -137,7 +138,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 		Expect(adminClient.Create(context.Background(), cfBuild)).To(Succeed())
 	})
 
-	It("creates a BuildWorkload with the buildRef, source, env, and buildpacks set", func() {
+	It("creates a BuildWorkload with the buildRef, source, env, buildpacks and stack set", func() {
 		eventuallyBuildWorkloadShould(func(workload *korifiv1alpha1.BuildWorkload, g Gomega) {
 			g.Expect(workload.Spec.BuilderName).To(Equal("buildpack-builder-name"))
 			g.Expect(workload.Spec.BuildRef.Name).To(Equal(cfBuild.Name))
@@ -189,6 +190,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 				}),
 			))
 			g.Expect(workload.Spec.Buildpacks).To(ConsistOf("first-buildpack", "second-buildpack"))
+			g.Expect(workload.Spec.Stack).To(Equal("my-stack"))
 			g.Expect(workload.GetOwnerReferences()).To(ConsistOf(metav1.OwnerReference{
 				UID:                cfBuild.UID,
 				Kind:               "CFBuild",
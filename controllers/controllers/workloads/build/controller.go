@@ -3,6 +3,7 @@ package build
 import (
 	"context"
 	"fmt"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
@@ -31,16 +32,18 @@ type DelegateReconciler interface {
 }
 
 type Reconciler struct {
-	log          logr.Logger
-	k8sClient    client.Client
-	scheme       *runtime.Scheme
-	buildCleaner BuildCleaner
-	delegate     DelegateReconciler
+	log            logr.Logger
+	k8sClient      client.Client
+	scheme         *runtime.Scheme
+	buildCleaner   BuildCleaner
+	delegate       DelegateReconciler
+	stagingTimeout time.Duration
 }
 
-var packageTypeToLifecycleType = map[korifiv1alpha1.PackageType]korifiv1alpha1.LifecycleType{
-	"bits":   "buildpack",
-	"docker": "docker",
+var lifecycleTypeToPackageType = map[korifiv1alpha1.LifecycleType]korifiv1alpha1.PackageType{
+	korifiv1alpha1.BuildpackLifecycle:  korifiv1alpha1.BitsPackage,
+	korifiv1alpha1.DockerLifecycle:     korifiv1alpha1.DockerPackage,
+	korifiv1alpha1.DockerfileLifecycle: korifiv1alpha1.BitsPackage,
 }
 
 func NewReconciler(
@@ -49,13 +52,15 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	buildCleaner BuildCleaner,
 	delegate DelegateReconciler,
+	stagingTimeout time.Duration,
 ) *Reconciler {
 	return &Reconciler{
-		log:          log,
-		k8sClient:    k8sClient,
-		scheme:       scheme,
-		buildCleaner: buildCleaner,
-		delegate:     delegate,
+		log:            log,
+		k8sClient:      k8sClient,
+		scheme:         scheme,
+		buildCleaner:   buildCleaner,
+		delegate:       delegate,
+		stagingTimeout: stagingTimeout,
 	}
 }
 
@@ -97,6 +102,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if cfBuild.Spec.PackageRef.Name == "" {
+		return r.reconcileDroplet(ctx, cfBuild)
+	}
+
 	cfPackage := new(korifiv1alpha1.CFPackage)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
 	if err != nil {
@@ -124,7 +133,91 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 		return ctrl.Result{}, nil
 	}
 
-	return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
+	if cfBuild.Spec.Cancelled {
+		return r.failStaging(ctx, cfBuild, "StagingCancelled", "Staging was cancelled")
+	}
+
+	stagingStatus := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+	if r.stagingTimeout == 0 || stagingStatus == nil || stagingStatus.Status != metav1.ConditionTrue {
+		return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
+	}
+
+	remaining := r.stagingTimeout - time.Since(stagingStatus.LastTransitionTime.Time)
+	if remaining <= 0 {
+		return r.failStaging(ctx, cfBuild, "StagingTimeExpired", fmt.Sprintf("Staging did not complete within %s", r.stagingTimeout))
+	}
+
+	result, err := r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
+	if err == nil && (result.RequeueAfter == 0 || result.RequeueAfter > remaining) {
+		result.RequeueAfter = remaining
+	}
+
+	return result, err
+}
+
+// failStaging stops staging by deleting the build workload. Builders delete
+// the kpack build or the staging job of a workload when it is deleted
+func (r *Reconciler) failStaging(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, reason, message string) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	err := r.k8sClient.Delete(ctx, &korifiv1alpha1.BuildWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfBuild.Name,
+			Namespace: cfBuild.Namespace,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to delete build workload", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	log.Info("staging stopped", "reason", reason)
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDroplet handles builds that are not staged from a package, e.g.
+// uploaded or copied droplets. The build succeeds once the droplet image is set
+func (r *Reconciler) reconcileDroplet(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if cfBuild.Spec.Droplet == nil || cfBuild.Spec.Droplet.Registry.Image == "" {
+		log.V(1).Info("waiting for the droplet to be uploaded")
+		return ctrl.Result{}, nil
+	}
+
+	cfBuild.Status.Droplet = cfBuild.Spec.Droplet.DeepCopy()
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DropletProvided",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	return ctrl.Result{}, nil
 }
 
 func validateLifecycleTypes(
@@ -132,7 +225,7 @@ func validateLifecycleTypes(
 	cfPackage *korifiv1alpha1.CFPackage,
 	cfBuild *korifiv1alpha1.CFBuild,
 ) error {
-	if cfBuild.Spec.Lifecycle.Type != packageTypeToLifecycleType[cfPackage.Spec.Type] {
+	if lifecycleTypeToPackageType[cfBuild.Spec.Lifecycle.Type] != cfPackage.Spec.Type {
 		return fmt.Errorf(
 			"cannot build %s package with %s build",
 			cfPackage.Spec.Type,
@@ -140,7 +233,7 @@ func validateLifecycleTypes(
 		)
 	}
 
-	if cfApp.Spec.Lifecycle.Type != packageTypeToLifecycleType[cfPackage.Spec.Type] {
+	if cfApp.Spec.Lifecycle.Type != cfBuild.Spec.Lifecycle.Type {
 		return fmt.Errorf(
 			"cannot build %s package for %s app",
 			cfPackage.Spec.Type,
@@ -1,6 +1,8 @@
 package build_test
 
 import (
+	"time"
+
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -9,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -215,6 +218,114 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("the build has no package", func() {
+		BeforeEach(func() {
+			cfBuild.Spec.PackageRef = v1.LocalObjectReference{}
+		})
+
+		It("does not reconcile the build", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeNil())
+			}).Should(Succeed())
+		})
+
+		When("the build has a droplet", func() {
+			BeforeEach(func() {
+				cfBuild.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "my-image@sha256:123",
+						ImagePullSecrets: []v1.LocalObjectReference{{Name: "image-secret"}},
+					},
+					ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run-me"}},
+				}
+			})
+
+			It("succeeds the build with the droplet", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+					g.Expect(cfBuild.Status.Droplet).To(Equal(cfBuild.Spec.Droplet))
+				}).Should(Succeed())
+				Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+			})
+		})
+	})
+
+	When("the build is cancelled", func() {
+		var buildWorkload *korifiv1alpha1.BuildWorkload
+
+		JustBeforeEach(func() {
+			buildWorkload = &korifiv1alpha1.BuildWorkload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      cfBuild.Name,
+				},
+				Spec: korifiv1alpha1.BuildWorkloadSpec{
+					BuildRef:    korifiv1alpha1.RequiredLocalObjectReference{Name: cfBuild.Name},
+					BuilderName: "my-builder",
+				},
+			}
+			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, cfBuild, func() {
+				cfBuild.Spec.Cancelled = true
+			})).To(Succeed())
+		})
+
+		It("fails the build", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededCondition.Reason).To(Equal("StagingCancelled"))
+				g.Expect(succeededCondition.Message).To(Equal("Staging was cancelled"))
+			}).Should(Succeed())
+		})
+
+		It("deletes the build workload", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("staging takes longer than the staging timeout", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.FindStatusCondition(cfBuild.Status.Conditions, "delegateInvokedCondition")).NotTo(BeNil())
+			}).Should(Succeed())
+
+			helpers.EnsurePatch(adminClient, cfBuild, func(b *korifiv1alpha1.CFBuild) {
+				meta.SetStatusCondition(&b.Status.Conditions, metav1.Condition{
+					Type:               korifiv1alpha1.StagingConditionType,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * stagingTimeout)),
+					Reason:             "BuildRunning",
+				})
+			})
+		})
+
+		It("fails the build", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededCondition.Reason).To(Equal("StagingTimeExpired"))
+				g.Expect(succeededCondition.Message).To(ContainSubstring("Staging did not complete within"))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the build succeeds", func() {
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,6 +47,8 @@ func NewReconciler(
 	imageConfigGetter ImageConfigGetter,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	rootNamespace string,
+	stagingTimeout time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild] {
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild](
 		log,
@@ -57,13 +61,16 @@ func NewReconciler(
 			&dockerBuildReconciler{
 				k8sClient:         k8sClient,
 				imageConfigGetter: imageConfigGetter,
+				rootNamespace:     rootNamespace,
 			},
+			stagingTimeout,
 		))
 }
 
 type dockerBuildReconciler struct {
 	k8sClient         client.Client
 	imageConfigGetter ImageConfigGetter
+	rootNamespace     string
 }
 
 func (r *dockerBuildReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -82,6 +89,8 @@ func dockerBuildFilter(object client.Object) bool {
 	return cfBuild.Spec.Lifecycle.Type == korifiv1alpha1.LifecycleType("docker")
 }
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get;list;watch
+
 // +kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds/finalizers,verbs=update
@@ -99,6 +108,30 @@ func (r *dockerBuildReconciler) ReconcileBuild(
 		return ctrl.Result{}, nil
 	}
 
+	dockerEnabled, disabledMessage, err := r.isDockerEnabled(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !dockerEnabled {
+		meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.StagingConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "FeatureDisabled",
+			Message:            disabledMessage,
+			ObservedGeneration: cfBuild.Generation,
+		})
+		meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "BuildFailed",
+			Message:            disabledMessage,
+			ObservedGeneration: cfBuild.Generation,
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	secretNames := []string{}
 	for _, secretRef := range cfPackage.Spec.Source.Registry.ImagePullSecrets {
 		secretNames = append(secretNames, secretRef.Name)
@@ -169,6 +202,29 @@ func (r *dockerBuildReconciler) ReconcileBuild(
 	return ctrl.Result{}, nil
 }
 
+func (r *dockerBuildReconciler) isDockerEnabled(ctx context.Context) (bool, string, error) {
+	featureFlag := &korifiv1alpha1.CFFeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      korifiv1alpha1.FeatureFlagDiegoDocker,
+		},
+	}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(featureFlag), featureFlag)
+	if k8serrors.IsNotFound(err) {
+		return korifiv1alpha1.DefaultFeatureFlags[korifiv1alpha1.FeatureFlagDiegoDocker], "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get the %s feature flag: %w", korifiv1alpha1.FeatureFlagDiegoDocker, err)
+	}
+
+	message := featureFlag.Spec.CustomErrorMessage
+	if message == "" {
+		message = korifiv1alpha1.FeatureFlagDiegoDocker
+	}
+
+	return featureFlag.Spec.Enabled, "Feature Disabled: " + message, nil
+}
+
 func isRoot(user string) bool {
 	user = strings.Split(user, ":")[0]
 	return user == "" || user == "root" || user == "0"
@@ -157,6 +157,37 @@ var _ = Describe("CFDockerBuildReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the diego_docker feature flag is disabled", func() {
+		BeforeEach(func() {
+			featureFlag := &korifiv1alpha1.CFFeatureFlag{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      korifiv1alpha1.FeatureFlagDiegoDocker,
+				},
+				Spec: korifiv1alpha1.CFFeatureFlagSpec{
+					Enabled:            false,
+					CustomErrorMessage: "docker is not allowed",
+				},
+			}
+			Expect(adminClient.Create(ctx, featureFlag)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(adminClient.Delete(ctx, featureFlag)).To(Succeed())
+			})
+		})
+
+		It("fails the build", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededCondition.Message).To(Equal("Feature Disabled: docker is not allowed"))
+				g.Expect(cfBuild.Status.Droplet).To(BeNil())
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("privileged images", func() {
 		succeededCondition := func(g Gomega) metav1.Condition {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
@@ -36,6 +36,7 @@ var (
 	testEnv           *envtest.Environment
 	adminClient       client.Client
 	testNamespace     string
+	rootNamespace     string
 	containerRegistry *oci.Registry
 )
 
@@ -72,6 +73,13 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
 	k8sClient, err := k8sclient.NewForConfig(k8sManager.GetConfig())
 	Expect(err).NotTo(HaveOccurred())
 
@@ -81,6 +89,8 @@ var _ = BeforeSuite(func() {
 		image.NewClient(k8sClient),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFDockerBuild"),
+		rootNamespace,
+		0,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
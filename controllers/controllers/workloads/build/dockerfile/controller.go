@@ -0,0 +1,213 @@
+package dockerfile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewReconciler returns a reconciler staging builds with the dockerfile
+// lifecycle. The package source is expected to contain a Dockerfile, which is
+// built by the image builder configured as the dockerfile builder
+func NewReconciler(
+	k8sClient client.Client,
+	buildCleaner build.BuildCleaner,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
+	stagingTimeout time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild] {
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild](
+		log,
+		k8sClient,
+		build.NewReconciler(
+			log,
+			k8sClient,
+			scheme,
+			buildCleaner,
+			&dockerfileBuildReconciler{
+				k8sClient:        k8sClient,
+				controllerConfig: controllerConfig,
+				scheme:           scheme,
+			},
+			stagingTimeout,
+		))
+}
+
+type dockerfileBuildReconciler struct {
+	k8sClient        client.Client
+	controllerConfig *config.ControllerConfig
+	scheme           *runtime.Scheme
+}
+
+func (r *dockerfileBuildReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFBuild{}).
+		Named("dockerfile_build").
+		Watches(
+			&korifiv1alpha1.BuildWorkload{},
+			handler.EnqueueRequestsFromMapFunc(buildworkloadToBuild),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(r.dockerfileBuildFilter))
+}
+
+func (r *dockerfileBuildReconciler) dockerfileBuildFilter(object client.Object) bool {
+	buildWorkload, ok := object.(*korifiv1alpha1.BuildWorkload)
+	if ok {
+		return buildWorkload.Spec.BuilderName == r.controllerConfig.DockerfileBuilderName
+	}
+
+	cfBuild, ok := object.(*korifiv1alpha1.CFBuild)
+	if !ok {
+		return false
+	}
+
+	return cfBuild.Spec.Lifecycle.Type == korifiv1alpha1.DockerfileLifecycle
+}
+
+func buildworkloadToBuild(ctx context.Context, o client.Object) []reconcile.Request {
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      o.GetLabels()[korifiv1alpha1.CFBuildGUIDLabelKey],
+				Namespace: o.GetNamespace(),
+			},
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/finalizers,verbs=update
+
+func (r *dockerfileBuildReconciler) ReconcileBuild(
+	ctx context.Context,
+	cfBuild *korifiv1alpha1.CFBuild,
+	cfApp *korifiv1alpha1.CFApp,
+	cfPackage *korifiv1alpha1.CFPackage,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	stagingStatus := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+	if stagingStatus == nil {
+		err := r.createBuildWorkload(ctx, cfBuild, cfApp, cfPackage)
+		if err != nil {
+			log.Info("failed to create BuildWorkload", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.StagingConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "BuildRunning",
+			ObservedGeneration: cfBuild.Generation,
+		})
+
+		return ctrl.Result{}, nil
+	}
+
+	var buildWorkload korifiv1alpha1.BuildWorkload
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), &buildWorkload)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("error when fetching BuildWorkload", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	workloadSucceededStatus := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	if workloadSucceededStatus == nil || workloadSucceededStatus.Status == metav1.ConditionUnknown {
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	if workloadSucceededStatus.Status == metav1.ConditionFalse {
+		meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "BuildFailed",
+			Message:            fmt.Sprintf("%s: %s", workloadSucceededStatus.Reason, workloadSucceededStatus.Message),
+			ObservedGeneration: cfBuild.Generation,
+		})
+
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "BuildSucceeded",
+		ObservedGeneration: cfBuild.Generation,
+	})
+	cfBuild.Status.Droplet = buildWorkload.Status.Droplet
+
+	return ctrl.Result{}, nil
+}
+
+func (r *dockerfileBuildReconciler) createBuildWorkload(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, cfApp *korifiv1alpha1.CFApp, cfPackage *korifiv1alpha1.CFPackage) error {
+	buildWorkload := &korifiv1alpha1.BuildWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfBuild.Name,
+			Namespace: cfBuild.Namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFBuildGUIDLabelKey: cfBuild.Name,
+				korifiv1alpha1.CFAppGUIDLabelKey:   cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.BuildWorkloadSpec{
+			BuildRef: korifiv1alpha1.RequiredLocalObjectReference{
+				Name: cfBuild.Name,
+			},
+			Source: korifiv1alpha1.PackageSource{
+				Registry: korifiv1alpha1.Registry{
+					Image:            cfPackage.Spec.Source.Registry.Image,
+					ImagePullSecrets: cfPackage.Spec.Source.Registry.ImagePullSecrets,
+				},
+			},
+			BuilderName: r.controllerConfig.DockerfileBuilderName,
+		},
+	}
+
+	err := controllerutil.SetControllerReference(cfBuild, buildWorkload, r.scheme)
+	if err != nil {
+		return fmt.Errorf("failed to set OwnerRef on BuildWorkload: %w", err)
+	}
+
+	err = r.k8sClient.Create(ctx, buildWorkload)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create BuildWorkload: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package dockerfile_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("CFDockerfileBuildReconciler Integration Tests", func() {
+	var (
+		cfApp     *korifiv1alpha1.CFApp
+		cfPackage *korifiv1alpha1.CFPackage
+		cfBuild   *korifiv1alpha1.CFBuild
+	)
+
+	BeforeEach(func() {
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "test-app-name",
+				DesiredState: "STOPPED",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "dockerfile",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfPackage = &korifiv1alpha1.CFPackage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFPackageSpec{
+				Type: "bits",
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+				Source: korifiv1alpha1.PackageSource{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "ref",
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "source-registry-image-pull-secret"}},
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfPackage)).To(Succeed())
+
+		cfBuild = &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				PackageRef: corev1.LocalObjectReference{
+					Name: cfPackage.Name,
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "dockerfile",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfBuild)).To(Succeed())
+	})
+
+	patchBuildWorkloadStatus := func(modify func(*korifiv1alpha1.BuildWorkload)) {
+		GinkgoHelper()
+
+		Eventually(func(g Gomega) {
+			workload := new(korifiv1alpha1.BuildWorkload)
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfBuild.Name, Namespace: testNamespace}, workload)).To(Succeed())
+			g.Expect(k8s.Patch(ctx, adminClient, workload, func() {
+				modify(workload)
+			})).To(Succeed())
+		}).Should(Succeed())
+	}
+
+	It("creates a BuildWorkload for the dockerfile builder", func() {
+		Eventually(func(g Gomega) {
+			workload := new(korifiv1alpha1.BuildWorkload)
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfBuild.Name, Namespace: testNamespace}, workload)).To(Succeed())
+
+			g.Expect(workload.Labels).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.CFBuildGUIDLabelKey, cfBuild.Name),
+				HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name),
+			))
+			g.Expect(workload.Spec.BuilderName).To(Equal("dockerfile-builder-name"))
+			g.Expect(workload.Spec.BuildRef.Name).To(Equal(cfBuild.Name))
+			g.Expect(workload.Spec.Source).To(Equal(cfPackage.Spec.Source))
+			g.Expect(workload.Spec.Buildpacks).To(BeEmpty())
+			g.Expect(workload.GetOwnerReferences()).To(ConsistOf(metav1.OwnerReference{
+				UID:                cfBuild.UID,
+				Kind:               "CFBuild",
+				APIVersion:         "korifi.cloudfoundry.org/v1alpha1",
+				Name:               cfBuild.Name,
+				Controller:         tools.PtrTo(true),
+				BlockOwnerDeletion: tools.PtrTo(true),
+			}))
+		}).Should(Succeed())
+	})
+
+	It("sets the 'build-running' status conditions on CFBuild", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+			stagingCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+			g.Expect(stagingCondition).NotTo(BeNil())
+			g.Expect(stagingCondition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(stagingCondition.Reason).To(Equal("BuildRunning"))
+		}).Should(Succeed())
+	})
+
+	When("the app does not have the dockerfile lifecycle", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.Lifecycle.Type = "buildpack"
+			})).To(Succeed())
+		})
+
+		It("fails the build", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededCondition.Message).To(Equal("cannot build bits package for buildpack app"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the BuildWorkload failed", func() {
+		JustBeforeEach(func() {
+			patchBuildWorkloadStatus(func(workload *korifiv1alpha1.BuildWorkload) {
+				meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+					Type:    korifiv1alpha1.SucceededConditionType,
+					Status:  metav1.ConditionFalse,
+					Reason:  "BuildFailed",
+					Message: "Check build log output",
+				})
+			})
+		})
+
+		It("fails the CFBuild", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				stagingCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+				g.Expect(stagingCondition).NotTo(BeNil())
+				g.Expect(stagingCondition.Status).To(Equal(metav1.ConditionFalse))
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeededCondition.Reason).To(Equal("BuildFailed"))
+				g.Expect(succeededCondition.Message).To(Equal("BuildFailed: Check build log output"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the BuildWorkload finished successfully", func() {
+		JustBeforeEach(func() {
+			patchBuildWorkloadStatus(func(workload *korifiv1alpha1.BuildWorkload) {
+				meta.SetStatusCondition(&workload.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.SucceededConditionType,
+					Status: metav1.ConditionTrue,
+					Reason: "BuildSucceeded",
+				})
+				workload.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "some-org/my-image@sha256:some-sha",
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+					},
+					Ports: []int32{8080},
+				}
+			})
+		})
+
+		It("succeeds the CFBuild and sets its droplet", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+
+				succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeededCondition).NotTo(BeNil())
+				g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionTrue))
+
+				g.Expect(cfBuild.Status.Droplet).NotTo(BeNil())
+				g.Expect(cfBuild.Status.Droplet.Registry.Image).To(Equal("some-org/my-image@sha256:some-sha"))
+				g.Expect(cfBuild.Status.Droplet.Ports).To(ConsistOf(BeEquivalentTo(8080)))
+			}).Should(Succeed())
+		})
+	})
+})
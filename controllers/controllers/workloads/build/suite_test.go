@@ -34,6 +34,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const stagingTimeout = time.Minute
+
 var (
 	ctx             context.Context
 	stopManager     context.CancelFunc
@@ -128,6 +130,7 @@ var _ = BeforeSuite(func() {
 			scheme.Scheme,
 			buildCleaner,
 			delegateReconciler,
+			stagingTimeout,
 		),
 	).SetupWithManager(k8sManager)).To(Succeed())
 
@@ -0,0 +1,220 @@
+package buildcaches
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// completed requests are kept for a day, so that their jobs can be polled
+const completedRequestRetention = 24 * time.Hour
+
+// Reconciler requests the build caches of the apps targeted by a
+// CFBuildCacheClear to be cleared and tracks the apps until their caches have
+// been cleared. Apps are labelled with the name of the builder that builds
+// them and annotated with the name of the CFBuildCacheClear. The builder
+// removes both once it has cleared the build cache of the app. The apps are
+// checked bypassing the cache, so that an app that has just been labelled is
+// never seen as cleared.
+type Reconciler struct {
+	k8sClient     client.Client
+	reader        client.Reader
+	log           logr.Logger
+	builderName   string
+	rootNamespace string
+}
+
+func NewReconciler(
+	client client.Client,
+	reader client.Reader,
+	log logr.Logger,
+	builderName string,
+	rootNamespace string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFBuildCacheClear] {
+	buildCacheReconciler := Reconciler{
+		k8sClient:     client,
+		reader:        reader,
+		log:           log,
+		builderName:   builderName,
+		rootNamespace: rootNamespace,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuildCacheClear](log, client, &buildCacheReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFBuildCacheClear{}).
+		Watches(
+			&korifiv1alpha1.CFApp{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		)
+}
+
+func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	var clears korifiv1alpha1.CFBuildCacheClearList
+	if err := r.k8sClient.List(ctx, &clears); err != nil {
+		return []reconcile.Request{}
+	}
+
+	app := korifiv1alpha1.CFBuildCacheClearApp{Namespace: o.GetNamespace(), Name: o.GetName()}
+	for _, clear := range clears.Items {
+		if slices.Contains(clear.Status.PendingApps, app) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      clear.Name,
+					Namespace: clear.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildcacheclears,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildcacheclears/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;patch
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuildCacheClear *korifiv1alpha1.CFBuildCacheClear) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfBuildCacheClear.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfBuildCacheClear.Status.ObservedGeneration = cfBuildCacheClear.Generation
+	log.V(1).Info("set observed generation", "generation", cfBuildCacheClear.Status.ObservedGeneration)
+
+	if readyCondition := meta.FindStatusCondition(cfBuildCacheClear.Status.Conditions, korifiv1alpha1.StatusConditionReady); readyCondition != nil && readyCondition.Status == metav1.ConditionTrue {
+		expiresIn := time.Until(readyCondition.LastTransitionTime.Add(completedRequestRetention))
+		if expiresIn > 0 {
+			return ctrl.Result{RequeueAfter: expiresIn}, nil
+		}
+
+		log.V(1).Info("deleting completed build cache clear")
+		return ctrl.Result{}, client.IgnoreNotFound(r.k8sClient.Delete(ctx, cfBuildCacheClear))
+	}
+
+	if !meta.IsStatusConditionTrue(cfBuildCacheClear.Status.Conditions, korifiv1alpha1.BuildCacheClearAppsRequestedConditionType) {
+		pendingApps, err := r.requestClear(ctx, cfBuildCacheClear)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		cfBuildCacheClear.Status.PendingApps = pendingApps
+		meta.SetStatusCondition(&cfBuildCacheClear.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.BuildCacheClearAppsRequestedConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "AppsRequested",
+			ObservedGeneration: cfBuildCacheClear.Generation,
+		})
+	}
+
+	pendingApps, err := r.pendingApps(ctx, cfBuildCacheClear.Status.PendingApps)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cfBuildCacheClear.Status.PendingApps = pendingApps
+	if len(pendingApps) > 0 {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("Clearing").
+			WithMessage(fmt.Sprintf("waiting for the build cache of %d app(s) to be cleared", len(pendingApps))).
+			WithNoRequeue()
+	}
+
+	return ctrl.Result{RequeueAfter: completedRequestRetention}, nil
+}
+
+// requestClear labels the buildpack apps targeted by the CFBuildCacheClear.
+// Other apps have no build cache and are left alone.
+func (r *Reconciler) requestClear(ctx context.Context, cfBuildCacheClear *korifiv1alpha1.CFBuildCacheClear) ([]korifiv1alpha1.CFBuildCacheClearApp, error) {
+	cfApps, err := r.targetApps(ctx, cfBuildCacheClear)
+	if err != nil {
+		return nil, err
+	}
+
+	requestedApps := []korifiv1alpha1.CFBuildCacheClearApp{}
+	for i := range cfApps {
+		cfApp := &cfApps[i]
+		if cfApp.Spec.Lifecycle.Type != korifiv1alpha1.BuildpackLifecycle {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, r.k8sClient, cfApp, func() {
+			cfApp.Labels = tools.SetMapValue(cfApp.Labels, korifiv1alpha1.ClearBuildCacheLabelKey, r.builderName)
+			cfApp.Annotations = tools.SetMapValue(cfApp.Annotations, korifiv1alpha1.ClearBuildCacheRequestAnnotationKey, cfBuildCacheClear.Name)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to request clearing the build cache of app %q: %w", cfApp.Name, err)
+		}
+
+		requestedApps = append(requestedApps, korifiv1alpha1.CFBuildCacheClearApp{Namespace: cfApp.Namespace, Name: cfApp.Name})
+	}
+
+	return requestedApps, nil
+}
+
+func (r *Reconciler) targetApps(ctx context.Context, cfBuildCacheClear *korifiv1alpha1.CFBuildCacheClear) ([]korifiv1alpha1.CFApp, error) {
+	if cfBuildCacheClear.Spec.AppRef != nil {
+		cfApp := korifiv1alpha1.CFApp{}
+		err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfBuildCacheClear.Namespace, Name: cfBuildCacheClear.Spec.AppRef.Name}, &cfApp)
+		if err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+
+		return []korifiv1alpha1.CFApp{cfApp}, nil
+	}
+
+	listOpts := []client.ListOption{}
+	if cfBuildCacheClear.Namespace != r.rootNamespace {
+		listOpts = append(listOpts, client.InNamespace(cfBuildCacheClear.Namespace))
+	}
+
+	var cfApps korifiv1alpha1.CFAppList
+	if err := r.k8sClient.List(ctx, &cfApps, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	return cfApps.Items, nil
+}
+
+// pendingApps returns the apps that still carry the clear build cache label.
+// The label may have been set again by a later request, in which case the
+// builder clears the cache again before removing it.
+func (r *Reconciler) pendingApps(ctx context.Context, apps []korifiv1alpha1.CFBuildCacheClearApp) ([]korifiv1alpha1.CFBuildCacheClearApp, error) {
+	pendingApps := []korifiv1alpha1.CFBuildCacheClearApp{}
+	for _, app := range apps {
+		cfApp := korifiv1alpha1.CFApp{}
+		err := r.reader.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: app.Name}, &cfApp)
+		if err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get app %q: %w", app.Name, err)
+		}
+
+		if _, ok := cfApp.Labels[korifiv1alpha1.ClearBuildCacheLabelKey]; ok {
+			pendingApps = append(pendingApps, app)
+		}
+	}
+
+	return pendingApps, nil
+}
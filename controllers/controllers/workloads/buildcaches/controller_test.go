@@ -0,0 +1,196 @@
+package buildcaches_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFBuildCacheClear Reconciler", func() {
+	var (
+		cfApp             *korifiv1alpha1.CFApp
+		cfBuildCacheClear *korifiv1alpha1.CFBuildCacheClear
+	)
+
+	createApp := func(namespace string, lifecycleType korifiv1alpha1.LifecycleType) *korifiv1alpha1.CFApp {
+		GinkgoHelper()
+
+		app := &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  uuid.NewString(),
+				DesiredState: "STOPPED",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: lifecycleType,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, app)).To(Succeed())
+		return app
+	}
+
+	// acknowledge plays the builder and removes the clear build cache label
+	acknowledge := func(app *korifiv1alpha1.CFApp) {
+		GinkgoHelper()
+
+		helpers.EnsurePatch(adminClient, app, func(a *korifiv1alpha1.CFApp) {
+			delete(a.Labels, korifiv1alpha1.ClearBuildCacheLabelKey)
+			delete(a.Annotations, korifiv1alpha1.ClearBuildCacheRequestAnnotationKey)
+		})
+	}
+
+	BeforeEach(func() {
+		cfApp = createApp(testNamespace, korifiv1alpha1.BuildpackLifecycle)
+
+		cfBuildCacheClear = &korifiv1alpha1.CFBuildCacheClear{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFBuildCacheClearSpec{
+				AppRef: &corev1.LocalObjectReference{Name: cfApp.Name},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfBuildCacheClear)).To(Succeed())
+	})
+
+	It("labels the app for the builder and annotates it with the request", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+			g.Expect(cfApp.Labels).To(HaveKeyWithValue(korifiv1alpha1.ClearBuildCacheLabelKey, builderName))
+			g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.ClearBuildCacheRequestAnnotationKey, cfBuildCacheClear.Name))
+		}).Should(Succeed())
+	})
+
+	It("is not ready while the app is pending", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+			g.Expect(cfBuildCacheClear.Status.PendingApps).To(ConsistOf(korifiv1alpha1.CFBuildCacheClearApp{
+				Namespace: cfApp.Namespace,
+				Name:      cfApp.Name,
+			}))
+			g.Expect(cfBuildCacheClear.Status.Conditions).To(ContainElement(SatisfyAll(
+				HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+				HasStatus(Equal(metav1.ConditionFalse)),
+				HasReason(Equal("Clearing")),
+			)))
+		}).Should(Succeed())
+	})
+
+	When("the builder acknowledges the request", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).To(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+			}).Should(Succeed())
+			acknowledge(cfApp)
+		})
+
+		It("becomes ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+				g.Expect(cfBuildCacheClear.Status.PendingApps).To(BeEmpty())
+				g.Expect(cfBuildCacheClear.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app is a docker app", func() {
+		BeforeEach(func() {
+			cfApp = createApp(testNamespace, korifiv1alpha1.DockerLifecycle)
+			cfBuildCacheClear.Spec.AppRef.Name = cfApp.Name
+		})
+
+		It("does not label the app and becomes ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+				g.Expect(cfBuildCacheClear.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+			Expect(cfApp.Labels).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+		})
+	})
+
+	When("the request is in the root namespace and targets no app", func() {
+		var anotherApp *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			anotherApp = createApp(testNamespace, korifiv1alpha1.BuildpackLifecycle)
+			cfBuildCacheClear.Namespace = rootNamespace
+			cfBuildCacheClear.Spec.AppRef = nil
+		})
+
+		It("labels the apps in all namespaces", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+				g.Expect(cfBuildCacheClear.Status.PendingApps).To(ContainElements(
+					korifiv1alpha1.CFBuildCacheClearApp{Namespace: cfApp.Namespace, Name: cfApp.Name},
+					korifiv1alpha1.CFBuildCacheClearApp{Namespace: anotherApp.Namespace, Name: anotherApp.Name},
+				))
+			}).Should(Succeed())
+		})
+	})
+
+	When("another request targets the same app", func() {
+		var anotherClear *korifiv1alpha1.CFBuildCacheClear
+
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+				g.Expect(cfBuildCacheClear.Status.PendingApps).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			anotherClear = &korifiv1alpha1.CFBuildCacheClear{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFBuildCacheClearSpec{
+					AppRef: &corev1.LocalObjectReference{Name: cfApp.Name},
+				},
+			}
+			Expect(adminClient.Create(ctx, anotherClear)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.ClearBuildCacheRequestAnnotationKey, anotherClear.Name))
+			}).Should(Succeed())
+		})
+
+		It("keeps the first request pending until the builder acknowledges", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildCacheClear), cfBuildCacheClear)).To(Succeed())
+				g.Expect(cfBuildCacheClear.Status.PendingApps).NotTo(BeEmpty())
+			}, "2s").Should(Succeed())
+
+			acknowledge(cfApp)
+
+			Eventually(func(g Gomega) {
+				for _, clear := range []*korifiv1alpha1.CFBuildCacheClear{cfBuildCacheClear, anotherClear} {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clear), clear)).To(Succeed())
+					g.Expect(clear.Status.PendingApps).To(BeEmpty())
+				}
+			}).Should(Succeed())
+		})
+	})
+})
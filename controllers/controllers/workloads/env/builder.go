@@ -28,15 +28,29 @@ type ServiceDetails struct {
 	BindingName    *string        `json:"binding_name"`
 	Credentials    map[string]any `json:"credentials"`
 	SyslogDrainURL *string        `json:"syslog_drain_url"`
-	VolumeMounts   []string       `json:"volume_mounts"`
+	VolumeMounts   []VolumeMount  `json:"volume_mounts"`
+}
+
+type VolumeMount struct {
+	ContainerDir string `json:"container_dir"`
+	Mode         string `json:"mode"`
+	DeviceType   string `json:"device_type"`
 }
 
 type AppEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient     client.Client
+	rootNamespace string
+	envVarGroup   string
 }
 
-func NewAppEnvBuilder(k8sClient client.Client) *AppEnvBuilder {
-	return &AppEnvBuilder{k8sClient: k8sClient}
+// NewAppEnvBuilder returns a builder that merges the given env var group
+// (i.e. running or staging) into the app env
+func NewAppEnvBuilder(k8sClient client.Client, rootNamespace string, envVarGroup string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		envVarGroup:   envVarGroup,
+	}
 }
 
 func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
@@ -63,8 +77,24 @@ func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp)
 		}
 	}
 
+	envVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: b.envVarGroup}, envVarGroup)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("error when trying to fetch %s env var group: %w", b.envVarGroup, err)
+	}
+
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return sortEnvVars(envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)), nil
+	envVars := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	// App env vars take precedence over the env var group ones
+	for name, value := range envVarGroup.Spec.Env {
+		if slices.ContainsFunc(envVars, func(envVar corev1.EnvVar) bool { return envVar.Name == name }) {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return sortEnvVars(envVars), nil
 }
 
 func sortEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
@@ -98,9 +128,9 @@ type ProcessEnvBuilder struct {
 	k8sClient     client.Client
 }
 
-func NewProcessEnvBuilder(k8sClient client.Client) *ProcessEnvBuilder {
+func NewProcessEnvBuilder(k8sClient client.Client, rootNamespace string) *ProcessEnvBuilder {
 	return &ProcessEnvBuilder{
-		appEnvBuilder: NewAppEnvBuilder(k8sClient),
+		appEnvBuilder: NewAppEnvBuilder(k8sClient, rootNamespace, korifiv1alpha1.RunningEnvVarGroupName),
 		k8sClient:     k8sClient,
 	}
 }
@@ -128,7 +158,6 @@ func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFA
 func (b *ProcessEnvBuilder) buildPortEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error) {
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err := b.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -101,7 +101,7 @@ var _ = Describe("EnvBuilder", func() {
 		var builder *env.AppEnvBuilder
 
 		BeforeEach(func() {
-			builder = env.NewAppEnvBuilder(controllersClient)
+			builder = env.NewAppEnvBuilder(controllersClient, rootNamespace, korifiv1alpha1.StagingEnvVarGroupName)
 		})
 
 		JustBeforeEach(func() {
@@ -257,6 +257,43 @@ var _ = Describe("EnvBuilder", func() {
 				))
 			})
 		})
+		When("the env var group is set", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{
+							"HTTP_PROXY": "http://proxy.example.com",
+							"app-secret": "group-value",
+						},
+					},
+				})
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{
+							"RUNNING_ONLY": "true",
+						},
+					},
+				})
+			})
+
+			It("adds the env var group values, letting the app env win", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+					Equal(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com"}),
+				))
+			})
+		})
 	})
 
 	Describe("ProcessEnvBuilder", func() {
@@ -277,7 +314,7 @@ var _ = Describe("EnvBuilder", func() {
 				},
 			}
 			helpers.EnsureCreate(controllersClient, cfProcess)
-			builder = env.NewProcessEnvBuilder(controllersClient)
+			builder = env.NewProcessEnvBuilder(controllersClient, rootNamespace)
 		})
 
 		JustBeforeEach(func() {
@@ -64,7 +64,6 @@ func (b *VCAPApplicationEnvValueBuilder) getAppURIs(ctx context.Context, cfApp *
 	err := b.k8sClient.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
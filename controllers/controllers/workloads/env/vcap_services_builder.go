@@ -128,6 +128,15 @@ func fromServiceBinding(
 		return ServiceDetails{}, fmt.Errorf("failed to get credentials for service binding %q: %w", serviceBinding.Name, err)
 	}
 
+	volumeMounts := []VolumeMount{}
+	for _, volumeMount := range serviceBinding.Status.VolumeMounts {
+		volumeMounts = append(volumeMounts, VolumeMount{
+			ContainerDir: volumeMount.ContainerDir,
+			Mode:         volumeMount.Mode,
+			DeviceType:   volumeMount.DeviceType,
+		})
+	}
+
 	return ServiceDetails{
 		Label:          serviceLabel,
 		Name:           serviceName,
@@ -138,6 +147,6 @@ func fromServiceBinding(
 		BindingName:    bindingName,
 		Credentials:    creds,
 		SyslogDrainURL: nil,
-		VolumeMounts:   []string{},
+		VolumeMounts:   volumeMounts,
 	}, nil
 }
@@ -210,6 +210,32 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service binding has volume mounts", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Status.VolumeMounts = []korifiv1alpha1.ServiceBindingVolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						VolumeID:     "volume-id",
+					}}
+				})
+			})
+
+			It("includes the volume mounts", func() {
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"sb-1-type": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"volume_mounts": ConsistOf(MatchAllKeys(Keys{
+							"container_dir": Equal("/data"),
+							"mode":          Equal("rw"),
+							"device_type":   Equal("shared"),
+						})),
+					})),
+				}))
+			})
+		})
+
 		When("service instance tags are nil", func() {
 			BeforeEach(func() {
 				helpers.EnsurePatch(controllersClient, serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
@@ -16,8 +16,9 @@ func FromRoutes(cfRoutes []korifiv1alpha1.CFRoute, appGUID, processType string)
 		for _, destination := range cfRoute.Status.Destinations {
 			if destination.AppRef.Name == appGUID &&
 				destination.ProcessType == processType &&
-				destination.Port != nil {
-				ports = append(ports, int32(*destination.Port))
+				destination.Port != nil &&
+				!slices.Contains(ports, *destination.Port) {
+				ports = append(ports, *destination.Port)
 			}
 		}
 	}
@@ -66,6 +66,16 @@ var _ = Describe("FromRoutes", func() {
 		It("returns the ports ordered by route age", func() {
 			Expect(processPorts).To(Equal([]int32{1234, 9876}))
 		})
+
+		When("the routes target the same port", func() {
+			BeforeEach(func() {
+				cfRoutes[1].Status.Destinations[0].Port = tools.PtrTo[int32](9876)
+			})
+
+			It("returns the port once", func() {
+				Expect(processPorts).To(Equal([]int32{9876}))
+			})
+		})
 	})
 
 	When("the destination does not reference the process app", func() {
@@ -45,6 +45,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -82,9 +83,36 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&korifiv1alpha1.CFEnvVarGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllCFProcessRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isRunningEnvVarGroup)),
 		)
 }
 
+func (r *Reconciler) isRunningEnvVarGroup(o client.Object) bool {
+	return o.GetNamespace() == r.controllerConfig.CFRootNamespace && o.GetName() == korifiv1alpha1.RunningEnvVarGroupName
+}
+
+// enqueueAllCFProcessRequests re-rolls all processes on the platform, as
+// changes to the running env var group affect the env of every app
+func (r *Reconciler) enqueueAllCFProcessRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList)
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses failed: %w", err), "envVarGroup", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range processList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&processList.Items[i])})
+	}
+
+	return requests
+}
+
 func (r *Reconciler) enqueueCFProcessRequestsForApp(ctx context.Context, o client.Object) []reconcile.Request {
 	return r.cfProcessRequestsForAppGUID(ctx, o.GetNamespace(), o.GetName())
 }
@@ -127,6 +155,7 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfenvvargroups,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -239,7 +268,6 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err = r.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -284,6 +312,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		}
 		appWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
 		appWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
+		appWorkload.Spec.Sidecars = sidecarsForProcess(cfProcess, cfApp)
 		appWorkload.Spec.AppGUID = cfApp.Name
 		appWorkload.Spec.Image = cfBuild.Status.Droplet.Registry.Image
 		appWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
@@ -295,6 +324,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 		appWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 		appWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
+		appWorkload.Spec.ReadinessProbe = readinessProbe(cfProcess, appPorts)
 		appWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
 
 		if appWorkload.CreationTimestamp.IsZero() {
@@ -379,6 +409,10 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 		cmd = process.Spec.DetectedCommand
 	}
 
+	return commandForApp(cmd, app)
+}
+
+func commandForApp(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if cmd == "" {
 		return []string{}
 	}
@@ -390,13 +424,37 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 	return []string{"/bin/sh", "-c", cmd}
 }
 
-func makeProbeHandler(cfProcess *korifiv1alpha1.CFProcess, port int32) corev1.ProbeHandler {
+func sidecarsForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []korifiv1alpha1.AppWorkloadSidecar {
+	sidecars := []korifiv1alpha1.AppWorkloadSidecar{}
+	for _, sidecar := range app.Spec.Sidecars {
+		if !slices.Contains(sidecar.ProcessTypes, process.Spec.ProcessType) {
+			continue
+		}
+
+		workloadSidecar := korifiv1alpha1.AppWorkloadSidecar{
+			Name:    sidecar.Name,
+			Command: commandForApp(sidecar.Command, app),
+		}
+		if sidecar.MemoryMB > 0 {
+			workloadSidecar.Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(sidecar.MemoryMB)},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(sidecar.MemoryMB)},
+			}
+		}
+
+		sidecars = append(sidecars, workloadSidecar)
+	}
+
+	return sidecars
+}
+
+func makeProbeHandler(healthCheckType korifiv1alpha1.HealthCheckType, httpEndpoint string, port int32) corev1.ProbeHandler {
 	var probeHandler corev1.ProbeHandler
 
-	switch cfProcess.Spec.HealthCheck.Type {
+	switch healthCheckType {
 	case korifiv1alpha1.HTTPHealthCheckType:
 		probeHandler.HTTPGet = &corev1.HTTPGetAction{
-			Path: cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
+			Path: httpEndpoint,
 			Port: intstr.FromInt32(port),
 		}
 	case korifiv1alpha1.PortHealthCheckType:
@@ -418,7 +476,7 @@ func startupProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Pr
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:   makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:   makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds: int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
 		PeriodSeconds:  2,
 		FailureThreshold: int32(cfProcess.Spec.HealthCheck.Data.TimeoutSeconds/2 +
@@ -436,13 +494,39 @@ func livenessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.P
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:     makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:     makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds:   int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
 		PeriodSeconds:    30,
 		FailureThreshold: 1,
 	}
 }
 
+// The readiness probe timeout and period default to the values the
+// kubernetes API server would otherwise set, so that the probe does not change
+// between reconciles
+const (
+	defaultReadinessTimeoutSeconds int32 = 1
+	defaultReadinessPeriodSeconds  int32 = 10
+)
+
+func readinessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Probe {
+	readinessHealthCheck := cfProcess.Spec.ReadinessHealthCheck
+	if readinessHealthCheck.Type == "" || readinessHealthCheck.Type == korifiv1alpha1.ProcessHealthCheckType {
+		return nil
+	}
+
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     makeProbeHandler(readinessHealthCheck.Type, readinessHealthCheck.Data.HTTPEndpoint, ports[0]),
+		TimeoutSeconds:   tools.IfZero(readinessHealthCheck.Data.InvocationTimeoutSeconds, defaultReadinessTimeoutSeconds),
+		PeriodSeconds:    tools.IfZero(readinessHealthCheck.Data.IntervalSeconds, defaultReadinessPeriodSeconds),
+		FailureThreshold: 1,
+	}
+}
+
 func mebibyteQuantity(miB int64) resource.Quantity {
 	return *resource.NewQuantity(miB*1024*1024, resource.BinarySI)
 }
@@ -366,6 +366,60 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the CFProcess has an http readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 4,
+						IntervalSeconds:          7,
+					},
+				}
+			})
+
+			It("sets the readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Path).To(Equal("/ready"))
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(8080))
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(7))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(4))
+					g.Expect(appWorkload.Spec.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(1))
+				})
+			})
+		})
+
+		When("the CFProcess has a readiness health check with no interval or timeout", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{
+					Type: "port",
+				}
+			})
+
+			It("defaults the readiness probe period and timeout", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.TCPSocket).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(10))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(1))
+				})
+			})
+		})
+
+		When("the CFProcess has a process readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{Type: "process"}
+			})
+
+			It("does not set the readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).To(BeNil())
+				})
+			})
+		})
+
 		When("the app workload actual instances are set", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -454,6 +508,79 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app has sidecars", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Sidecars = []korifiv1alpha1.CFAppSidecar{
+						{
+							GUID:         "sidecar-1-guid",
+							Name:         "sidecar-1",
+							Command:      "sidecar-1 command",
+							ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb},
+							MemoryMB:     64,
+						},
+						{
+							GUID:         "sidecar-2-guid",
+							Name:         "sidecar-2",
+							Command:      "sidecar-2 command",
+							ProcessTypes: []string{"worker"},
+						},
+					}
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Status.ObservedGeneration = cfApp.Generation
+				})).To(Succeed())
+			})
+
+			It("sets the sidecars attached to the process type on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(1))
+					sidecar := appWorkload.Spec.Sidecars[0]
+					g.Expect(sidecar.Name).To(Equal("sidecar-1"))
+					g.Expect(sidecar.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "sidecar-1 command"}))
+					g.Expect(sidecar.Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+					g.Expect(sidecar.Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+				})
+			})
+		})
+
+		When("the running env var group changes", func() {
+			var envVarGroup *korifiv1alpha1.CFEnvVarGroup
+
+			BeforeEach(func() {
+				envVarGroup = &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+				}
+				Expect(adminClient.Create(ctx, envVarGroup)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(adminClient.Delete(ctx, envVarGroup)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Env).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Name": Equal("HTTP_PROXY")})))
+				})
+
+				Expect(k8s.PatchResource(ctx, adminClient, envVarGroup, func() {
+					envVarGroup.Spec.Env = map[string]string{"HTTP_PROXY": "http://proxy.example.com"}
+				})).To(Succeed())
+			})
+
+			It("updates the app workload env", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Env).To(ContainElement(corev1.EnvVar{
+						Name:  "HTTP_PROXY",
+						Value: "http://proxy.example.com",
+					}))
+				})
+			})
+		})
+
 		When("the app bindings change after the workload has been created", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -0,0 +1,207 @@
+package usageevents
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ProcessReconciler records an app usage event whenever the desired state,
+// instance count or memory limit of a CFProcess changes, and a STOPPED event
+// before a running CFProcess is deleted
+type ProcessReconciler struct {
+	recorder
+	log logr.Logger
+}
+
+func NewProcessReconciler(
+	client client.Client,
+	reader client.Reader,
+	log logr.Logger,
+	rootNamespace string,
+) *ProcessReconciler {
+	return &ProcessReconciler{
+		recorder: recorder{
+			k8sClient:     client,
+			reader:        reader,
+			rootNamespace: rootNamespace,
+		},
+		log: log,
+	}
+}
+
+func (r *ProcessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cfprocess-usage-events").
+		For(&korifiv1alpha1.CFProcess{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, finalizersChangedPredicate))).
+		Watches(
+			&korifiv1alpha1.CFApp{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForApp),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+func (r *ProcessReconciler) enqueueCFProcessRequestsForApp(ctx context.Context, o client.Object) []reconcile.Request {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList, client.InNamespace(o.GetNamespace()), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: o.GetName()})
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses for CFApp guid failed: %w", err), "cfAppGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range processList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&processList.Items[i])})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch
+
+func (r *ProcessReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("ProcessUsageEvents").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name)
+
+	cfProcess := &korifiv1alpha1.CFProcess{}
+	found, err := r.getResource(ctx, req.NamespacedName, cfProcess)
+	if err != nil {
+		log.Info("unable to fetch process", "reason", err)
+		return ctrl.Result{}, err
+	}
+	if !found {
+		return ctrl.Result{}, nil
+	}
+
+	if cfProcess.GetDeletionTimestamp().IsZero() {
+		err = r.addFinalizer(ctx, cfProcess)
+		if err != nil {
+			log.Info("failed to add finalizer", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	lastEvent, err := r.lastEvent(ctx, cfProcess)
+	if err != nil {
+		log.Info("failed to get last usage event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	spec, err := r.currentUsage(ctx, cfProcess, lastEvent)
+	if err != nil {
+		log.Info("failed to compute process usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if spec != nil && appUsageChanged(lastEvent, spec) {
+		if lastEvent != nil {
+			spec.PreviousState = lastEvent.Spec.State
+			spec.App.PreviousInstanceCount = tools.PtrTo(lastEvent.Spec.App.InstanceCount)
+			spec.App.PreviousMemoryInMBPerInstance = tools.PtrTo(lastEvent.Spec.App.MemoryInMBPerInstance)
+		}
+
+		log.V(1).Info("recording app usage event", "state", spec.State, "instances", spec.App.InstanceCount)
+		err = r.record(ctx, cfProcess, *spec)
+		if err != nil {
+			log.Info("failed to record app usage event", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if !cfProcess.GetDeletionTimestamp().IsZero() {
+		err = r.removeFinalizer(ctx, cfProcess)
+		if err != nil {
+			log.Info("failed to remove finalizer", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// currentUsage returns the usage of the process as it should be recorded,
+// or nil if the process is being deleted and nothing was ever recorded for it
+func (r *ProcessReconciler) currentUsage(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, lastEvent *korifiv1alpha1.CFUsageEvent) (*korifiv1alpha1.CFUsageEventSpec, error) {
+	if !cfProcess.GetDeletionTimestamp().IsZero() {
+		return stoppedUsage(lastEvent), nil
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfProcess.Namespace, Name: cfProcess.Spec.AppRef.Name}, cfApp)
+	if k8serrors.IsNotFound(err) {
+		return stoppedUsage(lastEvent), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %w", err)
+	}
+
+	spaceName, orgGUID, err := r.spaceDetails(ctx, cfProcess.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	state := korifiv1alpha1.UsageEventStateStopped
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState && cfApp.GetDeletionTimestamp().IsZero() {
+		state = korifiv1alpha1.UsageEventStateStarted
+	}
+
+	return &korifiv1alpha1.CFUsageEventSpec{
+		Type:      korifiv1alpha1.UsageEventTypeApp,
+		State:     state,
+		SpaceGUID: cfProcess.Namespace,
+		SpaceName: spaceName,
+		OrgGUID:   orgGUID,
+		App: &korifiv1alpha1.CFAppUsage{
+			AppGUID:               cfApp.Name,
+			AppName:               cfApp.Spec.DisplayName,
+			ProcessGUID:           cfProcess.Name,
+			ProcessType:           cfProcess.Spec.ProcessType,
+			InstanceCount:         tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+			MemoryInMBPerInstance: cfProcess.Spec.MemoryMB,
+		},
+	}, nil
+}
+
+// stoppedUsage returns the last recorded usage of a process that no longer
+// exists, marked as stopped
+func stoppedUsage(lastEvent *korifiv1alpha1.CFUsageEvent) *korifiv1alpha1.CFUsageEventSpec {
+	if lastEvent == nil {
+		return nil
+	}
+
+	spec := lastEvent.Spec.DeepCopy()
+	spec.State = korifiv1alpha1.UsageEventStateStopped
+	spec.PreviousState = ""
+	spec.App.PreviousInstanceCount = nil
+	spec.App.PreviousMemoryInMBPerInstance = nil
+	return spec
+}
+
+func appUsageChanged(lastEvent *korifiv1alpha1.CFUsageEvent, spec *korifiv1alpha1.CFUsageEventSpec) bool {
+	if lastEvent == nil {
+		// A process that has never run does not use anything
+		return spec.State == korifiv1alpha1.UsageEventStateStarted
+	}
+
+	if lastEvent.Spec.State != spec.State {
+		return true
+	}
+
+	// Scaling a stopped process does not change its usage
+	return spec.State == korifiv1alpha1.UsageEventStateStarted && (lastEvent.Spec.App.InstanceCount != spec.App.InstanceCount ||
+		lastEvent.Spec.App.MemoryInMBPerInstance != spec.App.MemoryInMBPerInstance)
+}
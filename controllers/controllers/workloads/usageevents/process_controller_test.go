@@ -0,0 +1,207 @@
+package usageevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ProcessReconciler", func() {
+	var (
+		cfApp     *korifiv1alpha1.CFApp
+		cfProcess *korifiv1alpha1.CFProcess
+	)
+
+	BeforeEach(func() {
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType: "web",
+				HealthCheck: korifiv1alpha1.HealthCheck{
+					Type: "process",
+				},
+				DesiredInstances: tools.PtrTo[int32](1),
+				MemoryMB:         256,
+				DiskQuotaMB:      512,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+	})
+
+	It("records a STARTED app usage event", func() {
+		Eventually(func(g Gomega) {
+			events := listUsageEvents(g, cfProcess.Name)
+			g.Expect(events).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+					"Labels": MatchAllKeys(Keys{
+						korifiv1alpha1.CFUsageEventTypeLabelKey:         Equal(korifiv1alpha1.UsageEventTypeApp),
+						korifiv1alpha1.CFUsageEventResourceGUIDLabelKey: Equal(cfProcess.Name),
+						korifiv1alpha1.SpaceGUIDKey:                     Equal(testNamespace),
+					}),
+				}),
+				"Spec": Equal(korifiv1alpha1.CFUsageEventSpec{
+					Type:      korifiv1alpha1.UsageEventTypeApp,
+					State:     korifiv1alpha1.UsageEventStateStarted,
+					SpaceGUID: testNamespace,
+					SpaceName: "my-space",
+					OrgGUID:   "org-guid",
+					App: &korifiv1alpha1.CFAppUsage{
+						AppGUID:               cfApp.Name,
+						AppName:               "my-app",
+						ProcessGUID:           cfProcess.Name,
+						ProcessType:           "web",
+						InstanceCount:         1,
+						MemoryInMBPerInstance: 256,
+					},
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("annotates the process with the last usage event and adds a finalizer", func() {
+		Eventually(func(g Gomega) {
+			events := listUsageEvents(g, cfProcess.Name)
+			g.Expect(events).To(HaveLen(1))
+
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFLastUsageEventGUIDAnnotationKey, events[0].Name))
+			g.Expect(cfProcess.Finalizers).To(ContainElement(korifiv1alpha1.CFUsageEventsFinalizerName))
+		}).Should(Succeed())
+	})
+
+	When("the process is scaled", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+				cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+			})).To(Succeed())
+		})
+
+		It("records the change along with the previous values", func() {
+			Eventually(func(g Gomega) {
+				events := listUsageEvents(g, cfProcess.Name)
+				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":         Equal(korifiv1alpha1.UsageEventStateStarted),
+						"PreviousState": Equal(korifiv1alpha1.UsageEventStateStarted),
+						"App": PointTo(MatchFields(IgnoreExtras, Fields{
+							"InstanceCount":         BeEquivalentTo(3),
+							"PreviousInstanceCount": PointTo(BeEquivalentTo(1)),
+						})),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app is stopped", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+			})).To(Succeed())
+		})
+
+		It("records a STOPPED app usage event", func() {
+			Eventually(func(g Gomega) {
+				events := listUsageEvents(g, cfProcess.Name)
+				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":         Equal(korifiv1alpha1.UsageEventStateStopped),
+						"PreviousState": Equal(korifiv1alpha1.UsageEventStateStarted),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the stopped process is scaled", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listUsageEvents(g, cfProcess.Name)).To(HaveLen(2))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				})).To(Succeed())
+			})
+
+			It("does not record an event", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(listUsageEvents(g, cfProcess.Name)).To(HaveLen(2))
+				}, "2s").Should(Succeed())
+			})
+		})
+	})
+
+	When("the process is deleted", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfProcess)).To(Succeed())
+		})
+
+		It("records a STOPPED app usage event with the last known details", func() {
+			Eventually(func(g Gomega) {
+				events := listUsageEvents(g, cfProcess.Name)
+				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State": Equal(korifiv1alpha1.UsageEventStateStopped),
+						"App": PointTo(MatchFields(IgnoreExtras, Fields{
+							"AppName":       Equal("my-app"),
+							"InstanceCount": BeEquivalentTo(1),
+						})),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("removes the finalizer", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,161 @@
+package usageevents
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfusageevents,verbs=get;create
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// recorder appends usage events to the root namespace. Events are never
+// updated, so the current state of a resource is given by the last event
+// recorded for it. The guid of that event is annotated on the resource, and
+// both the resource and the event are read bypassing the cache, so that an
+// event that has just been recorded is never recorded twice. Resources carry
+// a finalizer until their final event has been recorded, so that deleting a
+// resource while the controller is down does not lose the event.
+type recorder struct {
+	k8sClient     client.Client
+	reader        client.Reader
+	rootNamespace string
+}
+
+// getResource reads the resource bypassing the cache. It returns false if the
+// resource is gone.
+func (r *recorder) getResource(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
+	err := r.reader.Get(ctx, key, obj)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %T %v: %w", obj, key, err)
+	}
+
+	return true, nil
+}
+
+// lastEvent returns the last event recorded for the resource, or nil if none
+// has been recorded yet or the last one has expired
+func (r *recorder) lastEvent(ctx context.Context, obj client.Object) (*korifiv1alpha1.CFUsageEvent, error) {
+	guid := obj.GetAnnotations()[korifiv1alpha1.CFLastUsageEventGUIDAnnotationKey]
+	if guid == "" {
+		return nil, nil
+	}
+
+	event := &korifiv1alpha1.CFUsageEvent{}
+	err := r.reader.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, event)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage event %q: %w", guid, err)
+	}
+
+	return event, nil
+}
+
+// record creates a usage event for the resource and annotates the resource
+// with its guid
+func (r *recorder) record(ctx context.Context, obj client.Object, spec korifiv1alpha1.CFUsageEventSpec) error {
+	// Version 7 UUIDs are time-ordered, which lets consumers page through
+	// events in the order they were recorded by comparing their GUIDs
+	guid, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate usage event guid: %w", err)
+	}
+
+	event := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid.String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFUsageEventTypeLabelKey:         spec.Type,
+				korifiv1alpha1.CFUsageEventResourceGUIDLabelKey: obj.GetName(),
+				korifiv1alpha1.SpaceGUIDKey:                     spec.SpaceGUID,
+			},
+		},
+		Spec: spec,
+	}
+
+	err = r.k8sClient.Create(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+
+	return k8s.PatchResource(ctx, r.k8sClient, obj, func() {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[korifiv1alpha1.CFLastUsageEventGUIDAnnotationKey] = event.Name
+		obj.SetAnnotations(annotations)
+	})
+}
+
+// addFinalizer makes sure that the resource is not deleted before its final
+// usage event has been recorded
+func (r *recorder) addFinalizer(ctx context.Context, obj client.Object) error {
+	if controllerutil.ContainsFinalizer(obj, korifiv1alpha1.CFUsageEventsFinalizerName) {
+		return nil
+	}
+
+	return k8s.PatchResource(ctx, r.k8sClient, obj, func() {
+		controllerutil.AddFinalizer(obj, korifiv1alpha1.CFUsageEventsFinalizerName)
+	})
+}
+
+func (r *recorder) removeFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.ContainsFinalizer(obj, korifiv1alpha1.CFUsageEventsFinalizerName) {
+		return nil
+	}
+
+	return k8s.PatchResource(ctx, r.k8sClient, obj, func() {
+		controllerutil.RemoveFinalizer(obj, korifiv1alpha1.CFUsageEventsFinalizerName)
+	})
+}
+
+// hasOtherFinalizers returns true while other controllers are still cleaning
+// up the deleted resource
+func hasOtherFinalizers(obj client.Object) bool {
+	for _, finalizer := range obj.GetFinalizers() {
+		if finalizer != korifiv1alpha1.CFUsageEventsFinalizerName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// finalizersChangedPredicate lets through the updates of deleted resources
+// whose finalizers change, as they do not change the resource generation
+var finalizersChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !e.ObjectNew.GetDeletionTimestamp().IsZero() &&
+			len(e.ObjectNew.GetFinalizers()) != len(e.ObjectOld.GetFinalizers())
+	},
+}
+
+// spaceDetails returns the name of the space and the guid of its org, as
+// recorded on the space namespace
+func (r *recorder) spaceDetails(ctx context.Context, spaceGUID string) (string, string, error) {
+	namespace := &corev1.Namespace{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, namespace)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get namespace %q: %w", spaceGUID, err)
+	}
+
+	return namespace.Annotations[korifiv1alpha1.SpaceNameKey], namespace.Labels[korifiv1alpha1.OrgGUIDKey], nil
+}
@@ -0,0 +1,135 @@
+package usageevents
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ServiceInstanceReconciler records a service usage event when a
+// CFServiceInstance is created and when it has been deprovisioned
+type ServiceInstanceReconciler struct {
+	recorder
+	log logr.Logger
+}
+
+func NewServiceInstanceReconciler(
+	client client.Client,
+	reader client.Reader,
+	log logr.Logger,
+	rootNamespace string,
+) *ServiceInstanceReconciler {
+	return &ServiceInstanceReconciler{
+		recorder: recorder{
+			k8sClient:     client,
+			reader:        reader,
+			rootNamespace: rootNamespace,
+		},
+		log: log,
+	}
+}
+
+func (r *ServiceInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cfserviceinstance-usage-events").
+		For(&korifiv1alpha1.CFServiceInstance{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, finalizersChangedPredicate))).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;patch
+
+func (r *ServiceInstanceReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("ServiceInstanceUsageEvents").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name)
+
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{}
+	found, err := r.getResource(ctx, req.NamespacedName, cfServiceInstance)
+	if err != nil {
+		log.Info("unable to fetch service instance", "reason", err)
+		return ctrl.Result{}, err
+	}
+	if !found {
+		return ctrl.Result{}, nil
+	}
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.finalize(ctx, log, cfServiceInstance)
+	}
+
+	err = r.addFinalizer(ctx, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to add finalizer", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfServiceInstance.Annotations[korifiv1alpha1.CFLastUsageEventGUIDAnnotationKey] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	spaceName, orgGUID, err := r.spaceDetails(ctx, cfServiceInstance.Namespace)
+	if err != nil {
+		log.Info("failed to get space details", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.recordEvent(ctx, log, cfServiceInstance, korifiv1alpha1.CFUsageEventSpec{
+		Type:      korifiv1alpha1.UsageEventTypeService,
+		State:     korifiv1alpha1.UsageEventStateCreated,
+		SpaceGUID: cfServiceInstance.Namespace,
+		SpaceName: spaceName,
+		OrgGUID:   orgGUID,
+		ServiceInstance: &korifiv1alpha1.CFServiceInstanceUsage{
+			GUID:     cfServiceInstance.Name,
+			Name:     cfServiceInstance.Spec.DisplayName,
+			Type:     cfServiceInstance.Spec.Type,
+			PlanGUID: cfServiceInstance.Spec.PlanGUID,
+		},
+	})
+}
+
+// finalize records the DELETED event once the service instance has been
+// deprovisioned, i.e. once all other finalizers are gone
+func (r *ServiceInstanceReconciler) finalize(ctx context.Context, log logr.Logger, cfServiceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if hasOtherFinalizers(cfServiceInstance) {
+		return nil
+	}
+
+	lastEvent, err := r.lastEvent(ctx, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to get last usage event", "reason", err)
+		return err
+	}
+
+	if lastEvent != nil && lastEvent.Spec.State != korifiv1alpha1.UsageEventStateDeleted {
+		spec := lastEvent.Spec.DeepCopy()
+		spec.State = korifiv1alpha1.UsageEventStateDeleted
+		spec.PreviousState = lastEvent.Spec.State
+		err = r.recordEvent(ctx, log, cfServiceInstance, *spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.removeFinalizer(ctx, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to remove finalizer", "reason", err)
+	}
+	return err
+}
+
+func (r *ServiceInstanceReconciler) recordEvent(ctx context.Context, log logr.Logger, cfServiceInstance *korifiv1alpha1.CFServiceInstance, spec korifiv1alpha1.CFUsageEventSpec) error {
+	log.V(1).Info("recording service usage event", "state", spec.State)
+	err := r.record(ctx, cfServiceInstance, spec)
+	if err != nil {
+		log.Info("failed to record service usage event", "reason", err)
+	}
+	return err
+}
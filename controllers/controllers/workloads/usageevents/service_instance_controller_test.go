@@ -0,0 +1,122 @@
+package usageevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("ServiceInstanceReconciler", func() {
+	var cfServiceInstance *korifiv1alpha1.CFServiceInstance
+
+	BeforeEach(func() {
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "my-service-instance",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    "plan-guid",
+			},
+		}
+		Expect(adminClient.Create(ctx, cfServiceInstance)).To(Succeed())
+	})
+
+	It("records a CREATED service usage event", func() {
+		Eventually(func(g Gomega) {
+			events := listUsageEvents(g, cfServiceInstance.Name)
+			g.Expect(events).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Spec": Equal(korifiv1alpha1.CFUsageEventSpec{
+					Type:      korifiv1alpha1.UsageEventTypeService,
+					State:     korifiv1alpha1.UsageEventStateCreated,
+					SpaceGUID: testNamespace,
+					SpaceName: "my-space",
+					OrgGUID:   "org-guid",
+					ServiceInstance: &korifiv1alpha1.CFServiceInstanceUsage{
+						GUID:     cfServiceInstance.Name,
+						Name:     "my-service-instance",
+						Type:     korifiv1alpha1.ManagedType,
+						PlanGUID: "plan-guid",
+					},
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("adds a finalizer", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+			g.Expect(cfServiceInstance.Finalizers).To(ContainElement(korifiv1alpha1.CFUsageEventsFinalizerName))
+		}).Should(Succeed())
+	})
+
+	When("the service instance is deleted", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfServiceInstance.Name)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+		})
+
+		It("removes the finalizer", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("records a DELETED service usage event", func() {
+			Eventually(func(g Gomega) {
+				events := listUsageEvents(g, cfServiceInstance.Name)
+				g.Expect(events).To(HaveLen(2))
+				g.Expect(events).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":         Equal(korifiv1alpha1.UsageEventStateDeleted),
+						"PreviousState": Equal(korifiv1alpha1.UsageEventStateCreated),
+						"ServiceInstance": PointTo(MatchFields(IgnoreExtras, Fields{
+							"Name": Equal("my-service-instance"),
+						})),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance is being deprovisioned", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfServiceInstance.Name)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+				controllerutil.AddFinalizer(cfServiceInstance, "test/deprovisioning")
+			})).To(Succeed())
+			Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+		})
+
+		It("does not record a DELETED event until the deprovisioning is done", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfServiceInstance.Name)).To(HaveLen(1))
+			}, "2s").Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+				controllerutil.RemoveFinalizer(cfServiceInstance, "test/deprovisioning")
+			})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(listUsageEvents(g, cfServiceInstance.Name)).To(HaveLen(2))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package usageevents
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// UsageEventReconciler deletes CFUsageEvents once they are older than the
+// configured TTL, so that the number of recorded events stays bounded
+type UsageEventReconciler struct {
+	k8sClient             client.Client
+	log                   logr.Logger
+	usageEventTTLDuration time.Duration
+}
+
+func NewUsageEventReconciler(
+	client client.Client,
+	log logr.Logger,
+	usageEventTTLDuration time.Duration,
+) *UsageEventReconciler {
+	return &UsageEventReconciler{
+		k8sClient:             client,
+		log:                   log,
+		usageEventTTLDuration: usageEventTTLDuration,
+	}
+}
+
+func (r *UsageEventReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFUsageEvent{}).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfusageevents,verbs=get;list;watch;delete
+
+func (r *UsageEventReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("UsageEvents").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name)
+
+	cfUsageEvent := &korifiv1alpha1.CFUsageEvent{}
+	err := r.k8sClient.Get(ctx, req.NamespacedName, cfUsageEvent)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch usage event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if !cfUsageEvent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiresIn := time.Until(cfUsageEvent.CreationTimestamp.Add(r.usageEventTTLDuration))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	log.V(1).Info("deleting-expired-usage-event")
+	err = r.k8sClient.Delete(ctx, cfUsageEvent)
+	if err != nil {
+		log.Info("error-deleting-usage-event", "reason", err)
+	}
+	return ctrl.Result{}, client.IgnoreNotFound(err)
+}
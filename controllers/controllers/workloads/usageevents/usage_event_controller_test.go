@@ -0,0 +1,46 @@
+package usageevents_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UsageEventReconciler", func() {
+	var cfUsageEvent *korifiv1alpha1.CFUsageEvent
+
+	BeforeEach(func() {
+		cfUsageEvent = &korifiv1alpha1.CFUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFUsageEventSpec{
+				Type:      korifiv1alpha1.UsageEventTypeService,
+				State:     korifiv1alpha1.UsageEventStateCreated,
+				SpaceGUID: testNamespace,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfUsageEvent)).To(Succeed())
+	})
+
+	It("keeps the usage event until it expires", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfUsageEvent), &korifiv1alpha1.CFUsageEvent{})).To(Succeed())
+		}, "2s").Should(Succeed())
+	})
+
+	It("deletes the usage event after it expires", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfUsageEvent), &korifiv1alpha1.CFUsageEvent{})
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).WithTimeout(usageEventTTL + 10*time.Second).Should(Succeed())
+	})
+})
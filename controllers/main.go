@@ -38,8 +38,11 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/auditevents"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/dockerfile"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/buildcaches"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
@@ -47,6 +50,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/spaces"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/usageevents"
 	"code.cloudfoundry.org/korifi/controllers/coordination"
 	"code.cloudfoundry.org/korifi/controllers/k8s"
 	controllersfinalizer "code.cloudfoundry.org/korifi/controllers/webhooks/finalizer"
@@ -64,6 +68,7 @@ import (
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
+	stackswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/stacks"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
@@ -182,6 +187,13 @@ func main() {
 			os.Exit(1)
 		}
 
+		var stagingTimeout time.Duration
+		stagingTimeout, err = controllerConfig.ParseStagingTimeout()
+		if err != nil {
+			setupLog.Error(err, "failed to parse staging timeout", "stagingTimeout", controllerConfig.StagingTimeout)
+			os.Exit(1)
+		}
+
 		buildCleaner := cleanup.NewBuildCleaner(controllersClient, controllerConfig.MaxRetainedBuildsPerApp)
 		if err = buildpack.NewReconciler(
 			controllersClient,
@@ -189,7 +201,8 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewAppEnvBuilder(controllersClient),
+			env.NewAppEnvBuilder(controllersClient, controllerConfig.CFRootNamespace, korifiv1alpha1.StagingEnvVarGroupName),
+			stagingTimeout,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -201,11 +214,36 @@ func main() {
 			imageClient,
 			mgr.GetScheme(),
 			controllersLog,
+			controllerConfig.CFRootNamespace,
+			stagingTimeout,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFDockerBuild")
 			os.Exit(1)
 		}
 
+		if err = dockerfile.NewReconciler(
+			controllersClient,
+			buildCleaner,
+			mgr.GetScheme(),
+			controllersLog,
+			controllerConfig,
+			stagingTimeout,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFDockerfileBuild")
+			os.Exit(1)
+		}
+
+		if err = buildcaches.NewReconciler(
+			controllersClient,
+			mgr.GetAPIReader(),
+			controllersLog,
+			controllerConfig.BuilderName,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFBuildCacheClear")
+			os.Exit(1)
+		}
+
 		if err = packages.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -223,7 +261,7 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewProcessEnvBuilder(controllersClient),
+			env.NewProcessEnvBuilder(controllersClient, controllerConfig.CFRootNamespace),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -295,13 +333,85 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			controllersLog,
-			env.NewAppEnvBuilder(controllersClient),
+			env.NewAppEnvBuilder(controllersClient, controllerConfig.CFRootNamespace, korifiv1alpha1.RunningEnvVarGroupName),
 			taskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		var auditEventTTL time.Duration
+		auditEventTTL, err = controllerConfig.ParseAuditEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event TTL", "controller", "CFAuditEvent", "auditEventTTL", controllerConfig.AuditEventTTL)
+			os.Exit(1)
+		}
+		if err = auditevents.NewReconciler(
+			controllersClient,
+			controllersLog,
+			auditEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAuditEvent")
+			os.Exit(1)
+		}
+
+		if controllerConfig.RegistryGC.Enabled {
+			var registryGCInterval time.Duration
+			registryGCInterval, err = controllerConfig.ParseRegistryGCInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse registry GC interval", "interval", controllerConfig.RegistryGC.Interval)
+				os.Exit(1)
+			}
+			if err = mgr.Add(cleanup.NewRegistryCollector(
+				mgr.GetAPIReader(),
+				imageClient,
+				controllersLog,
+				controllerConfig.CFRootNamespace,
+				controllerConfig.ContainerRegistrySecretNames,
+				controllerConfig.ContainerRepositoryPrefix,
+				registryGCInterval,
+				controllerConfig.RegistryGC.DryRun,
+			)); err != nil {
+				setupLog.Error(err, "unable to add registry collector")
+				os.Exit(1)
+			}
+		}
+
+		if err = usageevents.NewProcessReconciler(
+			controllersClient,
+			mgr.GetAPIReader(),
+			controllersLog,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFProcessUsageEvents")
+			os.Exit(1)
+		}
+
+		if err = usageevents.NewServiceInstanceReconciler(
+			controllersClient,
+			mgr.GetAPIReader(),
+			controllersLog,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceInstanceUsageEvents")
+			os.Exit(1)
+		}
+
+		var usageEventTTL time.Duration
+		usageEventTTL, err = controllerConfig.ParseUsageEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse usage event TTL", "controller", "CFUsageEvent", "usageEventTTL", controllerConfig.UsageEventTTL)
+			os.Exit(1)
+		}
+		if err = usageevents.NewUsageEventReconciler(
+			controllersClient,
+			controllersLog,
+			usageEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFUsageEvent")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
@@ -428,6 +538,7 @@ func main() {
 
 		if err = domainswebhook.NewValidator(
 			uncachedClient,
+			controllerConfig.CFRootNamespace,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFDomain")
 			os.Exit(1)
@@ -449,6 +560,13 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = stackswebhook.NewValidator(
+			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, stackswebhook.StackEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFStack")
+			os.Exit(1)
+		}
+
 		if err = brokerswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, brokerswebhook.ServiceBrokerEntityType)),
 		).SetupWebhookWithManager(mgr); err != nil {
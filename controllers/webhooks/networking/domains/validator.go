@@ -19,6 +19,7 @@ package domains
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -36,9 +37,12 @@ import (
 )
 
 const (
-	DomainDecodingErrorType  = "DomainDecodingError"
-	DuplicateDomainErrorType = "DuplicateDomainError"
-	InvalidDomainErrorType   = "InvalidDomainError"
+	DomainDecodingErrorType         = "DomainDecodingError"
+	DuplicateDomainErrorType        = "DuplicateDomainError"
+	InvalidDomainErrorType          = "InvalidDomainError"
+	InvalidDomainSharingErrorType   = "InvalidDomainSharingError"
+	SharedDomainSharingErrorMessage = "Shared domains cannot be shared with organizations"
+	OwningOrgSharingErrorMessage    = "A private domain cannot be shared with the organization that owns it"
 )
 
 // log is for logging in this package.
@@ -47,14 +51,16 @@ var log = logf.Log.WithName("domain-validation")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfdomain,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfdomains,verbs=create;update,versions=v1alpha1,name=vcfdomain.korifi.cloudfoundry.org,admissionReviewVersions=v1
 
 type Validator struct {
-	client client.Client
+	client        client.Client
+	rootNamespace string
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(client client.Client) *Validator {
+func NewValidator(client client.Client, rootNamespace string) *Validator {
 	return &Validator{
-		client: client,
+		client:        client,
+		rootNamespace: rootNamespace,
 	}
 }
 
@@ -95,7 +101,7 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		}.ExportJSONError()
 	}
 
-	return nil, nil
+	return nil, v.validateSharedOrganizations(domain)
 }
 
 func validateDomainName(domainName string) error {
@@ -124,7 +130,29 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, o
 		}.ExportJSONError()
 	}
 
-	return nil, nil
+	return nil, v.validateSharedOrganizations(domain)
+}
+
+func (v *Validator) validateSharedOrganizations(domain *korifiv1alpha1.CFDomain) error {
+	if len(domain.Spec.SharedOrganizations) == 0 {
+		return nil
+	}
+
+	if domain.Namespace == v.rootNamespace {
+		return validationwebhook.ValidationError{
+			Type:    InvalidDomainSharingErrorType,
+			Message: SharedDomainSharingErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if slices.Contains(domain.Spec.SharedOrganizations, domain.Namespace) {
+		return validationwebhook.ValidationError{
+			Type:    InvalidDomainSharingErrorType,
+			Message: OwningOrgSharingErrorMessage,
+		}.ExportJSONError()
+	}
+
+	return nil
 }
 
 func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
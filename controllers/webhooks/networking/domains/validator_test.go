@@ -63,7 +63,7 @@ var _ = Describe("CFDomainValidator", func() {
 
 		requestDomainName = "foo.example.com"
 
-		validatingWebhook = domains.NewValidator(fakeClient)
+		validatingWebhook = domains.NewValidator(fakeClient, rootNamespace)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -150,6 +150,52 @@ var _ = Describe("CFDomainValidator", func() {
 				))
 			})
 		})
+
+		When("a shared domain is shared with organizations", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.SharedOrganizations = []string{"some-org"}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					domains.InvalidDomainSharingErrorType,
+					Equal(domains.SharedDomainSharingErrorMessage),
+				))
+			})
+		})
+
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				requestDomainCR.Namespace = "owning-org"
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("it is shared with other organizations", func() {
+				BeforeEach(func() {
+					requestDomainCR.Spec.SharedOrganizations = []string{"another-org"}
+				})
+
+				It("does not return an error", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("it is shared with its owning organization", func() {
+				BeforeEach(func() {
+					requestDomainCR.Spec.SharedOrganizations = []string{"another-org", "owning-org"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						domains.InvalidDomainSharingErrorType,
+						Equal(domains.OwningOrgSharingErrorMessage),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -183,6 +229,32 @@ var _ = Describe("CFDomainValidator", func() {
 				Expect(retErr).NotTo(HaveOccurred())
 			})
 		})
+
+		When("the shared organizations of a private domain are updated", func() {
+			BeforeEach(func() {
+				oldCFDomain.Namespace = "owning-org"
+				updatedCFDomain = oldCFDomain.DeepCopy()
+				updatedCFDomain.Spec.SharedOrganizations = []string{"another-org"}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("a shared domain is updated to be shared with organizations", func() {
+			BeforeEach(func() {
+				updatedCFDomain = oldCFDomain.DeepCopy()
+				updatedCFDomain.Spec.SharedOrganizations = []string{"another-org"}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					domains.InvalidDomainSharingErrorType,
+					Equal(domains.SharedDomainSharingErrorMessage),
+				))
+			})
+		})
 	})
 })
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -12,6 +13,7 @@ import (
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"github.com/hashicorp/go-multierror"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,6 +34,7 @@ const (
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteDomainNotAvailableErrorType       = "RouteDomainNotAvailableError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -152,6 +155,11 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	err = v.validateDomainAvailability(ctx, route, domain)
+	if err != nil {
+		return domain, err
+	}
+
 	err = v.validateDestinations(ctx, route)
 	if err != nil {
 		return domain, err
@@ -182,6 +190,34 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 	return domain, err
 }
 
+// validateDomainAvailability ensures that private domains are only used by
+// routes in the owning org or in the orgs the domain is shared with
+func (v *Validator) validateDomainAvailability(ctx context.Context, route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if domain.Namespace == v.rootNamespace {
+		return nil
+	}
+
+	routeNamespace := &corev1.Namespace{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: route.Namespace}, routeNamespace)
+	if err != nil {
+		logger.Info("error getting route namespace", "reason", err)
+		return validationwebhook.ValidationError{
+			Type:    validationwebhook.UnknownErrorType,
+			Message: validationwebhook.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	orgGUID := routeNamespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if domain.Namespace == orgGUID || slices.Contains(domain.Spec.SharedOrganizations, orgGUID) {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteDomainNotAvailableErrorType,
+		Message: fmt.Sprintf("Domain %q is not available in the organization of the route", domain.Spec.Name),
+	}.ExportJSONError()
+}
+
 func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
@@ -282,7 +318,12 @@ func validatePath(path string) error {
 
 func (v *Validator) checkDestinationsExistInNamespace(ctx context.Context, route korifiv1alpha1.CFRoute) error {
 	for _, destination := range route.Spec.Destinations {
-		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
+		namespace := route.DestinationNamespace(destination)
+		if namespace != route.Namespace && !slices.Contains(route.Spec.SharedSpaces, namespace) {
+			return apierrors.NewNotFound(korifiv1alpha1.SchemeGroupVersion.WithResource("cfapps").GroupResource(), destination.AppRef.Name)
+		}
+
+		err := v.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
 		if err != nil {
 			return err
 		}
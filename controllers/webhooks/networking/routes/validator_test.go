@@ -33,6 +33,7 @@ var _ = Describe("CFRouteValidator", func() {
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
 		cfApp              *korifiv1alpha1.CFApp
+		routeNamespace     *v1.Namespace
 		validatingWebhook  *routes.Validator
 
 		testRouteGUID       string
@@ -45,9 +46,10 @@ var _ = Describe("CFRouteValidator", func() {
 		testDomainNamespace string
 		rootNamespace       string
 
-		getDomainError error
-		getAppError    error
-		retErr         error
+		getDomainError         error
+		getAppError            error
+		getRouteNamespaceError error
+		retErr                 error
 
 		getDomainCallCount int
 	)
@@ -66,17 +68,19 @@ var _ = Describe("CFRouteValidator", func() {
 		testRoutePath = "/my-path"
 		testDomainGUID = "domain-guid"
 		testDomainName = "test.domain.name"
-		testDomainNamespace = "domain-ns"
 		rootNamespace = "root-ns"
+		testDomainNamespace = rootNamespace
 		getDomainError = nil
 		getAppError = nil
+		getRouteNamespaceError = nil
 		getDomainCallCount = 0
 
 		cfRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, testRouteNamespace, testDomainGUID, testDomainNamespace)
 
 		cfDomain = &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: testDomainGUID,
+				Name:      testDomainGUID,
+				Namespace: testDomainNamespace,
 			},
 			Spec: korifiv1alpha1.CFDomainSpec{
 				Name: testDomainName,
@@ -85,6 +89,15 @@ var _ = Describe("CFRouteValidator", func() {
 
 		cfApp = &korifiv1alpha1.CFApp{}
 
+		routeNamespace = &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testRouteNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.OrgGUIDKey: "route-org",
+				},
+			},
+		}
+
 		duplicateValidator = new(fake.NameValidator)
 		fakeClient = new(controllerfake.Client)
 
@@ -97,6 +110,9 @@ var _ = Describe("CFRouteValidator", func() {
 			case *korifiv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return getAppError
+			case *v1.Namespace:
+				routeNamespace.DeepCopyInto(obj)
+				return getRouteNamespaceError
 			default:
 				panic("TestClient Get provided an unexpected object type")
 			}
@@ -150,6 +166,52 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				cfDomain.Namespace = "route-org"
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the domain is owned by another org", func() {
+				BeforeEach(func() {
+					cfDomain.Namespace = "another-org"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDomainNotAvailableErrorType,
+						Equal(`Domain "test.domain.name" is not available in the organization of the route`),
+					))
+				})
+
+				When("the domain is shared with the route org", func() {
+					BeforeEach(func() {
+						cfDomain.Spec.SharedOrganizations = []string{"route-org"}
+					})
+
+					It("allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("getting the route namespace fails", func() {
+				BeforeEach(func() {
+					getRouteNamespaceError = errors.New("get-ns-err")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						validationwebhook.UnknownErrorType,
+						Equal(validationwebhook.UnknownErrorMessage),
+					))
+				})
+			})
+		})
+
 		When("the host is invalid", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "inVAl!dnAme?"
@@ -294,6 +356,44 @@ var _ = Describe("CFRouteValidator", func() {
 					))
 				})
 			})
+
+			When("the destination app is in another space", func() {
+				var getAppNamespace string
+
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].AppNamespace = "other-ns"
+					fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+						switch obj := obj.(type) {
+						case *korifiv1alpha1.CFDomain:
+							cfDomain.DeepCopyInto(obj)
+						case *korifiv1alpha1.CFApp:
+							getAppNamespace = key.Namespace
+							cfApp.DeepCopyInto(obj)
+						case *v1.Namespace:
+							routeNamespace.DeepCopyInto(obj)
+						}
+						return nil
+					}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDestinationNotInSpaceErrorType,
+						Equal(routes.RouteDestinationNotInSpaceErrorMessage),
+					))
+				})
+
+				When("the route is shared with that space", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.SharedSpaces = []string{"other-ns"}
+					})
+
+					It("looks up the app in the shared space and allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+						Expect(getAppNamespace).To(Equal("other-ns"))
+					})
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,79 @@
+package stacks
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	StackEntityType = "cfstack"
+)
+
+var cfstacklog = logf.Log.WithName("cfstack-validator")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfstack,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=create;update;delete,versions=v1alpha1,name=vcfstack.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFStack{}).
+		WithValidator(v).
+		Complete()
+}
+
+type Validator struct {
+	duplicateValidator webhooks.NameValidator
+}
+
+var _ webhook.CustomValidator = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+	return &Validator{
+		duplicateValidator: duplicateValidator,
+	}
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfstacklog, stack.Namespace, stack)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	if !stack.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldStack, ok := oldObj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", oldObj))
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfstacklog, stack.Namespace, oldStack, stack)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stack, ok := obj.(*korifiv1alpha1.CFStack)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFStack but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfstacklog, stack.Namespace, stack)
+}
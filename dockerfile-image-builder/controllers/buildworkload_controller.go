@@ -0,0 +1,429 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/dockerfile-image-builder/controllers/config"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	DockerfileReconcilerName = "dockerfile-image-builder"
+	BuildWorkloadLabelKey    = "korifi.cloudfoundry.org/build-workload-name"
+
+	workspaceDir        = "/workspace"
+	outputDir           = "/output"
+	imageTarballPath    = "/output/image.tar"
+	buildkitStateDir    = "/home/user/.local/share/buildkit"
+	dockerConfigDir     = "/registry-credentials"
+	buildContainer      = "build"
+	fetcherContainer    = "fetch-source"
+	pushContainer       = "push"
+	workspaceVolume     = "workspace"
+	outputVolume        = "output"
+	buildkitStateVolume = "buildkit-state"
+	credentialsVolume   = "registry-credentials"
+
+	// the user of the rootless BuildKit image
+	builderUID = int64(1000)
+)
+
+//counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
+
+type ImageConfigGetter interface {
+	Config(ctx context.Context, creds image.Creds, imageRef string) (image.Config, error)
+}
+
+//counterfeiter:generate -o fake -fake-name RepositoryCreator . RepositoryCreator
+
+type RepositoryCreator interface {
+	CreateRepository(ctx context.Context, name string) error
+}
+
+func NewBuildWorkloadReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	config *config.Config,
+	imageConfigGetter ImageConfigGetter,
+	imageRepoCreator RepositoryCreator,
+) *k8s.PatchingReconciler[korifiv1alpha1.BuildWorkload] {
+	buildWorkloadReconciler := BuildWorkloadReconciler{
+		k8sClient:         c,
+		scheme:            scheme,
+		log:               log,
+		controllerConfig:  config,
+		imageConfigGetter: imageConfigGetter,
+		imageRepoCreator:  imageRepoCreator,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.BuildWorkload](log, c, &buildWorkloadReconciler)
+}
+
+// BuildWorkloadReconciler builds the Dockerfile at the root of the package
+// source of BuildWorkloads with the configured builder image (rootless
+// BuildKit) in a Job
+type BuildWorkloadReconciler struct {
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	log               logr.Logger
+	controllerConfig  *config.Config
+	imageConfigGetter ImageConfigGetter
+	imageRepoCreator  RepositoryCreator
+}
+
+func (r *BuildWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.BuildWorkload{}).
+		Owns(&batchv1.Job{}).
+		WithEventFilter(predicate.NewPredicateFuncs(filterBuildWorkloads))
+}
+
+func filterBuildWorkloads(object client.Object) bool {
+	buildWorkload, ok := object.(*korifiv1alpha1.BuildWorkload)
+	if !ok {
+		return true
+	}
+
+	// Only reconcile buildworkloads that have their Spec.BuilderName matching this builder
+	return buildWorkload.Spec.BuilderName == DockerfileReconcilerName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+//+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch
+
+func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	buildWorkload.Status.ObservedGeneration = buildWorkload.Generation
+	log.V(1).Info("set observed generation", "generation", buildWorkload.Status.ObservedGeneration)
+
+	if !buildWorkload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if hasCompleted(buildWorkload) {
+		return ctrl.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), job)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, r.createBuildJob(ctx, log, buildWorkload)
+		}
+
+		log.Info("error when fetching build job", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	switch {
+	case jobHasCondition(job, batchv1.JobFailed):
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "BuildFailed",
+			Message:            "Check build log output",
+			ObservedGeneration: buildWorkload.Generation,
+		})
+	case jobHasCondition(job, batchv1.JobComplete):
+		imageRef := r.repositoryRef(buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]) + ":" + buildWorkload.Name
+		imageConfig, err := r.imageConfigGetter.Config(ctx, image.Creds{
+			Namespace:          buildWorkload.Namespace,
+			ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
+		}, imageRef)
+		if err != nil {
+			log.Info("error when getting the built image config", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		if isRoot(imageConfig.User) {
+			meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+				Type:   korifiv1alpha1.SucceededConditionType,
+				Status: metav1.ConditionFalse,
+				Reason: "BuildFailed",
+				Message: fmt.Sprintf(
+					"Image %q is configured to run as the root user. That is insecure on Kubernetes and therefore not supported by Korifi.",
+					imageRef,
+				),
+				ObservedGeneration: buildWorkload.Generation,
+			})
+			return ctrl.Result{}, nil
+		}
+
+		buildWorkload.Status.Droplet, err = r.generateDropletStatus(ctx, buildWorkload, imageConfig)
+		if err != nil {
+			log.Info("error when compiling the DropletStatus", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "BuildSucceeded",
+			Message:            "Image built successfully",
+			ObservedGeneration: buildWorkload.Generation,
+		})
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func hasCompleted(buildWorkload *korifiv1alpha1.BuildWorkload) bool {
+	succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	return succeeded != nil && succeeded.Status != metav1.ConditionUnknown
+}
+
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func isRoot(user string) bool {
+	user = strings.Split(user, ":")[0]
+	return user == "" || user == "root" || user == "0"
+}
+
+func (r *BuildWorkloadReconciler) createBuildJob(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) error {
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: buildWorkload.Namespace,
+		Name:      r.controllerConfig.BuilderServiceAccount,
+	}, serviceAccount)
+	if err != nil {
+		log.Info("error when fetching builder ServiceAccount", "reason", err)
+		return err
+	}
+
+	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
+	if err = r.imageRepoCreator.CreateRepository(ctx, r.repositoryRef(appGUID)); err != nil {
+		log.Info("failed to create image repository", "reason", err)
+		return err
+	}
+
+	job := r.buildJob(buildWorkload, serviceAccount)
+	if err = controllerutil.SetControllerReference(buildWorkload, job, r.scheme); err != nil {
+		log.Info("unable to set owner reference on build job", "reason", err)
+		return err
+	}
+
+	if err = r.k8sClient.Create(ctx, job); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		log.Info("failed to create build job", "reason", err)
+		return err
+	}
+
+	meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "BuildRunning",
+		Message:            "Waiting for image build to complete",
+		ObservedGeneration: buildWorkload.Generation,
+	})
+
+	return nil
+}
+
+// buildJob runs the build in three steps, so that the user Dockerfile never
+// has access to the registry credentials: the source is fetched into the
+// workspace, BuildKit builds it into an image tarball without any credentials
+// and the tarball is finally pushed to the droplets repository. All the
+// containers comply with the restricted pod security standard.
+func (r *BuildWorkloadReconciler) buildJob(buildWorkload *korifiv1alpha1.BuildWorkload, serviceAccount *corev1.ServiceAccount) *batchv1.Job {
+	destination := r.repositoryRef(buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]) + ":" + buildWorkload.Name
+
+	volumes := []corev1.Volume{
+		{Name: workspaceVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: outputVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: buildkitStateVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	credentialMounts := []corev1.VolumeMount{}
+	credentialEnv := []corev1.EnvVar{}
+
+	// the source fetcher and the pusher read registry credentials from a
+	// docker config file, which is not mounted into the build container
+	if len(serviceAccount.ImagePullSecrets) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: credentialsVolume,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: serviceAccount.ImagePullSecrets[0].Name,
+				Items: []corev1.KeyToPath{{
+					Key:  corev1.DockerConfigJsonKey,
+					Path: "config.json",
+				}},
+			}},
+		})
+		credentialMounts = append(credentialMounts, corev1.VolumeMount{Name: credentialsVolume, MountPath: dockerConfigDir, ReadOnly: true})
+		credentialEnv = append(credentialEnv, corev1.EnvVar{Name: "DOCKER_CONFIG", Value: dockerConfigDir})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildWorkload.Name,
+			Namespace: buildWorkload.Namespace,
+			Labels: map[string]string{
+				BuildWorkloadLabelKey:            buildWorkload.Name,
+				korifiv1alpha1.CFAppGUIDLabelKey: buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: tools.PtrTo(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						BuildWorkloadLabelKey: buildWorkload.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					ServiceAccountName:           r.controllerConfig.BuilderServiceAccount,
+					AutomountServiceAccountToken: tools.PtrTo(false),
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: tools.PtrTo(true),
+						RunAsUser:    tools.PtrTo(builderUID),
+						RunAsGroup:   tools.PtrTo(builderUID),
+						FSGroup:      tools.PtrTo(builderUID),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Volumes: volumes,
+					InitContainers: []corev1.Container{
+						{
+							Name:    fetcherContainer,
+							Image:   r.controllerConfig.SourceFetcherImage,
+							Command: []string{"sh", "-c", fetchSourceScript},
+							Env: slices.Concat(credentialEnv, []corev1.EnvVar{
+								{Name: "SOURCE_IMAGE", Value: buildWorkload.Spec.Source.Registry.Image},
+							}),
+							VolumeMounts: slices.Concat(credentialMounts, []corev1.VolumeMount{
+								{Name: workspaceVolume, MountPath: workspaceDir},
+							}),
+							SecurityContext: restrictedSecurityContext(),
+						},
+						{
+							Name:    buildContainer,
+							Image:   r.controllerConfig.BuilderImage,
+							Command: []string{"buildctl-daemonless.sh"},
+							Args: []string{
+								"build",
+								"--frontend=dockerfile.v0",
+								"--local=context=" + workspaceDir,
+								"--local=dockerfile=" + workspaceDir,
+								"--output=type=docker,name=" + destination + ",dest=" + imageTarballPath,
+							},
+							Env: []corev1.EnvVar{
+								// BuildKit cannot create PID namespaces
+								// without privileges
+								{Name: "BUILDKITD_FLAGS", Value: "--oci-worker-no-process-sandbox"},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: workspaceVolume, MountPath: workspaceDir, ReadOnly: true},
+								{Name: outputVolume, MountPath: outputDir},
+								{Name: buildkitStateVolume, MountPath: buildkitStateDir},
+							},
+							Resources:       GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+							SecurityContext: restrictedSecurityContext(),
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:    pushContainer,
+						Image:   r.controllerConfig.SourceFetcherImage,
+						Command: []string{"crane", "push", imageTarballPath, destination},
+						Env:     credentialEnv,
+						VolumeMounts: slices.Concat(credentialMounts, []corev1.VolumeMount{
+							{Name: outputVolume, MountPath: outputDir, ReadOnly: true},
+						}),
+						SecurityContext: restrictedSecurityContext(),
+					}},
+				},
+			},
+		},
+	}
+}
+
+func restrictedSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+}
+
+// fetchSourceScript extracts the package source image into the workspace
+const fetchSourceScript = `set -e
+crane export "$SOURCE_IMAGE" - | tar -xf - -C ` + workspaceDir
+
+func GetBuildResources(diskMB, memoryMB int64) corev1.ResourceRequirements {
+	resourceRequirements := corev1.ResourceRequirements{
+		Requests: map[corev1.ResourceName]resource.Quantity{},
+	}
+
+	if diskMB != 0 {
+		resourceRequirements.Requests[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(diskMB, resource.Mega)
+	}
+
+	if memoryMB != 0 {
+		resourceRequirements.Requests[corev1.ResourceMemory] = *resource.NewScaledQuantity(memoryMB, resource.Mega)
+	}
+
+	return resourceRequirements
+}
+
+func (r *BuildWorkloadReconciler) generateDropletStatus(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, imageConfig image.Config) (*korifiv1alpha1.BuildDropletStatus, error) {
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: buildWorkload.Namespace,
+		Name:      r.controllerConfig.BuilderServiceAccount,
+	}, serviceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting builder service account: %w", err)
+	}
+
+	return &korifiv1alpha1.BuildDropletStatus{
+		Registry: korifiv1alpha1.Registry{
+			Image:            r.repositoryRef(buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]) + "@" + imageConfig.Digest,
+			ImagePullSecrets: serviceAccount.ImagePullSecrets,
+		},
+		Ports: imageConfig.ExposedPorts,
+	}, nil
+}
+
+func (r *BuildWorkloadReconciler) repositoryRef(appGUID string) string {
+	return r.controllerConfig.ContainerRepositoryPrefix + appGUID + "-droplets"
+}
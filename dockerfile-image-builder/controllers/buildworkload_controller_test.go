@@ -0,0 +1,266 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/dockerfile-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admission "k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const appGUID = "app-guid"
+
+var _ = Describe("BuildWorkloadReconciler", func() {
+	const registryCredentialsSecret = "image-registry-credentials"
+
+	var (
+		namespaceGUID string
+		buildWorkload *korifiv1alpha1.BuildWorkload
+	)
+
+	BeforeEach(func() {
+		namespaceGUID = prefixedGUID("namespace")
+		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceGUID}})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "builder-service-account",
+				Namespace: namespaceGUID,
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryCredentialsSecret}},
+		})).To(Succeed())
+
+		fakeImageConfigGetter.ConfigReturns(image.Config{
+			User:         "1000",
+			ExposedPorts: []int32{8080},
+			Digest:       "sha256:abc",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		buildWorkload = &korifiv1alpha1.BuildWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespaceGUID,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: korifiv1alpha1.BuildWorkloadSpec{
+				BuildRef: korifiv1alpha1.RequiredLocalObjectReference{Name: "build-guid"},
+				Source: korifiv1alpha1.PackageSource{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "my.registry/my-prefix/app-guid-packages@sha256:def",
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryCredentialsSecret}},
+					},
+				},
+				BuilderName: controllers.DockerfileReconcilerName,
+			},
+		}
+		Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
+	})
+
+	getJob := func(g Gomega) *batchv1.Job {
+		job := &batchv1.Job{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), job)).To(Succeed())
+		return job
+	}
+
+	succeededCondition := func(g Gomega) *metav1.Condition {
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+		succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+		g.Expect(succeeded).NotTo(BeNil())
+		return succeeded
+	}
+
+	It("creates the droplet repository", func() {
+		Eventually(imageRepoCreator.CreateRepositoryCallCount).Should(BeNumerically(">", 0))
+		_, repoName := imageRepoCreator.CreateRepositoryArgsForCall(0)
+		Expect(repoName).To(Equal("my.repository/my-prefix/app-guid-droplets"))
+	})
+
+	It("creates a build job owned by the build workload", func() {
+		Eventually(func(g Gomega) {
+			job := getJob(g)
+			g.Expect(job.Labels).To(HaveKeyWithValue(controllers.BuildWorkloadLabelKey, buildWorkload.Name))
+			g.Expect(job.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":       Equal(buildWorkload.Name),
+				"Controller": PointTo(BeTrue()),
+			})))
+
+			podSpec := job.Spec.Template.Spec
+			g.Expect(podSpec.ServiceAccountName).To(Equal("builder-service-account"))
+			g.Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+
+			g.Expect(podSpec.AutomountServiceAccountToken).To(PointTo(BeFalse()))
+
+			g.Expect(podSpec.InitContainers).To(HaveLen(2))
+			g.Expect(podSpec.InitContainers[0]).To(MatchFields(IgnoreExtras, Fields{
+				"Name":  Equal("fetch-source"),
+				"Image": Equal(sourceFetcherImage),
+				"Env": ContainElements(
+					corev1.EnvVar{Name: "SOURCE_IMAGE", Value: "my.registry/my-prefix/app-guid-packages@sha256:def"},
+					corev1.EnvVar{Name: "DOCKER_CONFIG", Value: "/registry-credentials"},
+				),
+				"VolumeMounts": ContainElement(MatchFields(IgnoreExtras, Fields{
+					"MountPath": Equal("/registry-credentials"),
+				})),
+			}))
+			g.Expect(podSpec.InitContainers[1]).To(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("build"),
+				"Image":   Equal(builderImage),
+				"Command": ConsistOf("buildctl-daemonless.sh"),
+				"Args": ConsistOf(
+					"build",
+					"--frontend=dockerfile.v0",
+					"--local=context=/workspace",
+					"--local=dockerfile=/workspace",
+					"--output=type=docker,name=my.repository/my-prefix/app-guid-droplets:"+buildWorkload.Name+",dest=/output/image.tar",
+				),
+				"Resources": Equal(corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceEphemeralStorage: resource.MustParse("2048M"),
+						corev1.ResourceMemory:           resource.MustParse("1234M"),
+					},
+				}),
+			}))
+
+			g.Expect(podSpec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("push"),
+				"Image":   Equal(sourceFetcherImage),
+				"Command": Equal([]string{"crane", "push", "/output/image.tar", "my.repository/my-prefix/app-guid-droplets:" + buildWorkload.Name}),
+				"Env":     ContainElement(corev1.EnvVar{Name: "DOCKER_CONFIG", Value: "/registry-credentials"}),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("does not expose the registry credentials to the build", func() {
+		Eventually(func(g Gomega) {
+			build := getJob(g).Spec.Template.Spec.InitContainers[1]
+			g.Expect(build.Env).NotTo(ContainElement(HaveField("Name", "DOCKER_CONFIG")))
+			g.Expect(build.VolumeMounts).NotTo(ContainElement(HaveField("Name", "registry-credentials")))
+		}).Should(Succeed())
+	})
+
+	It("creates a build job that complies with the restricted pod security standard", func() {
+		evaluator, err := policy.NewEvaluator(policy.DefaultChecks())
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			podTemplate := getJob(g).Spec.Template
+			result := policy.AggregateCheckResults(evaluator.EvaluatePod(
+				admission.LevelVersion{Level: admission.LevelRestricted, Version: admission.LatestVersion()},
+				&podTemplate.ObjectMeta,
+				&podTemplate.Spec,
+			))
+			g.Expect(result.Allowed).To(BeTrue(), result.ForbiddenDetail())
+		}).Should(Succeed())
+	})
+
+	It("marks the build workload as running", func() {
+		Eventually(func(g Gomega) {
+			succeeded := succeededCondition(g)
+			g.Expect(succeeded.Status).To(Equal(metav1.ConditionUnknown))
+			g.Expect(succeeded.Reason).To(Equal("BuildRunning"))
+		}).Should(Succeed())
+	})
+
+	When("the build job completes", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				job := getJob(g)
+				g.Expect(k8s.Patch(ctx, adminClient, job, func() {
+					job.Status.StartTime = tools.PtrTo(metav1.Now())
+					job.Status.CompletionTime = tools.PtrTo(metav1.Now())
+					job.Status.Succeeded = 1
+					job.Status.Conditions = append(job.Status.Conditions,
+						batchv1.JobCondition{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue},
+						batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					)
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("reads the built image config with the builder service account", func() {
+			Eventually(fakeImageConfigGetter.ConfigCallCount).Should(BeNumerically(">", 0))
+			_, creds, imageRef := fakeImageConfigGetter.ConfigArgsForCall(0)
+			Expect(creds).To(Equal(image.Creds{
+				Namespace:          namespaceGUID,
+				ServiceAccountName: "builder-service-account",
+			}))
+			Expect(imageRef).To(Equal("my.repository/my-prefix/app-guid-droplets:" + buildWorkload.Name))
+		})
+
+		It("marks the build workload as succeeded", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(succeededCondition(g).Status).To(Equal(metav1.ConditionTrue))
+			}).Should(Succeed())
+		})
+
+		It("sets the droplet status from the built image", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+				g.Expect(buildWorkload.Status.Droplet).NotTo(BeNil())
+				g.Expect(buildWorkload.Status.Droplet.Registry.Image).To(Equal("my.repository/my-prefix/app-guid-droplets@sha256:abc"))
+				g.Expect(buildWorkload.Status.Droplet.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: registryCredentialsSecret}))
+				g.Expect(buildWorkload.Status.Droplet.Ports).To(ConsistOf(int32(8080)))
+			}).Should(Succeed())
+		})
+
+		When("the built image runs as root", func() {
+			BeforeEach(func() {
+				fakeImageConfigGetter.ConfigReturns(image.Config{User: "root", Digest: "sha256:abc"}, nil)
+			})
+
+			It("fails the build workload", func() {
+				Eventually(func(g Gomega) {
+					succeeded := succeededCondition(g)
+					g.Expect(succeeded.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(succeeded.Reason).To(Equal("BuildFailed"))
+					g.Expect(succeeded.Message).To(ContainSubstring("not supported"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the build job fails", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				job := getJob(g)
+				g.Expect(k8s.Patch(ctx, adminClient, job, func() {
+					job.Status.StartTime = tools.PtrTo(metav1.Now())
+					job.Status.Failed = 1
+					job.Status.Conditions = append(job.Status.Conditions,
+						batchv1.JobCondition{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue},
+						batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+					)
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("marks the build workload as failed", func() {
+			Eventually(func(g Gomega) {
+				succeeded := succeededCondition(g)
+				g.Expect(succeeded.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeeded.Reason).To(Equal("BuildFailed"))
+			}).Should(Succeed())
+		})
+	})
+})
+
+func prefixedGUID(prefix string) string {
+	return prefix + "-" + uuid.NewString()[:8]
+}
@@ -0,0 +1,14 @@
+package config
+
+import (
+	controllersconfig "code.cloudfoundry.org/korifi/controllers/config"
+)
+
+type Config struct {
+	CFStagingResources        controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
+	BuilderImage              string                               `yaml:"builderImage"`
+	SourceFetcherImage        string                               `yaml:"sourceFetcherImage"`
+	BuilderServiceAccount     string                               `yaml:"builderServiceAccount"`
+	ContainerRepositoryPrefix string                               `yaml:"containerRepositoryPrefix"`
+	ContainerRegistryType     string                               `yaml:"containerRegistryType"`
+}
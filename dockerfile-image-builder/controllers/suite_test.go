@@ -0,0 +1,109 @@
+package controllers_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllersconfig "code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/dockerfile-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/dockerfile-image-builder/controllers/config"
+	"code.cloudfoundry.org/korifi/dockerfile-image-builder/controllers/fake"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	builderImage       = "my.registry/buildkit:rootless"
+	sourceFetcherImage = "my.registry/crane:debug"
+)
+
+var (
+	ctx                   context.Context
+	stopManager           context.CancelFunc
+	stopClientCache       context.CancelFunc
+	adminClient           client.Client
+	testEnv               *envtest.Environment
+	fakeImageConfigGetter *fake.ImageConfigGetter
+	imageRepoCreator      *fake.RepositoryCreator
+	k8sManager            manager.Manager
+)
+
+func TestAPIs(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	SetDefaultEventuallyTimeout(10 * time.Second)
+	SetDefaultEventuallyPollingInterval(200 * time.Millisecond)
+	SetDefaultConsistentlyDuration(5 * time.Second)
+	SetDefaultConsistentlyPollingInterval(200 * time.Millisecond)
+
+	RunSpecs(t, "Controller Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+	ctx = context.Background()
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "helm", "korifi", "controllers", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	_, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	Expect(testEnv.Stop()).To(Succeed())
+})
+
+var _ = BeforeEach(func() {
+	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
+	k8sManager = helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "dockerfile-image-builder", "role.yaml"))
+
+	controllerConfig := &config.Config{
+		BuilderImage:              builderImage,
+		SourceFetcherImage:        sourceFetcherImage,
+		BuilderServiceAccount:     "builder-service-account",
+		ContainerRepositoryPrefix: "my.repository/my-prefix/",
+		CFStagingResources: controllersconfig.CFStagingResources{
+			BuildCacheMB: 1024,
+			DiskMB:       2048,
+			MemoryMB:     1234,
+		},
+	}
+
+	imageRepoCreator = new(fake.RepositoryCreator)
+	fakeImageConfigGetter = new(fake.ImageConfigGetter)
+
+	Expect(controllers.NewBuildWorkloadReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		ctrl.Log.WithName("dockerfile-image-builder").WithName("BuildWorkload"),
+		controllerConfig,
+		fakeImageConfigGetter,
+		imageRepoCreator,
+	).SetupWithManager(k8sManager)).To(Succeed())
+
+	stopManager = helpers.StartK8sManager(k8sManager)
+})
+
+var _ = AfterEach(func() {
+	stopClientCache()
+	stopManager()
+})
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/uuid"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// BuildCacheController clears the build cache of the apps labelled with
+// korifiv1alpha1.ClearBuildCacheLabelKey for this builder. The cache volume
+// or the registry cache image of the kpack Image of the app is deleted, kpack
+// recreates it empty for the next build. The label is removed once the cache
+// has been cleared, unless the app has been labelled again in the meantime.
+type BuildCacheController struct {
+	log                    logr.Logger
+	k8sClient              client.Client
+	imageDeleter           ImageDeleter
+	registryServiceAccount string
+}
+
+func NewBuildCacheController(
+	k8sClient client.Client,
+	log logr.Logger,
+	imageDeleter ImageDeleter,
+	registryServiceAccount string,
+) *BuildCacheController {
+	return &BuildCacheController{
+		log:                    log,
+		k8sClient:              k8sClient,
+		imageDeleter:           imageDeleter,
+		registryServiceAccount: registryServiceAccount,
+	}
+}
+
+func (c *BuildCacheController) SetupWithManager(mgr manager.Manager) error {
+	// ignoring error as this construction is not dynamic
+	labelSelector, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			korifiv1alpha1.ClearBuildCacheLabelKey: KpackReconcilerName,
+		},
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("buildcache").
+		For(&korifiv1alpha1.CFApp{}).
+		WithEventFilter(labelSelector).
+		Complete(c)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=kpack.io,resources=images,verbs=get
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=delete
+
+func (c *BuildCacheController) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := c.log.WithName("BuildCache").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name).
+		WithValues("logID", uuid.NewString())
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := c.k8sClient.Get(ctx, req.NamespacedName, cfApp)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch app", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfApp.Labels[korifiv1alpha1.ClearBuildCacheLabelKey] != KpackReconcilerName {
+		return ctrl.Result{}, nil
+	}
+
+	err = c.deleteCache(ctx, cfApp)
+	if err != nil {
+		log.Info("unable to delete build cache", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	// the optimistic lock makes sure that the cache is cleared again if the
+	// app has been labelled by another request while it was being cleared
+	original := cfApp.DeepCopy()
+	delete(cfApp.Labels, korifiv1alpha1.ClearBuildCacheLabelKey)
+	delete(cfApp.Annotations, korifiv1alpha1.ClearBuildCacheRequestAnnotationKey)
+	err = c.k8sClient.Patch(ctx, cfApp, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		log.Info("unable to remove clear build cache label from app", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (c *BuildCacheController) deleteCache(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	kpackImage := &buildv1alpha2.Image{}
+	err := c.k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), kpackImage)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if kpackImage.Spec.Cache == nil {
+		return nil
+	}
+
+	if kpackImage.Spec.Cache.Volume != nil {
+		err = c.k8sClient.Delete(ctx, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: kpackImage.Namespace,
+				Name:      kpackImage.CacheName(),
+			},
+		})
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete cache volume: %w", err)
+		}
+	}
+
+	if kpackImage.Spec.Cache.Registry != nil {
+		err = c.deleteCacheImage(ctx, kpackImage)
+		if err != nil {
+			return fmt.Errorf("failed to delete cache image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *BuildCacheController) deleteCacheImage(ctx context.Context, kpackImage *buildv1alpha2.Image) error {
+	cacheTag, err := name.NewTag(kpackImage.Spec.Cache.Registry.Tag)
+	if err != nil {
+		return err
+	}
+
+	err = c.imageDeleter.Delete(ctx, image.Creds{
+		Namespace:          kpackImage.Namespace,
+		ServiceAccountName: c.registryServiceAccount,
+	}, cacheTag.String(), cacheTag.TagStr())
+
+	// the cache image does not exist until the first build has pushed it
+	var structuredErr *transport.Error
+	if errors.As(err, &structuredErr) && structuredErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,213 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuildCacheController", func() {
+	var (
+		namespaceGUID string
+		cfApp         *korifiv1alpha1.CFApp
+		kpackImage    *buildv1alpha2.Image
+		cacheVolume   *corev1.PersistentVolumeClaim
+	)
+
+	BeforeEach(func() {
+		namespaceGUID = PrefixedGUID("namespace")
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespaceGUID,
+			},
+		})).To(Succeed())
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceGUID,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  uuid.NewString(),
+				DesiredState: "STOPPED",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cacheSize := resource.MustParse("1Gi")
+		kpackImage = &buildv1alpha2.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceGUID,
+				Name:      cfApp.Name,
+			},
+			Spec: buildv1alpha2.ImageSpec{
+				Tag: "my.repository/my-prefix/" + cfApp.Name,
+				Builder: corev1.ObjectReference{
+					Kind:       "ClusterBuilder",
+					Name:       clusterBuilderName,
+					APIVersion: "kpack.io/v1alpha2",
+				},
+				Cache: &buildv1alpha2.ImageCacheConfig{
+					Volume: &buildv1alpha2.ImagePersistentVolumeCache{
+						Size: &cacheSize,
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, kpackImage)).To(Succeed())
+
+		cacheVolume = &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceGUID,
+				Name:      kpackImage.CacheName(),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: cacheSize,
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cacheVolume)).To(Succeed())
+	})
+
+	It("does not delete the cache volume", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cacheVolume), cacheVolume)).To(Succeed())
+			g.Expect(cacheVolume.DeletionTimestamp).To(BeNil())
+		}, "2s").Should(Succeed())
+	})
+
+	When("the app is labelled for clearing its build cache", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: controllers.KpackReconcilerName,
+				}
+				app.Annotations = map[string]string{
+					korifiv1alpha1.ClearBuildCacheRequestAnnotationKey: "clear-request-guid",
+				}
+			})
+		})
+
+		It("deletes the cache volume of the kpack image", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cacheVolume), cacheVolume)
+				if err == nil {
+					// the pvc-protection finalizer is never removed in the test environment
+					g.Expect(cacheVolume.DeletionTimestamp).NotTo(BeNil())
+					return
+				}
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("removes the label and the request annotation from the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+				g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheRequestAnnotationKey))
+			}).Should(Succeed())
+		})
+
+		It("keeps the kpack image", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(kpackImage), kpackImage)).To(Succeed())
+			}, "2s").Should(Succeed())
+		})
+	})
+
+	When("the app is labelled for clearing its build cache by another builder", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: "another-builder",
+				}
+			})
+		})
+
+		It("leaves the app alone", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cacheVolume), cacheVolume)).To(Succeed())
+				g.Expect(cacheVolume.DeletionTimestamp).To(BeNil())
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).To(HaveKeyWithValue(korifiv1alpha1.ClearBuildCacheLabelKey, "another-builder"))
+			}, "2s").Should(Succeed())
+		})
+	})
+
+	When("the kpack image caches to the registry", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, kpackImage, func(image *buildv1alpha2.Image) {
+				image.Spec.Cache = &buildv1alpha2.ImageCacheConfig{
+					Registry: &buildv1alpha2.RegistryCache{
+						Tag: "my.repository/my-prefix/" + cfApp.Name + "-cache:build-cache",
+					},
+				}
+			})
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: controllers.KpackReconcilerName,
+				}
+			})
+		})
+
+		It("deletes the cache image", func() {
+			Eventually(func(g Gomega) {
+				found := false
+				for i := range fakeImageDeleter.DeleteCallCount() {
+					_, creds, imageRef, tags := fakeImageDeleter.DeleteArgsForCall(i)
+					if imageRef != "my.repository/my-prefix/"+cfApp.Name+"-cache:build-cache" {
+						continue
+					}
+
+					found = true
+					g.Expect(creds.Namespace).To(Equal(namespaceGUID))
+					g.Expect(creds.ServiceAccountName).To(Equal("builder-service-account"))
+					g.Expect(tags).To(ConsistOf("build-cache"))
+				}
+				g.Expect(found).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("removes the label from the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app has no kpack image", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Delete(ctx, kpackImage)).To(Succeed())
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: controllers.KpackReconcilerName,
+				}
+			})
+		})
+
+		It("removes the label from the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -161,9 +161,18 @@ func clusterBuilderToStacks(clusterBuilder *buildv1alpha2.ClusterBuilder, update
 	}
 }
 
+// clusterBuilderToBuildpacks lists the buildpacks the builder has been
+// configured with. The entries added for CFBuildpacks are left out, as the API
+// reports those from the CFBuildpacks themselves.
 func clusterBuilderToBuildpacks(builder *buildv1alpha2.ClusterBuilder, updatedTimestamp metav1.Time) []korifiv1alpha1.BuilderInfoStatusBuildpack {
+	managed := managedImages(builder)
+
 	buildpackRecords := make([]korifiv1alpha1.BuilderInfoStatusBuildpack, 0, len(builder.Status.Order))
-	for _, orderEntry := range builder.Status.Order {
+	for i, orderEntry := range builder.Status.Order {
+		if i < len(builder.Spec.Order) && isManagedEntry(builder.Spec.Order[i], managed) {
+			continue
+		}
+
 		buildpackRecords = append(buildpackRecords, korifiv1alpha1.BuilderInfoStatusBuildpack{
 			Name:              orderEntry.Group[0].Id,
 			Stack:             builder.Status.Stack.ID,
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/tests/helpers"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -205,6 +206,37 @@ var _ = Describe("BuilderInfoReconciler", func() {
 		})
 	})
 
+	When("the builder order contains a buildpack added for a CFBuildpack", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, &v1alpha1.CFBuildpack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace.Name,
+				},
+				Spec: v1alpha1.CFBuildpackSpec{
+					DisplayName: "my-golang",
+					Position:    1,
+					Enabled:     true,
+					Image:       "my.registry/golang",
+				},
+			})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+				g.Expect(clusterBuilder.Spec.Order).To(HaveLen(1))
+			}).Should(Succeed())
+		})
+
+		It("leaves it out of the BuilderInfo buildpacks", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				g.Expect(info.Status.Buildpacks).To(HaveLen(2))
+				g.Expect(info.Status.Buildpacks[0].Name).To(Equal(pythonBuildpackName))
+				g.Expect(info.Status.Buildpacks[1].Name).To(Equal(javaBuildpackName))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the ClusterBuilder changes after the BuilderInfo has reconciled", func() {
 		const (
 			rustBuildpackName    = "rust"
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ManagedBuildpackImagesAnnotation records the buildpack images added to the
+// ClusterBuilder order for CFBuildpacks, so that they can be told apart from
+// the buildpacks the builder has been configured with
+const ManagedBuildpackImagesAnnotation = "korifi.cloudfoundry.org/managed-buildpack-images"
+
+// BuildpackOrderController keeps the order of the ClusterBuilder in sync with
+// the CFBuildpacks in the root namespace. Enabled buildpacks that have an
+// image are inserted at their position as image order entries, which kpack
+// resolves without them having to be added to the ClusterStore.
+type BuildpackOrderController struct {
+	log                logr.Logger
+	k8sClient          client.Client
+	clusterBuilderName string
+	rootNamespaceName  string
+}
+
+func NewBuildpackOrderController(
+	k8sClient client.Client,
+	log logr.Logger,
+	clusterBuilderName string,
+	rootNamespaceName string,
+) *BuildpackOrderController {
+	return &BuildpackOrderController{
+		log:                log,
+		k8sClient:          k8sClient,
+		clusterBuilderName: clusterBuilderName,
+		rootNamespaceName:  rootNamespaceName,
+	}
+}
+
+func (c *BuildpackOrderController) SetupWithManager(mgr manager.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("buildpackorder").
+		For(
+			&buildv1alpha2.ClusterBuilder{},
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() == c.clusterBuilderName
+			})),
+		).
+		Watches(
+			&korifiv1alpha1.CFBuildpack{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueueClusterBuilderRequest),
+		).
+		Complete(c)
+}
+
+func (c *BuildpackOrderController) enqueueClusterBuilderRequest(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetNamespace() != c.rootNamespaceName {
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: c.clusterBuilderName},
+	}}
+}
+
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildpacks,verbs=get;list;watch
+
+func (c *BuildpackOrderController) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := c.log.WithName("BuildpackOrder").
+		WithValues("name", req.Name).
+		WithValues("logID", uuid.NewString())
+
+	clusterBuilder := &buildv1alpha2.ClusterBuilder{}
+	err := c.k8sClient.Get(ctx, req.NamespacedName, clusterBuilder)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch cluster builder", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	cfBuildpacks := &korifiv1alpha1.CFBuildpackList{}
+	err = c.k8sClient.List(ctx, cfBuildpacks, client.InNamespace(c.rootNamespaceName))
+	if err != nil {
+		log.Info("unable to list buildpacks", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = k8s.Patch(ctx, c.k8sClient, clusterBuilder, func() {
+		order, managedImages := buildpackOrder(clusterBuilder, cfBuildpacks.Items)
+		clusterBuilder.Spec.Order = order
+		clusterBuilder.Annotations = tools.SetMapValue(clusterBuilder.Annotations, ManagedBuildpackImagesAnnotation, marshalImages(managedImages))
+	})
+	if err != nil {
+		log.Info("unable to patch cluster builder order", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+type positionedOrderEntry struct {
+	entry    buildv1alpha2.BuilderOrderEntry
+	position int
+	include  bool
+}
+
+// buildpackOrder inserts the buildpacks at their position among the order
+// entries of the builder. Disabled buildpacks and buildpacks awaiting upload
+// still take up their position so that the positions reported by the API
+// match the builder order, but are left out of it.
+func buildpackOrder(clusterBuilder *buildv1alpha2.ClusterBuilder, cfBuildpacks []korifiv1alpha1.CFBuildpack) ([]buildv1alpha2.BuilderOrderEntry, []string) {
+	previouslyManaged := managedImages(clusterBuilder)
+
+	builderEntries := []positionedOrderEntry{}
+	for _, entry := range clusterBuilder.Spec.Order {
+		if isManagedEntry(entry, previouslyManaged) {
+			continue
+		}
+		builderEntries = append(builderEntries, positionedOrderEntry{entry: entry, include: true})
+	}
+
+	slices.SortStableFunc(cfBuildpacks, func(a, b korifiv1alpha1.CFBuildpack) int {
+		return cmp.Or(
+			cmp.Compare(a.Spec.Position, b.Spec.Position),
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+		)
+	})
+
+	buildpackEntries := []positionedOrderEntry{}
+	for _, cfBuildpack := range cfBuildpacks {
+		buildpackEntries = append(buildpackEntries, positionedOrderEntry{
+			entry: buildv1alpha2.BuilderOrderEntry{
+				Group: []buildv1alpha2.BuilderBuildpackRef{{Image: cfBuildpack.Spec.Image}},
+			},
+			position: cfBuildpack.Spec.Position,
+			include:  cfBuildpack.Spec.Enabled && cfBuildpack.Spec.Image != "",
+		})
+	}
+
+	order := []buildv1alpha2.BuilderOrderEntry{}
+	images := []string{}
+	for _, e := range tools.InsertAtPositions(builderEntries, buildpackEntries, func(e positionedOrderEntry) int {
+		return e.position
+	}) {
+		if !e.include {
+			continue
+		}
+
+		order = append(order, e.entry)
+		if e.position > 0 {
+			images = append(images, e.entry.Group[0].Image)
+		}
+	}
+
+	return order, images
+}
+
+func managedImages(clusterBuilder *buildv1alpha2.ClusterBuilder) []string {
+	images := []string{}
+	value, ok := clusterBuilder.Annotations[ManagedBuildpackImagesAnnotation]
+	if !ok {
+		return images
+	}
+
+	// an unparseable annotation means that no images are managed yet
+	_ = json.Unmarshal([]byte(value), &images)
+	return images
+}
+
+func isManagedEntry(entry buildv1alpha2.BuilderOrderEntry, managedImages []string) bool {
+	return len(entry.Group) == 1 &&
+		entry.Group[0].Image != "" &&
+		slices.Contains(managedImages, entry.Group[0].Image)
+}
+
+func marshalImages(images []string) string {
+	// marshalling a slice of strings cannot fail
+	value, _ := json.Marshal(images)
+	return string(value)
+}
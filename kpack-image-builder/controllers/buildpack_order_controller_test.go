@@ -0,0 +1,128 @@
+package controllers_test
+
+import (
+	"encoding/json"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuildpackOrderController", func() {
+	var clusterBuilder *buildv1alpha2.ClusterBuilder
+
+	idEntry := func(id string) buildv1alpha2.BuilderOrderEntry {
+		return buildv1alpha2.BuilderOrderEntry{Group: []buildv1alpha2.BuilderBuildpackRef{{
+			BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: id}},
+		}}}
+	}
+
+	imageEntry := func(image string) buildv1alpha2.BuilderOrderEntry {
+		return buildv1alpha2.BuilderOrderEntry{Group: []buildv1alpha2.BuilderBuildpackRef{{Image: image}}}
+	}
+
+	createBuildpack := func(position int, image string, enabled bool) *korifiv1alpha1.CFBuildpack {
+		cfBuildpack := &korifiv1alpha1.CFBuildpack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace.Name,
+			},
+			Spec: korifiv1alpha1.CFBuildpackSpec{
+				DisplayName: "my-buildpack",
+				Position:    position,
+				Enabled:     enabled,
+				Image:       image,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfBuildpack)).To(Succeed())
+
+		return cfBuildpack
+	}
+
+	BeforeEach(func() {
+		clusterBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterBuilderName,
+			},
+			Spec: buildv1alpha2.ClusterBuilderSpec{
+				BuilderSpec: buildv1alpha2.BuilderSpec{
+					Order: []buildv1alpha2.BuilderOrderEntry{idEntry("go"), idEntry("java")},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterBuilder)).To(Succeed())
+	})
+
+	It("leaves the builder order unchanged when there are no buildpacks", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+			g.Expect(clusterBuilder.Annotations).To(HaveKeyWithValue(controllers.ManagedBuildpackImagesAnnotation, "[]"))
+		}).Should(Succeed())
+
+		Expect(clusterBuilder.Spec.Order).To(Equal([]buildv1alpha2.BuilderOrderEntry{idEntry("go"), idEntry("java")}))
+	})
+
+	When("there are buildpacks", func() {
+		BeforeEach(func() {
+			createBuildpack(1, "my.registry/first", true)
+			createBuildpack(3, "my.registry/third", true)
+			createBuildpack(2, "my.registry/disabled", false)
+			createBuildpack(9, "", true)
+		})
+
+		It("inserts the enabled uploaded buildpacks at their position", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+				g.Expect(clusterBuilder.Spec.Order).To(Equal([]buildv1alpha2.BuilderOrderEntry{
+					imageEntry("my.registry/first"),
+					imageEntry("my.registry/third"),
+					idEntry("go"),
+					idEntry("java"),
+				}))
+			}).Should(Succeed())
+
+			var managed []string
+			Expect(json.Unmarshal([]byte(clusterBuilder.Annotations[controllers.ManagedBuildpackImagesAnnotation]), &managed)).To(Succeed())
+			Expect(managed).To(ConsistOf("my.registry/first", "my.registry/third"))
+		})
+
+		When("a buildpack is disabled", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+					g.Expect(clusterBuilder.Spec.Order).To(HaveLen(4))
+				}).Should(Succeed())
+
+				cfBuildpacks := &korifiv1alpha1.CFBuildpackList{}
+				Expect(adminClient.List(ctx, cfBuildpacks, client.InNamespace(rootNamespace.Name))).To(Succeed())
+				for i := range cfBuildpacks.Items {
+					cfBuildpack := &cfBuildpacks.Items[i]
+					if cfBuildpack.Spec.Image == "my.registry/first" {
+						Expect(k8s.Patch(ctx, adminClient, cfBuildpack, func() {
+							cfBuildpack.Spec.Enabled = false
+						})).To(Succeed())
+					}
+				}
+			})
+
+			It("removes it from the builder order", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterBuilder), clusterBuilder)).To(Succeed())
+					g.Expect(clusterBuilder.Spec.Order).To(Equal([]buildv1alpha2.BuilderOrderEntry{
+						imageEntry("my.registry/third"),
+						idEntry("go"),
+						idEntry("java"),
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -158,6 +159,7 @@ func filterBuildWorkloads(object client.Object) bool {
 //+kubebuilder:rbac:groups=kpack.io,resources=images/status,verbs=get;patch
 //+kubebuilder:rbac:groups=kpack.io,resources=builds,verbs=deletecollection
 //+kubebuilder:rbac:groups=kpack.io,resources=builders,verbs=get;list;watch;create;patch;update
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders;clusterstacks,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=list;watch
 
@@ -399,13 +401,30 @@ func ignoreDoNotRetryError(err error) error {
 	return err
 }
 
-func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
-	var (
-		defaultBuilder *buildv1alpha2.ClusterBuilder
-		err            error
-	)
+// stackBuilder describes how app images are built on a stack: on the stack of
+// a ClusterBuilder, or on a ClusterStack with the store and order of the
+// default ClusterBuilder
+type stackBuilder struct {
+	clusterBuilder *buildv1alpha2.ClusterBuilder
+	stack          corev1.ObjectReference
+	// The id of the stack if it is not the one of the default ClusterBuilder
+	stackID string
+}
+
+func (b stackBuilder) onClusterBuilderStack() bool {
+	return b.stack == b.clusterBuilder.Spec.Stack
+}
 
-	if defaultBuilder, err = r.getDefaultClusterBuilder(ctx); err != nil {
+// resolveBuilder returns the builder the kpack Image of the BuildWorkload
+// should use, creating a Builder if the buildpacks or the stack of the
+// BuildWorkload are not those of a ClusterBuilder
+func (r *BuildWorkloadReconciler) resolveBuilder(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (corev1.ObjectReference, error) {
+	if len(buildWorkload.Spec.Buildpacks) == 0 && buildWorkload.Spec.Stack == "" {
+		return clusterBuilderRef(r.controllerConfig.ClusterBuilderName), nil
+	}
+
+	defaultBuilder, err := r.getDefaultClusterBuilder(ctx)
+	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 				Type:               korifiv1alpha1.SucceededConditionType,
@@ -414,14 +433,95 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 				Message:            "Default ClusterBuilder not found",
 				ObservedGeneration: buildWorkload.Generation,
 			})
-			return "", newDoNotRetryError(fmt.Errorf("default ClusterBuilder not found: %w", err))
+			return corev1.ObjectReference{}, newDoNotRetryError(fmt.Errorf("default ClusterBuilder not found: %w", err))
 		}
 
 		log.Info("error when fetching default ClusterBuilder", "reason", err)
-		return "", err
+		return corev1.ObjectReference{}, err
+	}
+
+	stackBuilder, err := r.findStackBuilder(ctx, log, buildWorkload, defaultBuilder)
+	if err != nil {
+		return corev1.ObjectReference{}, err
+	}
+
+	if len(buildWorkload.Spec.Buildpacks) == 0 && stackBuilder.onClusterBuilderStack() {
+		return clusterBuilderRef(stackBuilder.clusterBuilder.Name), nil
+	}
+
+	builderName, err := r.ensureKpackBuilder(ctx, log, buildWorkload, stackBuilder)
+	if err != nil {
+		return corev1.ObjectReference{}, err
+	}
+
+	return corev1.ObjectReference{
+		Kind:       "Builder",
+		Name:       builderName,
+		Namespace:  buildWorkload.Namespace,
+		APIVersion: clusterBuilderAPIVersion,
+	}, nil
+}
+
+// findStackBuilder looks for a ClusterBuilder, then for a ClusterStack with
+// the stack id of the BuildWorkload. The legacy default stack name that apps
+// carry when they do not choose a stack is built on the default
+// ClusterBuilder. Any other stack without a builder fails the BuildWorkload.
+func (r *BuildWorkloadReconciler) findStackBuilder(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, defaultBuilder *buildv1alpha2.ClusterBuilder) (stackBuilder, error) {
+	stack := buildWorkload.Spec.Stack
+	if stack == "" || stack == r.controllerConfig.DefaultStack || stack == defaultBuilder.Status.Stack.ID {
+		return stackBuilder{clusterBuilder: defaultBuilder, stack: defaultBuilder.Spec.Stack}, nil
+	}
+
+	var clusterBuilders buildv1alpha2.ClusterBuilderList
+	if err := r.k8sClient.List(ctx, &clusterBuilders); err != nil {
+		log.Info("error when listing ClusterBuilders", "reason", err)
+		return stackBuilder{}, fmt.Errorf("failed listing ClusterBuilders: %w", err)
+	}
+
+	slices.SortFunc(clusterBuilders.Items, func(a, b buildv1alpha2.ClusterBuilder) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range clusterBuilders.Items {
+		clusterBuilder := &clusterBuilders.Items[i]
+		if clusterBuilder.Status.Stack.ID == stack {
+			return stackBuilder{clusterBuilder: clusterBuilder, stack: clusterBuilder.Spec.Stack, stackID: stack}, nil
+		}
 	}
 
-	if err = r.checkBuildpacks(ctx, buildWorkload, defaultBuilder); err != nil {
+	var clusterStacks buildv1alpha2.ClusterStackList
+	if err := r.k8sClient.List(ctx, &clusterStacks); err != nil {
+		log.Info("error when listing ClusterStacks", "reason", err)
+		return stackBuilder{}, fmt.Errorf("failed listing ClusterStacks: %w", err)
+	}
+
+	slices.SortFunc(clusterStacks.Items, func(a, b buildv1alpha2.ClusterStack) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, clusterStack := range clusterStacks.Items {
+		if clusterStack.Status.Id == stack || clusterStack.Spec.Id == stack {
+			return stackBuilder{
+				clusterBuilder: defaultBuilder,
+				stack: corev1.ObjectReference{
+					Kind: "ClusterStack",
+					Name: clusterStack.Name,
+				},
+				stackID: stack,
+			}, nil
+		}
+	}
+
+	meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "UnknownStack",
+		Message:            fmt.Sprintf("No builder found for stack %q", stack),
+		ObservedGeneration: buildWorkload.Generation,
+	})
+	return stackBuilder{}, newDoNotRetryError(fmt.Errorf("no builder found for stack %q", stack))
+}
+
+func (r *BuildWorkloadReconciler) ensureKpackBuilder(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, stackBuilder stackBuilder) (string, error) {
+	if err := r.checkBuildpacks(ctx, buildWorkload, stackBuilder.clusterBuilder); err != nil {
 		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.SucceededConditionType,
 			Status:             metav1.ConditionFalse,
@@ -433,9 +533,9 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 		return "", newDoNotRetryError(err)
 	}
 
-	builderName := ComputeBuilderName(buildWorkload.Spec.Buildpacks)
+	builderName := ComputeBuilderName(stackBuilder.stackID, buildWorkload.Spec.Buildpacks)
 	builderRepo := fmt.Sprintf("%sbuilders-%s", r.controllerConfig.ContainerRepositoryPrefix, builderName)
-	err = r.imageRepoCreator.CreateRepository(ctx, builderRepo)
+	err := r.imageRepoCreator.CreateRepository(ctx, builderRepo)
 	if err != nil {
 		log.Info("failed creating builder repo", "reason", err)
 		return "", fmt.Errorf("failed to create builder repo: %w", err)
@@ -454,8 +554,8 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 		}
 
 		builder.Spec.Tag = builderRepo
-		builder.Spec.Stack = defaultBuilder.Spec.Stack
-		builder.Spec.Store = defaultBuilder.Spec.Store
+		builder.Spec.Stack = stackBuilder.stack
+		builder.Spec.Store = stackBuilder.clusterBuilder.Spec.Store
 		builder.Spec.ServiceAccountName = r.controllerConfig.BuilderServiceAccount
 		builder.Spec.Order = nil
 		for _, bp := range buildWorkload.Spec.Buildpacks {
@@ -469,6 +569,9 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 				}},
 			})
 		}
+		if len(buildWorkload.Spec.Buildpacks) == 0 {
+			builder.Spec.Order = stackBuilder.clusterBuilder.Spec.Order
+		}
 
 		return nil
 	})
@@ -480,19 +583,27 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 	return builder.Name, nil
 }
 
-func ComputeBuilderName(bps []string) string {
-	return uuid.NewSHA1(uuid.Nil, []byte(strings.Join(bps, "\x00"))).String()
+// ComputeBuilderName names the Builder for the buildpacks on the stack with
+// the given id. The stack id is empty for the stack of the default
+// ClusterBuilder.
+func ComputeBuilderName(stackID string, bps []string) string {
+	key := strings.Join(bps, "\x00")
+	if stackID != "" {
+		key = stackID + "\x01" + key
+	}
+
+	return uuid.NewSHA1(uuid.Nil, []byte(key)).String()
 }
 
-func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, defaultBuilder *buildv1alpha2.ClusterBuilder) error {
+func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilder *buildv1alpha2.ClusterBuilder) error {
 	validIDs := map[string]bool{}
-	for _, bp := range clusterBuilderToBuildpacks(defaultBuilder, metav1.Now()) {
+	for _, bp := range clusterBuilderToBuildpacks(clusterBuilder, metav1.Now()) {
 		validIDs[bp.Name] = true
 	}
 
 	for _, bp := range buildWorkload.Spec.Buildpacks {
 		if !validIDs[bp] {
-			return fmt.Errorf("buildpack %q not present in default ClusterStore. See `cf buildpacks`", bp)
+			return fmt.Errorf("buildpack %q not present in ClusterStore of ClusterBuilder %q. See `cf buildpacks`", bp, clusterBuilder.Name)
 		}
 	}
 	return nil
@@ -603,15 +714,10 @@ func latestBuild(builds []buildv1alpha2.Build) (*buildv1alpha2.Build, error) {
 }
 
 func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (ctrl.Result, error) {
-	var builderName string
-	var err error
-
-	if len(buildWorkload.Spec.Buildpacks) > 0 {
-		builderName, err = r.ensureKpackBuilderForBuildpacks(ctx, log, buildWorkload)
-		if err != nil {
-			log.Info("failed ensuring custom builder", "reason", err)
-			return ctrl.Result{}, ignoreDoNotRetryError(fmt.Errorf("failed ensuring custom builder: %w", err))
-		}
+	builderRef, err := r.resolveBuilder(ctx, log, buildWorkload)
+	if err != nil {
+		log.Info("failed ensuring custom builder", "reason", err)
+		return ctrl.Result{}, ignoreDoNotRetryError(fmt.Errorf("failed ensuring custom builder: %w", err))
 	}
 
 	err = r.ensureRegistryImagePullSecretsExist(ctx, buildWorkload)
@@ -620,7 +726,15 @@ func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, builderName)
+	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, builderRef)
+}
+
+func clusterBuilderRef(name string) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:       clusterBuilderKind,
+		Name:       name,
+		APIVersion: clusterBuilderAPIVersion,
+	}
 }
 
 func (r *BuildWorkloadReconciler) ensureRegistryImagePullSecretsExist(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) error {
@@ -638,7 +752,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 	ctx context.Context,
 	log logr.Logger,
 	buildWorkload *korifiv1alpha1.BuildWorkload,
-	customBuilderName string,
+	builderRef corev1.ObjectReference,
 ) error {
 	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
 	kpackImageNamespace := buildWorkload.Namespace
@@ -689,12 +803,8 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 		}
 
 		desiredKpackImage.Spec = buildv1alpha2.ImageSpec{
-			Tag: kpackImageTag,
-			Builder: corev1.ObjectReference{
-				Kind:       clusterBuilderKind,
-				Name:       r.controllerConfig.ClusterBuilderName,
-				APIVersion: clusterBuilderAPIVersion,
-			},
+			Tag:                kpackImageTag,
+			Builder:            builderRef,
 			ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
 			Source: corev1alpha1.SourceConfig{
 				Registry: &corev1alpha1.Registry{
@@ -713,12 +823,6 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 				},
 			},
 		}
-		if customBuilderName != "" {
-			desiredKpackImage.Spec.Builder.Kind = "Builder"
-			desiredKpackImage.Spec.Builder.Name = customBuilderName
-			desiredKpackImage.Spec.Builder.Namespace = buildWorkload.Namespace
-		}
-
 		// Cannot use SetControllerReference here as multiple BuildWorkloads can "own" the same Image.
 		err = controllerutil.SetOwnerReference(buildWorkload, &desiredKpackImage, r.scheme)
 		if err != nil {
@@ -45,6 +45,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		services                  []corev1.ObjectReference
 		reconcilerName            string
 		buildpacks                []string
+		stack                     string
 		imageRepoCreatorCallCount int
 		expectedCacheVolumeSize   string
 	)
@@ -125,6 +126,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		}
 
 		buildpacks = nil
+		stack = ""
 
 		fakeImageConfigGetter.ConfigReturns(image.Config{
 			Labels: map[string]string{
@@ -193,6 +195,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 	Describe("BuildWorkload initialization phase", func() {
 		JustBeforeEach(func() {
 			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Spec.Stack = stack
 			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
 		})
 
@@ -349,7 +352,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			})
 
 			It("creates a kpack Builder", func() {
-				builderName := controllers.ComputeBuilderName(buildWorkload.Spec.Buildpacks)
+				builderName := controllers.ComputeBuilderName("", buildWorkload.Spec.Buildpacks)
 				builder := &buildv1alpha2.Builder{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      builderName,
@@ -383,7 +386,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 				Eventually(func(g Gomega) {
 					kpackImage := new(buildv1alpha2.Image)
 					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
-					g.Expect(kpackImage.Spec.Builder.Name).To(Equal(controllers.ComputeBuilderName(buildWorkload.Spec.Buildpacks)))
+					g.Expect(kpackImage.Spec.Builder.Name).To(Equal(controllers.ComputeBuilderName("", buildWorkload.Spec.Buildpacks)))
 					g.Expect(kpackImage.Spec.Builder.Namespace).To(Equal(buildWorkload.Namespace))
 					g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("Builder"))
 				}).Should(Succeed())
@@ -402,7 +405,7 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 
 					sharedBuilder = &buildv1alpha2.Builder{
 						ObjectMeta: metav1.ObjectMeta{
-							Name:      controllers.ComputeBuilderName(anotherBuildWorkload.Spec.Buildpacks),
+							Name:      controllers.ComputeBuilderName("", anotherBuildWorkload.Spec.Buildpacks),
 							Namespace: namespaceGUID,
 						},
 					}
@@ -443,6 +446,178 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			})
 		})
 
+		When("a stack is specified", func() {
+			getKpackImage := func(g Gomega) *buildv1alpha2.Image {
+				kpackImage := new(buildv1alpha2.Image)
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+				return kpackImage
+			}
+
+			BeforeEach(func() {
+				stack = "my-stack"
+			})
+
+			When("the stack is the one of the default ClusterBuilder", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, clusterBuilder, func() {
+						clusterBuilder.Status.Stack.ID = "my-stack"
+					})).To(Succeed())
+				})
+
+				It("uses the default ClusterBuilder", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := getKpackImage(g)
+						g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal(clusterBuilder.Name))
+					}).Should(Succeed())
+				})
+			})
+
+			When("another ClusterBuilder has the stack", func() {
+				var stackClusterBuilder *buildv1alpha2.ClusterBuilder
+
+				BeforeEach(func() {
+					stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: uuid.NewString(),
+						},
+						Spec: buildv1alpha2.ClusterBuilderSpec{
+							BuilderSpec: buildv1alpha2.BuilderSpec{
+								Stack: corev1.ObjectReference{
+									Kind: "ClusterStack",
+									Name: "my-other-cluster-stack",
+								},
+							},
+						},
+					}
+					Expect(adminClient.Create(ctx, stackClusterBuilder)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(adminClient.Delete(ctx, stackClusterBuilder)).To(Succeed())
+					})
+
+					Expect(k8s.Patch(ctx, adminClient, stackClusterBuilder, func() {
+						stackClusterBuilder.Status.Stack.ID = "my-stack"
+					})).To(Succeed())
+				})
+
+				It("uses that ClusterBuilder", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := getKpackImage(g)
+						g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal(stackClusterBuilder.Name))
+					}).Should(Succeed())
+				})
+			})
+
+			When("a ClusterStack has the stack id", func() {
+				var clusterStack *buildv1alpha2.ClusterStack
+
+				BeforeEach(func() {
+					clusterStack = &buildv1alpha2.ClusterStack{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: uuid.NewString(),
+						},
+						Spec: buildv1alpha2.ClusterStackSpec{
+							Id: "my-stack",
+							BuildImage: buildv1alpha2.ClusterStackSpecImage{
+								Image: "my/build-image",
+							},
+							RunImage: buildv1alpha2.ClusterStackSpecImage{
+								Image: "my/run-image",
+							},
+						},
+					}
+					Expect(adminClient.Create(ctx, clusterStack)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(adminClient.Delete(ctx, clusterStack)).To(Succeed())
+					})
+				})
+
+				It("creates a kpack Builder on the ClusterStack", func() {
+					builder := &buildv1alpha2.Builder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      controllers.ComputeBuilderName("my-stack", nil),
+							Namespace: namespaceGUID,
+						},
+					}
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(builder), builder)).To(Succeed())
+						g.Expect(builder.Spec.Stack).To(Equal(corev1.ObjectReference{
+							Kind: "ClusterStack",
+							Name: clusterStack.Name,
+						}))
+						g.Expect(builder.Spec.Store).To(Equal(clusterBuilder.Spec.Store))
+						g.Expect(builder.Spec.Order).To(Equal(clusterBuilder.Spec.Order))
+					}).Should(Succeed())
+				})
+
+				It("sets the builder ref on the image", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := getKpackImage(g)
+						g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("Builder"))
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal(controllers.ComputeBuilderName("my-stack", nil)))
+						g.Expect(kpackImage.Spec.Builder.Namespace).To(Equal(namespaceGUID))
+					}).Should(Succeed())
+				})
+
+				When("buildpacks are specified", func() {
+					BeforeEach(func() {
+						buildpacks = []string{"repo/my-buildpack"}
+					})
+
+					It("creates a kpack Builder for the buildpacks on the ClusterStack", func() {
+						builder := &buildv1alpha2.Builder{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      controllers.ComputeBuilderName("my-stack", buildpacks),
+								Namespace: namespaceGUID,
+							},
+						}
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(builder), builder)).To(Succeed())
+							g.Expect(builder.Spec.Stack.Name).To(Equal(clusterStack.Name))
+							g.Expect(builder.Spec.Order).To(HaveLen(1))
+							g.Expect(builder.Spec.Order[0].Group[0].Id).To(Equal("repo/my-buildpack"))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("no builder has the stack", func() {
+				It("fails the build workload", func() {
+					updatedWorkload := &korifiv1alpha1.BuildWorkload{ObjectMeta: metav1.ObjectMeta{Name: buildWorkloadGUID, Namespace: namespaceGUID}}
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(updatedWorkload), updatedWorkload)).To(Succeed())
+						succeededCondition := mustHaveCondition(g, updatedWorkload.Status.Conditions, "Succeeded")
+						g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+						g.Expect(succeededCondition.Reason).To(Equal("UnknownStack"))
+						g.Expect(succeededCondition.Message).To(ContainSubstring("my-stack"))
+					}).Should(Succeed())
+				})
+
+				It("does not create a kpack image", func() {
+					Consistently(func(g Gomega) {
+						kpackImage := new(buildv1alpha2.Image)
+						err := adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}, "2s").Should(Succeed())
+				})
+			})
+
+			When("the stack is the legacy default stack", func() {
+				BeforeEach(func() {
+					stack = "cflinuxfs3"
+				})
+
+				It("uses the default ClusterBuilder", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := getKpackImage(g)
+						g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal(clusterBuilder.Name))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("reconciler name on BuildWorkload is not kpack-image-builder", func() {
 			BeforeEach(func() {
 				reconcilerName = "notkpackreconciler"
@@ -10,6 +10,7 @@ type Config struct {
 	CFRootNamespace           string                               `yaml:"cfRootNamespace"`
 	CFStagingResources        controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
 	ClusterBuilderName        string                               `yaml:"clusterBuilderName"`
+	DefaultStack              string                               `yaml:"defaultStack"`
 	BuilderServiceAccount     string                               `yaml:"builderServiceAccount"`
 	BuilderReadinessTimeout   time.Duration                        `yaml:"builderReadinessTimeout"`
 	ContainerRepositoryPrefix string                               `yaml:"containerRepositoryPrefix"`
@@ -142,6 +142,7 @@ var _ = BeforeEach(func() {
 	controllerConfig := &config.Config{
 		CFRootNamespace:           rootNamespace.Name,
 		ClusterBuilderName:        clusterBuilderName,
+		DefaultStack:              "cflinuxfs3",
 		BuilderServiceAccount:     "builder-service-account",
 		BuilderReadinessTimeout:   4 * time.Second,
 		ContainerRepositoryPrefix: "my.repository/my-prefix/",
@@ -174,6 +175,15 @@ var _ = BeforeEach(func() {
 		).SetupWithManager(k8sManager),
 	).To(Succeed())
 
+	Expect(
+		controllers.NewBuildpackOrderController(
+			k8sManager.GetClient(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("BuildpackOrder"),
+			clusterBuilderName,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
+
 	fakeImageDeleter = new(fake.ImageDeleter)
 	kpackBuildReconciler := controllers.NewKpackBuildController(
 		k8sManager.GetClient(),
@@ -183,6 +193,15 @@ var _ = BeforeEach(func() {
 	)
 	Expect(kpackBuildReconciler.SetupWithManager(k8sManager)).To(Succeed())
 
+	Expect(
+		controllers.NewBuildCacheController(
+			k8sManager.GetClient(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("BuildCache"),
+			fakeImageDeleter,
+			"builder-service-account",
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
+
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
 
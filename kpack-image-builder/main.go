@@ -140,6 +140,15 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		return fmt.Errorf("unable to create BuilderInfo controller: %v", err)
 	}
 
+	if err = controllers.NewBuildpackOrderController(
+		controllersClient,
+		controllersLog,
+		controllerConfig.ClusterBuilderName,
+		controllerConfig.CFRootNamespace,
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create BuildpackOrder controller: %v", err)
+	}
+
 	if err = controllers.NewKpackBuildController(
 		controllersClient,
 		controllersLog,
@@ -149,6 +158,15 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		return fmt.Errorf("unable to create KpackBuild controller: %v", err)
 	}
 
+	if err = controllers.NewBuildCacheController(
+		controllersClient,
+		controllersLog,
+		imageClient,
+		controllerConfig.BuilderServiceAccount,
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create BuildCache controller: %v", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// BuildCacheController acknowledges the requests to clear the build cache of
+// the apps labelled with korifiv1alpha1.ClearBuildCacheLabelKey for this
+// builder. Every build runs in a fresh Job without any cache being kept
+// between builds, so there is nothing to clear and the label is removed.
+type BuildCacheController struct {
+	log       logr.Logger
+	k8sClient client.Client
+}
+
+func NewBuildCacheController(
+	k8sClient client.Client,
+	log logr.Logger,
+) *BuildCacheController {
+	return &BuildCacheController{
+		log:       log,
+		k8sClient: k8sClient,
+	}
+}
+
+func (c *BuildCacheController) SetupWithManager(mgr manager.Manager) error {
+	// ignoring error as this construction is not dynamic
+	labelSelector, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			korifiv1alpha1.ClearBuildCacheLabelKey: LifecycleReconcilerName,
+		},
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("buildcache").
+		For(&korifiv1alpha1.CFApp{}).
+		WithEventFilter(labelSelector).
+		Complete(c)
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;patch
+
+func (c *BuildCacheController) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := c.log.WithName("BuildCache").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name).
+		WithValues("logID", uuid.NewString())
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := c.k8sClient.Get(ctx, req.NamespacedName, cfApp)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch app", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfApp.Labels[korifiv1alpha1.ClearBuildCacheLabelKey] != LifecycleReconcilerName {
+		return ctrl.Result{}, nil
+	}
+
+	original := cfApp.DeepCopy()
+	delete(cfApp.Labels, korifiv1alpha1.ClearBuildCacheLabelKey)
+	delete(cfApp.Annotations, korifiv1alpha1.ClearBuildCacheRequestAnnotationKey)
+	err = c.k8sClient.Patch(ctx, cfApp, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		log.Info("unable to remove clear build cache label from app", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
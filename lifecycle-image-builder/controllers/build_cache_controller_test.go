@@ -0,0 +1,76 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/lifecycle-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuildCacheController", func() {
+	var cfApp *korifiv1alpha1.CFApp
+
+	BeforeEach(func() {
+		namespaceGUID := prefixedGUID("namespace")
+		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceGUID}})).To(Succeed())
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceGUID,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  uuid.NewString(),
+				DesiredState: "STOPPED",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+	})
+
+	When("the app is labelled for clearing its build cache", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: controllers.LifecycleReconcilerName,
+				}
+				app.Annotations = map[string]string{
+					korifiv1alpha1.ClearBuildCacheRequestAnnotationKey: "clear-request-guid",
+				}
+			})
+		})
+
+		It("removes the label and the request annotation from the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheLabelKey))
+				g.Expect(cfApp.Annotations).NotTo(HaveKey(korifiv1alpha1.ClearBuildCacheRequestAnnotationKey))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app is labelled for clearing its build cache by another builder", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfApp, func(app *korifiv1alpha1.CFApp) {
+				app.Labels = map[string]string{
+					korifiv1alpha1.ClearBuildCacheLabelKey: "kpack-image-builder",
+				}
+			})
+		})
+
+		It("leaves the label in place", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Labels).To(HaveKeyWithValue(korifiv1alpha1.ClearBuildCacheLabelKey, "kpack-image-builder"))
+			}, "2s").Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	builderMetadataLabel = "io.buildpacks.builder.metadata"
+	buildpackOrderLabel  = "io.buildpacks.buildpack.order"
+)
+
+func NewBuilderInfoReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	imageConfigGetter ImageConfigGetter,
+	builderImage string,
+	builderServiceAccount string,
+	rootNamespaceName string,
+) *k8s.PatchingReconciler[korifiv1alpha1.BuilderInfo] {
+	builderInfoReconciler := BuilderInfoReconciler{
+		k8sClient:             c,
+		scheme:                scheme,
+		log:                   log,
+		imageConfigGetter:     imageConfigGetter,
+		builderImage:          builderImage,
+		builderServiceAccount: builderServiceAccount,
+		rootNamespaceName:     rootNamespaceName,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.BuilderInfo](log, c, &builderInfoReconciler)
+}
+
+// BuilderInfoReconciler reports the stack and the buildpacks of the builder
+// image, as read from the labels the builder image has been created with
+type BuilderInfoReconciler struct {
+	k8sClient             client.Client
+	scheme                *runtime.Scheme
+	log                   logr.Logger
+	imageConfigGetter     ImageConfigGetter
+	builderImage          string
+	builderServiceAccount string
+	rootNamespaceName     string
+}
+
+func (r *BuilderInfoReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.BuilderInfo{}).
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterBuilderInfos))
+}
+
+func (r *BuilderInfoReconciler) filterBuilderInfos(object client.Object) bool {
+	builderInfo, ok := object.(*korifiv1alpha1.BuilderInfo)
+	if !ok {
+		return true
+	}
+
+	return builderInfo.Name == LifecycleReconcilerName && builderInfo.Namespace == r.rootNamespaceName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=builderinfos,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=builderinfos/status,verbs=get;patch
+
+func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *korifiv1alpha1.BuilderInfo) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !info.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	info.Status.ObservedGeneration = info.Generation
+	log.V(1).Info("set observed generation", "generation", info.Status.ObservedGeneration)
+
+	config, err := r.imageConfigGetter.Config(ctx, image.Creds{
+		Namespace:          r.rootNamespaceName,
+		ServiceAccountName: r.builderServiceAccount,
+	}, r.builderImage)
+	if err != nil {
+		r.log.Info("error when fetching builder image config", "reason", err)
+
+		info.Status.Stacks = []korifiv1alpha1.BuilderInfoStatusStack{}
+		info.Status.Buildpacks = []korifiv1alpha1.BuilderInfoStatusBuildpack{}
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("BuilderImageUnavailable").
+			WithMessage(fmt.Sprintf("Error fetching builder image %q: %s", r.builderImage, err))
+	}
+
+	var metadata builderMetadata
+	if err = json.Unmarshal([]byte(config.Labels[builderMetadataLabel]), &metadata); err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("InvalidBuilderImage").
+			WithMessage(fmt.Sprintf("Builder image %q has no valid %s label", r.builderImage, builderMetadataLabel)).
+			WithNoRequeue()
+	}
+
+	var order []builderOrderEntry
+	if err = json.Unmarshal([]byte(config.Labels[buildpackOrderLabel]), &order); err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithCause(err).
+			WithReason("InvalidBuilderImage").
+			WithMessage(fmt.Sprintf("Builder image %q has no valid %s label", r.builderImage, buildpackOrderLabel)).
+			WithNoRequeue()
+	}
+
+	stackID := config.Labels[stackIDLabel]
+	info.Status.Stacks = []korifiv1alpha1.BuilderInfoStatusStack{}
+	if stackID != "" {
+		info.Status.Stacks = append(info.Status.Stacks, korifiv1alpha1.BuilderInfoStatusStack{
+			Name:              stackID,
+			Description:       metadata.Description,
+			CreationTimestamp: info.CreationTimestamp,
+			UpdatedTimestamp:  info.CreationTimestamp,
+		})
+	}
+	info.Status.Buildpacks = orderToBuildpacks(order, metadata, stackID, info.CreationTimestamp)
+
+	return ctrl.Result{}, nil
+}
+
+type builderMetadata struct {
+	Description string                `json:"description"`
+	Buildpacks  []builderBuildpackRef `json:"buildpacks"`
+}
+
+type builderOrderEntry struct {
+	Group []builderBuildpackRef `json:"group"`
+}
+
+type builderBuildpackRef struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// orderToBuildpacks lists the first buildpack of each order group. Order
+// entries may omit the version when the builder contains a single version of
+// the buildpack, in which case it is looked up in the builder metadata.
+func orderToBuildpacks(order []builderOrderEntry, metadata builderMetadata, stackID string, timestamp metav1.Time) []korifiv1alpha1.BuilderInfoStatusBuildpack {
+	versions := map[string]string{}
+	for _, bp := range metadata.Buildpacks {
+		versions[bp.ID] = bp.Version
+	}
+
+	buildpacks := make([]korifiv1alpha1.BuilderInfoStatusBuildpack, 0, len(order))
+	for _, entry := range order {
+		if len(entry.Group) == 0 {
+			continue
+		}
+
+		version := entry.Group[0].Version
+		if version == "" {
+			version = versions[entry.Group[0].ID]
+		}
+
+		buildpacks = append(buildpacks, korifiv1alpha1.BuilderInfoStatusBuildpack{
+			Name:              entry.Group[0].ID,
+			Stack:             stackID,
+			Version:           version,
+			CreationTimestamp: timestamp,
+			UpdatedTimestamp:  timestamp,
+		})
+	}
+
+	return buildpacks
+}
@@ -0,0 +1,120 @@
+package controllers_test
+
+import (
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/lifecycle-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tools/image"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("BuilderInfoReconciler", func() {
+	var info *korifiv1alpha1.BuilderInfo
+
+	BeforeEach(func() {
+		fakeImageConfigGetter.ConfigReturns(image.Config{
+			Labels: map[string]string{
+				"io.buildpacks.stack.id":         "io.buildpacks.stacks.jammy",
+				"io.buildpacks.builder.metadata": `{"description":"Ubuntu Jammy base","buildpacks":[{"id":"paketo-buildpacks/go","version":"4.1.0"},{"id":"paketo-buildpacks/ruby","version":"0.41.0"}]}`,
+				"io.buildpacks.buildpack.order":  `[{"group":[{"id":"paketo-buildpacks/ruby"}]},{"group":[{"id":"paketo-buildpacks/go","version":"4.1.0"}]}]`,
+			},
+		}, nil)
+
+		info = &korifiv1alpha1.BuilderInfo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      controllers.LifecycleReconcilerName,
+				Namespace: rootNamespace.Name,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, info)).To(Succeed())
+	})
+
+	It("reads the builder image with the builder service account", func() {
+		Eventually(fakeImageConfigGetter.ConfigCallCount).Should(BeNumerically(">", 0))
+		_, creds, imageRef := fakeImageConfigGetter.ConfigArgsForCall(0)
+		Expect(creds).To(Equal(image.Creds{
+			Namespace:          rootNamespace.Name,
+			ServiceAccountName: "builder-service-account",
+		}))
+		Expect(imageRef).To(Equal(builderImage))
+	})
+
+	It("sets the buildpacks in the builder order on the BuilderInfo", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+			g.Expect(info.Status.Buildpacks).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal("paketo-buildpacks/ruby"),
+					"Version": Equal("0.41.0"),
+					"Stack":   Equal("io.buildpacks.stacks.jammy"),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":    Equal("paketo-buildpacks/go"),
+					"Version": Equal("4.1.0"),
+					"Stack":   Equal("io.buildpacks.stacks.jammy"),
+				}),
+			))
+		}).Should(Succeed())
+	})
+
+	It("sets the stack on the BuilderInfo", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+			g.Expect(info.Status.Stacks).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":        Equal("io.buildpacks.stacks.jammy"),
+				"Description": Equal("Ubuntu Jammy base"),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("marks the BuilderInfo as ready", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+			readyCondition := meta.FindStatusCondition(info.Status.Conditions, "Ready")
+			g.Expect(readyCondition).NotTo(BeNil())
+			g.Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	When("the builder image cannot be read", func() {
+		BeforeEach(func() {
+			fakeImageConfigGetter.ConfigReturns(image.Config{}, errors.New("boom"))
+		})
+
+		It("marks the BuilderInfo as not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(info.Status.Conditions, "Ready")
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("BuilderImageUnavailable"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the builder image has no builder metadata", func() {
+		BeforeEach(func() {
+			fakeImageConfigGetter.ConfigReturns(image.Config{Labels: map[string]string{}}, nil)
+		})
+
+		It("marks the BuilderInfo as not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(info.Status.Conditions, "Ready")
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("InvalidBuilderImage"))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,513 @@
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/lifecycle-image-builder/controllers/config"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	LifecycleReconcilerName = "lifecycle-image-builder"
+	BuildWorkloadLabelKey   = "korifi.cloudfoundry.org/build-workload-name"
+
+	buildpackBuildMetadataLabel = "io.buildpacks.build.metadata"
+	stackIDLabel                = "io.buildpacks.stack.id"
+
+	// the platform API version the creator is invoked with
+	cnbPlatformAPI = "0.12"
+
+	workspaceDir      = "/workspace"
+	layersDir         = "/layers"
+	orderPath         = "/layers/korifi-order.toml"
+	dockerConfigDir   = "/registry-credentials"
+	platformBindings  = "/platform/bindings"
+	buildContainer    = "build"
+	fetcherContainer  = "fetch-source"
+	workspaceVolume   = "workspace"
+	layersVolume      = "layers"
+	credentialsVolume = "registry-credentials"
+)
+
+//counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
+
+type ImageConfigGetter interface {
+	Config(ctx context.Context, creds image.Creds, imageRef string) (image.Config, error)
+}
+
+//counterfeiter:generate -o fake -fake-name RepositoryCreator . RepositoryCreator
+
+type RepositoryCreator interface {
+	CreateRepository(ctx context.Context, name string) error
+}
+
+func NewBuildWorkloadReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	config *config.Config,
+	imageConfigGetter ImageConfigGetter,
+	imageRepoCreator RepositoryCreator,
+) *k8s.PatchingReconciler[korifiv1alpha1.BuildWorkload] {
+	buildWorkloadReconciler := BuildWorkloadReconciler{
+		k8sClient:         c,
+		scheme:            scheme,
+		log:               log,
+		controllerConfig:  config,
+		imageConfigGetter: imageConfigGetter,
+		imageRepoCreator:  imageRepoCreator,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.BuildWorkload](log, c, &buildWorkloadReconciler)
+}
+
+// BuildWorkloadReconciler builds BuildWorkloads by running the CNB lifecycle
+// `creator` of the configured builder image in a Job
+type BuildWorkloadReconciler struct {
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	log               logr.Logger
+	controllerConfig  *config.Config
+	imageConfigGetter ImageConfigGetter
+	imageRepoCreator  RepositoryCreator
+}
+
+func (r *BuildWorkloadReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.BuildWorkload{}).
+		Owns(&batchv1.Job{}).
+		WithEventFilter(predicate.NewPredicateFuncs(filterBuildWorkloads))
+}
+
+func filterBuildWorkloads(object client.Object) bool {
+	buildWorkload, ok := object.(*korifiv1alpha1.BuildWorkload)
+	if !ok {
+		return true
+	}
+
+	// Only reconcile buildworkloads that have their Spec.BuilderName matching this builder
+	return buildWorkload.Spec.BuilderName == LifecycleReconcilerName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+//+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch
+
+func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	buildWorkload.Status.ObservedGeneration = buildWorkload.Generation
+	log.V(1).Info("set observed generation", "generation", buildWorkload.Status.ObservedGeneration)
+
+	if !buildWorkload.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if hasCompleted(buildWorkload) {
+		return ctrl.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), job)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, r.createBuildJob(ctx, log, buildWorkload)
+		}
+
+		log.Info("error when fetching build job", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	switch {
+	case jobHasCondition(job, batchv1.JobFailed):
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "BuildFailed",
+			Message:            "Check build log output",
+			ObservedGeneration: buildWorkload.Generation,
+		})
+	case jobHasCondition(job, batchv1.JobComplete):
+		buildWorkload.Status.Droplet, err = r.generateDropletStatus(ctx, buildWorkload)
+		if err != nil {
+			log.Info("error when compiling the DropletStatus", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "BuildSucceeded",
+			Message:            "Image built successfully",
+			ObservedGeneration: buildWorkload.Generation,
+		})
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func hasCompleted(buildWorkload *korifiv1alpha1.BuildWorkload) bool {
+	succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	return succeeded != nil && succeeded.Status != metav1.ConditionUnknown
+}
+
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BuildWorkloadReconciler) createBuildJob(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) error {
+	supported, err := r.isSupportedStack(ctx, buildWorkload.Spec.Stack)
+	if err != nil {
+		log.Info("error when checking the stack", "reason", err)
+		return err
+	}
+
+	if !supported {
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "UnknownStack",
+			Message:            fmt.Sprintf("Stack %q is not the stack of the builder image. See `cf stacks`", buildWorkload.Spec.Stack),
+			ObservedGeneration: buildWorkload.Generation,
+		})
+		return nil
+	}
+
+	order, err := r.buildpackOrder(ctx, buildWorkload)
+	if err != nil {
+		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SucceededConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "InvalidBuildpacks",
+			Message:            err.Error(),
+			ObservedGeneration: buildWorkload.Generation,
+		})
+		return nil
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: buildWorkload.Namespace,
+		Name:      r.controllerConfig.BuilderServiceAccount,
+	}, serviceAccount)
+	if err != nil {
+		log.Info("error when fetching builder ServiceAccount", "reason", err)
+		return err
+	}
+
+	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
+	if err = r.imageRepoCreator.CreateRepository(ctx, r.repositoryRef(appGUID)); err != nil {
+		log.Info("failed to create image repository", "reason", err)
+		return err
+	}
+
+	job := r.buildJob(buildWorkload, serviceAccount, order)
+	if err = controllerutil.SetControllerReference(buildWorkload, job, r.scheme); err != nil {
+		log.Info("unable to set owner reference on build job", "reason", err)
+		return err
+	}
+
+	if err = r.k8sClient.Create(ctx, job); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		log.Info("failed to create build job", "reason", err)
+		return err
+	}
+
+	meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "BuildRunning",
+		Message:            "Waiting for image build to complete",
+		ObservedGeneration: buildWorkload.Generation,
+	})
+
+	return nil
+}
+
+// isSupportedStack tells whether the builder image can build on the stack.
+// There is a single builder image, so besides the legacy default stack name
+// that apps carry when they do not choose a stack, only the stack of the
+// builder image is supported.
+func (r *BuildWorkloadReconciler) isSupportedStack(ctx context.Context, stack string) (bool, error) {
+	if stack == "" || stack == r.controllerConfig.DefaultStack {
+		return true, nil
+	}
+
+	info := &korifiv1alpha1.BuilderInfo{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: r.controllerConfig.CFRootNamespace,
+		Name:      LifecycleReconcilerName,
+	}, info)
+	if err != nil {
+		return false, fmt.Errorf("failed to get builder info: %w", err)
+	}
+
+	return slices.ContainsFunc(info.Status.Stacks, func(s korifiv1alpha1.BuilderInfoStatusStack) bool {
+		return s.Name == stack
+	}), nil
+}
+
+// buildpackOrder returns the content of an order.toml file restricting
+// detection to the requested buildpacks, or an empty string when the order of
+// the builder should be used. The lifecycle looks buildpacks up by id and
+// version, so the versions are taken from the BuilderInfo.
+func (r *BuildWorkloadReconciler) buildpackOrder(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+	if len(buildWorkload.Spec.Buildpacks) == 0 {
+		return "", nil
+	}
+
+	info := &korifiv1alpha1.BuilderInfo{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: r.controllerConfig.CFRootNamespace,
+		Name:      LifecycleReconcilerName,
+	}, info)
+	if err != nil {
+		return "", fmt.Errorf("failed to get builder info: %w", err)
+	}
+
+	versions := map[string]string{}
+	for _, bp := range info.Status.Buildpacks {
+		versions[bp.Name] = bp.Version
+	}
+
+	var order strings.Builder
+	order.WriteString("[[order]]\n")
+	for _, bp := range buildWorkload.Spec.Buildpacks {
+		version, ok := versions[bp]
+		if !ok {
+			return "", fmt.Errorf("buildpack %q not present in builder image. See `cf buildpacks`", bp)
+		}
+		fmt.Fprintf(&order, "[[order.group]]\nid = %q\nversion = %q\n", bp, version)
+	}
+
+	return order.String(), nil
+}
+
+func (r *BuildWorkloadReconciler) buildJob(buildWorkload *korifiv1alpha1.BuildWorkload, serviceAccount *corev1.ServiceAccount, order string) *batchv1.Job {
+	volumes := []corev1.Volume{
+		{Name: workspaceVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: layersVolume, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: workspaceVolume, MountPath: workspaceDir},
+		{Name: layersVolume, MountPath: layersDir},
+	}
+	env := []corev1.EnvVar{
+		{Name: "CNB_PLATFORM_API", Value: cnbPlatformAPI},
+	}
+
+	// both the source fetcher and the lifecycle read registry credentials
+	// from a docker config file
+	if len(serviceAccount.ImagePullSecrets) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: credentialsVolume,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: serviceAccount.ImagePullSecrets[0].Name,
+				Items: []corev1.KeyToPath{{
+					Key:  corev1.DockerConfigJsonKey,
+					Path: "config.json",
+				}},
+			}},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: credentialsVolume, MountPath: dockerConfigDir, ReadOnly: true})
+		env = append(env, corev1.EnvVar{Name: "DOCKER_CONFIG", Value: dockerConfigDir})
+	}
+
+	buildMounts := slices.Clone(mounts)
+	for _, service := range buildWorkload.Spec.Services {
+		volumeName := "binding-" + service.Name
+		volumes = append(volumes, corev1.Volume{
+			Name:         volumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: service.Name}},
+		})
+		buildMounts = append(buildMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: platformBindings + "/" + service.Name,
+			ReadOnly:  true,
+		})
+	}
+
+	buildEnv := slices.Concat(env, buildWorkload.Spec.Env)
+	if order != "" {
+		buildEnv = append(buildEnv, corev1.EnvVar{Name: "CNB_ORDER_PATH", Value: orderPath})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildWorkload.Name,
+			Namespace: buildWorkload.Namespace,
+			Labels: map[string]string{
+				BuildWorkloadLabelKey:            buildWorkload.Name,
+				korifiv1alpha1.CFAppGUIDLabelKey: buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: tools.PtrTo(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						BuildWorkloadLabelKey: buildWorkload.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
+					Volumes:            volumes,
+					InitContainers: []corev1.Container{{
+						Name:    fetcherContainer,
+						Image:   r.controllerConfig.SourceFetcherImage,
+						Command: []string{"sh", "-c", fetchSourceScript},
+						Env: slices.Concat(env, []corev1.EnvVar{
+							{Name: "SOURCE_IMAGE", Value: buildWorkload.Spec.Source.Registry.Image},
+							{Name: "ORDER", Value: order},
+						}),
+						VolumeMounts: mounts,
+					}},
+					Containers: []corev1.Container{{
+						Name:    buildContainer,
+						Image:   r.controllerConfig.BuilderImage,
+						Command: []string{"/cnb/lifecycle/creator"},
+						Args: []string{
+							"-app=" + workspaceDir,
+							"-layers=" + layersDir,
+							r.repositoryRef(buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]) + ":" + buildWorkload.Name,
+						},
+						Env:          buildEnv,
+						VolumeMounts: buildMounts,
+						Resources:    GetBuildResources(r.controllerConfig.CFStagingResources.DiskMB, r.controllerConfig.CFStagingResources.MemoryMB),
+					}},
+				},
+			},
+		},
+	}
+}
+
+// fetchSourceScript extracts the package source image into the workspace and
+// writes the buildpack order, if any
+const fetchSourceScript = `set -e
+crane export "$SOURCE_IMAGE" - | tar -xf - -C ` + workspaceDir + `
+if [ -n "$ORDER" ]; then
+  printf '%s' "$ORDER" > ` + orderPath + `
+fi`
+
+func GetBuildResources(diskMB, memoryMB int64) corev1.ResourceRequirements {
+	resourceRequirements := corev1.ResourceRequirements{
+		Requests: map[corev1.ResourceName]resource.Quantity{},
+	}
+
+	if diskMB != 0 {
+		resourceRequirements.Requests[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(diskMB, resource.Mega)
+	}
+
+	if memoryMB != 0 {
+		resourceRequirements.Requests[corev1.ResourceMemory] = *resource.NewScaledQuantity(memoryMB, resource.Mega)
+	}
+
+	return resourceRequirements
+}
+
+func (r *BuildWorkloadReconciler) generateDropletStatus(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (*korifiv1alpha1.BuildDropletStatus, error) {
+	repoRef := r.repositoryRef(buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey])
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: buildWorkload.Namespace,
+		Name:      r.controllerConfig.BuilderServiceAccount,
+	}, serviceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting builder service account: %w", err)
+	}
+
+	config, err := r.imageConfigGetter.Config(ctx, image.Creds{
+		Namespace:          buildWorkload.Namespace,
+		ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
+	}, repoRef+":"+buildWorkload.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting image config: %w", err)
+	}
+
+	var buildMd buildMetadata
+	err = json.Unmarshal([]byte(config.Labels[buildpackBuildMetadataLabel]), &buildMd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to umarshal build metadata: %w", err)
+	}
+
+	processTypes := []korifiv1alpha1.ProcessType{}
+	for _, process := range buildMd.Processes {
+		processTypes = append(processTypes, korifiv1alpha1.ProcessType{
+			Type:    process.Type,
+			Command: extractFullCommand(process),
+		})
+	}
+
+	return &korifiv1alpha1.BuildDropletStatus{
+		Registry: korifiv1alpha1.Registry{
+			Image:            repoRef + "@" + config.Digest,
+			ImagePullSecrets: serviceAccount.ImagePullSecrets,
+		},
+
+		Stack: config.Labels[stackIDLabel],
+
+		ProcessTypes: processTypes,
+		Ports:        config.ExposedPorts,
+	}, nil
+}
+
+type buildMetadata struct {
+	Processes []process `json:"processes"`
+}
+
+type process struct {
+	Type    string   `json:"type"`
+	Command []string `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// extractFullCommand joins the command and arguments of a process. From
+// platform API 0.10 on, the command of a process is an array.
+func extractFullCommand(process process) string {
+	cmdString := strings.Join(process.Command, " ")
+	for _, a := range process.Args {
+		cmdString = fmt.Sprintf(`%s %q`, cmdString, a)
+	}
+	return cmdString
+}
+
+func (r *BuildWorkloadReconciler) repositoryRef(appGUID string) string {
+	return r.controllerConfig.ContainerRepositoryPrefix + appGUID + "-droplets"
+}
@@ -0,0 +1,343 @@
+package controllers_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/lifecycle-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const appGUID = "app-guid"
+
+var _ = Describe("BuildWorkloadReconciler", func() {
+	const registryCredentialsSecret = "image-registry-credentials"
+
+	var (
+		namespaceGUID string
+		buildWorkload *korifiv1alpha1.BuildWorkload
+		buildpacks    []string
+		stack         string
+		imageConfig   image.Config
+	)
+
+	BeforeEach(func() {
+		namespaceGUID = prefixedGUID("namespace")
+		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceGUID}})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "builder-service-account",
+				Namespace: namespaceGUID,
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryCredentialsSecret}},
+		})).To(Succeed())
+
+		imageConfig = image.Config{
+			Labels: map[string]string{
+				"io.buildpacks.build.metadata": `{"processes":[{"type":"web","command":["bundle","exec","rackup"],"args":["config.ru","-p","$PORT"],"direct":false}]}`,
+				"io.buildpacks.stack.id":       "io.buildpacks.stacks.jammy",
+			},
+			ExposedPorts: []int32{8080},
+			Digest:       "sha256:abc",
+		}
+		fakeImageConfigGetter.ConfigStub = func(_ context.Context, _ image.Creds, imageRef string) (image.Config, error) {
+			if imageRef == builderImage {
+				return image.Config{Labels: map[string]string{
+					"io.buildpacks.builder.metadata": `{"buildpacks":[{"id":"paketo-buildpacks/ruby","version":"1.2.3"}]}`,
+					"io.buildpacks.buildpack.order":  `[{"group":[{"id":"paketo-buildpacks/ruby"}]}]`,
+				}}, nil
+			}
+			return imageConfig, nil
+		}
+
+		buildpacks = nil
+		stack = ""
+	})
+
+	JustBeforeEach(func() {
+		buildWorkload = &korifiv1alpha1.BuildWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespaceGUID,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: korifiv1alpha1.BuildWorkloadSpec{
+				BuildRef: korifiv1alpha1.RequiredLocalObjectReference{Name: "build-guid"},
+				Source: korifiv1alpha1.PackageSource{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "my.registry/my-prefix/app-guid-packages@sha256:def",
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryCredentialsSecret}},
+					},
+				},
+				Env:         []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+				Services:    []corev1.ObjectReference{{Name: "my-binding"}},
+				Buildpacks:  buildpacks,
+				Stack:       stack,
+				BuilderName: controllers.LifecycleReconcilerName,
+			},
+		}
+		Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
+	})
+
+	getJob := func(g Gomega) *batchv1.Job {
+		job := &batchv1.Job{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), job)).To(Succeed())
+		return job
+	}
+
+	It("creates the droplet repository", func() {
+		Eventually(imageRepoCreator.CreateRepositoryCallCount).Should(BeNumerically(">", 0))
+		_, repoName := imageRepoCreator.CreateRepositoryArgsForCall(0)
+		Expect(repoName).To(Equal("my.repository/my-prefix/app-guid-droplets"))
+	})
+
+	It("creates a build job owned by the build workload", func() {
+		Eventually(func(g Gomega) {
+			job := getJob(g)
+			g.Expect(job.Labels).To(HaveKeyWithValue(controllers.BuildWorkloadLabelKey, buildWorkload.Name))
+			g.Expect(job.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":       Equal(buildWorkload.Name),
+				"Controller": PointTo(BeTrue()),
+			})))
+
+			podSpec := job.Spec.Template.Spec
+			g.Expect(podSpec.ServiceAccountName).To(Equal("builder-service-account"))
+			g.Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+
+			g.Expect(podSpec.InitContainers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Image": Equal(sourceFetcherImage),
+				"Env": ContainElements(
+					corev1.EnvVar{Name: "SOURCE_IMAGE", Value: "my.registry/my-prefix/app-guid-packages@sha256:def"},
+					corev1.EnvVar{Name: "DOCKER_CONFIG", Value: "/registry-credentials"},
+				),
+			})))
+
+			g.Expect(podSpec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Image":   Equal(builderImage),
+				"Command": Equal([]string{"/cnb/lifecycle/creator"}),
+				"Args":    ContainElement("my.repository/my-prefix/app-guid-droplets:" + buildWorkload.Name),
+				"Env": ContainElements(
+					corev1.EnvVar{Name: "CNB_PLATFORM_API", Value: "0.12"},
+					corev1.EnvVar{Name: "FOO", Value: "bar"},
+				),
+				"VolumeMounts": ContainElement(MatchFields(IgnoreExtras, Fields{
+					"MountPath": Equal("/platform/bindings/my-binding"),
+				})),
+				"Resources": Equal(corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceEphemeralStorage: resource.MustParse("2048M"),
+						corev1.ResourceMemory:           resource.MustParse("1234M"),
+					},
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("marks the build workload as running", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+			succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+			g.Expect(succeeded).NotTo(BeNil())
+			g.Expect(succeeded.Status).To(Equal(metav1.ConditionUnknown))
+			g.Expect(succeeded.Reason).To(Equal("BuildRunning"))
+		}).Should(Succeed())
+	})
+
+	When("buildpacks are requested", func() {
+		BeforeEach(func() {
+			buildpacks = []string{"paketo-buildpacks/ruby"}
+
+			info := &korifiv1alpha1.BuilderInfo{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controllers.LifecycleReconcilerName,
+					Namespace: rootNamespace.Name,
+				},
+			}
+			Expect(adminClient.Create(ctx, info)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				g.Expect(info.Status.Buildpacks).NotTo(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("restricts the order to the requested buildpacks", func() {
+			Eventually(func(g Gomega) {
+				job := getJob(g)
+				g.Expect(job.Spec.Template.Spec.InitContainers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name:  "ORDER",
+					Value: "[[order]]\n[[order.group]]\nid = \"paketo-buildpacks/ruby\"\nversion = \"1.2.3\"\n",
+				}))
+				g.Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name:  "CNB_ORDER_PATH",
+					Value: "/layers/korifi-order.toml",
+				}))
+			}).Should(Succeed())
+		})
+
+		When("a buildpack is not in the builder", func() {
+			BeforeEach(func() {
+				buildpacks = []string{"not-a-buildpack"}
+			})
+
+			It("fails the build workload", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+					succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+					g.Expect(succeeded).NotTo(BeNil())
+					g.Expect(succeeded.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(succeeded.Reason).To(Equal("InvalidBuildpacks"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("a stack is requested", func() {
+		BeforeEach(func() {
+			stack = "io.buildpacks.stacks.noble"
+
+			fakeImageConfigGetter.ConfigStub = func(_ context.Context, _ image.Creds, imageRef string) (image.Config, error) {
+				if imageRef == builderImage {
+					return image.Config{Labels: map[string]string{
+						"io.buildpacks.builder.metadata": `{"buildpacks":[{"id":"paketo-buildpacks/ruby","version":"1.2.3"}]}`,
+						"io.buildpacks.buildpack.order":  `[{"group":[{"id":"paketo-buildpacks/ruby"}]}]`,
+						"io.buildpacks.stack.id":         "io.buildpacks.stacks.jammy",
+					}}, nil
+				}
+				return imageConfig, nil
+			}
+
+			info := &korifiv1alpha1.BuilderInfo{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controllers.LifecycleReconcilerName,
+					Namespace: rootNamespace.Name,
+				},
+			}
+			Expect(adminClient.Create(ctx, info)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(info), info)).To(Succeed())
+				g.Expect(info.Status.Stacks).NotTo(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("fails the build workload as the builder image is on another stack", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+				succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeeded).NotTo(BeNil())
+				g.Expect(succeeded.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeeded.Reason).To(Equal("UnknownStack"))
+			}).Should(Succeed())
+		})
+
+		When("the stack is the one of the builder image", func() {
+			BeforeEach(func() {
+				stack = "io.buildpacks.stacks.jammy"
+			})
+
+			It("creates a build job", func() {
+				Eventually(func(g Gomega) {
+					getJob(g)
+				}).Should(Succeed())
+			})
+		})
+
+		When("the stack is the legacy default stack", func() {
+			BeforeEach(func() {
+				stack = "cflinuxfs3"
+			})
+
+			It("creates a build job", func() {
+				Eventually(func(g Gomega) {
+					getJob(g)
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the build job completes", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				job := getJob(g)
+				g.Expect(k8s.Patch(ctx, adminClient, job, func() {
+					job.Status.StartTime = tools.PtrTo(metav1.Now())
+					job.Status.CompletionTime = tools.PtrTo(metav1.Now())
+					job.Status.Succeeded = 1
+					job.Status.Conditions = append(job.Status.Conditions,
+						batchv1.JobCondition{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue},
+						batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					)
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("marks the build workload as succeeded", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+				succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeeded).NotTo(BeNil())
+				g.Expect(succeeded.Status).To(Equal(metav1.ConditionTrue))
+			}).Should(Succeed())
+		})
+
+		It("sets the droplet status from the built image", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+				g.Expect(buildWorkload.Status.Droplet).NotTo(BeNil())
+				g.Expect(buildWorkload.Status.Droplet.Registry.Image).To(Equal("my.repository/my-prefix/app-guid-droplets@sha256:abc"))
+				g.Expect(buildWorkload.Status.Droplet.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: registryCredentialsSecret}))
+				g.Expect(buildWorkload.Status.Droplet.Stack).To(Equal("io.buildpacks.stacks.jammy"))
+				g.Expect(buildWorkload.Status.Droplet.ProcessTypes).To(ConsistOf(korifiv1alpha1.ProcessType{
+					Type:    "web",
+					Command: `bundle exec rackup "config.ru" "-p" "$PORT"`,
+				}))
+				g.Expect(buildWorkload.Status.Droplet.Ports).To(ConsistOf(int32(8080)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the build job fails", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				job := getJob(g)
+				g.Expect(k8s.Patch(ctx, adminClient, job, func() {
+					job.Status.StartTime = tools.PtrTo(metav1.Now())
+					job.Status.Failed = 1
+					job.Status.Conditions = append(job.Status.Conditions,
+						batchv1.JobCondition{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue},
+						batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+					)
+				})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		It("marks the build workload as failed", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), buildWorkload)).To(Succeed())
+				succeeded := meta.FindStatusCondition(buildWorkload.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+				g.Expect(succeeded).NotTo(BeNil())
+				g.Expect(succeeded.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(succeeded.Reason).To(Equal("BuildFailed"))
+			}).Should(Succeed())
+		})
+	})
+})
+
+func prefixedGUID(prefix string) string {
+	return prefix + "-" + uuid.NewString()[:8]
+}
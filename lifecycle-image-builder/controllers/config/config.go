@@ -0,0 +1,16 @@
+package config
+
+import (
+	controllersconfig "code.cloudfoundry.org/korifi/controllers/config"
+)
+
+type Config struct {
+	CFRootNamespace           string                               `yaml:"cfRootNamespace"`
+	CFStagingResources        controllersconfig.CFStagingResources `yaml:"cfStagingResources"`
+	BuilderImage              string                               `yaml:"builderImage"`
+	DefaultStack              string                               `yaml:"defaultStack"`
+	SourceFetcherImage        string                               `yaml:"sourceFetcherImage"`
+	BuilderServiceAccount     string                               `yaml:"builderServiceAccount"`
+	ContainerRepositoryPrefix string                               `yaml:"containerRepositoryPrefix"`
+	ContainerRegistryType     string                               `yaml:"containerRegistryType"`
+}
@@ -19,6 +19,7 @@ package appworkload
 import (
 	"context"
 	"fmt"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
@@ -60,11 +61,14 @@ const (
 	LabelAppWorkloadGUID = "korifi.cloudfoundry.org/appworkload-guid"
 	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
 
-	ApplicationContainerName = "application"
-	ServiceAccountName       = "korifi-app"
+	ApplicationContainerName   = "application"
+	SidecarContainerNamePrefix = "sidecar"
+	ServiceAccountName         = "korifi-app"
 
 	LivenessFailureThreshold  = 4
 	ReadinessFailureThreshold = 1
+
+	crashRecordingRetryInterval = 10 * time.Second
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -73,6 +77,11 @@ type PDB interface {
 	Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error
 }
 
+//counterfeiter:generate -o ./fake -fake-name Volumes . Volumes
+type Volumes interface {
+	Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) error
+}
+
 //counterfeiter:generate -o ./fake -fake-name WorkloadToStatefulsetConverter . WorkloadToStatefulsetConverter
 type WorkloadToStatefulsetConverter interface {
 	Convert(appWorkload *korifiv1alpha1.AppWorkload) (*appsv1.StatefulSet, error)
@@ -84,8 +93,10 @@ type AppWorkloadReconciler struct {
 	scheme           *runtime.Scheme
 	workloadsToStSet WorkloadToStatefulsetConverter
 	pdb              PDB
+	volumes          Volumes
 	log              logr.Logger
 	stateCollector   *state.AppWorkloadStateCollector
+	crashRecorder    *state.AppWorkloadCrashRecorder
 }
 
 func NewAppWorkloadReconciler(
@@ -93,16 +104,20 @@ func NewAppWorkloadReconciler(
 	scheme *runtime.Scheme,
 	workloadsToStSet WorkloadToStatefulsetConverter,
 	pdb PDB,
+	volumes Volumes,
 	log logr.Logger,
 	stateCollector *state.AppWorkloadStateCollector,
+	crashRecorder *state.AppWorkloadCrashRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload] {
 	appWorkloadReconciler := AppWorkloadReconciler{
 		k8sClient:        c,
 		scheme:           scheme,
 		workloadsToStSet: workloadsToStSet,
 		pdb:              pdb,
+		volumes:          volumes,
 		log:              log,
 		stateCollector:   stateCollector,
+		crashRecorder:    crashRecorder,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.AppWorkload](log, c, &appWorkloadReconciler)
 }
@@ -156,6 +171,9 @@ func filterAppWorkloads(object client.Object) bool {
 
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;patch;deletecollection
 
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;create;patch;delete;deletecollection
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;patch;delete
+
 func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -166,6 +184,12 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		return r.finalize(ctx, appWorkload)
 	}
 
+	err := r.volumes.Update(ctx, appWorkload)
+	if err != nil {
+		log.Info("error when creating or patching service volumes", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	statefulSet, err := r.workloadsToStSet.Convert(appWorkload)
 	if err != nil {
 		log.Info("error when converting AppWorkload", "reason", err)
@@ -205,10 +229,26 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 	}
 	appWorkload.Status.InstancesStatus = instancesState
 
+	// The statefulset has been reconciled by now, so failing to record
+	// crashes only delays the next reconcile. Pods usually do not change
+	// after a crash, so the reconcile has to be requeued explicitly
+	err = r.crashRecorder.RecordCrashes(ctx, appWorkload)
+	if err != nil {
+		log.Info("error when recording instance crashes", "reason", err)
+		return ctrl.Result{RequeueAfter: crashRecordingRetryInterval}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 func (r *AppWorkloadReconciler) finalize(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
+	// Persistent volumes are cluster scoped and cannot be owned by the app workload
+	if err := r.k8sClient.DeleteAllOf(ctx, &corev1.PersistentVolume{}, client.MatchingLabels{
+		LabelAppWorkloadGUID: appWorkload.Name,
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.k8sClient.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{
 		LabelAppWorkloadGUID: appWorkload.Name,
 	}); err != nil {
@@ -37,9 +37,12 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		statefulSet            *v1.StatefulSet
 		fakeWorkloadToStSet    *fake.WorkloadToStatefulsetConverter
 		fakePDB                *fake.PDB
+		fakeVolumes            *fake.Volumes
 		getAppWorkloadError    error
 		getStatefulSetError    error
 		createStatefulSetError error
+		createAuditEventError  error
+		pods                   []corev1.Pod
 	)
 
 	BeforeEach(func() {
@@ -48,6 +51,11 @@ var _ = Describe("AppWorkload Reconcile", func() {
 				Name:      uuid.NewString(),
 				Namespace: uuid.NewString(),
 			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:        uuid.NewString(),
+				AppGUID:     "app-guid",
+				ProcessType: "web",
+			},
 		}
 
 		statefulSet = &v1.StatefulSet{
@@ -61,6 +69,7 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		fakeWorkloadToStSet.ConvertReturns(statefulSet, nil)
 
 		fakePDB = new(fake.PDB)
+		fakeVolumes = new(fake.Volumes)
 
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -76,6 +85,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Resource: "StatefulSet",
 		}, "some-resource")
 		createStatefulSetError = nil
+		createAuditEventError = nil
+		pods = nil
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
 			switch obj := obj.(type) {
@@ -96,18 +107,29 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			switch obj.(type) {
 			case *v1.StatefulSet:
 				return createStatefulSetError
+			case *korifiv1alpha1.CFAuditEvent:
+				return createAuditEventError
 			default:
 				panic("TestClient Create provided an unexpected object type")
 			}
 		}
 
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			if podList, ok := list.(*corev1.PodList); ok {
+				podList.Items = pods
+			}
+			return nil
+		}
+
 		reconciler = appworkload.NewAppWorkloadReconciler(
 			fakeClient,
 			scheme.Scheme,
 			fakeWorkloadToStSet,
 			fakePDB,
+			fakeVolumes,
 			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
 			state.NewAppWorkloadStateCollector(fakeClient),
+			state.NewAppWorkloadCrashRecorder(fakeClient),
 		)
 	})
 
@@ -135,6 +157,26 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(patchedAppWorkload.Status.ObservedGeneration).To(Equal(patchedAppWorkload.Generation))
 		})
 
+		It("updates the service volumes", func() {
+			Expect(fakeVolumes.UpdateCallCount()).To(Equal(1))
+			_, actualWorkload := fakeVolumes.UpdateArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+		})
+
+		When("updating the service volumes fails", func() {
+			BeforeEach(func() {
+				fakeVolumes.UpdateReturns(errors.New("volumes-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("volumes-error"))
+			})
+
+			It("does not create the statefulset", func() {
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+			})
+		})
+
 		When("coverting the app workload to statefulset fails", func() {
 			BeforeEach(func() {
 				fakeWorkloadToStSet.ConvertReturns(nil, errors.New("convert-error"))
@@ -190,6 +232,153 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(fakeWorkloadToStSet.ConvertCallCount()).To(Equal(0))
 			Expect(fakeClient.CreateCallCount()).To(Equal(0))
 		})
+
+		It("deletes the service persistent volumes", func() {
+			Expect(fakeClient.DeleteAllOfCallCount()).To(BeNumerically(">=", 1))
+			_, obj, opts := fakeClient.DeleteAllOfArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(new(corev1.PersistentVolume)))
+			Expect(opts).To(ContainElement(client.MatchingLabels{
+				appworkload.LabelAppWorkloadGUID: appWorkload.Name,
+			}))
+		})
+	})
+
+	When("an app instance has crashed", func() {
+		BeforeEach(func() {
+			pods = []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod-0",
+					Namespace: appWorkload.Namespace,
+					UID:       "pod-uid",
+					Labels: map[string]string{
+						korifiv1alpha1.PodIndexLabelKey: "0",
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:         appworkload.ApplicationContainerName,
+						RestartCount: 2,
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 137,
+								Reason:   "OOMKilled",
+							},
+						},
+					}},
+				},
+			}}
+		})
+
+		It("records an app.crash audit event", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(Equal(2))
+			_, obj, _ := fakeClient.CreateArgsForCall(1)
+			auditEvent, ok := obj.(*korifiv1alpha1.CFAuditEvent)
+			Expect(ok).To(BeTrue())
+
+			Expect(auditEvent.Namespace).To(Equal(appWorkload.Namespace))
+			Expect(auditEvent.Labels).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTypeLabelKey, "app.crash"),
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, "app-guid"),
+			))
+			Expect(auditEvent.Spec.Type).To(Equal("app.crash"))
+			Expect(auditEvent.Spec.Target).To(Equal(korifiv1alpha1.CFAuditEventParticipant{
+				GUID: "app-guid",
+				Type: "app",
+			}))
+
+			data, err := korifiv1alpha1.AsMap(auditEvent.Spec.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(SatisfyAll(
+				HaveKeyWithValue("instance", "pod-uid"),
+				HaveKeyWithValue("index", BeEquivalentTo(0)),
+				HaveKeyWithValue("exit_status", BeEquivalentTo(137)),
+				HaveKeyWithValue("exit_description", "OOMKilled"),
+				HaveKeyWithValue("reason", "CRASHED"),
+				HaveKeyWithValue("crash_count", BeEquivalentTo(2)),
+			))
+		})
+
+		It("names the event after the pod and its restart count", func() {
+			_, firstEvent, _ := fakeClient.CreateArgsForCall(1)
+
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, secondEvent, _ := fakeClient.CreateArgsForCall(3)
+			Expect(secondEvent.GetName()).To(Equal(firstEvent.GetName()))
+		})
+
+		When("the crash has already been recorded", func() {
+			BeforeEach(func() {
+				createAuditEventError = apierrors.NewAlreadyExists(schema.GroupResource{}, "event")
+			})
+
+			It("does not return an error", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("recording the crash fails", func() {
+			BeforeEach(func() {
+				createAuditEventError = errors.New("record-error")
+			})
+
+			It("does not fail the reconcile", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+			})
+
+			It("requeues the reconcile to retry recording the crash", func() {
+				Expect(reconcileResult.RequeueAfter).To(BeNumerically(">", 0))
+			})
+
+			It("still reconciles the statefulset", func() {
+				Expect(fakeClient.CreateCallCount()).To(Equal(2))
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				Expect(obj).To(BeAssignableToTypeOf(&v1.StatefulSet{}))
+			})
+		})
+
+		When("the container has terminated and not been restarted yet", func() {
+			BeforeEach(func() {
+				pods[0].Status.ContainerStatuses[0] = corev1.ContainerStatus{
+					Name:         appworkload.ApplicationContainerName,
+					RestartCount: 0,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Reason:   "Error",
+						},
+					},
+				}
+			})
+
+			It("records the crash", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(Equal(2))
+				_, obj, _ := fakeClient.CreateArgsForCall(1)
+				auditEvent, ok := obj.(*korifiv1alpha1.CFAuditEvent)
+				Expect(ok).To(BeTrue())
+
+				data, err := korifiv1alpha1.AsMap(auditEvent.Spec.Data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(SatisfyAll(
+					HaveKeyWithValue("exit_status", BeEquivalentTo(1)),
+					HaveKeyWithValue("crash_count", BeEquivalentTo(1)),
+				))
+			})
+
+			When("the pod is being deleted", func() {
+				BeforeEach(func() {
+					pods[0].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("does not record a crash", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(fakeClient.CreateCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 	When("the appworkload is being updated", func() {
@@ -107,6 +107,45 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		return envs[i].Name < envs[j].Name
 	})
 
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+
+	volumeMounts := slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+		return corev1.VolumeMount{
+			Name:      s.Name,
+			ReadOnly:  true,
+			MountPath: filepath.Join(bindingRootPath, s.Name),
+		}
+	}))
+
+	volumes := slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
+		return corev1.Volume{
+			Name: s.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  s.Secret,
+					DefaultMode: tools.PtrTo[int32](0o644),
+				},
+			},
+		}
+	}))
+
+	serviceVolumes, err := serviceVolumes(appWorkload)
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceVolume := range serviceVolumes {
+		volumes = append(volumes, serviceVolume.volume())
+		volumeMounts = append(volumeMounts, serviceVolume.volumeMount())
+	}
+
 	containers := []corev1.Container{
 		{
 			Name:            ApplicationContainerName,
@@ -117,28 +156,28 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Ports: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Ports), func(port int32) corev1.ContainerPort {
 				return corev1.ContainerPort{ContainerPort: port}
 			})),
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
-			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
-				return corev1.VolumeMount{
-					Name:      s.Name,
-					ReadOnly:  true,
-					MountPath: filepath.Join(bindingRootPath, s.Name),
-				}
-			})),
+			SecurityContext: securityContext,
+			Resources:       appWorkload.Spec.Resources,
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
+			ReadinessProbe:  appWorkload.Spec.ReadinessProbe,
+			VolumeMounts:    volumeMounts,
 		},
 	}
 
+	for i, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            sidecarContainerName(sidecar.Name, i),
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: securityContext,
+			Resources:       sidecar.Resources,
+			VolumeMounts:    volumeMounts,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -163,17 +202,7 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 						},
 					},
 					ServiceAccountName: ServiceAccountName,
-					Volumes: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.Volume {
-						return corev1.Volume{
-							Name: s.Name,
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName:  s.Secret,
-									DefaultMode: tools.PtrTo[int32](0o644),
-								},
-							},
-						}
-					})),
+					Volumes:            volumes,
 				},
 			},
 		},
@@ -226,6 +255,14 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	return statefulSet, nil
 }
 
+// sidecarContainerName returns a valid container name for the sidecar, as
+// sidecar names can contain characters not allowed in container names
+func sidecarContainerName(sidecarName string, index int) string {
+	const containerNameMaxLen = 63
+	name := fmt.Sprintf("%s-%s", SidecarContainerNamePrefix, strings.ReplaceAll(sidecarName, ".", "-"))
+	return sanitizeNameWithMaxStringLen(name, fmt.Sprintf("%s-%d", SidecarContainerNamePrefix, index), containerNameMaxLen)
+}
+
 func sanitizeName(name, fallback string) string {
 	const sanitizedNameMaxLen = 40
 	return sanitizeNameWithMaxStringLen(name, fallback, sanitizedNameMaxLen)
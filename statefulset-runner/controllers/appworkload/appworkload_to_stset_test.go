@@ -70,6 +70,16 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 					PeriodSeconds:    30,
 					FailureThreshold: 1,
 				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(8080)},
+						},
+					},
+					PeriodSeconds:    10,
+					FailureThreshold: 1,
+				},
 				Ports:      []int32{8888, 9999},
 				Instances:  1,
 				RunnerName: "statefulset-runner",
@@ -183,6 +193,10 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe).To(Equal(appWorkload.Spec.LivenessProbe))
 	})
 
+	It("should set the readiness probe", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(appWorkload.Spec.ReadinessProbe))
+	})
+
 	It("should not automount service account token", func() {
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 	})
@@ -406,6 +420,116 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		})
 	})
 
+	When("the service bindings have volume mounts", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				GUID:   "binding-guid",
+				Secret: "service-secret",
+				Name:   "binding-name",
+				VolumeMounts: []korifiv1alpha1.ServiceBindingVolumeMount{
+					{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "rw",
+						MountConfig:  map[string]string{"source": "nfs://nfs.example.com/export"},
+					},
+					{
+						Driver:       "csi.example.com",
+						ContainerDir: "/config",
+						Mode:         "r",
+						MountConfig:  map[string]string{"share": "config"},
+					},
+				},
+			}}
+		})
+
+		It("adds a persistent volume claim volume for nfs mounts", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name": HavePrefix("volume-"),
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"PersistentVolumeClaim": PointTo(MatchFields(IgnoreExtras, Fields{
+						"ClaimName": HavePrefix(appWorkload.Name + "-volume-"),
+						"ReadOnly":  BeFalse(),
+					})),
+				}),
+			})))
+		})
+
+		It("adds an inline csi volume for other drivers", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"CSI": PointTo(Equal(corev1.CSIVolumeSource{
+						Driver:           "csi.example.com",
+						ReadOnly:         tools.PtrTo(true),
+						VolumeAttributes: map[string]string{"share": "config"},
+					})),
+				}),
+			})))
+		})
+
+		It("mounts the volumes at the requested container dirs", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"MountPath": Equal("/data"),
+					"ReadOnly":  BeFalse(),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"MountPath": Equal("/config"),
+					"ReadOnly":  BeTrue(),
+				}),
+			))
+		})
+	})
+
+	When("the app workload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{
+				{
+					Name:    "apm.agent",
+					Command: []string{"/bin/sh", "-c", "run-agent"},
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+				{
+					Name:    "Invalid Name!",
+					Command: []string{"/bin/sh", "-c", "run-other"},
+				},
+			}
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				Name:   "binding-name",
+				Secret: "binding-secret",
+			}}
+		})
+
+		It("adds a container running the app image for each sidecar", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(3))
+			Expect(containers[0].Name).To(Equal(appworkload.ApplicationContainerName))
+
+			Expect(containers[1]).To(MatchFields(IgnoreExtras, Fields{
+				"Name":            Equal("sidecar-apm-agent"),
+				"Image":           Equal("gcr.io/foo/bar"),
+				"ImagePullPolicy": Equal(corev1.PullAlways),
+				"Command":         Equal([]string{"/bin/sh", "-c", "run-agent"}),
+				"Env":             Equal(containers[0].Env),
+				"SecurityContext": Equal(containers[0].SecurityContext),
+				"VolumeMounts":    Equal(containers[0].VolumeMounts),
+				"Ports":           BeEmpty(),
+				"StartupProbe":    BeNil(),
+				"LivenessProbe":   BeNil(),
+				"ReadinessProbe":  BeNil(),
+			}))
+			Expect(containers[1].Resources.Limits.Memory().String()).To(Equal("64Mi"))
+		})
+
+		It("falls back to a generated container name when the sidecar name is not valid", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[2].Name).To(Equal("sidecar-1"))
+		})
+	})
+
 	It("should produce a stable statefulset regardless of labels iteration order", func() {
 		for i := 0; i < 100; i++ {
 			ss, err := converter.Convert(appWorkload)
@@ -0,0 +1,170 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	applicationContainerName = "application"
+	crashedReason            = "CRASHED"
+)
+
+// AppWorkloadCrashRecorder records an "app.crash" CFAuditEvent for every
+// termination of an app instance container, whether or not it has been
+// restarted yet
+type AppWorkloadCrashRecorder struct {
+	client client.Client
+}
+
+func NewAppWorkloadCrashRecorder(client client.Client) *AppWorkloadCrashRecorder {
+	return &AppWorkloadCrashRecorder{
+		client: client,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+func (r *AppWorkloadCrashRecorder) RecordCrashes(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("record-crashes")
+
+	workloadPods := &corev1.PodList{}
+	err := r.client.List(ctx, workloadPods,
+		client.InNamespace(appWorkload.Namespace),
+		client.MatchingLabels{
+			controllers.LabelGUID: appWorkload.Spec.GUID,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list pods for workload %q: %w", appWorkload.Spec.GUID, err)
+	}
+
+	// Failing to record a crash does not stop the remaining crashes from
+	// being recorded, all failures are returned so that the caller can retry
+	var recordErrs []error
+	for _, pod := range workloadPods.Items {
+		containerStatus, ok := applicationContainerStatus(pod)
+		if !ok {
+			continue
+		}
+
+		for _, c := range podCrashes(pod, containerStatus) {
+			err = r.client.Create(ctx, crashEvent(appWorkload, pod, c))
+			if k8serrors.IsAlreadyExists(err) {
+				continue
+			}
+			if err != nil {
+				log.Info("failed to record crash", "pod", pod.Name, "crashCount", c.count, "reason", err)
+				recordErrs = append(recordErrs, fmt.Errorf("failed to record crash %d of pod %q: %w", c.count, pod.Name, err))
+				continue
+			}
+
+			log.V(1).Info("recorded crash", "pod", pod.Name, "crashCount", c.count)
+		}
+	}
+
+	return errors.Join(recordErrs...)
+}
+
+type crash struct {
+	terminated *corev1.ContainerStateTerminated
+	// count is the number of times the container has terminated, including
+	// this crash
+	count int32
+}
+
+// podCrashes returns the crashes visible in the container status. The
+// previous termination has been followed by restartCount restarts, while a
+// currently terminated container has not been restarted yet. Terminations of
+// pods that are being deleted are expected and are not crashes.
+func podCrashes(pod corev1.Pod, containerStatus corev1.ContainerStatus) []crash {
+	crashes := []crash{}
+
+	if containerStatus.LastTerminationState.Terminated != nil {
+		crashes = append(crashes, crash{
+			terminated: containerStatus.LastTerminationState.Terminated,
+			count:      containerStatus.RestartCount,
+		})
+	}
+
+	if containerStatus.State.Terminated != nil && pod.DeletionTimestamp == nil {
+		crashes = append(crashes, crash{
+			terminated: containerStatus.State.Terminated,
+			count:      containerStatus.RestartCount + 1,
+		})
+	}
+
+	return crashes
+}
+
+func applicationContainerStatus(pod corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == applicationContainerName {
+			return containerStatus, true
+		}
+	}
+
+	return corev1.ContainerStatus{}, false
+}
+
+func crashEvent(appWorkload *korifiv1alpha1.AppWorkload, pod corev1.Pod, c crash) *korifiv1alpha1.CFAuditEvent {
+	terminated := c.terminated
+
+	exitDescription := terminated.Message
+	if exitDescription == "" {
+		exitDescription = terminated.Reason
+	}
+
+	index, err := strconv.Atoi(pod.Labels[korifiv1alpha1.PodIndexLabelKey])
+	if err != nil {
+		index = -1
+	}
+
+	data, _ := korifiv1alpha1.AsRawExtension(map[string]any{
+		"instance":         string(pod.UID),
+		"index":            index,
+		"process_type":     appWorkload.Spec.ProcessType,
+		"exit_status":      terminated.ExitCode,
+		"exit_description": exitDescription,
+		"reason":           crashedReason,
+		"crash_count":      c.count,
+		"crash_timestamp":  terminated.FinishedAt.UnixNano(),
+	})
+
+	app := korifiv1alpha1.CFAuditEventParticipant{
+		GUID: appWorkload.Spec.AppGUID,
+		Type: korifiv1alpha1.AuditEventActorTypeApp,
+	}
+
+	return &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: appWorkload.Namespace,
+			// The name is derived from the pod and its crash count, so
+			// that every crash is recorded exactly once
+			Name: uuid.NewSHA1(uuid.NameSpaceOID, fmt.Appendf(nil, "%s/%d", pod.UID, c.count)).String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       korifiv1alpha1.AuditEventTypeAppCrash,
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: appWorkload.Spec.AppGUID,
+				korifiv1alpha1.SpaceGUIDKey:                   appWorkload.Namespace,
+			},
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type:   korifiv1alpha1.AuditEventTypeAppCrash,
+			Actor:  app,
+			Target: app,
+			Data:   data,
+		},
+	}
+}
@@ -0,0 +1,277 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NFSMountConfigSource  = "source"
+	NFSMountConfigVersion = "version"
+
+	// Kubernetes requires a capacity on persistent volumes, even though it
+	// is not enforced for NFS volumes
+	nfsVolumeCapacity = "1Gi"
+)
+
+// serviceVolume is a volume requested by a service broker in its bind
+// response, mounted onto all the app workload containers
+type serviceVolume struct {
+	name      string
+	claimName string
+	mount     korifiv1alpha1.ServiceBindingVolumeMount
+}
+
+func serviceVolumes(appWorkload *korifiv1alpha1.AppWorkload) ([]serviceVolume, error) {
+	volumes := []serviceVolume{}
+	for _, service := range appWorkload.Spec.Services {
+		bindingHash, err := hash(service.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate hash for volume name: %w", err)
+		}
+
+		for i, mount := range service.VolumeMounts {
+			name := fmt.Sprintf("volume-%s-%d", bindingHash, i)
+			volumes = append(volumes, serviceVolume{
+				name:      name,
+				claimName: fmt.Sprintf("%s-%s", appWorkload.Name, name),
+				mount:     mount,
+			})
+		}
+	}
+
+	return volumes, nil
+}
+
+func (v serviceVolume) isNFS() bool {
+	return v.mount.Driver == korifiv1alpha1.NFSVolumeDriver
+}
+
+func (v serviceVolume) readOnly() bool {
+	return v.mount.Mode == korifiv1alpha1.VolumeMountModeReadOnly
+}
+
+func (v serviceVolume) persistentVolumeName(namespace string) string {
+	return fmt.Sprintf("%s-%s", namespace, v.claimName)
+}
+
+func (v serviceVolume) accessMode() corev1.PersistentVolumeAccessMode {
+	if v.readOnly() {
+		return corev1.ReadOnlyMany
+	}
+
+	return corev1.ReadWriteMany
+}
+
+func (v serviceVolume) volume() corev1.Volume {
+	if v.isNFS() {
+		return corev1.Volume{
+			Name: v.name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: v.claimName,
+					ReadOnly:  v.readOnly(),
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: v.name,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           v.mount.Driver,
+				ReadOnly:         tools.PtrTo(v.readOnly()),
+				VolumeAttributes: v.mount.MountConfig,
+			},
+		},
+	}
+}
+
+func (v serviceVolume) volumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      v.name,
+		MountPath: v.mount.ContainerDir,
+		ReadOnly:  v.readOnly(),
+	}
+}
+
+// parseNFSSource accepts both the `nfs://server/export` form used by the
+// CF nfs volume broker and the `server:/export` form used by mount
+func parseNFSSource(source string) (*corev1.NFSVolumeSource, error) {
+	if strings.HasPrefix(source, "nfs://") {
+		sourceURL, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nfs source %q: %w", source, err)
+		}
+
+		if sourceURL.Host == "" || sourceURL.Path == "" {
+			return nil, fmt.Errorf("invalid nfs source %q: expected nfs://<server>/<path>", source)
+		}
+
+		return &corev1.NFSVolumeSource{Server: sourceURL.Hostname(), Path: sourceURL.Path}, nil
+	}
+
+	server, path, found := strings.Cut(source, ":")
+	if !found || server == "" || !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid nfs source %q: expected <server>:/<path>", source)
+	}
+
+	return &corev1.NFSVolumeSource{Server: server, Path: path}, nil
+}
+
+type VolumesUpdater struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewVolumesUpdater(client client.Client, scheme *runtime.Scheme) *VolumesUpdater {
+	return &VolumesUpdater{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// Update creates the persistent volumes and claims backing the NFS volume
+// mounts of the app workload and deletes the ones that are no longer bound.
+// CSI volumes are declared inline in the pod template and need no objects.
+func (u *VolumesUpdater) Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) error {
+	volumes, err := serviceVolumes(appWorkload)
+	if err != nil {
+		return err
+	}
+
+	desiredClaims := map[string]bool{}
+	desiredVolumes := map[string]bool{}
+	for _, volume := range volumes {
+		if !volume.isNFS() {
+			continue
+		}
+
+		if err = u.createOrPatchNFSVolume(ctx, appWorkload, volume); err != nil {
+			return err
+		}
+
+		desiredClaims[volume.claimName] = true
+		desiredVolumes[volume.persistentVolumeName(appWorkload.Namespace)] = true
+	}
+
+	return u.deleteStale(ctx, appWorkload, desiredClaims, desiredVolumes)
+}
+
+func (u *VolumesUpdater) createOrPatchNFSVolume(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, volume serviceVolume) error {
+	nfsSource, err := parseNFSSource(volume.mount.MountConfig[NFSMountConfigSource])
+	if err != nil {
+		return err
+	}
+	nfsSource.ReadOnly = volume.readOnly()
+
+	labels := map[string]string{
+		LabelAppWorkloadGUID: appWorkload.Name,
+	}
+
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: volume.persistentVolumeName(appWorkload.Namespace),
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, u.client, pv, func() error {
+		pv.Labels = labels
+		pv.Spec.Capacity = corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(nfsVolumeCapacity),
+		}
+		pv.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{volume.accessMode()}
+		pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		pv.Spec.StorageClassName = ""
+		pv.Spec.PersistentVolumeSource = corev1.PersistentVolumeSource{NFS: nfsSource}
+		// Pre-bind the volume to its claim. Kubernetes fills in the claim
+		// uid once bound, so only set it on creation.
+		if pv.Spec.ClaimRef == nil {
+			pv.Spec.ClaimRef = &corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Namespace:  appWorkload.Namespace,
+				Name:       volume.claimName,
+			}
+		}
+
+		pv.Spec.MountOptions = nil
+		if version, ok := volume.mount.MountConfig[NFSMountConfigVersion]; ok {
+			pv.Spec.MountOptions = []string{"nfsvers=" + version}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch persistent volume %q: %w", pv.Name, err)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volume.claimName,
+			Namespace: appWorkload.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, u.client, pvc, func() error {
+		pvc.Labels = labels
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{volume.accessMode()}
+		pvc.Spec.StorageClassName = tools.PtrTo("")
+		pvc.Spec.VolumeName = pv.Name
+		pvc.Spec.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(nfsVolumeCapacity),
+		}
+
+		return controllerutil.SetControllerReference(appWorkload, pvc, u.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch persistent volume claim %q: %w", pvc.Name, err)
+	}
+
+	return nil
+}
+
+func (u *VolumesUpdater) deleteStale(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, desiredClaims, desiredVolumes map[string]bool) error {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := u.client.List(ctx, pvcs, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{LabelAppWorkloadGUID: appWorkload.Name}); err != nil {
+		return fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	for _, pvc := range pvcs.Items {
+		if desiredClaims[pvc.Name] {
+			continue
+		}
+
+		if err := u.client.Delete(ctx, &pvc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete persistent volume claim %q: %w", pvc.Name, err)
+		}
+	}
+
+	pvs := &corev1.PersistentVolumeList{}
+	if err := u.client.List(ctx, pvs, client.MatchingLabels{LabelAppWorkloadGUID: appWorkload.Name}); err != nil {
+		return fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+
+	for _, pv := range pvs.Items {
+		if desiredVolumes[pv.Name] || pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != appWorkload.Namespace {
+			continue
+		}
+
+		if err := u.client.Delete(ctx, &pv); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete persistent volume %q: %w", pv.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Volumes", func() {
+	var (
+		updater     *appworkload.VolumesUpdater
+		appWorkload *korifiv1alpha1.AppWorkload
+		ctx         context.Context
+		existingPVC []corev1.PersistentVolumeClaim
+		updateErr   error
+	)
+
+	BeforeEach(func() {
+		updater = appworkload.NewVolumesUpdater(fakeClient, scheme.Scheme)
+		existingPVC = nil
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-guid",
+				Namespace: "space-guid",
+				UID:       "workload-uid",
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				Services: []korifiv1alpha1.ServiceBinding{{
+					GUID: "binding-guid",
+					Name: "binding-name",
+					VolumeMounts: []korifiv1alpha1.ServiceBindingVolumeMount{{
+						Driver:       "nfs",
+						ContainerDir: "/data",
+						Mode:         "r",
+						MountConfig: map[string]string{
+							"source":  "nfs://nfs.example.com/export/data",
+							"version": "4.1",
+						},
+					}},
+				}},
+			},
+		}
+
+		fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "not-found"))
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			if pvcList, ok := list.(*corev1.PersistentVolumeClaimList); ok {
+				pvcList.Items = existingPVC
+			}
+			return nil
+		}
+
+		ctx = context.Background()
+	})
+
+	JustBeforeEach(func() {
+		updateErr = updater.Update(ctx, appWorkload)
+	})
+
+	It("succeeds", func() {
+		Expect(updateErr).NotTo(HaveOccurred())
+	})
+
+	It("creates a persistent volume for the nfs mount", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(2))
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		Expect(obj).To(BeAssignableToTypeOf(&corev1.PersistentVolume{}))
+		pv := obj.(*corev1.PersistentVolume)
+
+		Expect(pv.Name).To(HavePrefix("space-guid-workload-guid-volume-"))
+		Expect(pv.Labels).To(HaveKeyWithValue(appworkload.LabelAppWorkloadGUID, "workload-guid"))
+		Expect(pv.Spec.NFS).To(Equal(&corev1.NFSVolumeSource{
+			Server:   "nfs.example.com",
+			Path:     "/export/data",
+			ReadOnly: true,
+		}))
+		Expect(pv.Spec.AccessModes).To(ConsistOf(corev1.ReadOnlyMany))
+		Expect(pv.Spec.MountOptions).To(ConsistOf("nfsvers=4.1"))
+		Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+		Expect(pv.Spec.ClaimRef.Namespace).To(Equal("space-guid"))
+		Expect(pv.Spec.ClaimRef.Name).To(HavePrefix("workload-guid-volume-"))
+	})
+
+	It("creates a persistent volume claim bound to the volume", func() {
+		_, pvObj, _ := fakeClient.CreateArgsForCall(0)
+		_, obj, _ := fakeClient.CreateArgsForCall(1)
+		Expect(obj).To(BeAssignableToTypeOf(&corev1.PersistentVolumeClaim{}))
+		pvc := obj.(*corev1.PersistentVolumeClaim)
+
+		Expect(pvc.Namespace).To(Equal("space-guid"))
+		Expect(pvc.Name).To(Equal(pvObj.(*corev1.PersistentVolume).Spec.ClaimRef.Name))
+		Expect(pvc.Spec.VolumeName).To(Equal(pvObj.GetName()))
+		Expect(pvc.Spec.StorageClassName).To(PointTo(BeEmpty()))
+		Expect(pvc.OwnerReferences).To(HaveLen(1))
+		Expect(pvc.OwnerReferences[0].UID).To(BeEquivalentTo("workload-uid"))
+	})
+
+	When("the nfs source uses the mount syntax", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].MountConfig["source"] = "nfs.example.com:/export/data"
+		})
+
+		It("parses the server and path", func() {
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			pv := obj.(*corev1.PersistentVolume)
+			Expect(pv.Spec.NFS.Server).To(Equal("nfs.example.com"))
+			Expect(pv.Spec.NFS.Path).To(Equal("/export/data"))
+		})
+	})
+
+	When("the nfs source is invalid", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].MountConfig["source"] = "not-a-source"
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("invalid nfs source")))
+		})
+	})
+
+	When("the mount uses a csi driver", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services[0].VolumeMounts[0].Driver = "csi.example.com"
+		})
+
+		It("does not create persistent volumes", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+	})
+
+	When("a claim is no longer bound", func() {
+		BeforeEach(func() {
+			existingPVC = []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "workload-guid-volume-stale-0",
+					Namespace: "space-guid",
+				},
+			}}
+		})
+
+		It("deletes it", func() {
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(obj.GetName()).To(Equal("workload-guid-volume-stale-0"))
+		})
+	})
+
+	When("creating the persistent volume fails", func() {
+		BeforeEach(func() {
+			fakeClient.CreateReturns(errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
@@ -87,8 +87,10 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		appworkload.NewAppWorkloadToStatefulsetConverter(k8sManager.GetScheme()),
 		appworkload.NewPDBUpdater(k8sManager.GetClient()),
+		appworkload.NewVolumesUpdater(k8sManager.GetClient(), k8sManager.GetScheme()),
 		ctrl.Log.WithName("statefulset-runner").WithName("AppWorkload"),
 		state.NewAppWorkloadStateCollector(k8sManager.GetClient()),
+		state.NewAppWorkloadCrashRecorder(k8sManager.GetClient()),
 	)
 	err := appWorkloadReconciler.SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
@@ -106,8 +106,10 @@ func setupControllers(mgr manager.Manager) error {
 		mgr.GetScheme(),
 		appworkload.NewAppWorkloadToStatefulsetConverter(mgr.GetScheme()),
 		appworkload.NewPDBUpdater(controllersClient),
+		appworkload.NewVolumesUpdater(controllersClient, mgr.GetScheme()),
 		controllersLog,
 		state.NewAppWorkloadStateCollector(controllersClient),
+		state.NewAppWorkloadCrashRecorder(controllersClient),
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create AppWorkload controller: %w", err)
 	}
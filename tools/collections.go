@@ -50,3 +50,23 @@ func GetMapValue[K comparable, V any](m map[K]V, key K, defaultValue V) V {
 	}
 	return defaultValue
 }
+
+// InsertAtPositions merges the positioned elements into elements. Positioned
+// elements are expected to be sorted by their 1-based position and end up at
+// that position unless there are not enough elements to fill the positions
+// before it. The remaining elements keep their relative order.
+func InsertAtPositions[S ~[]E, E any](elements S, positioned S, position func(E) int) S {
+	result := make(S, 0, len(elements)+len(positioned))
+	for len(elements) > 0 || len(positioned) > 0 {
+		if len(positioned) > 0 && (len(elements) == 0 || position(positioned[0]) <= len(result)+1) {
+			result = append(result, positioned[0])
+			positioned = positioned[1:]
+			continue
+		}
+
+		result = append(result, elements[0])
+		elements = elements[1:]
+	}
+
+	return result
+}
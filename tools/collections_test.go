@@ -1,6 +1,9 @@
 package tools_test
 
 import (
+	"fmt"
+	"strconv"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
@@ -60,4 +63,23 @@ var _ = Describe("Collections", func() {
 		Entry("Value is present", map[string]int{"a": 1}, "a", 1),
 		Entry("Value is missing", map[string]int{}, "a", -1),
 	)
+
+	DescribeTable("InsertAtPositions",
+		func(positions []int, expected []string) {
+			positioned := []string{}
+			for _, p := range positions {
+				positioned = append(positioned, fmt.Sprintf("p%d", p))
+			}
+
+			Expect(tools.InsertAtPositions([]string{"a", "b", "c"}, positioned, func(e string) int {
+				p, _ := strconv.Atoi(e[1:])
+				return p
+			})).To(Equal(expected))
+		},
+		Entry("no positioned elements", []int{}, []string{"a", "b", "c"}),
+		Entry("at the start", []int{1}, []string{"p1", "a", "b", "c"}),
+		Entry("in the middle", []int{2, 4}, []string{"a", "p2", "b", "p4", "c"}),
+		Entry("same position", []int{2, 2}, []string{"a", "p2", "p2", "b", "c"}),
+		Entry("after the end", []int{9, 10}, []string{"a", "b", "c", "p9", "p10"}),
+	)
 })
@@ -1,11 +1,16 @@
 package image
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/buildpacks/pack/pkg/archive"
@@ -13,7 +18,9 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
@@ -40,6 +47,7 @@ type Config struct {
 	Labels       map[string]string
 	User         string
 	ExposedPorts []int32
+	Digest       string
 }
 
 func NewClient(clietnset kubernetes.Interface) Client {
@@ -50,26 +58,51 @@ func NewClient(clietnset kubernetes.Interface) Client {
 }
 
 func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error) {
-	tmpFile, err := os.CreateTemp(os.TempDir(), "sourceimg-%s")
+	return c.push(ctx, creds, repoRef, zipReader, imageFromZip, tags...)
+}
+
+// PushDroplet pushes a droplet tarball. Tarballs created by `docker save` are
+// pushed as they are, other (gzipped) tarballs become the only layer of the
+// image.
+func (c Client) PushDroplet(ctx context.Context, creds Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error) {
+	return c.push(ctx, creds, repoRef, tarReader, imageFromTarball, tags...)
+}
+
+// ErrNotBuildpackArchive is returned when pushing a buildpack archive that is
+// not an OCI layout tarball, e.g. a classic zip buildpack, which CNB builders
+// cannot use
+var ErrNotBuildpackArchive = errors.New("buildpack archive is not an OCI layout tarball (.cnb)")
+
+// PushBuildpack pushes a buildpack archive, which must be an OCI layout
+// tarball (e.g. a `.cnb` buildpack archive).
+func (c Client) PushBuildpack(ctx context.Context, creds Creds, repoRef string, archiveReader io.Reader, tags ...string) (string, error) {
+	return c.push(ctx, creds, repoRef, archiveReader, imageFromBuildpackArchive, tags...)
+}
+
+// imageBuilder builds an image out of the file at path. workDir is an empty
+// directory the builder can extract the file into.
+type imageBuilder func(path string, workDir string) (v1.Image, error)
+
+func (c Client) push(ctx context.Context, creds Creds, repoRef string, reader io.Reader, buildImage imageBuilder, tags ...string) (string, error) {
+	tmpDir, err := os.MkdirTemp(os.TempDir(), "sourceimg-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp dir for image: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpFile, err := os.Create(filepath.Join(tmpDir, "source"))
 	if err != nil {
 		return "", fmt.Errorf("failed to create a temp file for image: %w", err)
 	}
 	defer tmpFile.Close()
 
-	if _, err = io.Copy(tmpFile, zipReader); err != nil {
+	if _, err = io.Copy(tmpFile, reader); err != nil {
 		return "", fmt.Errorf("failed to copy image source into temp file '%s' %w", tmpFile.Name(), err)
 	}
 
-	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
-		return archive.ReadZipAsTar(tmpFile.Name(), "/", 0, 0, -1, true, nil), nil
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to create a layer out of '%s': %w", tmpFile.Name(), err)
-	}
-
-	image, err := mutate.AppendLayers(empty.Image, layer)
+	image, err := buildImage(tmpFile.Name(), filepath.Join(tmpDir, "work"))
 	if err != nil {
-		return "", fmt.Errorf("failed to append layer: %w", err)
+		return "", err
 	}
 
 	ref, err := name.ParseReference(repoRef)
@@ -106,6 +139,340 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 	return refWithDigest.Name(), nil
 }
 
+// imageFromZip builds an image with a single layer out of a zip archive of
+// app bits
+func imageFromZip(path string, _ string) (v1.Image, error) {
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return archive.ReadZipAsTar(path, "/", 0, 0, -1, true, nil), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a layer out of '%s': %w", path, err)
+	}
+
+	return singleLayerImage(layer)
+}
+
+func imageFromTarball(path string, _ string) (v1.Image, error) {
+	format, err := archiveFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == formatZip {
+		return nil, fmt.Errorf("'%s' is not a tarball", path)
+	}
+
+	if format == formatTar {
+		if _, err = tarball.LoadManifest(func() (io.ReadCloser, error) { return os.Open(path) }); err == nil {
+			return tarball.ImageFromPath(path, nil)
+		}
+	}
+
+	layer, err := tarball.LayerFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a layer out of '%s': %w", path, err)
+	}
+
+	return singleLayerImage(layer)
+}
+
+func imageFromBuildpackArchive(path string, layoutDir string) (v1.Image, error) {
+	format, err := archiveFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if format != formatTar || !isOCILayout(path) {
+		return nil, ErrNotBuildpackArchive
+	}
+
+	return ociLayoutImage(path, layoutDir)
+}
+
+func singleLayerImage(layer v1.Layer) (v1.Image, error) {
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append layer: %w", err)
+	}
+
+	return image, nil
+}
+
+func isOCILayout(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	tarReader := tar.NewReader(f)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			return false
+		}
+
+		if strings.TrimPrefix(header.Name, "./") == "index.json" {
+			return true
+		}
+	}
+}
+
+func ociLayoutImage(path string, layoutDir string) (v1.Image, error) {
+	if err := extractTar(path, layoutDir); err != nil {
+		return nil, fmt.Errorf("failed to extract OCI layout: %w", err)
+	}
+
+	index, err := layout.ImageIndexFromPath(layoutDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI layout: %w", err)
+	}
+
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI layout index: %w", err)
+	}
+
+	if len(indexManifest.Manifests) == 0 {
+		return nil, errors.New("OCI layout does not contain any images")
+	}
+
+	return index.Image(indexManifest.Manifests[0].Digest)
+}
+
+func extractTar(path string, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tarReader := tar.NewReader(f)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(strings.TrimPrefix(header.Name, "/"))
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid path %q in archive", header.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = extractFile(tarReader, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+type fileFormat int
+
+const (
+	formatZip fileFormat = iota
+	formatTar
+	formatGzip
+)
+
+func archiveFormat(path string) (fileFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return formatZip, fmt.Errorf("failed to open '%s': %w", path, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, 262)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return formatZip, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	header = header[:n]
+
+	if bytes.HasPrefix(header, []byte{0x1f, 0x8b}) {
+		return formatGzip, nil
+	}
+
+	if len(header) == 262 && string(header[257:262]) == "ustar" {
+		return formatTar, nil
+	}
+
+	return formatZip, nil
+}
+
+// Download writes the image as a tarball that can be loaded with `docker load`
+// or pushed again
+func (c Client) Download(ctx context.Context, creds Creds, imageRef string, w io.Writer) error {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return fmt.Errorf("error getting image %q: %w", imageRef, err)
+	}
+
+	if err = tarball.Write(ref, img, w); err != nil {
+		return fmt.Errorf("failed to write image %q: %w", imageRef, err)
+	}
+
+	return nil
+}
+
+// Copy pushes an image that is already in a registry to another repository.
+// Layers are mounted from the source repository when possible, so they are
+// not uploaded again
+func (c Client) Copy(ctx context.Context, creds Creds, imageRef string, repoRef string, tags ...string) (string, error) {
+	srcRef, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image reference %s: %w", imageRef, err)
+	}
+
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(srcRef, authOpt)
+	if err != nil {
+		return "", fmt.Errorf("error getting image %q: %w", imageRef, err)
+	}
+
+	if err = remote.Write(ref, img, authOpt); err != nil {
+		return "", fmt.Errorf("failed to copy image: %w", err)
+	}
+
+	for _, tag := range tags {
+		err = remote.Tag(ref.Context().Tag(tag), img, authOpt)
+		if err != nil {
+			return "", fmt.Errorf("failed to tag image: %w", err)
+		}
+	}
+
+	imgDigest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("failed to get image digest: %w", err)
+	}
+
+	return ref.Context().Digest(imgDigest.String()).Name(), nil
+}
+
+// DownloadZip writes the image filesystem as a zip archive, i.e. the reverse
+// of pushing a zip archive of app bits
+func (c Client) DownloadZip(ctx context.Context, creds Creds, imageRef string, w io.Writer) error {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return fmt.Errorf("error getting image %q: %w", imageRef, err)
+	}
+
+	fsReader := mutate.Extract(img)
+	defer fsReader.Close()
+
+	if err = tarToZip(tar.NewReader(fsReader), w); err != nil {
+		return fmt.Errorf("failed to write image %q as zip: %w", imageRef, err)
+	}
+
+	return nil
+}
+
+func tarToZip(tarReader *tar.Reader, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		entryName := strings.TrimPrefix(header.Name, "/")
+		if entryName == "" {
+			continue
+		}
+
+		zipHeader, err := zip.FileInfoHeader(header.FileInfo())
+		if err != nil {
+			return err
+		}
+		zipHeader.Name = entryName
+		zipHeader.Method = zip.Deflate
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			zipHeader.Name = strings.TrimSuffix(entryName, "/") + "/"
+			zipHeader.Method = zip.Store
+			if _, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			entryWriter, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			if _, err = entryWriter.Write([]byte(header.Linkname)); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			entryWriter, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(entryWriter, tarReader); err != nil { // #nosec G110
+				return err
+			}
+		}
+	}
+
+	return zipWriter.Close()
+}
+
 func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Config, error) {
 	ref, err := name.ParseReference(imageRef)
 	if err != nil {
@@ -127,6 +494,11 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		return Config{}, fmt.Errorf("error getting image config file: %w", err)
 	}
 
+	digest, err := img.Digest()
+	if err != nil {
+		return Config{}, fmt.Errorf("error getting image digest: %w", err)
+	}
+
 	ports := []int32{}
 	for _, p := range parseExposedPorts(cfgFile.Config.ExposedPorts) {
 		parsed, err := net.ParsePort(p, false)
@@ -140,6 +512,7 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		Labels:       cfgFile.Config.Labels,
 		User:         cfgFile.Config.User,
 		ExposedPorts: ports,
+		Digest:       digest.String(),
 	}, nil
 }
 
@@ -206,17 +579,97 @@ func (c Client) Delete(ctx context.Context, creds Creds, imageRef string, tagsTo
 	return err
 }
 
+// Exists tells whether the image is still in the registry
+func (c Client) Exists(ctx context.Context, creds Creds, imageRef string) (bool, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return false, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return false, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	if _, err = remote.Head(ref, authOpt); err != nil {
+		var structuredErr *transport.Error
+		if errors.As(err, &structuredErr) && structuredErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListTags returns the digest each tag of the repository points to
+func (c Client) ListTags(ctx context.Context, creds Creds, repoRef string) (map[string]string, error) {
+	repo, err := name.NewRepository(repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	return c.tagDigests(repo, authOpt)
+}
+
+// ListRepositories returns the fully qualified names of the repositories
+// listed by the catalog of the registry. Not all registries implement it.
+func (c Client) ListRepositories(ctx context.Context, creds Creds, registryHost string) ([]string, error) {
+	registry, err := name.NewRegistry(registryHost)
+	if err != nil {
+		return nil, err
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	repos, err := remote.Catalog(ctx, registry, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	result := []string{}
+	for _, repo := range repos {
+		result = append(result, registry.Repo(repo).Name())
+	}
+
+	return result, nil
+}
+
 func (c Client) getTagSet(ref name.Reference, authOpt remote.Option) (map[string]bool, error) {
-	allTags, err := remote.List(ref.Context(), authOpt)
+	tagDigests, err := c.tagDigests(ref.Context(), authOpt)
 	if err != nil {
 		c.logger.V(1).Info("failed to list tags - skipping tag deletion", "reason", err)
 		return nil, err
 	}
 
 	allTagSet := map[string]bool{}
+	for t, digest := range tagDigests {
+		if digest == ref.Identifier() {
+			allTagSet[t] = true
+		}
+	}
+
+	return allTagSet, nil
+}
+
+func (c Client) tagDigests(repo name.Repository, authOpt remote.Option) (map[string]string, error) {
+	allTags, err := remote.List(repo, authOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	tagDigests := map[string]string{}
 	for _, t := range allTags {
 		var tagRef name.Reference
-		tagRef, err = name.ParseReference(ref.Context().String() + ":" + t)
+		tagRef, err = name.ParseReference(repo.String() + ":" + t)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't create a tag ref: %w", err)
 		}
@@ -227,12 +680,10 @@ func (c Client) getTagSet(ref name.Reference, authOpt remote.Option) (map[string
 			return nil, fmt.Errorf("couldn't get tag: %w", err)
 		}
 
-		if descriptor.Digest.String() == ref.Identifier() {
-			allTagSet[t] = true
-		}
+		tagDigests[t] = descriptor.Digest.String()
 	}
 
-	return allTagSet, nil
+	return tagDigests, nil
 }
 
 func (c Client) deleteTag(ref name.Reference, tag string, authOpt remote.Option) error {
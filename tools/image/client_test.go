@@ -1,11 +1,22 @@
 package image_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -78,6 +89,18 @@ var _ = Describe("Client", func() {
 			})
 		})
 
+		When("the input is a gzipped tarball", func() {
+			BeforeEach(func() {
+				var err error
+				zipFile, err = os.Open("fixtures/layer.tgz")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("not a valid zip file")))
+			})
+		})
+
 		When("zip input is not valid", func() {
 			BeforeEach(func() {
 				var err error
@@ -129,6 +152,131 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("PushDroplet", func() {
+		var tarFile io.Reader
+
+		BeforeEach(func() {
+			var err error
+			tarFile, err = os.Open("fixtures/layer.tgz")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			imgRef, testErr = imgClient.PushDroplet(ctx, creds, pushRef, tarFile, "jim")
+		})
+
+		It("pushes the gzipped tarball as an image layer", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(imgRef).To(HavePrefix(pushRef))
+
+			_, err := imgClient.Config(ctx, creds, imgRef)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = imgClient.Config(ctx, creds, pushRef+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the input is an image tarball", func() {
+			var image v1.Image
+
+			BeforeEach(func() {
+				var err error
+				image, err = random.Image(16, 1)
+				Expect(err).NotTo(HaveOccurred())
+
+				ref, err := name.ParseReference(pushRef)
+				Expect(err).NotTo(HaveOccurred())
+				imageTarball := new(bytes.Buffer)
+				Expect(tarball.Write(ref, image, imageTarball)).To(Succeed())
+				tarFile = imageTarball
+			})
+
+			It("pushes the image as it is", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+
+				imageDigest, err := image.Digest()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(imgRef).To(Equal(pushRef + "@" + imageDigest.String()))
+			})
+		})
+
+		When("the input is a zip archive", func() {
+			BeforeEach(func() {
+				tarFile = zipFile
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("is not a tarball")))
+			})
+		})
+	})
+
+	Describe("PushBuildpack", func() {
+		var archiveFile io.Reader
+
+		var layoutImage v1.Image
+
+		BeforeEach(func() {
+			var err error
+			layoutImage, err = random.Image(16, 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			layoutDir := GinkgoT().TempDir()
+			layoutPath, err := layout.Write(layoutDir, empty.Index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(layoutPath.AppendImage(layoutImage)).To(Succeed())
+
+			archiveFile = tarDir(layoutDir)
+		})
+
+		JustBeforeEach(func() {
+			imgRef, testErr = imgClient.PushBuildpack(ctx, creds, pushRef, archiveFile, "jim")
+		})
+
+		It("pushes the image in the OCI layout", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			layoutDigest, err := layoutImage.Digest()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imgRef).To(Equal(pushRef + "@" + layoutDigest.String()))
+		})
+
+		When("the input is a zip archive", func() {
+			BeforeEach(func() {
+				archiveFile = zipFile
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(image.ErrNotBuildpackArchive))
+			})
+		})
+
+		When("the input is a tarball that is not an OCI layout", func() {
+			BeforeEach(func() {
+				dir := GinkgoT().TempDir()
+				Expect(os.WriteFile(filepath.Join(dir, "buildpack.toml"), []byte("api = \"0.8\""), 0o644)).To(Succeed())
+
+				archiveFile = tarDir(dir)
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(image.ErrNotBuildpackArchive))
+			})
+		})
+
+		When("the input is a gzipped tarball", func() {
+			BeforeEach(func() {
+				var err error
+				archiveFile, err = os.Open("fixtures/layer.tgz")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(image.ErrNotBuildpackArchive))
+			})
+		})
+	})
+
 	Describe("Config", func() {
 		var config image.Config
 
@@ -145,6 +293,7 @@ var _ = Describe("Client", func() {
 			Expect(config.Labels).To(Equal(map[string]string{"foo": "bar"}))
 			Expect(config.User).To(Equal("my-user"))
 			Expect(config.ExposedPorts).To(ConsistOf(int32(123), int32(456)))
+			Expect(config.Digest).To(HavePrefix("sha256:"))
 		})
 
 		When("the ref is invalid", func() {
@@ -209,6 +358,234 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Download", func() {
+		var downloaded *bytes.Buffer
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+			downloaded = new(bytes.Buffer)
+		})
+
+		JustBeforeEach(func() {
+			testErr = imgClient.Download(ctx, creds, imgRef, downloaded)
+		})
+
+		It("downloads an image tarball that can be pushed again", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			copyRef, err := imgClient.PushDroplet(ctx, creds, pushRef+"/copy", downloaded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Split(copyRef, "@")[1]).To(Equal(strings.Split(imgRef, "@")[1]))
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var copiedRef string
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, testErr = imgClient.Copy(ctx, creds, imgRef, pushRef+"/copy", "jim")
+		})
+
+		It("copies the image to the repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(copiedRef).To(HavePrefix(pushRef + "/copy@"))
+			Expect(strings.Split(copiedRef, "@")[1]).To(Equal(strings.Split(imgRef, "@")[1]))
+
+			_, err := imgClient.Config(ctx, creds, copiedRef)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("tags the copied image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			_, err := imgClient.Config(ctx, creds, pushRef+"/copy:jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the source image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+			})
+		})
+	})
+
+	Describe("DownloadZip", func() {
+		var downloaded *bytes.Buffer
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+			downloaded = new(bytes.Buffer)
+		})
+
+		JustBeforeEach(func() {
+			testErr = imgClient.DownloadZip(ctx, creds, imgRef, downloaded)
+		})
+
+		It("downloads the image files as a zip archive", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			zipReader, err := zip.NewReader(bytes.NewReader(downloaded.Bytes()), int64(downloaded.Len()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zipReader.File).To(HaveLen(1))
+			Expect(zipReader.File[0].Name).To(Equal("foo"))
+		})
+
+		It("downloads a zip archive that is pushed as the same image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			copyRef, err := imgClient.Push(ctx, creds, pushRef+"/copy", downloaded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Split(copyRef, "@")[1]).To(Equal(strings.Split(imgRef, "@")[1]))
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				imgRef = containerRegistry.ImageRef("foo/not-there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+			})
+		})
+	})
+
+	Describe("Exists", func() {
+		var exists bool
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			exists, testErr = imgClient.Exists(ctx, creds, imgRef)
+		})
+
+		It("returns true", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+
+		When("the image has been deleted", func() {
+			BeforeEach(func() {
+				Expect(imgClient.Delete(ctx, creds, imgRef)).To(Succeed())
+			})
+
+			It("returns false", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("Unauthorized")))
+			})
+		})
+	})
+
+	Describe("ListTags", func() {
+		var (
+			tags      map[string]string
+			otherRef  string
+			repoToUse string
+		)
+
+		BeforeEach(func() {
+			repoToUse = containerRegistry.ImageRef("list-tags/" + uuid.NewString())
+
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, repoToUse, zipFile, "jim", "bob")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherRef, err = imgClient.Push(ctx, creds, repoToUse, otherZipFile, "alice")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			tags, testErr = imgClient.ListTags(ctx, creds, repoToUse)
+		})
+
+		It("returns the digest of each tag", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			digest := strings.Split(imgRef, "@")[1]
+			otherDigest := strings.Split(otherRef, "@")[1]
+			Expect(tags).To(Equal(map[string]string{
+				"jim":   digest,
+				"bob":   digest,
+				"alice": otherDigest,
+			}))
+		})
+
+		When("the repository is invalid", func() {
+			BeforeEach(func() {
+				repoToUse = "foo:bar:baz"
+			})
+
+			It("returns an error", func() {
+				Expect(testErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ListRepositories", func() {
+		var repos []string
+
+		BeforeEach(func() {
+			_, err := imgClient.Push(ctx, creds, pushRef, zipFile, "jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			repos, testErr = imgClient.ListRepositories(ctx, creds, strings.Split(pushRef, "/")[0])
+		})
+
+		It("returns the fully qualified repository names", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(repos).To(ContainElement(pushRef))
+		})
+	})
+
 	Describe("Delete", func() {
 		var tagsToDelete []string
 
@@ -375,3 +752,19 @@ var _ = Describe("Client", func() {
 		})
 	}
 })
+
+func tarDir(dir string) *os.File {
+	GinkgoHelper()
+
+	tarFile, err := os.Create(filepath.Join(GinkgoT().TempDir(), "layout.tar"))
+	Expect(err).NotTo(HaveOccurred())
+
+	tarWriter := tar.NewWriter(tarFile)
+	Expect(tarWriter.AddFS(os.DirFS(dir))).To(Succeed())
+	Expect(tarWriter.Close()).To(Succeed())
+
+	_, err = tarFile.Seek(0, io.SeekStart)
+	Expect(err).NotTo(HaveOccurred())
+
+	return tarFile
+}
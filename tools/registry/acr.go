@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	ACRContainerRegistryType = "ACR"
+	AzureResourceManagerURL  = "https://management.azure.com"
+
+	acrScopeMapAPIVersion = "2023-07-01"
+)
+
+// ACRRepositoryCreator grants the token of an Azure Container Registry scope
+// map access to repositories. ACR creates repositories on push, but tokens
+// can only push to the repositories listed in their scope map. When no scope
+// map is configured, i.e. when using admin or Entra ID credentials, there is
+// nothing to do.
+type ACRRepositoryCreator struct {
+	httpClient *http.Client
+	armURL     string
+	scopeMapID string
+}
+
+// NewACRRepositoryCreator returns a creator updating the scope map with the
+// resource id scopeMapID via the Azure Resource Manager API at armURL. The
+// httpClient is expected to authenticate requests.
+func NewACRRepositoryCreator(httpClient *http.Client, armURL, scopeMapID string) ACRRepositoryCreator {
+	return ACRRepositoryCreator{
+		httpClient: httpClient,
+		armURL:     strings.TrimSuffix(armURL, "/"),
+		scopeMapID: scopeMapID,
+	}
+}
+
+type acrScopeMap struct {
+	Properties acrScopeMapProperties `json:"properties"`
+}
+
+type acrScopeMapProperties struct {
+	Actions []string `json:"actions"`
+}
+
+func (c ACRRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+	if c.scopeMapID == "" {
+		return nil
+	}
+
+	_, repository, _ := strings.Cut(ref, "/")
+	scopeMapURL := c.armURL + c.scopeMapID + "?api-version=" + acrScopeMapAPIVersion
+
+	scopeMap := acrScopeMap{}
+	resp, err := c.do(ctx, http.MethodGet, scopeMapURL, nil, &scopeMap)
+	if err != nil {
+		return fmt.Errorf("failed to get acr scope map: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get acr scope map: %w", responseError(resp))
+	}
+
+	actions := scopeMap.Properties.Actions
+	for _, action := range []string{"content/read", "content/write", "content/delete", "metadata/read"} {
+		repoAction := "repositories/" + repository + "/" + action
+		if !slices.Contains(actions, repoAction) {
+			actions = append(actions, repoAction)
+		}
+	}
+	if len(actions) == len(scopeMap.Properties.Actions) {
+		return nil
+	}
+
+	resp, err = c.do(ctx, http.MethodPatch, scopeMapURL, acrScopeMap{Properties: acrScopeMapProperties{Actions: actions}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update acr scope map: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to update acr scope map: %w", responseError(resp))
+	}
+
+	return nil
+}
+
+func (c ACRRepositoryCreator) do(ctx context.Context, method, url string, body, result any) (*http.Response, error) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doJSON(c.httpClient, req, result)
+}
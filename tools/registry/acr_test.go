@@ -0,0 +1,120 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ACR Repository Creator", func() {
+	const scopeMapID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/myacr/scopeMaps/korifi"
+
+	type scopeMap struct {
+		Properties struct {
+			Actions []string `json:"actions"`
+		} `json:"properties"`
+	}
+
+	var (
+		arm            *httptest.Server
+		actions        []string
+		patchedActions []string
+		patchCount     int
+		getStatus      int
+		configuredID   string
+		createErr      error
+	)
+
+	BeforeEach(func() {
+		actions = []string{"repositories/other/content/read"}
+		patchedActions = nil
+		patchCount = 0
+		getStatus = http.StatusOK
+		configuredID = scopeMapID
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(scopeMapID, func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("api-version")).NotTo(BeEmpty())
+
+			switch r.Method {
+			case http.MethodGet:
+				w.WriteHeader(getStatus)
+				sm := scopeMap{}
+				sm.Properties.Actions = actions
+				Expect(json.NewEncoder(w).Encode(sm)).To(Succeed())
+			case http.MethodPatch:
+				patchCount++
+				sm := scopeMap{}
+				Expect(json.NewDecoder(r.Body).Decode(&sm)).To(Succeed())
+				patchedActions = sm.Properties.Actions
+				Expect(json.NewEncoder(w).Encode(sm)).To(Succeed())
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		})
+		arm = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		arm.Close()
+	})
+
+	JustBeforeEach(func() {
+		creator := registry.NewACRRepositoryCreator(http.DefaultClient, arm.URL, configuredID)
+		createErr = creator.CreateRepository(context.Background(), "myacr.azurecr.io/my-prefix-app-guid-packages")
+	})
+
+	It("adds the repository to the scope map", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(patchCount).To(Equal(1))
+		Expect(patchedActions).To(ConsistOf(
+			"repositories/other/content/read",
+			"repositories/my-prefix-app-guid-packages/content/read",
+			"repositories/my-prefix-app-guid-packages/content/write",
+			"repositories/my-prefix-app-guid-packages/content/delete",
+			"repositories/my-prefix-app-guid-packages/metadata/read",
+		))
+	})
+
+	When("the scope map already grants access to the repository", func() {
+		BeforeEach(func() {
+			actions = []string{
+				"repositories/my-prefix-app-guid-packages/content/read",
+				"repositories/my-prefix-app-guid-packages/content/write",
+				"repositories/my-prefix-app-guid-packages/content/delete",
+				"repositories/my-prefix-app-guid-packages/metadata/read",
+			}
+		})
+
+		It("does not update the scope map", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(patchCount).To(BeZero())
+		})
+	})
+
+	When("the scope map cannot be read", func() {
+		BeforeEach(func() {
+			getStatus = http.StatusForbidden
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("failed to get acr scope map")))
+		})
+	})
+
+	When("no scope map is configured", func() {
+		BeforeEach(func() {
+			configuredID = ""
+		})
+
+		It("does nothing", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(patchCount).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	ArtifactRegistryContainerRegistryType = "GAR"
+	ArtifactRegistryAPIURL                = "https://artifactregistry.googleapis.com"
+
+	artifactRegistryHostSuffix = "-docker.pkg.dev"
+)
+
+// gcrLocations maps Container Registry hosts to the multi-region of the
+// Artifact Registry gcr.io repositories backing them
+var gcrLocations = map[string]string{
+	"gcr.io":      "us",
+	"us.gcr.io":   "us",
+	"eu.gcr.io":   "europe",
+	"asia.gcr.io": "asia",
+}
+
+// ArtifactRegistryRepositoryCreator creates the Google Artifact Registry
+// docker repository an image repository belongs to, i.e. `my-repo` for
+// `europe-west1-docker.pkg.dev/my-project/my-repo/my-image`. Images are
+// created on push within existing docker repositories. Container Registry
+// references such as `eu.gcr.io/my-project/my-image` are served by a gcr.io
+// repository named after their host.
+type ArtifactRegistryRepositoryCreator struct {
+	httpClient *http.Client
+	apiURL     string
+}
+
+// NewArtifactRegistryRepositoryCreator returns a creator calling the Artifact
+// Registry API at apiURL. The httpClient is expected to authenticate requests.
+func NewArtifactRegistryRepositoryCreator(httpClient *http.Client, apiURL string) ArtifactRegistryRepositoryCreator {
+	return ArtifactRegistryRepositoryCreator{
+		httpClient: httpClient,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+	}
+}
+
+func (c ArtifactRegistryRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+	project, location, repository, err := parseArtifactRegistryRef(ref)
+	if err != nil {
+		return err
+	}
+
+	createURL := fmt.Sprintf("%s/v1/projects/%s/locations/%s/repositories?repositoryId=%s",
+		c.apiURL,
+		url.PathEscape(project),
+		url.PathEscape(location),
+		url.QueryEscape(repository),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, createURL, bytes.NewReader([]byte(`{"format":"DOCKER"}`)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doJSON(c.httpClient, req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create artifact registry repository %q: %w", repository, err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("failed to create artifact registry repository %q: %w", repository, responseError(resp))
+	}
+
+	return nil
+}
+
+func parseArtifactRegistryRef(ref string) (string, string, string, error) {
+	host, path, _ := strings.Cut(ref, "/")
+	pathSegments := strings.Split(path, "/")
+
+	if location, ok := gcrLocations[host]; ok {
+		if len(pathSegments) < 2 {
+			return "", "", "", fmt.Errorf("%q is not a container registry repository: expected <project>/<image>", ref)
+		}
+		return pathSegments[0], location, host, nil
+	}
+
+	location, ok := strings.CutSuffix(host, artifactRegistryHostSuffix)
+	if !ok {
+		return "", "", "", fmt.Errorf("%q is not an artifact registry docker repository", ref)
+	}
+	if len(pathSegments) < 3 {
+		return "", "", "", fmt.Errorf("%q is not an artifact registry docker repository: expected <project>/<repository>/<image>", ref)
+	}
+
+	return pathSegments[0], location, pathSegments[1], nil
+}
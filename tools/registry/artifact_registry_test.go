@@ -0,0 +1,111 @@
+package registry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Artifact Registry Repository Creator", func() {
+	var (
+		artifactRegistry *httptest.Server
+		requests         []*http.Request
+		requestBodies    []string
+		createStatus     int
+		repoRef          string
+		createErr        error
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		requestBodies = nil
+		createStatus = http.StatusOK
+		repoRef = "europe-west1-docker.pkg.dev/my-project/my-repo/my-prefix-app-guid-packages"
+
+		artifactRegistry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			requests = append(requests, r)
+			requestBodies = append(requestBodies, string(body))
+			w.WriteHeader(createStatus)
+			_, _ = w.Write([]byte(`{"name":"operation"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		artifactRegistry.Close()
+	})
+
+	JustBeforeEach(func() {
+		creator := registry.NewArtifactRegistryRepositoryCreator(http.DefaultClient, artifactRegistry.URL)
+		createErr = creator.CreateRepository(context.Background(), repoRef)
+	})
+
+	It("creates the docker repository", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+		Expect(requests[0].URL.Path).To(Equal("/v1/projects/my-project/locations/europe-west1/repositories"))
+		Expect(requests[0].URL.Query().Get("repositoryId")).To(Equal("my-repo"))
+		Expect(requestBodies[0]).To(MatchJSON(`{"format":"DOCKER"}`))
+	})
+
+	When("the docker repository already exists", func() {
+		BeforeEach(func() {
+			createStatus = http.StatusConflict
+		})
+
+		It("succeeds", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the docker repository cannot be created", func() {
+		BeforeEach(func() {
+			createStatus = http.StatusForbidden
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("403")))
+		})
+	})
+
+	When("the reference is a container registry one", func() {
+		BeforeEach(func() {
+			repoRef = "eu.gcr.io/my-project/my-prefix-app-guid-packages"
+		})
+
+		It("creates the gcr.io repository for the host", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].URL.Path).To(Equal("/v1/projects/my-project/locations/europe/repositories"))
+			Expect(requests[0].URL.Query().Get("repositoryId")).To(Equal("eu.gcr.io"))
+		})
+	})
+
+	When("the reference is not an artifact registry one", func() {
+		BeforeEach(func() {
+			repoRef = "my.registry/my-project/my-repo/my-image"
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("not an artifact registry docker repository")))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	When("the reference has no docker repository", func() {
+		BeforeEach(func() {
+			repoRef = "europe-west1-docker.pkg.dev/my-project/my-image"
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("expected <project>/<repository>/<image>")))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const HarborContainerRegistryType = "Harbor"
+
+// HarborRepositoryCreator creates the Harbor project a repository belongs to.
+// Harbor creates repositories on push, but only within existing projects.
+// When retentionCount is positive, projects get a retention policy keeping the
+// retentionCount most recently pushed artifacts of each repository. Harbor does
+// not know which droplets are still referenced by apps and revisions, so the
+// policy may delete droplet images needed to roll back; the API rejects such
+// rollbacks rather than deploying a missing image.
+type HarborRepositoryCreator struct {
+	httpClient     *http.Client
+	apiURL         string
+	username       string
+	password       string
+	retentionCount int
+}
+
+// NewHarborRepositoryCreator returns a creator calling the Harbor API at
+// apiURL. An empty apiURL means the API of the registry in the repository
+// reference is used.
+func NewHarborRepositoryCreator(httpClient *http.Client, apiURL, username, password string, retentionCount int) HarborRepositoryCreator {
+	return HarborRepositoryCreator{
+		httpClient:     httpClient,
+		apiURL:         strings.TrimSuffix(apiURL, "/"),
+		username:       username,
+		password:       password,
+		retentionCount: retentionCount,
+	}
+}
+
+type harborProject struct {
+	ProjectName string                `json:"project_name,omitempty"`
+	ProjectID   int                   `json:"project_id,omitempty"`
+	Metadata    harborProjectMetadata `json:"metadata"`
+}
+
+type harborProjectMetadata struct {
+	Public      string `json:"public,omitempty"`
+	RetentionID string `json:"retention_id,omitempty"`
+}
+
+func (c HarborRepositoryCreator) CreateRepository(ctx context.Context, ref string) error {
+	host, path, _ := strings.Cut(ref, "/")
+	projectName, _, _ := strings.Cut(path, "/")
+	if projectName == "" {
+		return fmt.Errorf("repository %q is not within a harbor project", ref)
+	}
+
+	apiURL := c.apiURL
+	if apiURL == "" {
+		apiURL = "https://" + host
+	}
+	apiURL += "/api/v2.0"
+
+	resp, err := c.do(ctx, http.MethodPost, apiURL+"/projects", harborProject{
+		ProjectName: projectName,
+		Metadata:    harborProjectMetadata{Public: "false"},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create harbor project %q: %w", projectName, err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("failed to create harbor project %q: %w", projectName, responseError(resp))
+	}
+
+	if c.retentionCount <= 0 {
+		return nil
+	}
+
+	return c.ensureRetentionPolicy(ctx, apiURL, projectName)
+}
+
+func (c HarborRepositoryCreator) ensureRetentionPolicy(ctx context.Context, apiURL, projectName string) error {
+	project := harborProject{}
+	resp, err := c.do(ctx, http.MethodGet, apiURL+"/projects/"+projectName, nil, &project)
+	if err != nil {
+		return fmt.Errorf("failed to get harbor project %q: %w", projectName, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get harbor project %q: %w", projectName, responseError(resp))
+	}
+
+	if project.Metadata.RetentionID != "" {
+		return nil
+	}
+
+	resp, err = c.do(ctx, http.MethodPost, apiURL+"/retentions", c.retentionPolicy(project.ProjectID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create retention policy for harbor project %q: %w", projectName, err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create retention policy for harbor project %q: %w", projectName, responseError(resp))
+	}
+
+	return nil
+}
+
+func (c HarborRepositoryCreator) retentionPolicy(projectID int) map[string]any {
+	matchAll := func(decoration string) map[string]any {
+		return map[string]any{"kind": "doublestar", "decoration": decoration, "pattern": "**"}
+	}
+
+	return map[string]any{
+		"algorithm": "or",
+		"rules": []map[string]any{{
+			"action":        "retain",
+			"template":      "latestPushedK",
+			"params":        map[string]any{"latestPushedK": c.retentionCount},
+			"tag_selectors": []map[string]any{matchAll("matches")},
+			"scope_selectors": map[string]any{
+				"repository": []map[string]any{matchAll("repoMatches")},
+			},
+		}},
+		"trigger": map[string]any{
+			"kind":     "Schedule",
+			"settings": map[string]any{"cron": "0 0 0 * * *"},
+		},
+		"scope": map[string]any{"level": "project", "ref": projectID},
+	}
+}
+
+// do sends body as JSON and decodes the response into result when the request
+// succeeds. The response body is drained either way.
+func (c HarborRepositoryCreator) do(ctx context.Context, method, url string, body, result any) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/json")
+	// project names can be numeric, make sure harbor does not read them as ids
+	req.Header.Set("X-Is-Resource-Name", "true")
+
+	return doJSON(c.httpClient, req, result)
+}
@@ -0,0 +1,173 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/tools/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Harbor Repository Creator", func() {
+	type harborRequest struct {
+		method   string
+		path     string
+		username string
+		password string
+		body     map[string]any
+	}
+
+	var (
+		harbor                *httptest.Server
+		requests              []harborRequest
+		createProjectStatus   int
+		projectRetentionID    string
+		createRetentionStatus int
+		retentionCount        int
+		repoRef               string
+		createErr             error
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		createProjectStatus = http.StatusCreated
+		projectRetentionID = ""
+		createRetentionStatus = http.StatusCreated
+		retentionCount = 0
+		repoRef = "my.harbor/my-project/my-prefix-app-guid-packages"
+
+		recordRequest := func(r *http.Request) {
+			username, password, _ := r.BasicAuth()
+			req := harborRequest{method: r.Method, path: r.URL.Path, username: username, password: password}
+			bodyBytes, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			if len(bodyBytes) > 0 {
+				Expect(json.Unmarshal(bodyBytes, &req.body)).To(Succeed())
+			}
+			requests = append(requests, req)
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/v2.0/projects", func(w http.ResponseWriter, r *http.Request) {
+			recordRequest(r)
+			w.WriteHeader(createProjectStatus)
+		})
+		mux.HandleFunc("GET /api/v2.0/projects/my-project", func(w http.ResponseWriter, r *http.Request) {
+			recordRequest(r)
+			Expect(json.NewEncoder(w).Encode(map[string]any{
+				"project_id": 42,
+				"metadata":   map[string]any{"retention_id": projectRetentionID},
+			})).To(Succeed())
+		})
+		mux.HandleFunc("POST /api/v2.0/retentions", func(w http.ResponseWriter, r *http.Request) {
+			recordRequest(r)
+			w.WriteHeader(createRetentionStatus)
+		})
+		harbor = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		harbor.Close()
+	})
+
+	JustBeforeEach(func() {
+		creator := registry.NewHarborRepositoryCreator(http.DefaultClient, harbor.URL, "admin", "secret", retentionCount)
+		createErr = creator.CreateRepository(context.Background(), repoRef)
+	})
+
+	It("succeeds", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+	})
+
+	It("creates a private project", func() {
+		Expect(requests).To(ConsistOf(harborRequest{
+			method:   http.MethodPost,
+			path:     "/api/v2.0/projects",
+			username: "admin",
+			password: "secret",
+			body: map[string]any{
+				"project_name": "my-project",
+				"metadata":     map[string]any{"public": "false"},
+			},
+		}))
+	})
+
+	When("the project already exists", func() {
+		BeforeEach(func() {
+			createProjectStatus = http.StatusConflict
+		})
+
+		It("succeeds", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the project cannot be created", func() {
+		BeforeEach(func() {
+			createProjectStatus = http.StatusForbidden
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("403")))
+		})
+	})
+
+	When("the repository is not within a project", func() {
+		BeforeEach(func() {
+			repoRef = "my.harbor"
+		})
+
+		It("returns an error", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("not within a harbor project")))
+		})
+	})
+
+	When("a retention count is configured", func() {
+		BeforeEach(func() {
+			retentionCount = 5
+		})
+
+		It("creates a retention policy for the project", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(3))
+			Expect(requests[1].method).To(Equal(http.MethodGet))
+			Expect(requests[1].path).To(Equal("/api/v2.0/projects/my-project"))
+
+			Expect(requests[2].method).To(Equal(http.MethodPost))
+			Expect(requests[2].path).To(Equal("/api/v2.0/retentions"))
+			Expect(requests[2].body).To(HaveKeyWithValue("scope", SatisfyAll(
+				HaveKeyWithValue("level", "project"),
+				HaveKeyWithValue("ref", BeEquivalentTo(42)),
+			)))
+			Expect(requests[2].body).To(HaveKeyWithValue("rules", ConsistOf(SatisfyAll(
+				HaveKeyWithValue("template", "latestPushedK"),
+				HaveKeyWithValue("params", HaveKeyWithValue("latestPushedK", BeEquivalentTo(5))),
+			))))
+		})
+
+		When("the project already has a retention policy", func() {
+			BeforeEach(func() {
+				projectRetentionID = "7"
+			})
+
+			It("does not create another one", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(requests).To(HaveLen(2))
+			})
+		})
+
+		When("the retention policy cannot be created", func() {
+			BeforeEach(func() {
+				createRetentionStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("failed to create retention policy")))
+			})
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// doJSON sends req and decodes successful JSON responses into result, if not
+// nil. The body of the returned response can still be read to build errors.
+func doJSON(httpClient *http.Client, req *http.Request, result any) (*http.Response, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if result != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err = json.Unmarshal(body, result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("unexpected response status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+}
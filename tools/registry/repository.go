@@ -3,13 +3,18 @@ package registry
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/tools"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/microsoft"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -35,9 +40,62 @@ func createECRClient() *ecr.Client {
 	return ecr.NewFromConfig(awsConfig)
 }
 
+func createHarborRepositoryCreator() HarborRepositoryCreator {
+	retentionCount := 0
+	if count := os.Getenv("HARBOR_RETENTION_COUNT"); count != "" {
+		var err error
+		retentionCount, err = strconv.Atoi(count)
+		if err != nil {
+			ctrl.Log.Error(err, "invalid HARBOR_RETENTION_COUNT")
+			os.Exit(1)
+		}
+	}
+
+	return NewHarborRepositoryCreator(
+		http.DefaultClient,
+		os.Getenv("HARBOR_URL"),
+		os.Getenv("HARBOR_USERNAME"),
+		os.Getenv("HARBOR_PASSWORD"),
+		retentionCount,
+	)
+}
+
+func createArtifactRegistryHTTPClient() *http.Client {
+	httpClient, err := google.DefaultClient(context.Background(), "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		ctrl.Log.Error(err, "error creating the artifact registry client")
+		os.Exit(1)
+	}
+
+	return httpClient
+}
+
+func createACRRepositoryCreator() RepositoryCreator {
+	scopeMapID := os.Getenv("AZURE_ACR_SCOPE_MAP_ID")
+	if scopeMapID == "" {
+		return NoopRepositoryCreator{}
+	}
+
+	credentials := clientcredentials.Config{
+		ClientID:     os.Getenv("AZURE_CLIENT_ID"),
+		ClientSecret: os.Getenv("AZURE_CLIENT_SECRET"),
+		TokenURL:     microsoft.AzureADEndpoint(os.Getenv("AZURE_TENANT_ID")).TokenURL,
+		Scopes:       []string{AzureResourceManagerURL + "/.default"},
+	}
+
+	return NewACRRepositoryCreator(credentials.Client(context.Background()), AzureResourceManagerURL, scopeMapID)
+}
+
 func NewRepositoryCreator(registryType string) RepositoryCreator {
-	if registryType == ECRContainerRegistryType {
+	switch registryType {
+	case ECRContainerRegistryType:
 		return NewECRRepositoryCreator(createECRClient())
+	case HarborContainerRegistryType:
+		return createHarborRepositoryCreator()
+	case ArtifactRegistryContainerRegistryType:
+		return NewArtifactRegistryRepositoryCreator(createArtifactRegistryHTTPClient(), ArtifactRegistryAPIURL)
+	case ACRContainerRegistryType:
+		return createACRRepositoryCreator()
 	}
 
 	return NoopRepositoryCreator{}